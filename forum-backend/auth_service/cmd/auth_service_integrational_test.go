@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
@@ -15,6 +16,7 @@ import (
 	http2 "github.com/miqxzz/miqxzzforum/auth_service/internal/delivery/http"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -43,6 +45,19 @@ func setupTestDB(t *testing.T) *sqlx.DB {
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			user_id INTEGER NOT NULL,
 			token TEXT NOT NULL,
+			family_id TEXT,
+			expires_at DATETIME,
+			revoked_at DATETIME,
+			FOREIGN KEY (user_id) REFERENCES users(id)
+		);
+		CREATE TABLE refresh_tokens (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			token_hash TEXT NOT NULL UNIQUE,
+			family_id TEXT NOT NULL,
+			expires_at DATETIME NOT NULL,
+			revoked_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (user_id) REFERENCES users(id)
 		);
 	`)
@@ -50,6 +65,16 @@ func setupTestDB(t *testing.T) *sqlx.DB {
 		t.Fatalf("Failed to create tables: %s", err)
 	}
 
+	for _, migration := range []string{"004_create_rbac.up.sql", "005_create_invites.up.sql", "015_create_user_sanctions.up.sql", "016_create_audit_log.up.sql"} {
+		schema, err := os.ReadFile("../migrations/" + migration)
+		if err != nil {
+			t.Fatalf("Failed to read migration %s: %s", migration, err)
+		}
+		if _, err := db.Exec(string(schema)); err != nil {
+			t.Fatalf("Failed to apply migration %s: %s", migration, err)
+		}
+	}
+
 	return db
 }
 
@@ -62,18 +87,36 @@ func TestAuthService_Integration(t *testing.T) {
 
 	authRepo := repository.NewAuthRepository(db, logger)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
-	authUsecase := usecase.NewAuthUsecase(authRepo, jwtUtil, logger)
-	authHandler := http2.NewAuthHandler(authUsecase, jwtUtil, logger)
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+	roleRepo := repository.NewRoleRepository(db, logger)
+	inviteRepo := repository.NewInviteRepository(db, logger)
+	sanctionRepo := repository.NewSanctionRepository(db, logger)
+	authUsecase := usecase.NewAuthUsecase(authRepo, roleRepo, inviteRepo, sanctionRepo, jwtUtil, tokenIssuer, nil, logger)
+	roleUsecase := usecase.NewRoleUsecase(roleRepo, logger)
+	inviteUsecase := usecase.NewInviteUsecase(inviteRepo, roleRepo, logger)
+	auditor := http2.NewAuditor(usecase.NewAuditUsecase(repository.NewAuditRepository(db, logger), logger), logger)
+	authHandler := http2.NewAuthHandler(authUsecase, jwtUtil, auditor, logger)
+	roleHandler := http2.NewRoleHandler(roleUsecase, auditor, logger)
+	inviteHandler := http2.NewInviteHandler(inviteUsecase, auditor, logger)
+	authMiddleware := http2.NewAuthMiddleware(authUsecase, roleUsecase, logger)
 
 	r := gin.Default()
 	r.POST("/register", authHandler.Register)
 	r.POST("/login", authHandler.Login)
+	r.POST("/refresh", authHandler.Refresh)
+	r.POST("/logout", authHandler.Logout)
+	r.POST("/update-role", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermRoleAssign), authHandler.UpdateUserRole)
+	admin := r.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermRoleManage))
+	admin.POST("/roles", roleHandler.CreateRole)
+	admin.PUT("/roles/:name/permissions", roleHandler.SetRolePermissions)
+	invites := r.Group("/admin/invites", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermInviteManage))
+	invites.POST("", inviteHandler.CreateInvite)
+	invites.DELETE("/:id", inviteHandler.RevokeInvite)
 
 	t.Run("RegisterUser", func(t *testing.T) {
 		reqBody := entity.RegisterRequest{
 			Username: "testuser",
 			Password: "password",
-			Role:     "user",
 		}
 		reqBodyBytes, _ := json.Marshal(reqBody)
 
@@ -109,7 +152,6 @@ func TestAuthService_Integration(t *testing.T) {
 		reqBody := entity.RegisterRequest{
 			Username: "shortpass",
 			Password: "123",
-			Role:     "user",
 		}
 		reqBodyBytes, _ := json.Marshal(reqBody)
 
@@ -126,7 +168,6 @@ func TestAuthService_Integration(t *testing.T) {
 		reqBody := entity.RegisterRequest{
 			Username: "testuser",
 			Password: "password",
-			Role:     "user",
 		}
 		reqBodyBytes, _ := json.Marshal(reqBody)
 
@@ -170,4 +211,128 @@ func TestAuthService_Integration(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Contains(t, w.Body.String(), "invalid credentials")
 	})
+	t.Run("RefreshAndLogout", func(t *testing.T) {
+		reqBodyBytes, _ := json.Marshal(entity.LoginRequest{Username: "testuser", Password: "password"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(reqBodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var login entity.LoginResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &login))
+		assert.NotEmpty(t, login.RefreshToken)
+
+		refresh := func(refreshToken string) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(entity.RefreshRequest{RefreshToken: refreshToken})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/refresh", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		w = refresh(login.RefreshToken)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var rotated entity.RefreshResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &rotated))
+
+		// Повторное использование старого токена завершает всю сессию
+		w = refresh(login.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = refresh(rotated.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest(http.MethodPost, "/logout", nil)
+		req.Header.Set("Authorization", "Bearer "+rotated.Token)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("RoleManagement", func(t *testing.T) {
+		login := func(username string) string {
+			body, _ := json.Marshal(entity.LoginRequest{Username: username, Password: "password"})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			var resp entity.LoginResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			return resp.Token
+		}
+		do := func(method, path, token, body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		// У обычного пользователя нет права role.manage
+		w := do(http.MethodPost, "/admin/roles", login("testuser"), `{"name":"editor"}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		_, err := db.Exec("UPDATE users SET role = 'admin' WHERE username = 'testuser'")
+		assert.NoError(t, err)
+		adminToken := login("testuser")
+
+		w = do(http.MethodPost, "/admin/roles", adminToken, `{"name":"editor","permissions":["post.update.any"]}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "post.update.any")
+
+		w = do(http.MethodPut, "/admin/roles/editor/permissions", adminToken, `{"permissions":["no.such.permission"]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = do(http.MethodPost, "/update-role", adminToken, `{"user_id":1,"new_role":"superuser"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = do(http.MethodPost, "/update-role", adminToken, `{"user_id":1,"new_role":"editor"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Токены с прежней ролью отзываются вместе со сменой роли
+		w = do(http.MethodPost, "/admin/roles", adminToken, `{"name":"writer"}`)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Invites", func(t *testing.T) {
+		post := func(path, token, body string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			r.ServeHTTP(w, req)
+			return w
+		}
+
+		created, err := authUsecase.BootstrapAdmin("root", "password")
+		assert.NoError(t, err)
+		assert.True(t, created)
+		tokens, err := authUsecase.Login("root", "password")
+		assert.NoError(t, err)
+
+		// Самостоятельно выбрать роль при регистрации нельзя
+		w := post("/register", "", `{"username":"sneaky","password":"password","role":"admin"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		role, err := authUsecase.GetUserRole("sneaky")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.DefaultRole, role)
+
+		w = post("/admin/invites", tokens.AccessToken, `{"role":"moderator","max_uses":1}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var invite entity.CreateInviteResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &invite))
+
+		w = post("/register", "", `{"username":"mod","password":"password","invite_code":"`+invite.Code+`"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		role, err = authUsecase.GetUserRole("mod")
+		assert.NoError(t, err)
+		assert.Equal(t, "moderator", role)
+
+		// Одноразовый код уже использован
+		w = post("/register", "", `{"username":"mod2","password":"password","invite_code":"`+invite.Code+`"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
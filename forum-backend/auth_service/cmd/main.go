@@ -22,7 +22,9 @@ import (
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/config"
 	mygrpc "github.com/miqxzz/miqxzzforum/auth_service/internal/delivery/grpc"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/delivery/http"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -68,6 +70,8 @@ func main() {
 		logger.Fatal("Failed to ping database", zap.Error(err))
 	}
 
+	requireFTS5(db, logger)
+
 	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
 	if err != nil {
 		logger.Fatal("Failed to create migrate driver", zap.Error(err))
@@ -86,7 +90,8 @@ func main() {
 
 	// Инициализация репозитория
 	userRepo := repository.NewAuthRepository(db, logger)
-	userServer := mygrpc.NewUserServer(userRepo)
+	userChanges := usecase.NewUserChanges(logger)
+	userServer := mygrpc.NewUserServer(userRepo, userChanges)
 
 	// Инициализация gRPC сервера
 	grpcServer := grpc.NewServer()
@@ -104,16 +109,41 @@ func main() {
 		}
 	}()
 
-
-	
 	jwtUtil := commonmiqx.NewJWTUtil(cfg.JWTSecret)
-	userUsecase := usecase.NewAuthUsecase(userRepo, jwtUtil, logger)
-	authHandler := http.NewAuthHandler(userUsecase, jwtUtil, logger)
+	tokenIssuer := token.NewIssuer(cfg.JWTSecret, cfg.AccessTTL, cfg.RefreshTTL)
+	roleRepo := repository.NewRoleRepository(db, logger)
+	inviteRepo := repository.NewInviteRepository(db, logger)
+	sanctionRepo := repository.NewSanctionRepository(db, logger)
+	auditRepo := repository.NewAuditRepository(db, logger)
+	userUsecase := usecase.NewAuthUsecase(userRepo, roleRepo, inviteRepo, sanctionRepo, jwtUtil, tokenIssuer, userChanges, logger)
+	roleUsecase := usecase.NewRoleUsecase(roleRepo, logger)
+	inviteUsecase := usecase.NewInviteUsecase(inviteRepo, roleRepo, logger)
+	sanctionUsecase := usecase.NewSanctionUsecase(sanctionRepo, userRepo, roleRepo, logger)
+	auditUsecase := usecase.NewAuditUsecase(auditRepo, logger)
+	auditor := http.NewAuditor(auditUsecase, logger)
+	authHandler := http.NewAuthHandler(userUsecase, jwtUtil, auditor, logger)
+	roleHandler := http.NewRoleHandler(roleUsecase, auditor, logger)
+	inviteHandler := http.NewInviteHandler(inviteUsecase, auditor, logger)
+	sanctionHandler := http.NewSanctionHandler(sanctionUsecase, auditor, logger)
+	auditHandler := http.NewAuditHandler(auditUsecase, logger)
+	authMiddleware := http.NewAuthMiddleware(userUsecase, roleUsecase, logger)
+
+	// Первый администратор: задается через BOOTSTRAP_ADMIN_USERNAME/PASSWORD
+	// и создается, только если в базе нет ни одного администратора.
+	if cfg.BootstrapAdminUsername != "" {
+		created, err := userUsecase.BootstrapAdmin(cfg.BootstrapAdminUsername, cfg.BootstrapAdminPassword)
+		if err != nil {
+			logger.Fatal("Failed to bootstrap admin", zap.Error(err))
+		}
+		if created {
+			logger.Info("Bootstrap admin created", zap.String("username", cfg.BootstrapAdminUsername))
+		}
+	}
 
 	router := gin.Default()
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET"},
+		AllowMethods:     []string{"PUT", "PATCH", "POST", "GET", "DELETE"},
 		AllowHeaders:     []string{"Content-type", "Origin", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
@@ -121,7 +151,35 @@ func main() {
 	}))
 	router.POST("/auth/register", authHandler.Register)
 	router.POST("/auth/login", authHandler.Login)
-	router.POST("/auth/update-role", authHandler.UpdateUserRole)
+	router.POST("/auth/refresh", authHandler.Refresh)
+	router.POST("/auth/logout", authHandler.Logout)
+	router.POST("/auth/logout-all", authHandler.LogoutAll)
+	router.POST("/auth/update-role", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermRoleAssign), authHandler.UpdateUserRole)
+
+	admin := router.Group("/admin", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermRoleManage))
+	admin.GET("/roles", roleHandler.ListRoles)
+	admin.POST("/roles", roleHandler.CreateRole)
+	admin.GET("/roles/:name", roleHandler.GetRole)
+	admin.DELETE("/roles/:name", roleHandler.DeleteRole)
+	admin.PUT("/roles/:name/permissions", roleHandler.SetRolePermissions)
+	admin.GET("/permissions", roleHandler.ListPermissions)
+	admin.POST("/permissions", roleHandler.CreatePermission)
+	admin.DELETE("/permissions/:name", roleHandler.DeletePermission)
+
+	invites := router.Group("/admin/invites", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermInviteManage))
+	invites.GET("", inviteHandler.ListInvites)
+	invites.POST("", inviteHandler.CreateInvite)
+	invites.DELETE("/:id", inviteHandler.RevokeInvite)
+
+	// Право на саму санкцию зависит от ее вида и проверяется в usecase
+	sanctions := router.Group("/admin", authMiddleware.RequireAuth())
+	sanctions.GET("/users/:id/sanctions", authMiddleware.RequirePermission(entity.PermSanctionView), sanctionHandler.ListUserSanctions)
+	sanctions.POST("/users/:id/sanctions", sanctionHandler.CreateSanction)
+	sanctions.DELETE("/sanctions/:id", sanctionHandler.RevokeSanction)
+
+	audit := router.Group("/admin/audit-log", authMiddleware.RequireAuth(), authMiddleware.RequirePermission(entity.PermAuditView))
+	audit.GET("", auditHandler.ListAuditLog)
+	audit.GET("/export", auditHandler.ExportAuditLog)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -136,3 +194,14 @@ func main() {
 
 	logger.Info("Shutting down server")
 }
+
+// requireFTS5 останавливает сервис, если SQLite собран без FTS5: миграция
+// 009_create_search_index создает таблицы FTS5 и без него не применится.
+// Сервис собирается с тегом sqlite_fts5 (make build или go build -tags
+// sqlite_fts5).
+func requireFTS5(db *sqlx.DB, logger *zap.Logger) {
+	var enabled bool
+	if err := db.Get(&enabled, `SELECT sqlite_compileoption_used('ENABLE_FTS5')`); err != nil || !enabled {
+		logger.Fatal("SQLite is built without FTS5, build the service with -tags sqlite_fts5", zap.Error(err))
+	}
+}
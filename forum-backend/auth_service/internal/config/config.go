@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +12,11 @@ type Config struct {
 	DBPath         string
 	MigrationsPath string
 	JWTSecret      string
+	AccessTTL      time.Duration
+	RefreshTTL     time.Duration
+	// Первый администратор создается при старте, если администраторов еще нет.
+	BootstrapAdminUsername string
+	BootstrapAdminPassword string
 }
 
 func LoadConfig() (Config, error) {
@@ -25,6 +31,11 @@ func LoadConfig() (Config, error) {
 		DBPath:         getEnv("DB_PATH", "../db/forum.db"),
 		MigrationsPath: getEnv("AUTH_SERVICE_MIGRATIONS_PATH", "C:\\forum-project\\forum-backend\\auth_service\\migrations"),
 		JWTSecret:      getEnv("JWT_SECRET", "your-secret-key"),
+		AccessTTL:      getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTTL:     getDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		BootstrapAdminUsername: os.Getenv("BOOTSTRAP_ADMIN_USERNAME"),
+		BootstrapAdminPassword: os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"),
 	}
 	return cfg, nil
 }
@@ -36,3 +47,11 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -2,18 +2,29 @@ package grpc
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	user "github.com/miqxzz/miqxzzforum/auth_service/internal/proto"
 	"github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// maxUsersBatch ограничивает размер одного запроса GetUsers.
+const maxUsersBatch = 1000
+
 type UserServer struct {
 	user.UnimplementedUserServiceServer // Важно: встраиваем стандартную реализацию
 	repo                                repository.AuthRepository
+	changes                             *usecase.UserChanges
 }
 
-func NewUserServer(repo repository.AuthRepository) *UserServer {
-	return &UserServer{repo: repo}
+func NewUserServer(repo repository.AuthRepository, changes *usecase.UserChanges) *UserServer {
+	return &UserServer{repo: repo, changes: changes}
 }
 
 // GetUsername - реализация метода из proto-файла
@@ -27,3 +38,86 @@ func (s *UserServer) GetUsername(ctx context.Context, req *user.UserRequest) (*u
 		Username: username,
 	}, nil
 }
+
+// GetUser возвращает имя и роль пользователя; для несуществующего id — NotFound.
+func (s *UserServer) GetUser(ctx context.Context, req *user.UserRequest) (*user.UserInfo, error) {
+	u, err := s.repo.GetUserByID(int(req.UserId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user %d not found", req.UserId)
+		}
+		return nil, err
+	}
+
+	return &user.UserInfo{
+		UserId:   int32(u.ID),
+		Username: u.Username,
+		Role:     u.Role,
+	}, nil
+}
+
+// GetUsers возвращает пользователей по списку id одним запросом к базе.
+// Повторяющиеся id схлопываются, неизвестные пропускаются.
+func (s *UserServer) GetUsers(ctx context.Context, req *user.UsersRequest) (*user.UsersResponse, error) {
+	if len(req.UserIds) > maxUsersBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "too many user ids: %d > %d", len(req.UserIds), maxUsersBatch)
+	}
+
+	seen := make(map[int32]bool, len(req.UserIds))
+	ids := make([]int, 0, len(req.UserIds))
+	for _, id := range req.UserIds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, int(id))
+		}
+	}
+
+	users, err := s.repo.GetUsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &user.UsersResponse{Users: make([]*user.UserInfo, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, &user.UserInfo{
+			UserId:   int32(u.ID),
+			Username: u.Username,
+			Role:     u.Role,
+		})
+	}
+	return resp, nil
+}
+
+// WatchUserChanges передает клиенту изменения пользователей, пока тот не
+// отключится. Если клиент отстал и события были потеряны, поток завершается
+// с Unavailable: клиент должен сбросить кэш и подписаться заново.
+func (s *UserServer) WatchUserChanges(req *user.WatchUserChangesRequest, stream grpc.ServerStreamingServer[user.UserChange]) error {
+	if s.changes == nil {
+		return status.Error(codes.Unimplemented, "user changes are not published")
+	}
+	events, unsubscribe := s.changes.Subscribe()
+	defer unsubscribe()
+	// Заголовки сообщают клиенту, что подписка оформлена и дальнейшие
+	// изменения он не пропустит.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case u, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "user changes subscriber lagged behind")
+			}
+			if err := stream.Send(&user.UserChange{
+				UserId:   int32(u.ID),
+				Username: u.Username,
+				Role:     u.Role,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
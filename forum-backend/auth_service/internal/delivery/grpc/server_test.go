@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	user "github.com/miqxzz/miqxzzforum/auth_service/internal/proto"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestUserServer_GetUser_Success(t *testing.T) {
+	mockRepo := new(mocks.AuthRepository)
+	mockRepo.On("GetUserByID", 5).Return(entity.User{ID: 5, Username: "alice", Password: "hash", Role: "moderator"}, nil)
+
+	resp, err := NewUserServer(mockRepo, nil).GetUser(context.Background(), &user.UserRequest{UserId: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), resp.UserId)
+	assert.Equal(t, "alice", resp.Username)
+	assert.Equal(t, "moderator", resp.Role)
+}
+
+func TestUserServer_GetUser_NotFound(t *testing.T) {
+	mockRepo := new(mocks.AuthRepository)
+	mockRepo.On("GetUserByID", 9).Return(entity.User{}, sql.ErrNoRows)
+
+	_, err := NewUserServer(mockRepo, nil).GetUser(context.Background(), &user.UserRequest{UserId: 9})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestUserServer_GetUsers_Deduplicates(t *testing.T) {
+	mockRepo := new(mocks.AuthRepository)
+	mockRepo.On("GetUsersByIDs", []int{1, 2, 3}).Return([]entity.User{
+		{ID: 1, Username: "alice", Role: "user"},
+		{ID: 3, Username: "carol", Role: "admin"},
+	}, nil)
+
+	resp, err := NewUserServer(mockRepo, nil).GetUsers(context.Background(), &user.UsersRequest{UserIds: []int32{1, 2, 1, 3}})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Users, 2)
+	assert.Equal(t, "carol", resp.Users[1].Username)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserServer_GetUsers_TooMany(t *testing.T) {
+	mockRepo := new(mocks.AuthRepository)
+
+	_, err := NewUserServer(mockRepo, nil).GetUsers(context.Background(), &user.UsersRequest{UserIds: make([]int32, maxUsersBatch+1)})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockRepo.AssertNotCalled(t, "GetUsersByIDs", mock.Anything)
+}
+
+func TestUserServer_WatchUserChanges(t *testing.T) {
+	changes := usecase.NewUserChanges(zap.NewNop())
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	user.RegisterUserServiceServer(srv, NewUserServer(new(mocks.AuthRepository), changes))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := user.NewUserServiceClient(conn).WatchUserChanges(ctx, &user.WatchUserChangesRequest{})
+	assert.NoError(t, err)
+
+	// Заголовки приходят после подписки на сервере
+	_, err = stream.Header()
+	assert.NoError(t, err)
+	changes.Publish(entity.User{ID: 3, Role: "moderator"})
+
+	change, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), change.UserId)
+	assert.Equal(t, "moderator", change.Role)
+}
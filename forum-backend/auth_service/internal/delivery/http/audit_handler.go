@@ -0,0 +1,193 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const maxAuditPageLimit = 100
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecase
+	logger       *zap.Logger
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditUsecase, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{auditUsecase: auditUsecase, logger: logger}
+}
+
+// ListAuditLog godoc
+// @Summary Журнал действий администрации
+// @Description Возвращает записи журнала привилегированных действий обоих сервисов, начиная с последней. Время в from и to — в формате RFC 3339, to не включается (требуется право audit.view)
+// @Tags Журнал
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query int false "ID пользователя, выполнившего действие"
+// @Param action query string false "Действие, например user.role.update"
+// @Param target_type query string false "Тип объекта: user, role, post, comment..."
+// @Param target_id query string false "ID объекта"
+// @Param from query string false "Начало периода"
+// @Param to query string false "Конец периода"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Записей на странице" default(50)
+// @Success 200 {object} map[string]interface{} "entries и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/audit-log [get]
+func (h *AuditHandler) ListAuditLog(c *gin.Context) {
+	filter, ok := auditFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxAuditPageLimit {
+		limit = 50
+	}
+	filter.Limit, filter.Offset = limit, (page-1)*limit
+
+	entries, total, err := h.auditUsecase.List(filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if entries == nil {
+		entries = []entity.AuditEntry{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// ExportAuditLog godoc
+// @Summary Выгрузка журнала действий
+// @Description Выгружает записи журнала по тем же фильтрам, что и /admin/audit-log, без разбивки на страницы (не больше 10000 записей) в CSV или JSON (требуется право audit.view)
+// @Tags Журнал
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param format query string false "csv или json" default(csv)
+// @Param actor_id query int false "ID пользователя, выполнившего действие"
+// @Param action query string false "Действие"
+// @Param target_type query string false "Тип объекта"
+// @Param target_id query string false "ID объекта"
+// @Param from query string false "Начало периода"
+// @Param to query string false "Конец периода"
+// @Success 200 {array} entity.AuditEntry
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/audit-log/export [get]
+func (h *AuditHandler) ExportAuditLog(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "формат выгрузки должен быть csv или json"})
+		return
+	}
+	filter, ok := auditFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.auditUsecase.Export(filter)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if entries == nil {
+		entries = []entity.AuditEntry{}
+	}
+
+	filename := "audit-log-" + time.Now().UTC().Format("20060102-150405") + "." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	if format == "json" {
+		c.JSON(http.StatusOK, entries)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"id", "created_at", "actor_id", "actor_role", "action", "target_type", "target_id", "before", "after", "ip", "user_agent"})
+	for _, e := range entries {
+		_ = w.Write([]string{
+			strconv.Itoa(e.ID),
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			strconv.Itoa(e.ActorID),
+			e.ActorRole,
+			e.Action,
+			e.TargetType,
+			e.TargetID,
+			string(e.Before),
+			string(e.After),
+			e.IP,
+			e.UserAgent,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		h.logger.Error("Failed to write audit CSV", zap.Error(err))
+	}
+}
+
+// auditFilterFromQuery разбирает общие фильтры журнала и отвечает 400 на
+// некорректные значения.
+func auditFilterFromQuery(c *gin.Context) (entity.AuditFilter, bool) {
+	filter := entity.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	if actorID := c.Query("actor_id"); actorID != "" {
+		id, err := strconv.Atoi(actorID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный actor_id"})
+			return filter, false
+		}
+		filter.ActorID = id
+	}
+	for _, bound := range []struct {
+		param string
+		dest  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "некорректное время в " + bound.param + ", нужен формат RFC 3339"})
+			return filter, false
+		}
+		*bound.dest = &t
+	}
+	return filter, true
+}
+
+func (h *AuditHandler) respondError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidAuditRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.logger.Error("Audit log query failed", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newAuditRouter(auditUsecase usecase.AuditUsecase) *gin.Engine {
+	handler := NewAuditHandler(auditUsecase, zap.NewNop())
+	router := gin.New()
+	router.GET("/admin/audit-log", handler.ListAuditLog)
+	router.GET("/admin/audit-log/export", handler.ExportAuditLog)
+	return router
+}
+
+func TestAuditHandler_ListAuditLog(t *testing.T) {
+	mockAuditUsecase := new(mocks.AuditUsecase)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockAuditUsecase.On("List", mock.MatchedBy(func(filter entity.AuditFilter) bool {
+		return filter.ActorID == 1 && filter.TargetType == entity.AuditTargetUser && filter.From != nil && filter.From.Equal(from) &&
+			filter.Limit == 20 && filter.Offset == 20
+	})).Return([]entity.AuditEntry{{ID: 7, ActorID: 1, Action: entity.AuditUserRoleUpdate, After: entity.AuditSnapshot(`{"role":"moderator"}`)}}, 21, nil)
+
+	w := httptest.NewRecorder()
+	newAuditRouter(mockAuditUsecase).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-log?actor_id=1&target_type=user&from=2026-01-01T00:00:00Z&page=2&limit=20", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"after":{"role":"moderator"}`)
+	assert.Contains(t, w.Body.String(), `"total":21`)
+	mockAuditUsecase.AssertExpectations(t)
+}
+
+func TestAuditHandler_ListAuditLog_BadTime(t *testing.T) {
+	mockAuditUsecase := new(mocks.AuditUsecase)
+
+	w := httptest.NewRecorder()
+	newAuditRouter(mockAuditUsecase).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-log?from=yesterday", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockAuditUsecase.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestAuditHandler_ExportAuditLog_CSV(t *testing.T) {
+	mockAuditUsecase := new(mocks.AuditUsecase)
+	mockAuditUsecase.On("Export", entity.AuditFilter{Action: entity.AuditSanctionIssue}).Return([]entity.AuditEntry{{
+		ID:         3,
+		ActorID:    1,
+		ActorRole:  "moderator",
+		Action:     entity.AuditSanctionIssue,
+		TargetType: entity.AuditTargetSanction,
+		TargetID:   "9",
+		After:      entity.AuditSnapshot(`{"type":"ban"}`),
+		CreatedAt:  time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}, nil)
+
+	w := httptest.NewRecorder()
+	newAuditRouter(mockAuditUsecase).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/audit-log/export?action=sanction.issue", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, `3,2026-01-02T03:04:05Z,1,moderator,sanction.issue,sanction,9,,"{""type"":""ban""}",,`, lines[1])
+}
+
+func TestAuditor_RecordsPrincipalAndRequest(t *testing.T) {
+	mockAuditUsecase := new(mocks.AuditUsecase)
+	mockAuditUsecase.On("Record", mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == sanctionIssuer.UserID && entry.ActorRole == "moderator" &&
+			entry.Action == entity.AuditSanctionRevoke && entry.TargetID == "3" &&
+			strings.Contains(string(entry.Before), `"active":true`) && strings.Contains(string(entry.After), `"active":false`) &&
+			entry.UserAgent == "test-agent" && entry.IP != ""
+	})).Return(nil)
+	mockSanctionUsecase := new(mocks.SanctionUsecase)
+	mockSanctionUsecase.On("Revoke", sanctionIssuer, 3).Return(entity.Sanction{ID: 3, Type: entity.SanctionBan, Active: false}, nil)
+
+	handler := NewSanctionHandler(mockSanctionUsecase, NewAuditor(mockAuditUsecase, zap.NewNop()), zap.NewNop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(principalKey, sanctionIssuer)
+	})
+	router.DELETE("/admin/sanctions/:id", handler.RevokeSanction)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/sanctions/3", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockAuditUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Auditor записывает привилегированные действия в журнал audit_log вместе с
+// пользователем из контекста, IP и User-Agent запроса. Действие к моменту
+// записи уже выполнено, поэтому ошибка записи только логируется. Nil-Auditor
+// ничего не пишет.
+type Auditor struct {
+	auditUsecase usecase.AuditUsecase
+	logger       *zap.Logger
+}
+
+func NewAuditor(auditUsecase usecase.AuditUsecase, logger *zap.Logger) *Auditor {
+	return &Auditor{auditUsecase: auditUsecase, logger: logger}
+}
+
+// Record пишет действие action над объектом targetType/targetID. before и
+// after — состояния объекта до и после действия, nil — состояния нет.
+func (a *Auditor) Record(c *gin.Context, action, targetType string, targetID interface{}, before, after interface{}) {
+	if a == nil {
+		return
+	}
+	principal, _ := PrincipalFromContext(c)
+	entry := entity.AuditEntry{
+		ActorID:    principal.UserID,
+		ActorRole:  principal.Role,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   fmt.Sprint(targetID),
+		Before:     a.snapshot(action, before),
+		After:      a.snapshot(action, after),
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+	if err := a.auditUsecase.Record(entry); err != nil {
+		a.logger.Error("Failed to write audit entry",
+			zap.Error(err),
+			zap.String("action", action),
+			zap.String("targetType", targetType),
+			zap.String("targetID", entry.TargetID),
+			zap.Int("actorID", principal.UserID))
+	}
+}
+
+func (a *Auditor) snapshot(action string, state interface{}) entity.AuditSnapshot {
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		a.logger.Error("Failed to marshal audit snapshot", zap.Error(err), zap.String("action", action))
+		return nil
+	}
+	return data
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	utils "github.com/miqxzz/commonmiqx"
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
@@ -14,16 +16,17 @@ import (
 type AuthHandler struct {
 	authUsecase usecase.AuthUsecase
 	jwtUtil     *utils.JWTUtil
+	audit       *Auditor
 	logger      *zap.Logger
 }
 
-func NewAuthHandler(authUsecase usecase.AuthUsecase, jwtUtil *utils.JWTUtil, logger *zap.Logger) *AuthHandler {
-	return &AuthHandler{authUsecase: authUsecase, jwtUtil: jwtUtil, logger: logger}
+func NewAuthHandler(authUsecase usecase.AuthUsecase, jwtUtil *utils.JWTUtil, audit *Auditor, logger *zap.Logger) *AuthHandler {
+	return &AuthHandler{authUsecase: authUsecase, jwtUtil: jwtUtil, audit: audit, logger: logger}
 }
 
 // Register godoc
 // @Summary Регистрация нового пользователя
-// @Description Создает нового пользователя в системе
+// @Description Создает пользователя с ролью user. Повышенную роль можно получить только по коду приглашения (invite_code).
 // @Tags Аутентификация
 // @Accept json
 // @Produce json
@@ -39,8 +42,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	if err := h.authUsecase.Register(req.Username, req.Password, req.Role); err != nil {
+	if err := h.authUsecase.Register(req.Username, req.Password, req.InviteCode); err != nil {
 		h.logger.Error("Failed to register user", zap.Error(err), zap.String("username", req.Username))
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -50,7 +57,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Аутентификация пользователя
-// @Description Вход пользователя в систему и получение токена
+// @Description Вход пользователя в систему и получение токена. Заблокированному пользователю возвращается 403 с причиной и сроком блокировки
 // @Tags Аутентификация
 // @Accept json
 // @Produce json
@@ -58,6 +65,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} entity.LoginResponse
 // @Failure 400 {object} entity.ErrorResponse
 // @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.BannedResponse
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -67,9 +75,18 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	token, err := h.authUsecase.Login(req.Username, req.Password)
+	tokens, err := h.authUsecase.Login(req.Username, req.Password)
 	if err != nil {
 		h.logger.Error("Failed to login user", zap.Error(err), zap.String("username", req.Username))
+		var banned *usecase.BannedError
+		if errors.As(err, &banned) {
+			c.JSON(http.StatusForbidden, entity.BannedResponse{
+				Error:     err.Error(),
+				Reason:    banned.Sanction.Reason,
+				ExpiresAt: banned.Sanction.ExpiresAt,
+			})
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -79,51 +96,133 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	userId, err := h.jwtUtil.GetUserIDFromToken(token)
+	userId, err := h.jwtUtil.GetUserIDFromToken(tokens.AccessToken)
 	if err != nil {
 		h.logger.Error("Failed to get user ID from token", zap.Error(err), zap.String("username", req.Username))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	h.logger.Info("User logged in successfully", zap.String("username", req.Username))
-	c.JSON(http.StatusOK, gin.H{"token": token, "role": role, "username": req.Username, "userID": userId})
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"role":          role,
+		"username":      req.Username,
+		"userID":        userId,
+	})
 }
 
-// UpdateUserRole godoc
-// @Summary Изменение роли пользователя
-// @Description Изменяет роль пользователя (только для администраторов)
+// Refresh godoc
+// @Summary Обновление токенов
+// @Description Обменивает refresh-токен на новую пару токенов. Старый refresh-токен становится недействительным
 // @Tags Аутентификация
 // @Accept json
 // @Produce json
-// @Param request body entity.UpdateRoleRequest true "Данные для изменения роли"
-// @Success 200 {object} entity.RegisterResponse
+// @Param request body entity.RefreshRequest true "Refresh-токен"
+// @Success 200 {object} entity.RefreshResponse
 // @Failure 400 {object} entity.ErrorResponse
 // @Failure 401 {object} entity.ErrorResponse
-// @Failure 403 {object} entity.ErrorResponse
 // @Failure 500 {object} entity.ErrorResponse
-// @Router /auth/update-role [post]
-func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
-	// Проверяем роль текущего пользователя
-	token := c.GetHeader("Authorization")
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req entity.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for refresh", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tokens, err := h.authUsecase.Refresh(req.RefreshToken)
+	if err != nil {
+		h.logger.Warn("Failed to refresh tokens", zap.Error(err))
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) || errors.Is(err, usecase.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+	})
+}
+
+// Logout godoc
+// @Summary Выход из системы
+// @Description Отзывает текущую сессию: access-токен и связанные с ним refresh-токены
+// @Tags Аутентификация
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.MessageResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	token := bearerToken(c)
 	if token == "" {
 		h.logger.Error("No authorization token provided")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
 		return
 	}
+	if err := h.authUsecase.Logout(token); err != nil {
+		h.logger.Warn("Failed to logout", zap.Error(err))
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "недействительный токен"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Выход выполнен"})
+}
 
-	role, err := h.jwtUtil.GetRoleFromToken(token)
+// LogoutAll godoc
+// @Summary Выход на всех устройствах
+// @Description Отзывает все активные сессии текущего пользователя
+// @Tags Аутентификация
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entity.MessageResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	token := bearerToken(c)
+	if token == "" {
+		h.logger.Error("No authorization token provided")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+		return
+	}
+	claims, err := h.authUsecase.ValidateAccessToken(token)
 	if err != nil {
-		h.logger.Error("Failed to get role from token", zap.Error(err))
+		h.logger.Warn("Invalid token on logout-all", zap.Error(err))
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "недействительный токен"})
 		return
 	}
-
-	if role != "admin" {
-		h.logger.Error("Unauthorized role update attempt", zap.String("role", role))
-		c.JSON(http.StatusForbidden, gin.H{"error": "недостаточно прав"})
+	if err := h.authUsecase.LogoutAll(claims.UserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": "Все сессии завершены"})
+}
 
+func bearerToken(c *gin.Context) string {
+	return strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+}
+
+// UpdateUserRole godoc
+// @Summary Изменение роли пользователя
+// @Description Изменяет роль пользователя (требуется право role.assign). При смене роли все токены пользователя отзываются, и ему нужно войти заново
+// @Tags Аутентификация
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.UpdateRoleRequest true "Данные для изменения роли"
+// @Success 200 {object} entity.RegisterResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /auth/update-role [post]
+func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 	var req entity.UpdateRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Failed to bind JSON for role update", zap.Error(err))
@@ -131,11 +230,20 @@ func (h *AuthHandler) UpdateUserRole(c *gin.Context) {
 		return
 	}
 
-	if err := h.authUsecase.UpdateUserRole(req.UserID, req.NewRole); err != nil {
+	previousRole, err := h.authUsecase.UpdateUserRole(req.UserID, req.NewRole)
+	if err != nil {
 		h.logger.Error("Failed to update user role", zap.Error(err), zap.Int("userID", req.UserID))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, usecase.ErrInvalidRole):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
+	h.audit.Record(c, entity.AuditUserRoleUpdate, entity.AuditTargetUser, req.UserID, gin.H{"role": previousRole}, gin.H{"role": req.NewRole})
 
 	h.logger.Info("User role updated successfully", zap.Int("userID", req.UserID), zap.String("newRole", req.NewRole))
 	c.JSON(http.StatusOK, gin.H{"message": "Роль пользователя успешно обновлена"})
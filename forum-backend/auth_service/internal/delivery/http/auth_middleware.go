@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const principalKey = "principal"
+
+// AuthMiddleware проверяет access-токен и кладет в контекст entity.Principal
+// с правами роли. Права берутся из role_permissions на каждый запрос, поэтому
+// изменения ролей действуют сразу, без перевыпуска токенов.
+type AuthMiddleware struct {
+	authUsecase usecase.AuthUsecase
+	roleUsecase usecase.RoleUsecase
+	logger      *zap.Logger
+}
+
+func NewAuthMiddleware(authUsecase usecase.AuthUsecase, roleUsecase usecase.RoleUsecase, logger *zap.Logger) *AuthMiddleware {
+	return &AuthMiddleware{authUsecase: authUsecase, roleUsecase: roleUsecase, logger: logger}
+}
+
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			m.logger.Error("No authorization token provided")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+			return
+		}
+
+		claims, err := m.authUsecase.ValidateAccessToken(token)
+		if err != nil {
+			m.logger.Warn("Invalid access token", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "недействительный токен"})
+			return
+		}
+
+		permissions, err := m.roleUsecase.GetRolePermissions(claims.Role)
+		if err != nil {
+			m.logger.Error("Failed to load role permissions", zap.Error(err), zap.String("role", claims.Role))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "не удалось проверить права"})
+			return
+		}
+
+		c.Set(principalKey, entity.Principal{UserID: claims.UserID, Role: claims.Role, Permissions: permissions})
+		c.Next()
+	}
+}
+
+// RequirePermission ставится после RequireAuth и пропускает запрос,
+// только если у роли пользователя есть право permission.
+func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+			return
+		}
+		if !principal.Can(permission) {
+			m.logger.Warn("Permission denied",
+				zap.Int("userID", principal.UserID),
+				zap.String("role", principal.Role),
+				zap.String("permission", permission))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "недостаточно прав"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func PrincipalFromContext(c *gin.Context) (entity.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return entity.Principal{}, false
+	}
+	principal, ok := value.(entity.Principal)
+	return principal, ok
+}
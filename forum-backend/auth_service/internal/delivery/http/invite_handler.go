@@ -0,0 +1,119 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type InviteHandler struct {
+	inviteUsecase usecase.InviteUsecase
+	audit         *Auditor
+	logger        *zap.Logger
+}
+
+func NewInviteHandler(inviteUsecase usecase.InviteUsecase, audit *Auditor, logger *zap.Logger) *InviteHandler {
+	return &InviteHandler{inviteUsecase: inviteUsecase, audit: audit, logger: logger}
+}
+
+// CreateInvite godoc
+// @Summary Создать приглашение
+// @Description Создает код приглашения для регистрации с указанной ролью (требуется право invite.manage). Код возвращается только в этом ответе.
+// @Tags Приглашения
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.CreateInviteRequest true "Параметры приглашения"
+// @Success 201 {object} entity.CreateInviteResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/invites [post]
+func (h *InviteHandler) CreateInvite(c *gin.Context) {
+	var req entity.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for invite creation", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+		return
+	}
+
+	ttl := time.Duration(req.ExpiresInHours) * time.Hour
+	invite, err := h.inviteUsecase.CreateInvite(principal.UserID, req.Role, req.MaxUses, ttl)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	// В журнал попадает приглашение без самого кода
+	h.audit.Record(c, entity.AuditInviteCreate, entity.AuditTargetInvite, invite.Invite.ID, nil, invite.Invite)
+	c.JSON(http.StatusCreated, invite)
+}
+
+// ListInvites godoc
+// @Summary Список приглашений
+// @Description Возвращает все приглашения, включая истекшие и отозванные (требуется право invite.manage)
+// @Tags Приглашения
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Invite
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/invites [get]
+func (h *InviteHandler) ListInvites(c *gin.Context) {
+	invites, err := h.inviteUsecase.ListInvites()
+	if err != nil {
+		h.logger.Error("Failed to list invites", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, invites)
+}
+
+// RevokeInvite godoc
+// @Summary Отозвать приглашение
+// @Description Отзывает приглашение, после чего по нему нельзя зарегистрироваться (требуется право invite.manage)
+// @Tags Приглашения
+// @Security BearerAuth
+// @Param id path int true "ID приглашения"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Router /admin/invites/{id} [delete]
+func (h *InviteHandler) RevokeInvite(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный id приглашения"})
+		return
+	}
+	if err := h.inviteUsecase.RevokeInvite(id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditInviteRevoke, entity.AuditTargetInvite, id, nil, nil)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *InviteHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInviteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Invite operation failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
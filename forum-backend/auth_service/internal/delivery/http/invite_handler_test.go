@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newInviteRouter(inviteUsecase usecase.InviteUsecase, logger *zap.Logger) *gin.Engine {
+	handler := NewInviteHandler(inviteUsecase, nil, logger)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(principalKey, entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermInviteManage}})
+	})
+	router.POST("/admin/invites", handler.CreateInvite)
+	router.DELETE("/admin/invites/:id", handler.RevokeInvite)
+	return router
+}
+
+func TestInviteHandler_CreateInvite_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockInviteUsecase := new(mocks.InviteUsecase)
+
+	response := entity.CreateInviteResponse{Code: "secret-code", Invite: entity.Invite{ID: 2, Role: "moderator", MaxUses: 5}}
+	mockInviteUsecase.On("CreateInvite", 1, "moderator", 5, 24*time.Hour).Return(response, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/invites", strings.NewReader(`{"role":"moderator","max_uses":5,"expires_in_hours":24}`))
+	newInviteRouter(mockInviteUsecase, logger).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"secret-code"`)
+	assert.NotContains(t, w.Body.String(), "code_hash")
+	mockInviteUsecase.AssertExpectations(t)
+}
+
+func TestInviteHandler_CreateInvite_UnknownRole(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockInviteUsecase := new(mocks.InviteUsecase)
+
+	mockInviteUsecase.On("CreateInvite", 1, "superuser", 0, time.Duration(0)).Return(entity.CreateInviteResponse{}, usecase.ErrInvalidRole)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/invites", strings.NewReader(`{"role":"superuser"}`))
+	newInviteRouter(mockInviteUsecase, logger).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestInviteHandler_RevokeInvite(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	cases := []struct {
+		name   string
+		id     string
+		err    error
+		status int
+	}{
+		{name: "Success", id: "3", status: http.StatusNoContent},
+		{name: "NotFound", id: "4", err: usecase.ErrInviteNotFound, status: http.StatusNotFound},
+		{name: "InvalidID", id: "abc", status: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInviteUsecase := new(mocks.InviteUsecase)
+			mockInviteUsecase.On("RevokeInvite", 3).Return(nil).Maybe()
+			mockInviteUsecase.On("RevokeInvite", 4).Return(tc.err).Maybe()
+
+			w := httptest.NewRecorder()
+			newInviteRouter(mockInviteUsecase, logger).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/invites/"+tc.id, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	roleUsecase usecase.RoleUsecase
+	audit       *Auditor
+	logger      *zap.Logger
+}
+
+func NewRoleHandler(roleUsecase usecase.RoleUsecase, audit *Auditor, logger *zap.Logger) *RoleHandler {
+	return &RoleHandler{roleUsecase: roleUsecase, audit: audit, logger: logger}
+}
+
+// ListRoles godoc
+// @Summary Список ролей
+// @Description Возвращает все роли с их правами (требуется право role.manage)
+// @Tags Роли
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Role
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleUsecase.ListRoles()
+	if err != nil {
+		h.logger.Error("Failed to list roles", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, roles)
+}
+
+// GetRole godoc
+// @Summary Получить роль
+// @Description Возвращает роль и ее права (требуется право role.manage)
+// @Tags Роли
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Имя роли"
+// @Success 200 {object} entity.Role
+// @Failure 404 {object} entity.ErrorResponse
+// @Router /admin/roles/{name} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	role, err := h.roleUsecase.GetRole(c.Param("name"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRole godoc
+// @Summary Создать роль
+// @Description Создает роль с набором прав (требуется право role.manage)
+// @Tags Роли
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.CreateRoleRequest true "Данные роли"
+// @Success 201 {object} entity.Role
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+// @Router /admin/roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req entity.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for role creation", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleUsecase.CreateRole(entity.Role{Name: req.Name, Description: req.Description, Permissions: req.Permissions})
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditRoleCreate, entity.AuditTargetRole, role.Name, nil, role)
+	c.JSON(http.StatusCreated, role)
+}
+
+// DeleteRole godoc
+// @Summary Удалить роль
+// @Description Удаляет роль, если она никому не назначена (требуется право role.manage)
+// @Tags Роли
+// @Security BearerAuth
+// @Param name path string true "Имя роли"
+// @Success 204 "No Content"
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+// @Router /admin/roles/{name} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	name := c.Param("name")
+	before, err := h.roleUsecase.GetRole(name)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if err := h.roleUsecase.DeleteRole(name); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditRoleDelete, entity.AuditTargetRole, name, before, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// SetRolePermissions godoc
+// @Summary Задать права роли
+// @Description Полностью заменяет набор прав роли (требуется право role.manage)
+// @Tags Роли
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Имя роли"
+// @Param request body entity.SetRolePermissionsRequest true "Права"
+// @Success 200 {object} entity.Role
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Router /admin/roles/{name}/permissions [put]
+func (h *RoleHandler) SetRolePermissions(c *gin.Context) {
+	var req entity.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for role permissions", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := c.Param("name")
+	before, err := h.roleUsecase.GetRole(name)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	role, err := h.roleUsecase.SetRolePermissions(name, req.Permissions)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditRolePermissions, entity.AuditTargetRole, name, before, role)
+	c.JSON(http.StatusOK, role)
+}
+
+// ListPermissions godoc
+// @Summary Список прав
+// @Description Возвращает все известные права (требуется право role.manage)
+// @Tags Роли
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Permission
+// @Router /admin/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.roleUsecase.ListPermissions()
+	if err != nil {
+		h.logger.Error("Failed to list permissions", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// CreatePermission godoc
+// @Summary Создать право
+// @Description Регистрирует новое право (требуется право role.manage)
+// @Tags Роли
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.CreatePermissionRequest true "Данные права"
+// @Success 201 {object} entity.Permission
+// @Failure 400 {object} entity.ErrorResponse
+// @Router /admin/permissions [post]
+func (h *RoleHandler) CreatePermission(c *gin.Context) {
+	var req entity.CreatePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for permission creation", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	permission := entity.Permission{Name: req.Name, Description: req.Description}
+	if err := h.roleUsecase.CreatePermission(permission); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditPermissionCreate, entity.AuditTargetPermission, permission.Name, nil, permission)
+	c.JSON(http.StatusCreated, permission)
+}
+
+// DeletePermission godoc
+// @Summary Удалить право
+// @Description Удаляет право и отвязывает его от всех ролей (требуется право role.manage)
+// @Tags Роли
+// @Security BearerAuth
+// @Param name path string true "Имя права"
+// @Success 204 "No Content"
+// @Failure 404 {object} entity.ErrorResponse
+// @Router /admin/permissions/{name} [delete]
+func (h *RoleHandler) DeletePermission(c *gin.Context) {
+	name := c.Param("name")
+	if err := h.roleUsecase.DeletePermission(name); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditPermissionDelete, entity.AuditTargetPermission, name, entity.Permission{Name: name}, nil)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RoleHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrRoleNotFound), errors.Is(err, usecase.ErrPermissionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrRoleExists), errors.Is(err, usecase.ErrPermissionExists),
+		errors.Is(err, usecase.ErrRoleInUse), errors.Is(err, usecase.ErrProtectedRole):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidRoleName), errors.Is(err, usecase.ErrInvalidPermission), errors.Is(err, usecase.ErrUnknownPermission):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Role operation failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
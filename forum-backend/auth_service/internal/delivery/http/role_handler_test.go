@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRoleHandler_ListRoles_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleUsecase := new(mocks.RoleUsecase)
+
+	roles := []entity.Role{{Name: "moderator", Permissions: []string{entity.PermPostDeleteAny}}}
+	mockRoleUsecase.On("ListRoles").Return(roles, nil)
+
+	router := gin.New()
+	router.GET("/admin/roles", NewRoleHandler(mockRoleUsecase, nil, logger).ListRoles)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/roles", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), entity.PermPostDeleteAny)
+	mockRoleUsecase.AssertExpectations(t)
+}
+
+func TestRoleHandler_CreateRole_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleUsecase := new(mocks.RoleUsecase)
+
+	role := entity.Role{Name: "editor", Permissions: []string{entity.PermPostUpdateAny}}
+	mockRoleUsecase.On("CreateRole", role).Return(role, nil)
+
+	router := gin.New()
+	router.POST("/admin/roles", NewRoleHandler(mockRoleUsecase, nil, logger).CreateRole)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/roles", strings.NewReader(`{"name":"editor","permissions":["post.update.any"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockRoleUsecase.AssertExpectations(t)
+}
+
+func TestRoleHandler_CreateRole_BadRequest(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleUsecase := new(mocks.RoleUsecase)
+
+	router := gin.New()
+	router.POST("/admin/roles", NewRoleHandler(mockRoleUsecase, nil, logger).CreateRole)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/roles", strings.NewReader(`{"description":"no name"}`)))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRoleHandler_DeleteRole_Errors(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "ghost", err: usecase.ErrRoleNotFound, status: http.StatusNotFound},
+		{name: "moderator", err: usecase.ErrRoleInUse, status: http.StatusConflict},
+		{name: "admin", err: usecase.ErrProtectedRole, status: http.StatusConflict},
+		{name: "editor", err: nil, status: http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRoleUsecase := new(mocks.RoleUsecase)
+			if errors.Is(tc.err, usecase.ErrRoleNotFound) {
+				mockRoleUsecase.On("GetRole", tc.name).Return(entity.Role{}, tc.err)
+			} else {
+				mockRoleUsecase.On("GetRole", tc.name).Return(entity.Role{Name: tc.name}, nil)
+				mockRoleUsecase.On("DeleteRole", tc.name).Return(tc.err)
+			}
+
+			router := gin.New()
+			router.DELETE("/admin/roles/:name", NewRoleHandler(mockRoleUsecase, nil, logger).DeleteRole)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/roles/"+tc.name, nil))
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
+
+func TestRoleHandler_SetRolePermissions_UnknownPermission(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleUsecase := new(mocks.RoleUsecase)
+
+	mockRoleUsecase.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockRoleUsecase.On("SetRolePermissions", "moderator", []string{"post.pin"}).Return(entity.Role{}, usecase.ErrUnknownPermission)
+
+	router := gin.New()
+	router.PUT("/admin/roles/:name/permissions", NewRoleHandler(mockRoleUsecase, nil, logger).SetRolePermissions)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/roles/moderator/permissions", strings.NewReader(`{"permissions":["post.pin"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRoleUsecase.AssertExpectations(t)
+}
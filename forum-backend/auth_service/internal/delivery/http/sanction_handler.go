@@ -0,0 +1,148 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	usecase "github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SanctionHandler struct {
+	sanctionUsecase usecase.SanctionUsecase
+	audit           *Auditor
+	logger          *zap.Logger
+}
+
+func NewSanctionHandler(sanctionUsecase usecase.SanctionUsecase, audit *Auditor, logger *zap.Logger) *SanctionHandler {
+	return &SanctionHandler{sanctionUsecase: sanctionUsecase, audit: audit, logger: logger}
+}
+
+// CreateSanction godoc
+// @Summary Наложить санкцию
+// @Description Блокирует пользователя (ban), оставляет ему только чтение (suspension) или запрещает писать в чат (mute). duration_hours 0 — бессрочно. Блокировка сразу завершает все сессии пользователя. Для ban и suspension нужно право user.ban, для mute — chat.mute. Пользователя с правом user.ban или с ролью не ниже своей наказать нельзя
+// @Tags Санкции
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Param request body entity.CreateSanctionRequest true "Вид, причина и срок санкции"
+// @Success 201 {object} entity.Sanction
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/users/{id}/sanctions [post]
+func (h *SanctionHandler) CreateSanction(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный id пользователя"})
+		return
+	}
+	var req entity.CreateSanctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON for sanction", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+		return
+	}
+
+	duration := time.Duration(req.DurationHours) * time.Hour
+	sanction, err := h.sanctionUsecase.Issue(principal, userID, req.Type, req.Reason, duration)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	h.audit.Record(c, entity.AuditSanctionIssue, entity.AuditTargetSanction, sanction.ID, nil, sanction)
+	c.JSON(http.StatusCreated, sanction)
+}
+
+// ListUserSanctions godoc
+// @Summary История санкций пользователя
+// @Description Возвращает все санкции пользователя, включая истекшие и снятые, начиная с последней (требуется право sanction.view)
+// @Tags Санкции
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {array} entity.Sanction
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /admin/users/{id}/sanctions [get]
+func (h *SanctionHandler) ListUserSanctions(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный id пользователя"})
+		return
+	}
+	sanctions, err := h.sanctionUsecase.ListUserSanctions(userID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	if sanctions == nil {
+		sanctions = []entity.Sanction{}
+	}
+	c.JSON(http.StatusOK, sanctions)
+}
+
+// RevokeSanction godoc
+// @Summary Снять санкцию
+// @Description Досрочно снимает действующую санкцию. Нужно то же право, что и для ее наложения
+// @Tags Санкции
+// @Security BearerAuth
+// @Param id path int true "ID санкции"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+// @Router /admin/sanctions/{id} [delete]
+func (h *SanctionHandler) RevokeSanction(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "некорректный id санкции"})
+		return
+	}
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "требуется авторизация"})
+		return
+	}
+	revoked, err := h.sanctionUsecase.Revoke(principal, id)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	before := revoked
+	before.RevokedAt, before.RevokedBy, before.Active = nil, nil, true
+	h.audit.Record(c, entity.AuditSanctionRevoke, entity.AuditTargetSanction, id, before, revoked)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SanctionHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound), errors.Is(err, usecase.ErrSanctionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSanctionForbidden), errors.Is(err, usecase.ErrSelfSanction), errors.Is(err, usecase.ErrSanctionTargetRank):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSanctionNotRevocable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidSanction), errors.Is(err, usecase.ErrSanctionReason), errors.Is(err, usecase.ErrSanctionDuration):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Sanction operation failed", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var sanctionIssuer = entity.Principal{UserID: 1, Role: "moderator", Permissions: []string{entity.PermUserBan, entity.PermSanctionView}}
+
+func newSanctionRouter(sanctionUsecase usecase.SanctionUsecase) *gin.Engine {
+	handler := NewSanctionHandler(sanctionUsecase, nil, zap.NewNop())
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(principalKey, sanctionIssuer)
+	})
+	router.POST("/admin/users/:id/sanctions", handler.CreateSanction)
+	router.GET("/admin/users/:id/sanctions", handler.ListUserSanctions)
+	router.DELETE("/admin/sanctions/:id", handler.RevokeSanction)
+	return router
+}
+
+func TestSanctionHandler_CreateSanction_Success(t *testing.T) {
+	mockSanctionUsecase := new(mocks.SanctionUsecase)
+	mockSanctionUsecase.On("Issue", sanctionIssuer, 5, entity.SanctionSuspension, "оскорбления", 72*time.Hour).
+		Return(entity.Sanction{ID: 2, UserID: 5, Type: entity.SanctionSuspension, Reason: "оскорбления", Active: true}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/5/sanctions", strings.NewReader(`{"type":"suspension","reason":"оскорбления","duration_hours":72}`))
+	newSanctionRouter(mockSanctionUsecase).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"active":true`)
+	mockSanctionUsecase.AssertExpectations(t)
+}
+
+func TestSanctionHandler_CreateSanction_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrInvalidSanction, http.StatusBadRequest},
+		{usecase.ErrSanctionForbidden, http.StatusForbidden},
+		{usecase.ErrSelfSanction, http.StatusForbidden},
+		{usecase.ErrSanctionTargetRank, http.StatusForbidden},
+		{usecase.ErrUserNotFound, http.StatusNotFound},
+	} {
+		mockSanctionUsecase := new(mocks.SanctionUsecase)
+		mockSanctionUsecase.On("Issue", mock.Anything, 5, entity.SanctionBan, "спам", time.Duration(0)).Return(entity.Sanction{}, tc.err)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/5/sanctions", strings.NewReader(`{"type":"ban","reason":"спам"}`))
+		newSanctionRouter(mockSanctionUsecase).ServeHTTP(w, req)
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestSanctionHandler_ListUserSanctions_Empty(t *testing.T) {
+	mockSanctionUsecase := new(mocks.SanctionUsecase)
+	mockSanctionUsecase.On("ListUserSanctions", 5).Return(nil, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/users/5/sanctions", nil)
+	newSanctionRouter(mockSanctionUsecase).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestSanctionHandler_RevokeSanction(t *testing.T) {
+	mockSanctionUsecase := new(mocks.SanctionUsecase)
+	mockSanctionUsecase.On("Revoke", sanctionIssuer, 3).Return(entity.Sanction{ID: 3, Type: entity.SanctionBan}, nil)
+	mockSanctionUsecase.On("Revoke", sanctionIssuer, 4).Return(entity.Sanction{}, usecase.ErrSanctionNotRevocable)
+
+	router := newSanctionRouter(mockSanctionUsecase)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/sanctions/3", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/sanctions/4", nil))
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
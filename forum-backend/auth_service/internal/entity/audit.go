@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// Действия, которые пишутся в журнал. Имена общие для обоих сервисов.
+const (
+	AuditUserRoleUpdate   = "user.role.update"
+	AuditRoleCreate       = "role.create"
+	AuditRoleDelete       = "role.delete"
+	AuditRolePermissions  = "role.permissions.update"
+	AuditPermissionCreate = "permission.create"
+	AuditPermissionDelete = "permission.delete"
+	AuditInviteCreate     = "invite.create"
+	AuditInviteRevoke     = "invite.revoke"
+	AuditSanctionIssue    = "sanction.issue"
+	AuditSanctionRevoke   = "sanction.revoke"
+)
+
+// Типы объектов в журнале.
+const (
+	AuditTargetUser       = "user"
+	AuditTargetRole       = "role"
+	AuditTargetPermission = "permission"
+	AuditTargetInvite     = "invite"
+	AuditTargetSanction   = "sanction"
+)
+
+// AuditEntry — запись журнала привилегированных действий. Before и After —
+// снимки объекта до и после действия; у создания нет Before, у удаления —
+// After.
+type AuditEntry struct {
+	ID         int           `json:"id" db:"id" example:"1"`
+	ActorID    int           `json:"actor_id" db:"actor_id" example:"1"`
+	ActorRole  string        `json:"actor_role" db:"actor_role" example:"admin"`
+	Action     string        `json:"action" db:"action" example:"user.role.update"`
+	TargetType string        `json:"target_type" db:"target_type" example:"user"`
+	TargetID   string        `json:"target_id" db:"target_id" example:"7"`
+	Before     AuditSnapshot `json:"before" db:"before" swaggertype:"object"`
+	After      AuditSnapshot `json:"after" db:"after" swaggertype:"object"`
+	IP         string        `json:"ip" db:"ip" example:"192.0.2.10"`
+	UserAgent  string        `json:"user_agent" db:"user_agent" example:"Mozilla/5.0"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter — условия выборки из журнала. Пустые поля не ограничивают
+// выборку, Limit 0 — без ограничения (для выгрузки).
+type AuditFilter struct {
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AuditSnapshot — JSON-снимок объекта. В базе хранится текстом, в ответах
+// API отдается как вложенный JSON.
+type AuditSnapshot []byte
+
+func (s AuditSnapshot) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+func (s *AuditSnapshot) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = append((*s)[0:0], data...)
+	return nil
+}
+
+func (s *AuditSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+	case string:
+		*s = AuditSnapshot(v)
+	case []byte:
+		*s = append(AuditSnapshot(nil), v...)
+	default:
+		return errors.New("unsupported audit snapshot type")
+	}
+	return nil
+}
+
+func (s AuditSnapshot) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return string(s), nil
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Invite — код приглашения, при регистрации по которому пользователь
+// получает Role. Сам код не хранится, только его хэш.
+type Invite struct {
+	ID        int        `json:"id" db:"id" example:"1"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	Role      string     `json:"role" db:"role" example:"moderator"`
+	MaxUses   int        `json:"max_uses" db:"max_uses" example:"1"`
+	Uses      int        `json:"uses" db:"uses" example:"0"`
+	CreatedBy int        `json:"created_by" db:"created_by" example:"1"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Usable сообщает, можно ли зарегистрироваться по приглашению в момент now.
+func (i Invite) Usable(now time.Time) bool {
+	return i.RevokedAt == nil && i.Uses < i.MaxUses && now.Before(i.ExpiresAt)
+}
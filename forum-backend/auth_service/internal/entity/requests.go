@@ -1,9 +1,9 @@
 package entity
 
 type RegisterRequest struct {
-	Username string `json:"username" example:"user123"`
-	Password string `json:"password" example:"P@ssw0rd"`
-	Role     string `json:"role" example:"user"`
+	Username   string `json:"username" example:"user123"`
+	Password   string `json:"password" example:"P@ssw0rd"`
+	InviteCode string `json:"invite_code,omitempty" example:"hQ7nW2c9xLkP4tZr"`
 }
 
 type LoginRequest struct {
@@ -15,3 +15,35 @@ type UpdateRoleRequest struct {
 	UserID  int    `json:"user_id" example:"1"`
 	NewRole string `json:"new_role" example:"admin"`
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"kq3X0m9b2rZ..."`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required" example:"editor"`
+	Description string   `json:"description" example:"Редактор"`
+	Permissions []string `json:"permissions" example:"post.update.any"`
+}
+
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" example:"post.delete.any,comment.delete.any"`
+}
+
+type CreatePermissionRequest struct {
+	Name        string `json:"name" binding:"required" example:"post.pin"`
+	Description string `json:"description" example:"Закрепление постов"`
+}
+
+type CreateInviteRequest struct {
+	Role           string `json:"role" binding:"required" example:"moderator"`
+	MaxUses        int    `json:"max_uses" binding:"omitempty,min=1" example:"1"`
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1" example:"72"`
+}
+
+type CreateSanctionRequest struct {
+	Type   string `json:"type" binding:"required" example:"suspension"`
+	Reason string `json:"reason" binding:"required" example:"Спам в комментариях"`
+	// DurationHours — срок санкции; 0 — бессрочно
+	DurationHours int `json:"duration_hours" binding:"omitempty,min=0" example:"72"`
+}
@@ -1,16 +1,45 @@
 package entity
 
+import "time"
+
 type RegisterResponse struct {
 	Message string `json:"message" example:"User registered successfully"`
 }
 
 type LoginResponse struct {
-	Token    string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	Role     string `json:"role" example:"user"`
-	Username string `json:"username" example:"user123"`
-	UserID   int    `json:"userID" example:"1"`
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"kq3X0m9b2rZ..."`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+	Role         string `json:"role" example:"user"`
+	Username     string `json:"username" example:"user123"`
+	UserID       int    `json:"userID" example:"1"`
+}
+
+type RefreshResponse struct {
+	Token        string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token" example:"kq3X0m9b2rZ..."`
+	ExpiresIn    int    `json:"expires_in" example:"900"`
+}
+
+type MessageResponse struct {
+	Message string `json:"message" example:"Logged out successfully"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error" example:"error message"`
 }
+
+// BannedResponse — ответ на вход заблокированного пользователя. У бессрочной
+// блокировки нет ExpiresAt.
+type BannedResponse struct {
+	Error     string     `json:"error" example:"пользователь заблокирован"`
+	Reason    string     `json:"reason" example:"Спам в комментариях"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateInviteResponse содержит код приглашения. Код показывается только
+// один раз, в ответе на создание.
+type CreateInviteResponse struct {
+	Code string `json:"code" example:"hQ7nW2c9xLkP4tZr"`
+	Invite
+}
@@ -0,0 +1,54 @@
+package entity
+
+// DefaultRole выдается при регистрации без кода приглашения.
+const DefaultRole = "user"
+
+// Права, на которые опираются проверки в обоих сервисах.
+const (
+	PermPostUpdateAny    = "post.update.any"
+	PermPostDeleteAny    = "post.delete.any"
+	PermCommentUpdateAny = "comment.update.any"
+	PermCommentDeleteAny = "comment.delete.any"
+	PermUserBan          = "user.ban"
+	PermChatMute         = "chat.mute"
+	PermRoleAssign       = "role.assign"
+	PermRoleManage       = "role.manage"
+	PermInviteManage     = "invite.manage"
+	PermCategoryManage   = "category.manage"
+	PermTagManage        = "tag.manage"
+	PermTrustManage      = "trust.manage"
+	PermTrustExempt      = "trust.exempt"
+	PermReportReview     = "report.review"
+	PermSanctionView     = "sanction.view"
+	PermAuditView        = "audit.view"
+	PermTrashManage      = "trash.manage"
+	PermChatRoomManage   = "chat.room.manage"
+)
+
+type Role struct {
+	Name        string   `json:"name" db:"name" example:"moderator"`
+	Description string   `json:"description" db:"description" example:"Модератор"`
+	Permissions []string `json:"permissions" db:"-" example:"post.delete.any,comment.delete.any"`
+}
+
+type Permission struct {
+	Name        string `json:"name" db:"name" example:"post.delete.any"`
+	Description string `json:"description" db:"description" example:"Удаление любых постов"`
+}
+
+// Principal — пользователь, от имени которого выполняется запрос.
+type Principal struct {
+	UserID      int      `json:"user_id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// Can сообщает, есть ли у пользователя право permission.
+func (p Principal) Can(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Виды санкций. ban запрещает вход и завершает все сессии, suspension
+// оставляет пользователю только чтение, mute запрещает писать в чат.
+const (
+	SanctionBan        = "ban"
+	SanctionSuspension = "suspension"
+	SanctionMute       = "mute"
+)
+
+// Sanction — санкция против пользователя. ExpiresAt nil — санкция
+// бессрочная. Снятая досрочно санкция хранится с RevokedAt.
+type Sanction struct {
+	ID        int        `json:"id" db:"id" example:"1"`
+	UserID    int        `json:"user_id" db:"user_id" example:"7"`
+	Type      string     `json:"type" db:"type" example:"ban"`
+	Reason    string     `json:"reason" db:"reason" example:"Спам в комментариях"`
+	IssuedBy  int        `json:"issued_by" db:"issued_by" example:"1"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy *int       `json:"revoked_by,omitempty" db:"revoked_by" example:"1"`
+	Active    bool       `json:"active" db:"-" example:"true"`
+}
+
+// ActiveAt сообщает, действует ли санкция в момент now.
+func (s Sanction) ActiveAt(now time.Time) bool {
+	return s.RevokedAt == nil && (s.ExpiresAt == nil || now.Before(*s.ExpiresAt))
+}
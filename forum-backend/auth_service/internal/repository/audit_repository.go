@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"strings"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// AuditRepository работает с журналом audit_log. Журнал только пополняется:
+// методов изменения и удаления нет, их запрещают и триггеры в базе.
+type AuditRepository interface {
+	Append(entry entity.AuditEntry) error
+	// List возвращает записи по фильтру, начиная с последней, и общее
+	// количество подходящих записей.
+	List(filter entity.AuditFilter) ([]entity.AuditEntry, int, error)
+}
+
+const auditColumns = "id, actor_id, actor_role, action, target_type, target_id, before, after, ip, user_agent, created_at"
+
+type auditRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewAuditRepository(db DB, logger *zap.Logger) AuditRepository {
+	return &auditRepository{db: db, logger: logger}
+}
+
+func (r *auditRepository) Append(entry entity.AuditEntry) error {
+	_, err := r.db.Exec("INSERT INTO audit_log (actor_id, actor_role, action, target_type, target_id, before, after, ip, user_agent, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		entry.ActorID, entry.ActorRole, entry.Action, entry.TargetType, entry.TargetID, entry.Before, entry.After, entry.IP, entry.UserAgent, entry.CreatedAt.UTC())
+	if err != nil {
+		r.logger.Error("Failed to append audit entry", zap.Error(err), zap.String("action", entry.Action), zap.Int("actorID", entry.ActorID))
+		return err
+	}
+	return nil
+}
+
+func (r *auditRepository) List(filter entity.AuditFilter) ([]entity.AuditEntry, int, error) {
+	where, args := auditFilterConditions(filter)
+
+	var total int
+	if err := r.db.Get(&total, "SELECT COUNT(*) FROM audit_log"+where, args...); err != nil {
+		r.logger.Error("Failed to count audit entries", zap.Error(err))
+		return nil, 0, err
+	}
+
+	query := "SELECT " + auditColumns + " FROM audit_log" + where + " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+	var entries []entity.AuditEntry
+	if err := r.db.Select(&entries, query, args...); err != nil {
+		r.logger.Error("Failed to list audit entries", zap.Error(err))
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+func auditFilterConditions(filter entity.AuditFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if filter.ActorID != 0 {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.ActorID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.TargetID)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To.UTC())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestAuditRepository_Append(t *testing.T) {
+	mockDB := new(mocks.DB)
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := entity.AuditEntry{
+		ActorID:    1,
+		ActorRole:  "admin",
+		Action:     entity.AuditUserRoleUpdate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   "2",
+		Before:     entity.AuditSnapshot(`{"role":"user"}`),
+		After:      entity.AuditSnapshot(`{"role":"moderator"}`),
+		IP:         "10.0.0.1",
+		UserAgent:  "curl/8.0",
+		CreatedAt:  createdAt,
+	}
+
+	mockDB.On("Exec", mock.MatchedBy(func(query string) bool { return query != "" }),
+		1, "admin", entity.AuditUserRoleUpdate, entity.AuditTargetUser, "2", entry.Before, entry.After, "10.0.0.1", "curl/8.0", createdAt).
+		Return(sqlResult{affected: 1}, nil)
+
+	auditRepo := NewAuditRepository(mockDB, zap.NewNop())
+
+	assert.NoError(t, auditRepo.Append(entry))
+	mockDB.AssertExpectations(t)
+}
+
+func TestAuditRepository_List_Filters(t *testing.T) {
+	mockDB := new(mocks.DB)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	filter := entity.AuditFilter{ActorID: 1, TargetType: entity.AuditTargetUser, TargetID: "2", From: &from, Limit: 50, Offset: 50}
+	where := " WHERE actor_id = ? AND target_type = ? AND target_id = ? AND created_at >= ?"
+
+	mockDB.On("Get", mock.Anything, "SELECT COUNT(*) FROM audit_log"+where, 1, entity.AuditTargetUser, "2", from.UTC()).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*int) = 51
+		}).
+		Return(nil)
+	mockDB.On("Select", mock.Anything, "SELECT "+auditColumns+" FROM audit_log"+where+" ORDER BY id DESC LIMIT ? OFFSET ?",
+		1, entity.AuditTargetUser, "2", from.UTC(), 50, 50).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]entity.AuditEntry) = []entity.AuditEntry{{ID: 1, ActorID: 1, Action: entity.AuditUserRoleUpdate}}
+		}).
+		Return(nil)
+
+	auditRepo := NewAuditRepository(mockDB, zap.NewNop())
+
+	entries, total, err := auditRepo.List(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 51, total)
+	assert.Len(t, entries, 1)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type InviteRepository interface {
+	CreateInvite(invite entity.Invite) (int, error)
+	ListInvites() ([]entity.Invite, error)
+	GetInviteByCodeHash(codeHash string) (entity.Invite, error)
+	ConsumeInvite(id int) (bool, error)
+	ReleaseInvite(id int) error
+	RevokeInvite(id int) (bool, error)
+}
+
+type inviteRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewInviteRepository(db DB, logger *zap.Logger) InviteRepository {
+	return &inviteRepository{db: db, logger: logger}
+}
+
+func (r *inviteRepository) CreateInvite(invite entity.Invite) (int, error) {
+	result, err := r.db.Exec("INSERT INTO invites (code_hash, role, max_uses, created_by, expires_at) VALUES (?, ?, ?, ?, ?)",
+		invite.CodeHash, invite.Role, invite.MaxUses, invite.CreatedBy, invite.ExpiresAt.UTC())
+	if err != nil {
+		r.logger.Error("Failed to create invite", zap.Error(err), zap.String("role", invite.Role))
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	r.logger.Info("Invite created successfully", zap.Int64("inviteID", id), zap.String("role", invite.Role))
+	return int(id), nil
+}
+
+func (r *inviteRepository) ListInvites() ([]entity.Invite, error) {
+	var invites []entity.Invite
+	err := r.db.Select(&invites, "SELECT id, code_hash, role, max_uses, uses, created_by, created_at, expires_at, revoked_at FROM invites ORDER BY id DESC")
+	if err != nil {
+		r.logger.Error("Failed to list invites", zap.Error(err))
+		return nil, err
+	}
+	return invites, nil
+}
+
+func (r *inviteRepository) GetInviteByCodeHash(codeHash string) (entity.Invite, error) {
+	var invite entity.Invite
+	err := r.db.Get(&invite, "SELECT id, code_hash, role, max_uses, uses, created_by, created_at, expires_at, revoked_at FROM invites WHERE code_hash = ?", codeHash)
+	if err != nil {
+		r.logger.Warn("Failed to get invite", zap.Error(err))
+		return invite, err
+	}
+	return invite, nil
+}
+
+// ConsumeInvite списывает одно использование и сообщает, удалось ли это.
+// Проверка лимита и отзыва выполняется тем же запросом, поэтому два
+// параллельных запроса не смогут потратить последнее использование дважды.
+func (r *inviteRepository) ConsumeInvite(id int) (bool, error) {
+	result, err := r.db.Exec("UPDATE invites SET uses = uses + 1 WHERE id = ? AND revoked_at IS NULL AND uses < max_uses", id)
+	if err != nil {
+		r.logger.Error("Failed to consume invite", zap.Error(err), zap.Int("inviteID", id))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ReleaseInvite возвращает использование, если регистрация по приглашению
+// не удалась.
+func (r *inviteRepository) ReleaseInvite(id int) error {
+	if _, err := r.db.Exec("UPDATE invites SET uses = uses - 1 WHERE id = ? AND uses > 0", id); err != nil {
+		r.logger.Error("Failed to release invite", zap.Error(err), zap.Int("inviteID", id))
+		return err
+	}
+	return nil
+}
+
+// RevokeInvite отзывает приглашение; false означает, что активного
+// приглашения с таким id нет.
+func (r *inviteRepository) RevokeInvite(id int) (bool, error) {
+	result, err := r.db.Exec("UPDATE invites SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		r.logger.Error("Failed to revoke invite", zap.Error(err), zap.Int("inviteID", id))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		r.logger.Info("Invite revoked", zap.Int("inviteID", id))
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestInviteRepository_ConsumeInvite_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "UPDATE invites SET uses = uses + 1 WHERE id = ? AND revoked_at IS NULL AND uses < max_uses", 3).
+		Return(sqlResult{affected: 1}, nil)
+
+	inviteRepo := NewInviteRepository(mockDB, logger)
+
+	consumed, err := inviteRepo.ConsumeInvite(3)
+
+	assert.NoError(t, err)
+	assert.True(t, consumed)
+	mockDB.AssertExpectations(t)
+}
+
+func TestInviteRepository_ConsumeInvite_Exhausted(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", mock.Anything, 3).Return(sqlResult{affected: 0}, nil)
+
+	inviteRepo := NewInviteRepository(mockDB, logger)
+
+	consumed, err := inviteRepo.ConsumeInvite(3)
+
+	assert.NoError(t, err)
+	assert.False(t, consumed)
+}
+
+func TestInviteRepository_RevokeInvite_NotFound(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "UPDATE invites SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", mock.Anything, 7).
+		Return(sqlResult{affected: 0}, nil)
+
+	inviteRepo := NewInviteRepository(mockDB, logger)
+
+	revoked, err := inviteRepo.RevokeInvite(7)
+
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestInviteRepository_ListInvites_Failure(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Select", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	inviteRepo := NewInviteRepository(mockDB, logger)
+
+	invites, err := inviteRepo.ListInvites()
+
+	assert.Error(t, err)
+	assert.Nil(t, invites)
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"strings"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type RoleRepository interface {
+	ListRoles() ([]entity.Role, error)
+	GetRole(name string) (entity.Role, error)
+	CreateRole(role entity.Role) error
+	DeleteRole(name string) error
+	CountUsersWithRole(name string) (int, error)
+	GetRolePermissions(role string) ([]string, error)
+	SetRolePermissions(role string, permissions []string) error
+	ListPermissions() ([]entity.Permission, error)
+	CreatePermission(permission entity.Permission) error
+	DeletePermission(name string) error
+}
+
+type roleRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewRoleRepository(db DB, logger *zap.Logger) RoleRepository {
+	return &roleRepository{db: db, logger: logger}
+}
+
+func (r *roleRepository) ListRoles() ([]entity.Role, error) {
+	var roles []entity.Role
+	if err := r.db.Select(&roles, "SELECT name, description FROM roles ORDER BY name"); err != nil {
+		r.logger.Error("Failed to list roles", zap.Error(err))
+		return nil, err
+	}
+	for i := range roles {
+		permissions, err := r.GetRolePermissions(roles[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		roles[i].Permissions = permissions
+	}
+	r.logger.Info("Roles listed successfully", zap.Int("count", len(roles)))
+	return roles, nil
+}
+
+func (r *roleRepository) GetRole(name string) (entity.Role, error) {
+	var role entity.Role
+	if err := r.db.Get(&role, "SELECT name, description FROM roles WHERE name = ?", name); err != nil {
+		r.logger.Error("Failed to get role", zap.Error(err), zap.String("role", name))
+		return role, err
+	}
+	permissions, err := r.GetRolePermissions(name)
+	if err != nil {
+		return role, err
+	}
+	role.Permissions = permissions
+	return role, nil
+}
+
+func (r *roleRepository) CreateRole(role entity.Role) error {
+	if _, err := r.db.Exec("INSERT INTO roles (name, description) VALUES (?, ?)", role.Name, role.Description); err != nil {
+		r.logger.Error("Failed to create role", zap.Error(err), zap.String("role", role.Name))
+		return err
+	}
+	r.logger.Info("Role created successfully", zap.String("role", role.Name))
+	return nil
+}
+
+// DeleteRole удаляет роль вместе с ее правами. Внешние ключи в SQLite
+// по умолчанию выключены, поэтому role_permissions чистится явно.
+func (r *roleRepository) DeleteRole(name string) error {
+	if _, err := r.db.Exec("DELETE FROM role_permissions WHERE role = ?", name); err != nil {
+		r.logger.Error("Failed to delete role permissions", zap.Error(err), zap.String("role", name))
+		return err
+	}
+	if _, err := r.db.Exec("DELETE FROM roles WHERE name = ?", name); err != nil {
+		r.logger.Error("Failed to delete role", zap.Error(err), zap.String("role", name))
+		return err
+	}
+	r.logger.Info("Role deleted successfully", zap.String("role", name))
+	return nil
+}
+
+func (r *roleRepository) CountUsersWithRole(name string) (int, error) {
+	var count int
+	if err := r.db.Get(&count, "SELECT COUNT(*) FROM users WHERE role = ?", name); err != nil {
+		r.logger.Error("Failed to count users with role", zap.Error(err), zap.String("role", name))
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *roleRepository) GetRolePermissions(role string) ([]string, error) {
+	permissions := []string{}
+	if err := r.db.Select(&permissions, "SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission", role); err != nil {
+		r.logger.Error("Failed to get role permissions", zap.Error(err), zap.String("role", role))
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *roleRepository) SetRolePermissions(role string, permissions []string) error {
+	if _, err := r.db.Exec("DELETE FROM role_permissions WHERE role = ?", role); err != nil {
+		r.logger.Error("Failed to clear role permissions", zap.Error(err), zap.String("role", role))
+		return err
+	}
+	if len(permissions) == 0 {
+		r.logger.Info("Role permissions cleared", zap.String("role", role))
+		return nil
+	}
+
+	placeholders := make([]string, len(permissions))
+	args := make([]any, 0, len(permissions)*2)
+	for i, permission := range permissions {
+		placeholders[i] = "(?, ?)"
+		args = append(args, role, permission)
+	}
+	query := "INSERT INTO role_permissions (role, permission) VALUES " + strings.Join(placeholders, ", ")
+	if _, err := r.db.Exec(query, args...); err != nil {
+		r.logger.Error("Failed to set role permissions", zap.Error(err), zap.String("role", role))
+		return err
+	}
+	r.logger.Info("Role permissions updated", zap.String("role", role), zap.Strings("permissions", permissions))
+	return nil
+}
+
+func (r *roleRepository) ListPermissions() ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	if err := r.db.Select(&permissions, "SELECT name, description FROM permissions ORDER BY name"); err != nil {
+		r.logger.Error("Failed to list permissions", zap.Error(err))
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *roleRepository) CreatePermission(permission entity.Permission) error {
+	if _, err := r.db.Exec("INSERT INTO permissions (name, description) VALUES (?, ?)", permission.Name, permission.Description); err != nil {
+		r.logger.Error("Failed to create permission", zap.Error(err), zap.String("permission", permission.Name))
+		return err
+	}
+	r.logger.Info("Permission created successfully", zap.String("permission", permission.Name))
+	return nil
+}
+
+func (r *roleRepository) DeletePermission(name string) error {
+	if _, err := r.db.Exec("DELETE FROM role_permissions WHERE permission = ?", name); err != nil {
+		r.logger.Error("Failed to detach permission", zap.Error(err), zap.String("permission", name))
+		return err
+	}
+	if _, err := r.db.Exec("DELETE FROM permissions WHERE name = ?", name); err != nil {
+		r.logger.Error("Failed to delete permission", zap.Error(err), zap.String("permission", name))
+		return err
+	}
+	r.logger.Info("Permission deleted successfully", zap.String("permission", name))
+	return nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestRoleRepository_SetRolePermissions_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "DELETE FROM role_permissions WHERE role = ?", "moderator").Return(sqlResult{affected: 3}, nil)
+	mockDB.On("Exec", "INSERT INTO role_permissions (role, permission) VALUES (?, ?), (?, ?)",
+		"moderator", "post.delete.any", "moderator", "chat.mute").Return(sqlResult{affected: 2}, nil)
+
+	roleRepo := NewRoleRepository(mockDB, logger)
+
+	err := roleRepo.SetRolePermissions("moderator", []string{"post.delete.any", "chat.mute"})
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestRoleRepository_SetRolePermissions_Empty(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "DELETE FROM role_permissions WHERE role = ?", "moderator").Return(sqlResult{affected: 3}, nil)
+
+	roleRepo := NewRoleRepository(mockDB, logger)
+
+	err := roleRepo.SetRolePermissions("moderator", nil)
+
+	assert.NoError(t, err)
+	mockDB.AssertNumberOfCalls(t, "Exec", 1)
+}
+
+func TestRoleRepository_GetRolePermissions_Failure(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Select", mock.Anything, "SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission", "admin").
+		Return(errors.New("db error"))
+
+	roleRepo := NewRoleRepository(mockDB, logger)
+
+	permissions, err := roleRepo.GetRolePermissions("admin")
+
+	assert.Error(t, err)
+	assert.Nil(t, permissions)
+}
+
+func TestRoleRepository_DeleteRole_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "DELETE FROM role_permissions WHERE role = ?", "editor").Return(sqlResult{affected: 1}, nil)
+	mockDB.On("Exec", "DELETE FROM roles WHERE name = ?", "editor").Return(sqlResult{affected: 1}, nil)
+
+	roleRepo := NewRoleRepository(mockDB, logger)
+
+	err := roleRepo.DeleteRole("editor")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestRoleRepository_CreatePermission_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	permission := entity.Permission{Name: "post.pin", Description: "Закрепление постов"}
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "INSERT INTO permissions (name, description) VALUES (?, ?)", permission.Name, permission.Description).
+		Return(sqlResult{affected: 1}, nil)
+
+	roleRepo := NewRoleRepository(mockDB, logger)
+
+	err := roleRepo.CreatePermission(permission)
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type SanctionRepository interface {
+	CreateSanction(sanction entity.Sanction) (int, error)
+	GetSanction(id int) (entity.Sanction, error)
+	// ListUserSanctions возвращает всю историю санкций пользователя, начиная
+	// с последней.
+	ListUserSanctions(userID int) ([]entity.Sanction, error)
+	// GetActiveSanctions возвращает санкции пользователя, действующие в
+	// момент now.
+	GetActiveSanctions(userID int, now time.Time) ([]entity.Sanction, error)
+	RevokeSanction(id, revokedBy int) (bool, error)
+}
+
+const sanctionColumns = "id, user_id, type, reason, issued_by, created_at, expires_at, revoked_at, revoked_by"
+
+type sanctionRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewSanctionRepository(db DB, logger *zap.Logger) SanctionRepository {
+	return &sanctionRepository{db: db, logger: logger}
+}
+
+func (r *sanctionRepository) CreateSanction(sanction entity.Sanction) (int, error) {
+	var expiresAt *time.Time
+	if sanction.ExpiresAt != nil {
+		utc := sanction.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	result, err := r.db.Exec("INSERT INTO user_sanctions (user_id, type, reason, issued_by, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		sanction.UserID, sanction.Type, sanction.Reason, sanction.IssuedBy, sanction.CreatedAt.UTC(), expiresAt)
+	if err != nil {
+		r.logger.Error("Failed to create sanction", zap.Error(err), zap.Int("userID", sanction.UserID), zap.String("type", sanction.Type))
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	r.logger.Info("Sanction created successfully", zap.Int64("sanctionID", id), zap.Int("userID", sanction.UserID), zap.String("type", sanction.Type))
+	return int(id), nil
+}
+
+func (r *sanctionRepository) GetSanction(id int) (entity.Sanction, error) {
+	var sanction entity.Sanction
+	err := r.db.Get(&sanction, "SELECT "+sanctionColumns+" FROM user_sanctions WHERE id = ?", id)
+	if err != nil {
+		r.logger.Warn("Failed to get sanction", zap.Error(err), zap.Int("sanctionID", id))
+		return sanction, err
+	}
+	return sanction, nil
+}
+
+func (r *sanctionRepository) ListUserSanctions(userID int) ([]entity.Sanction, error) {
+	var sanctions []entity.Sanction
+	err := r.db.Select(&sanctions, "SELECT "+sanctionColumns+" FROM user_sanctions WHERE user_id = ? ORDER BY id DESC", userID)
+	if err != nil {
+		r.logger.Error("Failed to list sanctions", zap.Error(err), zap.Int("userID", userID))
+		return nil, err
+	}
+	return sanctions, nil
+}
+
+func (r *sanctionRepository) GetActiveSanctions(userID int, now time.Time) ([]entity.Sanction, error) {
+	var sanctions []entity.Sanction
+	err := r.db.Select(&sanctions, "SELECT "+sanctionColumns+" FROM user_sanctions WHERE user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) ORDER BY id DESC",
+		userID, now.UTC())
+	if err != nil {
+		r.logger.Error("Failed to get active sanctions", zap.Error(err), zap.Int("userID", userID))
+		return nil, err
+	}
+	return sanctions, nil
+}
+
+// RevokeSanction снимает санкцию досрочно; false означает, что санкции с
+// таким id нет или она уже снята.
+func (r *sanctionRepository) RevokeSanction(id, revokedBy int) (bool, error) {
+	result, err := r.db.Exec("UPDATE user_sanctions SET revoked_at = ?, revoked_by = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), revokedBy, id)
+	if err != nil {
+		r.logger.Error("Failed to revoke sanction", zap.Error(err), zap.Int("sanctionID", id))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		r.logger.Info("Sanction revoked", zap.Int("sanctionID", id), zap.Int("revokedBy", revokedBy))
+	}
+	return affected > 0, nil
+}
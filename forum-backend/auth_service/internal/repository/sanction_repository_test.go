@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestSanctionRepository_GetActiveSanctions(t *testing.T) {
+	mockDB := new(mocks.DB)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("MSK", 3*60*60))
+
+	mockDB.On("Select", mock.Anything,
+		"SELECT "+sanctionColumns+" FROM user_sanctions WHERE user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?) ORDER BY id DESC",
+		5, now.UTC()).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]entity.Sanction) = []entity.Sanction{{ID: 1, UserID: 5, Type: entity.SanctionBan}}
+		}).
+		Return(nil)
+
+	sanctionRepo := NewSanctionRepository(mockDB, zap.NewNop())
+
+	sanctions, err := sanctionRepo.GetActiveSanctions(5, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, sanctions, 1)
+	mockDB.AssertExpectations(t)
+}
+
+func TestSanctionRepository_RevokeSanction(t *testing.T) {
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", "UPDATE user_sanctions SET revoked_at = ?, revoked_by = ? WHERE id = ? AND revoked_at IS NULL", mock.Anything, 1, 3).
+		Return(sqlResult{affected: 1}, nil)
+
+	sanctionRepo := NewSanctionRepository(mockDB, zap.NewNop())
+
+	revoked, err := sanctionRepo.RevokeSanction(3, 1)
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	mockDB.AssertExpectations(t)
+}
+
+func TestSanctionRepository_RevokeSanction_AlreadyRevoked(t *testing.T) {
+	mockDB := new(mocks.DB)
+	mockDB.On("Exec", mock.Anything, mock.Anything, 1, 3).Return(sqlResult{affected: 0}, nil)
+
+	sanctionRepo := NewSanctionRepository(mockDB, zap.NewNop())
+
+	revoked, err := sanctionRepo.RevokeSanction(3, 1)
+
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
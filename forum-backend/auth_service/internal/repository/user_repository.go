@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	"go.uber.org/zap"
@@ -11,14 +13,24 @@ import (
 type DB interface {
 	Exec(query string, args ...any) (sql.Result, error)
 	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type AuthRepository interface {
 	Register(user entity.User) error
 	GetUserByUsername(username string) (entity.User, error)
-	SaveToken(userID int, token string) error
+	GetUserByID(userID int) (entity.User, error)
+	SaveToken(userID int, token, familyID string, expiresAt time.Time) error
+	IsTokenRevoked(token string) (bool, error)
+	GetTokenFamily(token string) (string, error)
+	SaveRefreshToken(token entity.RefreshToken) error
+	GetRefreshToken(tokenHash string) (entity.RefreshToken, error)
+	RevokeRefreshToken(id int) (bool, error)
+	RevokeTokenFamily(familyID string) error
+	RevokeUserTokens(userID int) error
 	GetUsernameByID(ctx context.Context, userID int) (string, error)
+	GetUsersByIDs(userIDs []int) ([]entity.User, error)
 	UpdateUserRole(userID int, newRole string) error
 }
 
@@ -52,8 +64,18 @@ func (r *authRepository) GetUserByUsername(username string) (entity.User, error)
 	return user, nil
 }
 
-func (r *authRepository) SaveToken(userID int, token string) error {
-	_, err := r.db.Exec("INSERT INTO tokens (user_id, token) VALUES (?, ?)", userID, token)
+func (r *authRepository) GetUserByID(userID int) (entity.User, error) {
+	var user entity.User
+	err := r.db.Get(&user, "SELECT id, username, password, role FROM users WHERE id=?", userID)
+	if err != nil {
+		r.logger.Error("Failed to get user by ID", zap.Error(err), zap.Int("userID", userID))
+		return user, err
+	}
+	return user, nil
+}
+
+func (r *authRepository) SaveToken(userID int, token, familyID string, expiresAt time.Time) error {
+	_, err := r.db.Exec("INSERT INTO tokens (user_id, token, family_id, expires_at) VALUES (?, ?, ?, ?)", userID, token, familyID, expiresAt.UTC())
 	if err != nil {
 		r.logger.Error("Failed to save token", zap.Error(err), zap.Int("userID", userID))
 		return err
@@ -62,6 +84,95 @@ func (r *authRepository) SaveToken(userID int, token string) error {
 	return nil
 }
 
+// IsTokenRevoked считает отозванным и токен, которого нет в tokens: все
+// выданные токены сохраняются, поэтому неизвестный токен выдан не нами или
+// уже удален.
+func (r *authRepository) IsTokenRevoked(token string) (bool, error) {
+	var revoked bool
+	err := r.db.Get(&revoked, "SELECT revoked_at IS NOT NULL FROM tokens WHERE token=?", token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+		r.logger.Error("Failed to check token revocation", zap.Error(err))
+		return false, err
+	}
+	return revoked, nil
+}
+
+func (r *authRepository) GetTokenFamily(token string) (string, error) {
+	var familyID sql.NullString
+	err := r.db.Get(&familyID, "SELECT family_id FROM tokens WHERE token=?", token)
+	if err != nil {
+		r.logger.Error("Failed to get token family", zap.Error(err))
+		return "", err
+	}
+	return familyID.String, nil
+}
+
+func (r *authRepository) SaveRefreshToken(token entity.RefreshToken) error {
+	_, err := r.db.Exec("INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at) VALUES (?, ?, ?, ?)",
+		token.UserID, token.TokenHash, token.FamilyID, token.ExpiresAt.UTC())
+	if err != nil {
+		r.logger.Error("Failed to save refresh token", zap.Error(err), zap.Int("userID", token.UserID))
+		return err
+	}
+	return nil
+}
+
+func (r *authRepository) GetRefreshToken(tokenHash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := r.db.Get(&token, "SELECT id, user_id, token_hash, family_id, expires_at, revoked_at FROM refresh_tokens WHERE token_hash=?", tokenHash)
+	if err != nil {
+		r.logger.Error("Failed to get refresh token", zap.Error(err))
+		return token, err
+	}
+	return token, nil
+}
+
+// RevokeRefreshToken помечает токен отозванным и сообщает, был ли он активен
+// до этого. false означает, что токен уже использовали для ротации.
+func (r *authRepository) RevokeRefreshToken(id int) (bool, error) {
+	result, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		r.logger.Error("Failed to revoke refresh token", zap.Error(err), zap.Int("tokenID", id))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *authRepository) RevokeTokenFamily(familyID string) error {
+	now := time.Now().UTC()
+	if _, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", now, familyID); err != nil {
+		r.logger.Error("Failed to revoke refresh token family", zap.Error(err), zap.String("familyID", familyID))
+		return err
+	}
+	if _, err := r.db.Exec("UPDATE tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", now, familyID); err != nil {
+		r.logger.Error("Failed to revoke access tokens of family", zap.Error(err), zap.String("familyID", familyID))
+		return err
+	}
+	r.logger.Info("Token family revoked", zap.String("familyID", familyID))
+	return nil
+}
+
+func (r *authRepository) RevokeUserTokens(userID int) error {
+	now := time.Now().UTC()
+	if _, err := r.db.Exec("UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", now, userID); err != nil {
+		r.logger.Error("Failed to revoke refresh tokens", zap.Error(err), zap.Int("userID", userID))
+		return err
+	}
+	if _, err := r.db.Exec("UPDATE tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL", now, userID); err != nil {
+		r.logger.Error("Failed to revoke access tokens", zap.Error(err), zap.Int("userID", userID))
+		return err
+	}
+	r.logger.Info("All user tokens revoked", zap.Int("userID", userID))
+	return nil
+}
+
 func (r *authRepository) GetUsernameByID(ctx context.Context, userID int) (string, error) {
 	var username string
 	err := r.db.QueryRowContext(ctx, "SELECT username FROM users WHERE id = $1", userID).Scan(&username)
@@ -74,6 +185,29 @@ func (r *authRepository) GetUsernameByID(ctx context.Context, userID int) (strin
 	return username, nil
 }
 
+// GetUsersByIDs одним запросом возвращает найденных пользователей (без
+// паролей). Отсутствующие id просто не попадают в результат.
+func (r *authRepository) GetUsersByIDs(userIDs []int) ([]entity.User, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	query := "SELECT id, username, role FROM users WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+
+	var users []entity.User
+	if err := r.db.Select(&users, query, args...); err != nil {
+		r.logger.Error("Failed to get users by IDs", zap.Error(err), zap.Ints("userIDs", userIDs))
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *authRepository) UpdateUserRole(userID int, newRole string) error {
 	_, err := r.db.Exec("UPDATE users SET role = ? WHERE id = ?", newRole, userID)
 	if err != nil {
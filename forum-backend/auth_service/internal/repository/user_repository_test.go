@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
@@ -100,12 +101,13 @@ func TestAuthRepository_SaveToken_Success(t *testing.T) {
 
 	userID := 1
 	token := "valid.jwt.token"
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	mockDB.On("Exec", "INSERT INTO tokens (user_id, token) VALUES (?, ?)", userID, token).Return(sql.Result(nil), nil)
+	mockDB.On("Exec", "INSERT INTO tokens (user_id, token, family_id, expires_at) VALUES (?, ?, ?, ?)", userID, token, "family", expiresAt).Return(sql.Result(nil), nil)
 
 	authRepo := NewAuthRepository(mockDB, logger)
 
-	err := authRepo.SaveToken(userID, token)
+	err := authRepo.SaveToken(userID, token, "family", expiresAt)
 
 	assert.NoError(t, err)
 
@@ -120,12 +122,13 @@ func TestAuthRepository_SaveToken_Failure(t *testing.T) {
 
 	userID := 1
 	token := "valid.jwt.token"
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	mockDB.On("Exec", "INSERT INTO tokens (user_id, token) VALUES (?, ?)", userID, token).Return(nil, errors.New("failed to save token"))
+	mockDB.On("Exec", "INSERT INTO tokens (user_id, token, family_id, expires_at) VALUES (?, ?, ?, ?)", userID, token, "family", expiresAt).Return(nil, errors.New("failed to save token"))
 
 	authRepo := NewAuthRepository(mockDB, logger)
 
-	err := authRepo.SaveToken(userID, token)
+	err := authRepo.SaveToken(userID, token, "family", expiresAt)
 
 	assert.Error(t, err)
 
@@ -165,3 +168,85 @@ func TestAuthRepository_UpdateUserRole_Failure(t *testing.T) {
 	assert.Error(t, err)
 	mockDB.AssertExpectations(t)
 }
+
+func TestAuthRepository_RevokeRefreshToken_AlreadyRevoked(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockDB := new(mocks.DB)
+
+	mockDB.On("Exec", "UPDATE refresh_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", mock.Anything, 7).Return(sqlResult{affected: 0}, nil)
+
+	authRepo := NewAuthRepository(mockDB, logger)
+
+	rotated, err := authRepo.RevokeRefreshToken(7)
+
+	assert.NoError(t, err)
+	assert.False(t, rotated)
+	mockDB.AssertExpectations(t)
+}
+
+func TestAuthRepository_RevokeTokenFamily_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockDB := new(mocks.DB)
+
+	mockDB.On("Exec", "UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", mock.Anything, "family").Return(sqlResult{affected: 2}, nil)
+	mockDB.On("Exec", "UPDATE tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL", mock.Anything, "family").Return(sqlResult{affected: 2}, nil)
+
+	authRepo := NewAuthRepository(mockDB, logger)
+
+	err := authRepo.RevokeTokenFamily("family")
+
+	assert.NoError(t, err)
+	mockDB.AssertExpectations(t)
+}
+
+func TestAuthRepository_IsTokenRevoked_UnknownToken(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockDB := new(mocks.DB)
+
+	mockDB.On("Get", mock.Anything, "SELECT revoked_at IS NOT NULL FROM tokens WHERE token=?", "forged").Return(sql.ErrNoRows)
+
+	authRepo := NewAuthRepository(mockDB, logger)
+
+	revoked, err := authRepo.IsTokenRevoked("forged")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	mockDB.AssertExpectations(t)
+}
+
+type sqlResult struct{ affected int64 }
+
+func (r sqlResult) LastInsertId() (int64, error) { return 0, nil }
+func (r sqlResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+func TestAuthRepository_GetUsersByIDs_SingleQuery(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	mockDB.On("Select", mock.Anything, "SELECT id, username, role FROM users WHERE id IN (?, ?, ?)", 1, 2, 3).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]entity.User) = []entity.User{{ID: 1, Username: "alice"}, {ID: 3, Username: "carol"}}
+		}).
+		Return(nil)
+
+	authRepo := NewAuthRepository(mockDB, logger)
+
+	users, err := authRepo.GetUsersByIDs([]int{1, 2, 3})
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	mockDB.AssertNumberOfCalls(t, "Select", 1)
+}
+
+func TestAuthRepository_GetUsersByIDs_Empty(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockDB := new(mocks.DB)
+	authRepo := NewAuthRepository(mockDB, logger)
+
+	users, err := authRepo.GetUsersByIDs(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	mockDB.AssertNotCalled(t, "Select", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,102 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	utils "github.com/miqxzz/commonmiqx"
+)
+
+// Issuer выпускает access-токены в формате commonmiqx.Claims, чтобы их
+// по-прежнему мог проверять JWTUtil в обоих сервисах, но со своим сроком жизни.
+type Issuer struct {
+	secretKey  []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewIssuer(secretKey string, accessTTL, refreshTTL time.Duration) *Issuer {
+	return &Issuer{secretKey: []byte(secretKey), accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+func (i *Issuer) AccessTTL() time.Duration {
+	return i.accessTTL
+}
+
+func (i *Issuer) RefreshTTL() time.Duration {
+	return i.refreshTTL
+}
+
+// GenerateAccessToken возвращает подписанный JWT и момент его истечения.
+func (i *Issuer) GenerateAccessToken(userID int, role string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.accessTTL)
+	jti, err := randomString(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	claims := &utils.Claims{
+		UserID: userID,
+		Role:   role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secretKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// GenerateRefreshToken возвращает непрозрачный refresh-токен для клиента
+// и его хэш, который хранится в базе вместо самого токена.
+func (i *Issuer) GenerateRefreshToken() (string, string, error) {
+	raw, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return raw, HashRefreshToken(raw), nil
+}
+
+// NewFamilyID создает идентификатор цепочки refresh-токенов одной сессии.
+func NewFamilyID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateInviteCode возвращает код приглашения и его хэш для хранения в базе.
+func GenerateInviteCode() (string, string, error) {
+	raw, err := randomString(12)
+	if err != nil {
+		return "", "", err
+	}
+	return raw, HashInviteCode(raw), nil
+}
+
+func HashInviteCode(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	repository "github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidAuditRange = errors.New("начало периода должно быть раньше конца")
+
+// MaxAuditExport — сколько записей журнала отдается в одной выгрузке.
+const MaxAuditExport = 10000
+
+type AuditUsecase interface {
+	// Record добавляет запись в журнал. Время записи ставится здесь.
+	Record(entry entity.AuditEntry) error
+	List(filter entity.AuditFilter) ([]entity.AuditEntry, int, error)
+	// Export возвращает до MaxAuditExport записей по фильтру без разбивки
+	// на страницы.
+	Export(filter entity.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type auditUsecase struct {
+	auditRepo repository.AuditRepository
+	logger    *zap.Logger
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository, logger *zap.Logger) AuditUsecase {
+	return &auditUsecase{auditRepo: auditRepo, logger: logger}
+}
+
+func (u *auditUsecase) Record(entry entity.AuditEntry) error {
+	entry.CreatedAt = time.Now().UTC()
+	return u.auditRepo.Append(entry)
+}
+
+func (u *auditUsecase) List(filter entity.AuditFilter) ([]entity.AuditEntry, int, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, ErrInvalidAuditRange
+	}
+	return u.auditRepo.List(filter)
+}
+
+func (u *auditUsecase) Export(filter entity.AuditFilter) ([]entity.AuditEntry, error) {
+	filter.Limit, filter.Offset = MaxAuditExport, 0
+	entries, total, err := u.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	if total > len(entries) {
+		u.logger.Warn("Audit export truncated", zap.Int("total", total), zap.Int("exported", len(entries)))
+	}
+	return entries, nil
+}
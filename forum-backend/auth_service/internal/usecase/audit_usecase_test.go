@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestAuditUsecase_Record_SetsTime(t *testing.T) {
+	mockAuditRepo := new(mocks.AuditRepository)
+	mockAuditRepo.On("Append", mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.Action == entity.AuditRoleCreate && !entry.CreatedAt.IsZero() && entry.CreatedAt.Location() == time.UTC
+	})).Return(nil)
+
+	auditUsecase := NewAuditUsecase(mockAuditRepo, zap.NewNop())
+
+	assert.NoError(t, auditUsecase.Record(entity.AuditEntry{ActorID: 1, Action: entity.AuditRoleCreate}))
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditUsecase_List_InvalidRange(t *testing.T) {
+	mockAuditRepo := new(mocks.AuditRepository)
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	auditUsecase := NewAuditUsecase(mockAuditRepo, zap.NewNop())
+
+	_, _, err := auditUsecase.List(entity.AuditFilter{From: &from, To: &to})
+
+	assert.ErrorIs(t, err, ErrInvalidAuditRange)
+	mockAuditRepo.AssertNotCalled(t, "List", mock.Anything)
+}
+
+func TestAuditUsecase_Export_IgnoresPagination(t *testing.T) {
+	mockAuditRepo := new(mocks.AuditRepository)
+	mockAuditRepo.On("List", entity.AuditFilter{Action: entity.AuditSanctionIssue, Limit: MaxAuditExport}).
+		Return([]entity.AuditEntry{{ID: 3}}, 1, nil)
+
+	auditUsecase := NewAuditUsecase(mockAuditRepo, zap.NewNop())
+
+	entries, err := auditUsecase.Export(entity.AuditFilter{Action: entity.AuditSanctionIssue, Limit: 20, Offset: 40})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	mockAuditRepo.AssertExpectations(t)
+}
@@ -1,30 +1,56 @@
 package usecase
 
 import (
+	"database/sql"
 	"errors"
+	"time"
 
 	utils "github.com/miqxzz/commonmiqx"
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	repository "github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidRefreshToken = errors.New("недействительный refresh-токен")
+	ErrRefreshTokenReused  = errors.New("refresh-токен уже использован, сессия завершена")
+	ErrTokenRevoked        = errors.New("токен отозван")
+	ErrInvalidRole         = errors.New("недопустимая роль пользователя")
+	ErrUserExists          = errors.New("пользователь уже существует")
+)
+
 type AuthUsecase interface {
-	Register(username, password, role string) error
-	Login(username, password string) (string, error)
+	Register(username, password, inviteCode string) error
+	BootstrapAdmin(username, password string) (bool, error)
+	Login(username, password string) (entity.TokenPair, error)
+	Refresh(refreshToken string) (entity.TokenPair, error)
+	Logout(accessToken string) error
+	LogoutAll(userID int) error
+	ValidateAccessToken(accessToken string) (*utils.Claims, error)
 	GetUserRole(username string) (string, error)
-	UpdateUserRole(userID int, newRole string) error
+	// UpdateUserRole назначает пользователю роль newRole и возвращает
+	// прежнюю роль. При смене роли все токены пользователя отзываются:
+	// права берутся из роли в токене, и старый токен сохранил бы прежние.
+	UpdateUserRole(userID int, newRole string) (string, error)
 }
 
 type authUsecase struct {
-	authRepo repository.AuthRepository
-	jwtUtil  *utils.JWTUtil
-	logger   *zap.Logger
+	authRepo     repository.AuthRepository
+	roleRepo     repository.RoleRepository
+	inviteRepo   repository.InviteRepository
+	sanctionRepo repository.SanctionRepository
+	jwtUtil      *utils.JWTUtil
+	issuer       *token.Issuer
+	changes      *UserChanges
+	logger       *zap.Logger
 }
 
-func NewAuthUsecase(authRepo repository.AuthRepository, jwtUtil *utils.JWTUtil, logger *zap.Logger) AuthUsecase {
-	return &authUsecase{authRepo: authRepo, jwtUtil: jwtUtil, logger: logger}
+// NewAuthUsecase создает usecase; changes может быть nil, если
+// подписчиков на изменения пользователей нет.
+func NewAuthUsecase(authRepo repository.AuthRepository, roleRepo repository.RoleRepository, inviteRepo repository.InviteRepository, sanctionRepo repository.SanctionRepository, jwtUtil *utils.JWTUtil, issuer *token.Issuer, changes *UserChanges, logger *zap.Logger) AuthUsecase {
+	return &authUsecase{authRepo: authRepo, roleRepo: roleRepo, inviteRepo: inviteRepo, sanctionRepo: sanctionRepo, jwtUtil: jwtUtil, issuer: issuer, changes: changes, logger: logger}
 }
 
 func validatePassword(password string) error {
@@ -34,7 +60,9 @@ func validatePassword(password string) error {
 	return nil
 }
 
-func (u *authUsecase) Register(username, password, role string) error {
+// Register создает пользователя с ролью entity.DefaultRole. Другую роль
+// можно получить только по коду приглашения.
+func (u *authUsecase) Register(username, password, inviteCode string) error {
 	if err := validatePassword(password); err != nil {
 		u.logger.Error("Invalid password format", zap.Error(err), zap.String("username", username))
 		return err
@@ -45,36 +73,258 @@ func (u *authUsecase) Register(username, password, role string) error {
 		u.logger.Error("Failed to hash password", zap.Error(err), zap.String("username", username))
 		return err
 	}
+
+	role := entity.DefaultRole
+	inviteID := 0
+	if inviteCode != "" {
+		invite, err := u.consumeInvite(inviteCode)
+		if err != nil {
+			return err
+		}
+		role, inviteID = invite.Role, invite.ID
+	}
+
 	user := entity.User{Username: username, Password: string(hashedPassword), Role: role}
 	if err := u.authRepo.Register(user); err != nil {
 		u.logger.Error("Failed to register user", zap.Error(err), zap.String("username", username))
+		if inviteID != 0 {
+			if releaseErr := u.inviteRepo.ReleaseInvite(inviteID); releaseErr != nil {
+				u.logger.Error("Failed to release invite", zap.Error(releaseErr), zap.Int("inviteID", inviteID))
+			}
+		}
 		return err
 	}
-	u.logger.Info("User registered successfully", zap.String("username", username))
+	u.logger.Info("User registered successfully", zap.String("username", username), zap.String("role", role))
 	return nil
 }
 
-func (u *authUsecase) Login(username, password string) (string, error) {
+func (u *authUsecase) consumeInvite(code string) (entity.Invite, error) {
+	invite, err := u.inviteRepo.GetInviteByCodeHash(token.HashInviteCode(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.logger.Warn("Unknown invite code")
+			return entity.Invite{}, ErrInvalidInvite
+		}
+		return entity.Invite{}, err
+	}
+	if !invite.Usable(time.Now()) {
+		u.logger.Warn("Unusable invite code", zap.Int("inviteID", invite.ID))
+		return entity.Invite{}, ErrInvalidInvite
+	}
+	consumed, err := u.inviteRepo.ConsumeInvite(invite.ID)
+	if err != nil {
+		return entity.Invite{}, err
+	}
+	if !consumed {
+		// Последнее использование успел забрать параллельный запрос
+		return entity.Invite{}, ErrInvalidInvite
+	}
+	return invite, nil
+}
+
+// BootstrapAdmin создает первого администратора, если в системе еще нет
+// ни одного. Возвращает false, если администратор уже существует.
+func (u *authUsecase) BootstrapAdmin(username, password string) (bool, error) {
+	admins, err := u.roleRepo.CountUsersWithRole("admin")
+	if err != nil {
+		u.logger.Error("Failed to count admins", zap.Error(err))
+		return false, err
+	}
+	if admins > 0 {
+		return false, nil
+	}
+	if err := validatePassword(password); err != nil {
+		return false, err
+	}
+	if _, err := u.authRepo.GetUserByUsername(username); err == nil {
+		u.logger.Error("Bootstrap admin username is taken", zap.String("username", username))
+		return false, ErrUserExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return false, err
+	}
+	if err := u.authRepo.Register(entity.User{Username: username, Password: string(hashedPassword), Role: "admin"}); err != nil {
+		u.logger.Error("Failed to create bootstrap admin", zap.Error(err), zap.String("username", username))
+		return false, err
+	}
+	u.logger.Info("Bootstrap admin created", zap.String("username", username))
+	return true, nil
+}
+
+// Login выдает пару токенов. Заблокированному пользователю возвращается
+// *BannedError.
+func (u *authUsecase) Login(username, password string) (entity.TokenPair, error) {
 	user, err := u.authRepo.GetUserByUsername(username)
 	if err != nil {
 		u.logger.Error("Failed to get user by username", zap.Error(err), zap.String("username", username))
-		return "", errors.New("invalid credentials")
+		return entity.TokenPair{}, errors.New("invalid credentials")
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
 		u.logger.Error("Invalid password", zap.String("username", username))
-		return "", errors.New("invalid credentials")
+		return entity.TokenPair{}, errors.New("invalid credentials")
 	}
-	token, err := u.jwtUtil.GenerateToken(user.ID, user.Role)
+	// Блокировка проверяется после пароля, чтобы не раскрывать ее по одному
+	// имени пользователя
+	ban, err := activeBan(u.sanctionRepo, user.ID)
 	if err != nil {
-		u.logger.Error("Failed to generate token", zap.Error(err), zap.String("username", username))
-		return "", err
+		u.logger.Error("Failed to check user ban", zap.Error(err), zap.String("username", username))
+		return entity.TokenPair{}, err
 	}
-	if err := u.authRepo.SaveToken(user.ID, token); err != nil {
-		u.logger.Error("Failed to save token", zap.Error(err), zap.String("username", username))
-		return "", err
+	if ban != nil {
+		u.logger.Warn("Banned user tried to log in", zap.Int("userID", user.ID), zap.Int("sanctionID", ban.ID))
+		return entity.TokenPair{}, &BannedError{Sanction: *ban}
+	}
+	familyID, err := token.NewFamilyID()
+	if err != nil {
+		u.logger.Error("Failed to generate token family", zap.Error(err), zap.String("username", username))
+		return entity.TokenPair{}, err
+	}
+	pair, err := u.issueTokens(user, familyID)
+	if err != nil {
+		return entity.TokenPair{}, err
 	}
 	u.logger.Info("User logged in successfully", zap.String("username", username))
-	return token, nil
+	return pair, nil
+}
+
+// Refresh обменивает refresh-токен на новую пару. Предъявленный токен
+// отзывается; повторное предъявление уже отозванного токена считается
+// кражей и завершает всю цепочку сессии.
+func (u *authUsecase) Refresh(refreshToken string) (entity.TokenPair, error) {
+	stored, err := u.authRepo.GetRefreshToken(token.HashRefreshToken(refreshToken))
+	if err != nil {
+		u.logger.Warn("Unknown refresh token", zap.Error(err))
+		return entity.TokenPair{}, ErrInvalidRefreshToken
+	}
+	if stored.RevokedAt != nil {
+		return entity.TokenPair{}, u.handleRefreshReuse(stored)
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		u.logger.Warn("Expired refresh token", zap.Int("userID", stored.UserID))
+		return entity.TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	rotated, err := u.authRepo.RevokeRefreshToken(stored.ID)
+	if err != nil {
+		u.logger.Error("Failed to revoke refresh token", zap.Error(err), zap.Int("userID", stored.UserID))
+		return entity.TokenPair{}, err
+	}
+	if !rotated {
+		// Параллельный запрос успел использовать этот же токен
+		return entity.TokenPair{}, u.handleRefreshReuse(stored)
+	}
+
+	user, err := u.authRepo.GetUserByID(stored.UserID)
+	if err != nil {
+		u.logger.Error("Failed to get user for refresh", zap.Error(err), zap.Int("userID", stored.UserID))
+		return entity.TokenPair{}, ErrInvalidRefreshToken
+	}
+	pair, err := u.issueTokens(user, stored.FamilyID)
+	if err != nil {
+		return entity.TokenPair{}, err
+	}
+	u.logger.Info("Tokens refreshed", zap.Int("userID", user.ID))
+	return pair, nil
+}
+
+func (u *authUsecase) handleRefreshReuse(stored entity.RefreshToken) error {
+	u.logger.Warn("Refresh token reuse detected, revoking family",
+		zap.Int("userID", stored.UserID),
+		zap.String("familyID", stored.FamilyID),
+	)
+	if err := u.authRepo.RevokeTokenFamily(stored.FamilyID); err != nil {
+		u.logger.Error("Failed to revoke token family", zap.Error(err), zap.String("familyID", stored.FamilyID))
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (u *authUsecase) issueTokens(user entity.User, familyID string) (entity.TokenPair, error) {
+	accessToken, expiresAt, err := u.issuer.GenerateAccessToken(user.ID, user.Role)
+	if err != nil {
+		u.logger.Error("Failed to generate token", zap.Error(err), zap.Int("userID", user.ID))
+		return entity.TokenPair{}, err
+	}
+	if err := u.authRepo.SaveToken(user.ID, accessToken, familyID, expiresAt); err != nil {
+		u.logger.Error("Failed to save token", zap.Error(err), zap.Int("userID", user.ID))
+		return entity.TokenPair{}, err
+	}
+
+	refreshToken, refreshHash, err := u.issuer.GenerateRefreshToken()
+	if err != nil {
+		u.logger.Error("Failed to generate refresh token", zap.Error(err), zap.Int("userID", user.ID))
+		return entity.TokenPair{}, err
+	}
+	err = u.authRepo.SaveRefreshToken(entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: refreshHash,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(u.issuer.RefreshTTL()),
+	})
+	if err != nil {
+		u.logger.Error("Failed to save refresh token", zap.Error(err), zap.Int("userID", user.ID))
+		return entity.TokenPair{}, err
+	}
+
+	return entity.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(u.issuer.AccessTTL().Seconds()),
+	}, nil
+}
+
+// ValidateAccessToken проверяет подпись и срок действия токена, а также то,
+// что он не был отозван через logout.
+func (u *authUsecase) ValidateAccessToken(accessToken string) (*utils.Claims, error) {
+	claims, err := u.jwtUtil.ValidateToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := u.authRepo.IsTokenRevoked(accessToken)
+	if err != nil {
+		u.logger.Error("Failed to check token revocation", zap.Error(err), zap.Int("userID", claims.UserID))
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// Logout завершает одну сессию: отзываются access- и refresh-токены
+// той же цепочки, что и предъявленный токен.
+func (u *authUsecase) Logout(accessToken string) error {
+	claims, err := u.ValidateAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+	familyID, err := u.authRepo.GetTokenFamily(accessToken)
+	if err != nil {
+		u.logger.Error("Failed to get token family", zap.Error(err), zap.Int("userID", claims.UserID))
+		return err
+	}
+	if familyID == "" {
+		// Токен выпущен до появления refresh-токенов, отзывать можно только все сессии
+		return u.LogoutAll(claims.UserID)
+	}
+	if err := u.authRepo.RevokeTokenFamily(familyID); err != nil {
+		return err
+	}
+	u.logger.Info("User logged out", zap.Int("userID", claims.UserID))
+	return nil
+}
+
+func (u *authUsecase) LogoutAll(userID int) error {
+	if err := u.authRepo.RevokeUserTokens(userID); err != nil {
+		u.logger.Error("Failed to revoke user tokens", zap.Error(err), zap.Int("userID", userID))
+		return err
+	}
+	u.logger.Info("User logged out from all sessions", zap.Int("userID", userID))
+	return nil
 }
 
 func (u *authUsecase) GetUserRole(username string) (string, error) {
@@ -87,16 +337,33 @@ func (u *authUsecase) GetUserRole(username string) (string, error) {
 	return user.Role, nil
 }
 
-func (u *authUsecase) UpdateUserRole(userID int, newRole string) error {
-	if newRole != "user" && newRole != "admin" && newRole != "moderator" {
-		u.logger.Error("Invalid role", zap.String("role", newRole))
-		return errors.New("недопустимая роль пользователя")
+func (u *authUsecase) UpdateUserRole(userID int, newRole string) (string, error) {
+	if _, err := u.roleRepo.GetRole(newRole); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.logger.Error("Invalid role", zap.String("role", newRole))
+			return "", ErrInvalidRole
+		}
+		return "", err
+	}
+	user, err := u.authRepo.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
 	}
 
 	if err := u.authRepo.UpdateUserRole(userID, newRole); err != nil {
 		u.logger.Error("Failed to update user role", zap.Error(err), zap.Int("userID", userID), zap.String("newRole", newRole))
-		return err
+		return "", err
 	}
-	u.logger.Info("User role updated successfully", zap.Int("userID", userID), zap.String("newRole", newRole))
-	return nil
+	if user.Role != newRole {
+		if err := u.authRepo.RevokeUserTokens(userID); err != nil {
+			u.logger.Error("Failed to revoke tokens after role change", zap.Error(err), zap.Int("userID", userID))
+			return "", err
+		}
+	}
+	u.logger.Info("User role updated successfully", zap.Int("userID", userID), zap.String("previousRole", user.Role), zap.String("newRole", newRole))
+	u.changes.Publish(entity.User{ID: userID, Role: newRole})
+	return user.Role, nil
 }
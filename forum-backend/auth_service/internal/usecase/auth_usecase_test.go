@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	commonmiqx "github.com/miqxzz/commonmiqx"
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
 	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -31,26 +34,51 @@ func (m *mockAuthRepo) GetUserByUsername(username string) (entity.User, error) {
 	args := m.Called(username)
 	return args.Get(0).(entity.User), args.Error(1)
 }
-func (m *mockAuthRepo) SaveToken(userID int, token string) error { return nil }
+func (m *mockAuthRepo) GetUserByID(userID int) (entity.User, error) { return entity.User{}, nil }
+func (m *mockAuthRepo) SaveToken(userID int, token, familyID string, expiresAt time.Time) error {
+	return nil
+}
+func (m *mockAuthRepo) IsTokenRevoked(token string) (bool, error)        { return false, nil }
+func (m *mockAuthRepo) GetTokenFamily(token string) (string, error)      { return "", nil }
+func (m *mockAuthRepo) SaveRefreshToken(token entity.RefreshToken) error { return nil }
+func (m *mockAuthRepo) RevokeRefreshToken(id int) (bool, error)          { return true, nil }
+func (m *mockAuthRepo) RevokeTokenFamily(familyID string) error          { return nil }
+func (m *mockAuthRepo) RevokeUserTokens(userID int) error                { return nil }
+func (m *mockAuthRepo) GetRefreshToken(tokenHash string) (entity.RefreshToken, error) {
+	return entity.RefreshToken{}, nil
+}
 func (m *mockAuthRepo) GetUsernameByID(ctx context.Context, userID int) (string, error) {
 	return "", nil
 }
 
+func (m *mockAuthRepo) GetUsersByIDs(userIDs []int) ([]entity.User, error) {
+	return nil, nil
+}
+
+// noSanctions возвращает репозиторий санкций без действующих санкций.
+func noSanctions() *mocks.SanctionRepository {
+	repo := new(mocks.SanctionRepository)
+	repo.On("GetActiveSanctions", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
 func TestAuthUsecase_Register_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	password := "12345"
-	role := "user"
 
-	mockAuthRepo.On("Register", mock.AnythingOfType("entity.User")).Return(nil)
+	mockAuthRepo.On("Register", mock.MatchedBy(func(user entity.User) bool {
+		return user.Username == username && user.Role == entity.DefaultRole
+	})).Return(nil)
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := authUsecase.Register(username, password, role)
+	err := authUsecase.Register(username, password, "")
 
 	assert.NoError(t, err)
 
@@ -62,6 +90,7 @@ func TestAuthUsecase_Register_InvalidPassword(t *testing.T) {
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	testCases := []struct {
 		name     string
@@ -77,8 +106,8 @@ func TestAuthUsecase_Register_InvalidPassword(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
-			err := authUsecase.Register("testuser", tc.password, "user")
+			authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+			err := authUsecase.Register("testuser", tc.password, "")
 			assert.Error(t, err)
 			assert.Equal(t, tc.errorMsg, err.Error())
 		})
@@ -91,28 +120,171 @@ func TestAuthUsecase_Register_Failure(t *testing.T) {
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	password := "password"
-	role := "user"
 
 	mockAuthRepo.On("Register", mock.AnythingOfType("entity.User")).Return(errors.New("failed to register user"))
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	err := authUsecase.Register(username, password, "")
+
+	assert.Error(t, err)
+
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Register_WithInvite(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockInviteRepo := new(mocks.InviteRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	invite := entity.Invite{ID: 3, Role: "moderator", MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockInviteRepo.On("GetInviteByCodeHash", token.HashInviteCode("invite-code")).Return(invite, nil)
+	mockInviteRepo.On("ConsumeInvite", 3).Return(true, nil)
+	mockAuthRepo.On("Register", mock.MatchedBy(func(user entity.User) bool {
+		return user.Role == "moderator"
+	})).Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), mockInviteRepo, noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	err := authUsecase.Register("testuser", "12345", "invite-code")
+
+	assert.NoError(t, err)
+	mockAuthRepo.AssertExpectations(t)
+	mockInviteRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Register_InvalidInvite(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+	revokedAt := time.Now()
+
+	testCases := []struct {
+		name   string
+		invite entity.Invite
+		err    error
+	}{
+		{name: "Unknown", err: sql.ErrNoRows},
+		{name: "Expired", invite: entity.Invite{ID: 1, Role: "admin", MaxUses: 1, ExpiresAt: time.Now().Add(-time.Minute)}},
+		{name: "Exhausted", invite: entity.Invite{ID: 1, Role: "admin", MaxUses: 2, Uses: 2, ExpiresAt: time.Now().Add(time.Hour)}},
+		{name: "Revoked", invite: entity.Invite{ID: 1, Role: "admin", MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAuthRepo := new(mocks.AuthRepository)
+			mockInviteRepo := new(mocks.InviteRepository)
+			mockInviteRepo.On("GetInviteByCodeHash", mock.Anything).Return(tc.invite, tc.err)
+
+			authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), mockInviteRepo, noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+			err := authUsecase.Register("testuser", "12345", "bad-code")
+
+			assert.ErrorIs(t, err, ErrInvalidInvite)
+			mockAuthRepo.AssertNotCalled(t, "Register", mock.Anything)
+			mockInviteRepo.AssertNotCalled(t, "ConsumeInvite", mock.Anything)
+		})
+	}
+}
+
+func TestAuthUsecase_Register_InviteReleasedOnFailure(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockInviteRepo := new(mocks.InviteRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	invite := entity.Invite{ID: 3, Role: "moderator", MaxUses: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockInviteRepo.On("GetInviteByCodeHash", mock.Anything).Return(invite, nil)
+	mockInviteRepo.On("ConsumeInvite", 3).Return(true, nil)
+	mockInviteRepo.On("ReleaseInvite", 3).Return(nil)
+	mockAuthRepo.On("Register", mock.AnythingOfType("entity.User")).Return(errors.New("UNIQUE constraint failed: users.username"))
 
-	err := authUsecase.Register(username, password, role)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), mockInviteRepo, noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	err := authUsecase.Register("testuser", "12345", "invite-code")
 
 	assert.Error(t, err)
+	mockInviteRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_BootstrapAdmin_Created(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("CountUsersWithRole", "admin").Return(0, nil)
+	mockAuthRepo.On("GetUserByUsername", "root").Return(entity.User{}, sql.ErrNoRows)
+	mockAuthRepo.On("Register", mock.MatchedBy(func(user entity.User) bool {
+		return user.Username == "root" && user.Role == "admin"
+	})).Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
+	created, err := authUsecase.BootstrapAdmin("root", "12345")
+
+	assert.NoError(t, err)
+	assert.True(t, created)
 	mockAuthRepo.AssertExpectations(t)
 }
 
+func TestAuthUsecase_BootstrapAdmin_AdminExists(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("CountUsersWithRole", "admin").Return(1, nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	created, err := authUsecase.BootstrapAdmin("root", "12345")
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	mockAuthRepo.AssertNotCalled(t, "Register", mock.Anything)
+}
+
+func TestAuthUsecase_BootstrapAdmin_UsernameTaken(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("CountUsersWithRole", "admin").Return(0, nil)
+	mockAuthRepo.On("GetUserByUsername", "root").Return(entity.User{ID: 5, Username: "root", Role: "user"}, nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	created, err := authUsecase.BootstrapAdmin("root", "12345")
+
+	assert.ErrorIs(t, err, ErrUserExists)
+	assert.False(t, created)
+	mockAuthRepo.AssertNotCalled(t, "Register", mock.Anything)
+}
+
 func TestAuthUsecase_Login_Success(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	password := "password"
@@ -120,36 +292,69 @@ func TestAuthUsecase_Login_Success(t *testing.T) {
 	user := entity.User{ID: 1, Username: username, Password: string(hashedPassword), Role: "user"}
 
 	mockAuthRepo.On("GetUserByUsername", username).Return(user, nil)
-	mockAuthRepo.On("SaveToken", user.ID, mock.Anything).Return(nil)
+	mockAuthRepo.On("SaveToken", user.ID, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockAuthRepo.On("SaveRefreshToken", mock.AnythingOfType("entity.RefreshToken")).Return(nil)
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	resultToken, err := authUsecase.Login(username, password)
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, resultToken)
+	assert.NotEmpty(t, resultToken.AccessToken)
+	assert.NotEmpty(t, resultToken.RefreshToken)
 
 	mockAuthRepo.AssertExpectations(t)
 }
 
+func TestAuthUsecase_Login_Banned(t *testing.T) {
+	logger := zap.NewNop()
+
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	user := entity.User{ID: 1, Username: "testuser", Password: string(hashedPassword), Role: "user"}
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockAuthRepo.On("GetUserByUsername", "testuser").Return(user, nil)
+	mockSanctionRepo.On("GetActiveSanctions", 1, mock.Anything).Return([]entity.Sanction{
+		{ID: 2, UserID: 1, Type: entity.SanctionMute, Reason: "флуд"},
+		{ID: 1, UserID: 1, Type: entity.SanctionBan, Reason: "спам", ExpiresAt: &expiresAt},
+	}, nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), mockSanctionRepo, jwtUtil, tokenIssuer, nil, logger)
+
+	resultToken, err := authUsecase.Login("testuser", "password")
+
+	assert.ErrorIs(t, err, ErrUserBanned)
+	var banned *BannedError
+	assert.True(t, errors.As(err, &banned))
+	assert.Equal(t, "спам", banned.Sanction.Reason)
+	assert.Equal(t, entity.TokenPair{}, resultToken)
+	mockAuthRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything)
+}
+
 func TestAuthUsecase_Login_Failure_InvalidCredentials(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	password := "password"
 
 	mockAuthRepo.On("GetUserByUsername", username).Return(entity.User{}, errors.New("user not found"))
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	resultToken, err := authUsecase.Login(username, password)
 
 	assert.Error(t, err)
-	assert.Equal(t, "", resultToken)
+	assert.Equal(t, entity.TokenPair{}, resultToken)
 
 	mockAuthRepo.AssertExpectations(t)
 }
@@ -160,6 +365,7 @@ func TestAuthUsecase_Login_Failure_InvalidPassword(t *testing.T) {
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	password := "password"
@@ -168,12 +374,12 @@ func TestAuthUsecase_Login_Failure_InvalidPassword(t *testing.T) {
 
 	mockAuthRepo.On("GetUserByUsername", username).Return(user, nil)
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	resultToken, err := authUsecase.Login(username, password)
 
 	assert.Error(t, err)
-	assert.Equal(t, "", resultToken)
+	assert.Equal(t, entity.TokenPair{}, resultToken)
 
 	mockAuthRepo.AssertExpectations(t)
 }
@@ -184,13 +390,14 @@ func TestAuthUsecase_GetUserRole_Success(t *testing.T) {
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 	user := entity.User{ID: 1, Username: username, Password: "hashedpassword", Role: "user"}
 
 	mockAuthRepo.On("GetUserByUsername", username).Return(user, nil)
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	role, err := authUsecase.GetUserRole(username)
 
@@ -206,12 +413,13 @@ func TestAuthUsecase_GetUserRole_Failure(t *testing.T) {
 
 	mockAuthRepo := new(mocks.AuthRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	username := "testuser"
 
 	mockAuthRepo.On("GetUserByUsername", username).Return(entity.User{}, errors.New("user not found"))
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	role, err := authUsecase.GetUserRole(username)
 
@@ -224,117 +432,333 @@ func TestAuthUsecase_GetUserRole_Failure(t *testing.T) {
 func TestAuthUsecase_UpdateUserRole_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	userID := 1
 	newRole := "admin"
 
+	mockRoleRepo.On("GetRole", newRole).Return(entity.Role{Name: newRole}, nil)
+	mockAuthRepo.On("GetUserByID", userID).Return(entity.User{ID: userID, Role: "user"}, nil)
 	mockAuthRepo.On("UpdateUserRole", userID, newRole).Return(nil)
+	mockAuthRepo.On("RevokeUserTokens", userID).Return(nil)
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := authUsecase.UpdateUserRole(userID, newRole)
+	_, err := authUsecase.UpdateUserRole(userID, newRole)
 
 	assert.NoError(t, err)
 	mockAuthRepo.AssertExpectations(t)
+	mockRoleRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_UpdateUserRole_PublishesChange(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockAuthRepo.On("GetUserByID", 7).Return(entity.User{ID: 7, Role: "user"}, nil)
+	mockAuthRepo.On("UpdateUserRole", 7, "moderator").Return(nil)
+	mockAuthRepo.On("RevokeUserTokens", 7).Return(nil)
+
+	changes := NewUserChanges(logger)
+	events, unsubscribe := changes.Subscribe()
+	defer unsubscribe()
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, changes, logger)
+
+	previous, err := authUsecase.UpdateUserRole(7, "moderator")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", previous)
+	select {
+	case event := <-events:
+		assert.Equal(t, entity.User{ID: 7, Role: "moderator"}, event)
+	default:
+		t.Fatal("role change was not published")
+	}
+}
+
+func TestAuthUsecase_UpdateUserRole_SameRoleKeepsTokens(t *testing.T) {
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockAuthRepo.On("GetUserByID", 7).Return(entity.User{ID: 7, Role: "moderator"}, nil)
+	mockAuthRepo.On("UpdateUserRole", 7, "moderator").Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, zap.NewNop())
+
+	_, err := authUsecase.UpdateUserRole(7, "moderator")
+
+	assert.NoError(t, err)
+	mockAuthRepo.AssertNotCalled(t, "RevokeUserTokens", mock.Anything)
+}
+
+func TestAuthUsecase_UpdateUserRole_UserNotFound(t *testing.T) {
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockAuthRepo.On("GetUserByID", 9).Return(entity.User{}, sql.ErrNoRows)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, zap.NewNop())
+
+	_, err := authUsecase.UpdateUserRole(9, "moderator")
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	mockAuthRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything)
 }
 
 func TestAuthUsecase_UpdateUserRole_InvalidRole(t *testing.T) {
 	logger, _ := zap.NewProduction()
 	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	userID := 1
 	invalidRole := "invalid_role"
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	mockRoleRepo.On("GetRole", invalidRole).Return(entity.Role{}, sql.ErrNoRows)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := authUsecase.UpdateUserRole(userID, invalidRole)
+	_, err := authUsecase.UpdateUserRole(userID, invalidRole)
 
 	assert.Error(t, err)
 	assert.Equal(t, "недопустимая роль пользователя", err.Error())
 	mockAuthRepo.AssertExpectations(t)
+	mockRoleRepo.AssertExpectations(t)
 }
 
 func TestAuthUsecase_UpdateUserRole_RepositoryError(t *testing.T) {
 	logger, _ := zap.NewProduction()
 	mockAuthRepo := new(mocks.AuthRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 
 	userID := 1
 	newRole := "admin"
 
+	mockRoleRepo.On("GetRole", newRole).Return(entity.Role{Name: newRole}, nil)
+	mockAuthRepo.On("GetUserByID", userID).Return(entity.User{ID: userID, Role: "user"}, nil)
 	mockAuthRepo.On("UpdateUserRole", userID, newRole).Return(errors.New("database error"))
 
-	authUsecase := NewAuthUsecase(mockAuthRepo, jwtUtil, logger)
+	authUsecase := NewAuthUsecase(mockAuthRepo, mockRoleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := authUsecase.UpdateUserRole(userID, newRole)
+	_, err := authUsecase.UpdateUserRole(userID, newRole)
 
 	assert.Error(t, err)
 	mockAuthRepo.AssertExpectations(t)
+	mockRoleRepo.AssertExpectations(t)
 }
 
 func TestRegister_Success(t *testing.T) {
 	repo := new(mockAuthRepo)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	repo.On("Register", mock.AnythingOfType("entity.User")).Return(nil)
 
-	err := uc.Register("test", "12345", "user")
+	err := uc.Register("test", "12345", "")
 	assert.NoError(t, err)
 }
 
 func TestRegister_InvalidPassword(t *testing.T) {
 	repo := new(mockAuthRepo)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := uc.Register("test", "123", "user")
+	err := uc.Register("test", "123", "")
 	assert.Error(t, err)
 }
 
 func TestRegister_RepoError(t *testing.T) {
 	repo := new(mockAuthRepo)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
 	repo.On("Register", mock.AnythingOfType("entity.User")).Return(errors.New("db error"))
-	err := uc.Register("test", "12345", "user")
+	err := uc.Register("test", "12345", "")
 	assert.Error(t, err)
 }
 
 func TestUpdateUserRole_Success(t *testing.T) {
 	repo := new(mockAuthRepo)
+	roleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, roleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
+	roleRepo.On("GetRole", "admin").Return(entity.Role{Name: "admin"}, nil)
 	repo.On("UpdateUserRole", 1, "admin").Return(nil)
-	err := uc.UpdateUserRole(1, "admin")
+	_, err := uc.UpdateUserRole(1, "admin")
 	assert.NoError(t, err)
 }
 
 func TestUpdateUserRole_InvalidRole(t *testing.T) {
 	repo := new(mockAuthRepo)
+	roleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, roleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
-	err := uc.UpdateUserRole(1, "superuser")
+	roleRepo.On("GetRole", "superuser").Return(entity.Role{}, sql.ErrNoRows)
+	_, err := uc.UpdateUserRole(1, "superuser")
 	assert.Error(t, err)
 }
 
 func TestUpdateUserRole_RepoError(t *testing.T) {
 	repo := new(mockAuthRepo)
+	roleRepo := new(mocks.RoleRepository)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
 	logger, _ := zap.NewProduction()
-	uc := NewAuthUsecase(repo, jwtUtil, logger)
+	uc := NewAuthUsecase(repo, roleRepo, new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
 
+	roleRepo.On("GetRole", "admin").Return(entity.Role{Name: "admin"}, nil)
 	repo.On("UpdateUserRole", 1, "admin").Return(errors.New("db error"))
-	err := uc.UpdateUserRole(1, "admin")
+	_, err := uc.UpdateUserRole(1, "admin")
 	assert.Error(t, err)
 }
+
+func TestAuthUsecase_Refresh_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	stored := entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+	user := entity.User{ID: 1, Username: "testuser", Role: "user"}
+
+	mockAuthRepo.On("GetRefreshToken", token.HashRefreshToken("refresh")).Return(stored, nil)
+	mockAuthRepo.On("RevokeRefreshToken", 7).Return(true, nil)
+	mockAuthRepo.On("GetUserByID", 1).Return(user, nil)
+	mockAuthRepo.On("SaveToken", 1, mock.Anything, "family", mock.Anything).Return(nil)
+	mockAuthRepo.On("SaveRefreshToken", mock.MatchedBy(func(rt entity.RefreshToken) bool {
+		return rt.FamilyID == "family" && rt.UserID == 1
+	})).Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	pair, err := authUsecase.Refresh("refresh")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.NotEqual(t, "refresh", pair.RefreshToken)
+	assert.Equal(t, 900, pair.ExpiresIn)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Refresh_ReuseRevokesFamily(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	revokedAt := time.Now().Add(-time.Minute)
+	stored := entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+
+	mockAuthRepo.On("GetRefreshToken", token.HashRefreshToken("refresh")).Return(stored, nil)
+	mockAuthRepo.On("RevokeTokenFamily", "family").Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	_, err := authUsecase.Refresh("refresh")
+
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Refresh_ConcurrentRotation(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	stored := entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockAuthRepo.On("GetRefreshToken", token.HashRefreshToken("refresh")).Return(stored, nil)
+	mockAuthRepo.On("RevokeRefreshToken", 7).Return(false, nil)
+	mockAuthRepo.On("RevokeTokenFamily", "family").Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	_, err := authUsecase.Refresh("refresh")
+
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Refresh_Expired(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	stored := entity.RefreshToken{ID: 7, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Hour)}
+	mockAuthRepo.On("GetRefreshToken", token.HashRefreshToken("refresh")).Return(stored, nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	_, err := authUsecase.Refresh("refresh")
+
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_Logout_RevokesFamily(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	accessToken, _, err := tokenIssuer.GenerateAccessToken(1, "user")
+	assert.NoError(t, err)
+
+	mockAuthRepo.On("IsTokenRevoked", accessToken).Return(false, nil)
+	mockAuthRepo.On("GetTokenFamily", accessToken).Return("family", nil)
+	mockAuthRepo.On("RevokeTokenFamily", "family").Return(nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	assert.NoError(t, authUsecase.Logout(accessToken))
+	mockAuthRepo.AssertExpectations(t)
+}
+
+func TestAuthUsecase_ValidateAccessToken_Revoked(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockAuthRepo := new(mocks.AuthRepository)
+	jwtUtil := commonmiqx.NewJWTUtil("secret")
+	tokenIssuer := token.NewIssuer("secret", 15*time.Minute, 30*24*time.Hour)
+
+	accessToken, _, err := tokenIssuer.GenerateAccessToken(1, "user")
+	assert.NoError(t, err)
+
+	mockAuthRepo.On("IsTokenRevoked", accessToken).Return(true, nil)
+
+	authUsecase := NewAuthUsecase(mockAuthRepo, new(mocks.RoleRepository), new(mocks.InviteRepository), noSanctions(), jwtUtil, tokenIssuer, nil, logger)
+
+	claims, err := authUsecase.ValidateAccessToken(accessToken)
+
+	assert.ErrorIs(t, err, ErrTokenRevoked)
+	assert.Nil(t, claims)
+	mockAuthRepo.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	repository "github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidInvite  = errors.New("недействительный код приглашения")
+	ErrInviteNotFound = errors.New("приглашение не найдено")
+)
+
+// DefaultInviteTTL — срок действия приглашения, если он не указан явно.
+const DefaultInviteTTL = 72 * time.Hour
+
+type InviteUsecase interface {
+	CreateInvite(createdBy int, role string, maxUses int, ttl time.Duration) (entity.CreateInviteResponse, error)
+	ListInvites() ([]entity.Invite, error)
+	RevokeInvite(id int) error
+}
+
+type inviteUsecase struct {
+	inviteRepo repository.InviteRepository
+	roleRepo   repository.RoleRepository
+	logger     *zap.Logger
+}
+
+func NewInviteUsecase(inviteRepo repository.InviteRepository, roleRepo repository.RoleRepository, logger *zap.Logger) InviteUsecase {
+	return &inviteUsecase{inviteRepo: inviteRepo, roleRepo: roleRepo, logger: logger}
+}
+
+func (u *inviteUsecase) CreateInvite(createdBy int, role string, maxUses int, ttl time.Duration) (entity.CreateInviteResponse, error) {
+	if _, err := u.roleRepo.GetRole(role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			u.logger.Warn("Invite for unknown role", zap.String("role", role))
+			return entity.CreateInviteResponse{}, ErrInvalidRole
+		}
+		return entity.CreateInviteResponse{}, err
+	}
+	if maxUses <= 0 {
+		maxUses = 1
+	}
+	if ttl <= 0 {
+		ttl = DefaultInviteTTL
+	}
+
+	code, codeHash, err := token.GenerateInviteCode()
+	if err != nil {
+		u.logger.Error("Failed to generate invite code", zap.Error(err))
+		return entity.CreateInviteResponse{}, err
+	}
+	now := time.Now().UTC()
+	invite := entity.Invite{
+		CodeHash:  codeHash,
+		Role:      role,
+		MaxUses:   maxUses,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	id, err := u.inviteRepo.CreateInvite(invite)
+	if err != nil {
+		return entity.CreateInviteResponse{}, err
+	}
+	invite.ID = id
+
+	u.logger.Info("Invite created", zap.Int("inviteID", id), zap.String("role", role), zap.Int("createdBy", createdBy))
+	return entity.CreateInviteResponse{Code: code, Invite: invite}, nil
+}
+
+func (u *inviteUsecase) ListInvites() ([]entity.Invite, error) {
+	return u.inviteRepo.ListInvites()
+}
+
+func (u *inviteUsecase) RevokeInvite(id int) error {
+	revoked, err := u.inviteRepo.RevokeInvite(id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrInviteNotFound
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/auth_service/internal/token"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestInviteUsecase_CreateInvite_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockInviteRepo := new(mocks.InviteRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	var stored entity.Invite
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockInviteRepo.On("CreateInvite", mock.AnythingOfType("entity.Invite")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(entity.Invite) }).
+		Return(4, nil)
+
+	inviteUsecase := NewInviteUsecase(mockInviteRepo, mockRoleRepo, logger)
+
+	result, err := inviteUsecase.CreateInvite(1, "moderator", 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.ID)
+	assert.NotEmpty(t, result.Code)
+	assert.Equal(t, token.HashInviteCode(result.Code), stored.CodeHash)
+	assert.Equal(t, 1, stored.MaxUses)
+	assert.WithinDuration(t, time.Now().Add(DefaultInviteTTL), stored.ExpiresAt, time.Minute)
+	mockInviteRepo.AssertExpectations(t)
+}
+
+func TestInviteUsecase_CreateInvite_UnknownRole(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockInviteRepo := new(mocks.InviteRepository)
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "superuser").Return(entity.Role{}, sql.ErrNoRows)
+
+	inviteUsecase := NewInviteUsecase(mockInviteRepo, mockRoleRepo, logger)
+
+	_, err := inviteUsecase.CreateInvite(1, "superuser", 1, time.Hour)
+
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	mockInviteRepo.AssertNotCalled(t, "CreateInvite", mock.Anything)
+}
+
+func TestInviteUsecase_RevokeInvite_NotFound(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockInviteRepo := new(mocks.InviteRepository)
+
+	mockInviteRepo.On("RevokeInvite", 9).Return(false, nil)
+
+	inviteUsecase := NewInviteUsecase(mockInviteRepo, new(mocks.RoleRepository), logger)
+
+	err := inviteUsecase.RevokeInvite(9)
+
+	assert.ErrorIs(t, err, ErrInviteNotFound)
+}
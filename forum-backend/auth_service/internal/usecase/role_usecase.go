@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	repository "github.com/miqxzz/miqxzzforum/auth_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRoleNotFound       = errors.New("роль не найдена")
+	ErrRoleExists         = errors.New("роль уже существует")
+	ErrRoleInUse          = errors.New("роль назначена пользователям")
+	ErrProtectedRole      = errors.New("встроенную роль нельзя удалить")
+	ErrInvalidRoleName    = errors.New("недопустимое имя роли")
+	ErrUnknownPermission  = errors.New("неизвестное право")
+	ErrInvalidPermission  = errors.New("недопустимое имя права")
+	ErrPermissionNotFound = errors.New("право не найдено")
+	ErrPermissionExists   = errors.New("право уже существует")
+)
+
+var (
+	roleNamePattern       = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+	permissionNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)+$`)
+)
+
+// protectedRoles нужны самой системе: "user" выдается при регистрации,
+// "admin" — единственный гарантированный способ управлять ролями.
+var protectedRoles = map[string]bool{"user": true, "admin": true}
+
+type RoleUsecase interface {
+	ListRoles() ([]entity.Role, error)
+	GetRole(name string) (entity.Role, error)
+	CreateRole(role entity.Role) (entity.Role, error)
+	DeleteRole(name string) error
+	SetRolePermissions(role string, permissions []string) (entity.Role, error)
+	ListPermissions() ([]entity.Permission, error)
+	CreatePermission(permission entity.Permission) error
+	DeletePermission(name string) error
+	GetRolePermissions(role string) ([]string, error)
+}
+
+type roleUsecase struct {
+	roleRepo repository.RoleRepository
+	logger   *zap.Logger
+}
+
+func NewRoleUsecase(roleRepo repository.RoleRepository, logger *zap.Logger) RoleUsecase {
+	return &roleUsecase{roleRepo: roleRepo, logger: logger}
+}
+
+func (u *roleUsecase) ListRoles() ([]entity.Role, error) {
+	return u.roleRepo.ListRoles()
+}
+
+func (u *roleUsecase) GetRole(name string) (entity.Role, error) {
+	role, err := u.roleRepo.GetRole(name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return role, ErrRoleNotFound
+	}
+	return role, err
+}
+
+func (u *roleUsecase) CreateRole(role entity.Role) (entity.Role, error) {
+	if !roleNamePattern.MatchString(role.Name) {
+		u.logger.Warn("Invalid role name", zap.String("role", role.Name))
+		return entity.Role{}, ErrInvalidRoleName
+	}
+	if _, err := u.roleRepo.GetRole(role.Name); err == nil {
+		return entity.Role{}, ErrRoleExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return entity.Role{}, err
+	}
+	role.Permissions = uniqueStrings(role.Permissions)
+	if err := u.checkPermissionsExist(role.Permissions); err != nil {
+		return entity.Role{}, err
+	}
+
+	if err := u.roleRepo.CreateRole(role); err != nil {
+		return entity.Role{}, err
+	}
+	if err := u.roleRepo.SetRolePermissions(role.Name, role.Permissions); err != nil {
+		return entity.Role{}, err
+	}
+
+	u.logger.Info("Role created", zap.String("role", role.Name), zap.Strings("permissions", role.Permissions))
+	return u.roleRepo.GetRole(role.Name)
+}
+
+func (u *roleUsecase) DeleteRole(name string) error {
+	if protectedRoles[name] {
+		return ErrProtectedRole
+	}
+	if _, err := u.GetRole(name); err != nil {
+		return err
+	}
+
+	count, err := u.roleRepo.CountUsersWithRole(name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		u.logger.Warn("Role still assigned", zap.String("role", name), zap.Int("users", count))
+		return ErrRoleInUse
+	}
+
+	return u.roleRepo.DeleteRole(name)
+}
+
+func (u *roleUsecase) SetRolePermissions(role string, permissions []string) (entity.Role, error) {
+	if _, err := u.GetRole(role); err != nil {
+		return entity.Role{}, err
+	}
+	permissions = uniqueStrings(permissions)
+	if err := u.checkPermissionsExist(permissions); err != nil {
+		return entity.Role{}, err
+	}
+
+	if err := u.roleRepo.SetRolePermissions(role, permissions); err != nil {
+		return entity.Role{}, err
+	}
+	return u.roleRepo.GetRole(role)
+}
+
+func (u *roleUsecase) ListPermissions() ([]entity.Permission, error) {
+	return u.roleRepo.ListPermissions()
+}
+
+func (u *roleUsecase) CreatePermission(permission entity.Permission) error {
+	if !permissionNamePattern.MatchString(permission.Name) {
+		u.logger.Warn("Invalid permission name", zap.String("permission", permission.Name))
+		return ErrInvalidPermission
+	}
+	if err := u.checkPermissionsExist([]string{permission.Name}); err == nil {
+		return ErrPermissionExists
+	} else if !errors.Is(err, ErrUnknownPermission) {
+		return err
+	}
+	return u.roleRepo.CreatePermission(permission)
+}
+
+func (u *roleUsecase) DeletePermission(name string) error {
+	if err := u.checkPermissionsExist([]string{name}); errors.Is(err, ErrUnknownPermission) {
+		return ErrPermissionNotFound
+	} else if err != nil {
+		return err
+	}
+	return u.roleRepo.DeletePermission(name)
+}
+
+func (u *roleUsecase) GetRolePermissions(role string) ([]string, error) {
+	return u.roleRepo.GetRolePermissions(role)
+}
+
+func (u *roleUsecase) checkPermissionsExist(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	known, err := u.roleRepo.ListPermissions()
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(known))
+	for _, permission := range known {
+		exists[permission.Name] = true
+	}
+	for _, name := range names {
+		if !exists[name] {
+			u.logger.Warn("Unknown permission", zap.String("permission", name))
+			return ErrUnknownPermission
+		}
+	}
+	return nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"database/sql"
+	"testing"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var knownPermissions = []entity.Permission{
+	{Name: entity.PermPostDeleteAny},
+	{Name: entity.PermCommentDeleteAny},
+}
+
+func TestRoleUsecase_CreateRole_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	role := entity.Role{Name: "editor", Permissions: []string{entity.PermPostDeleteAny, entity.PermPostDeleteAny}}
+	created := entity.Role{Name: "editor", Permissions: []string{entity.PermPostDeleteAny}}
+
+	mockRoleRepo.On("GetRole", "editor").Return(entity.Role{}, sql.ErrNoRows).Once()
+	mockRoleRepo.On("ListPermissions").Return(knownPermissions, nil)
+	mockRoleRepo.On("CreateRole", mock.AnythingOfType("entity.Role")).Return(nil)
+	mockRoleRepo.On("SetRolePermissions", "editor", []string{entity.PermPostDeleteAny}).Return(nil)
+	mockRoleRepo.On("GetRole", "editor").Return(created, nil).Once()
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	result, err := roleUsecase.CreateRole(role)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, result)
+	mockRoleRepo.AssertExpectations(t)
+}
+
+func TestRoleUsecase_CreateRole_UnknownPermission(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "editor").Return(entity.Role{}, sql.ErrNoRows)
+	mockRoleRepo.On("ListPermissions").Return(knownPermissions, nil)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	_, err := roleUsecase.CreateRole(entity.Role{Name: "editor", Permissions: []string{"post.pin"}})
+
+	assert.ErrorIs(t, err, ErrUnknownPermission)
+	mockRoleRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+}
+
+func TestRoleUsecase_CreateRole_Exists(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	_, err := roleUsecase.CreateRole(entity.Role{Name: "moderator"})
+
+	assert.ErrorIs(t, err, ErrRoleExists)
+}
+
+func TestRoleUsecase_CreateRole_InvalidName(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	_, err := roleUsecase.CreateRole(entity.Role{Name: "Super User"})
+
+	assert.ErrorIs(t, err, ErrInvalidRoleName)
+	mockRoleRepo.AssertExpectations(t)
+}
+
+func TestRoleUsecase_DeleteRole_Protected(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	err := roleUsecase.DeleteRole("admin")
+
+	assert.ErrorIs(t, err, ErrProtectedRole)
+	mockRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+}
+
+func TestRoleUsecase_DeleteRole_InUse(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "moderator").Return(entity.Role{Name: "moderator"}, nil)
+	mockRoleRepo.On("CountUsersWithRole", "moderator").Return(2, nil)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	err := roleUsecase.DeleteRole("moderator")
+
+	assert.ErrorIs(t, err, ErrRoleInUse)
+	mockRoleRepo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+}
+
+func TestRoleUsecase_DeleteRole_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "editor").Return(entity.Role{Name: "editor"}, nil)
+	mockRoleRepo.On("CountUsersWithRole", "editor").Return(0, nil)
+	mockRoleRepo.On("DeleteRole", "editor").Return(nil)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	err := roleUsecase.DeleteRole("editor")
+
+	assert.NoError(t, err)
+	mockRoleRepo.AssertExpectations(t)
+}
+
+func TestRoleUsecase_SetRolePermissions_RoleNotFound(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("GetRole", "ghost").Return(entity.Role{}, sql.ErrNoRows)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	_, err := roleUsecase.SetRolePermissions("ghost", []string{entity.PermPostDeleteAny})
+
+	assert.ErrorIs(t, err, ErrRoleNotFound)
+}
+
+func TestRoleUsecase_CreatePermission_InvalidName(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	err := roleUsecase.CreatePermission(entity.Permission{Name: "pin"})
+
+	assert.ErrorIs(t, err, ErrInvalidPermission)
+}
+
+func TestRoleUsecase_CreatePermission_Exists(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockRoleRepo := new(mocks.RoleRepository)
+
+	mockRoleRepo.On("ListPermissions").Return(knownPermissions, nil)
+
+	roleUsecase := NewRoleUsecase(mockRoleRepo, logger)
+
+	err := roleUsecase.CreatePermission(entity.Permission{Name: entity.PermPostDeleteAny})
+
+	assert.ErrorIs(t, err, ErrPermissionExists)
+	mockRoleRepo.AssertNotCalled(t, "CreatePermission", mock.Anything)
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mocks "github.com/miqxzz/miqxzzforum/auth_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var moderator = entity.Principal{UserID: 1, Role: "moderator", Permissions: []string{entity.PermUserBan, entity.PermChatMute}}
+
+// userRole возвращает роли, в которых у "user" нет прав.
+func userRole() *mocks.RoleRepository {
+	roleRepo := new(mocks.RoleRepository)
+	roleRepo.On("GetRolePermissions", "user").Return([]string{}, nil)
+	return roleRepo
+}
+
+func TestSanctionUsecase_Issue_BanRevokesTokens(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+
+	var stored entity.Sanction
+	mockAuthRepo.On("GetUserByID", 5).Return(entity.User{ID: 5, Role: "user"}, nil)
+	mockSanctionRepo.On("CreateSanction", mock.AnythingOfType("entity.Sanction")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(entity.Sanction) }).
+		Return(3, nil)
+	mockAuthRepo.On("RevokeUserTokens", 5).Return(nil)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, mockAuthRepo, userRole(), zap.NewNop())
+
+	result, err := sanctionUsecase.Issue(moderator, 5, entity.SanctionBan, "  спам  ", 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.ID)
+	assert.True(t, result.Active)
+	assert.Equal(t, "спам", stored.Reason)
+	assert.Equal(t, 1, stored.IssuedBy)
+	if assert.NotNil(t, stored.ExpiresAt) {
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), *stored.ExpiresAt, time.Minute)
+	}
+	mockAuthRepo.AssertExpectations(t)
+	mockSanctionRepo.AssertExpectations(t)
+}
+
+func TestSanctionUsecase_Issue_MuteKeepsSessions(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	mockAuthRepo := new(mocks.AuthRepository)
+
+	mockAuthRepo.On("GetUserByID", 5).Return(entity.User{ID: 5, Role: "user"}, nil)
+	mockSanctionRepo.On("CreateSanction", mock.MatchedBy(func(s entity.Sanction) bool {
+		return s.Type == entity.SanctionMute && s.ExpiresAt == nil
+	})).Return(4, nil)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, mockAuthRepo, userRole(), zap.NewNop())
+
+	_, err := sanctionUsecase.Issue(moderator, 5, entity.SanctionMute, "флуд", 0)
+
+	assert.NoError(t, err)
+	mockAuthRepo.AssertNotCalled(t, "RevokeUserTokens", mock.Anything)
+}
+
+func TestSanctionUsecase_Issue_Validation(t *testing.T) {
+	muteOnly := entity.Principal{UserID: 1, Role: "helper", Permissions: []string{entity.PermChatMute}}
+
+	for _, tc := range []struct {
+		name      string
+		issuer    entity.Principal
+		userID    int
+		sanction  string
+		reason    string
+		duration  time.Duration
+		expectErr error
+	}{
+		{"unknown type", moderator, 5, "exile", "спам", 0, ErrInvalidSanction},
+		{"no permission", muteOnly, 5, entity.SanctionBan, "спам", 0, ErrSanctionForbidden},
+		{"empty reason", moderator, 5, entity.SanctionBan, "   ", 0, ErrSanctionReason},
+		{"negative duration", moderator, 5, entity.SanctionSuspension, "спам", -time.Hour, ErrSanctionDuration},
+		{"self", moderator, 1, entity.SanctionMute, "тест", 0, ErrSelfSanction},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSanctionRepo := new(mocks.SanctionRepository)
+			sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, new(mocks.AuthRepository), new(mocks.RoleRepository), zap.NewNop())
+
+			_, err := sanctionUsecase.Issue(tc.issuer, tc.userID, tc.sanction, tc.reason, tc.duration)
+
+			assert.ErrorIs(t, err, tc.expectErr)
+			mockSanctionRepo.AssertNotCalled(t, "CreateSanction", mock.Anything)
+		})
+	}
+}
+
+func TestSanctionUsecase_Issue_UserNotFound(t *testing.T) {
+	mockAuthRepo := new(mocks.AuthRepository)
+	mockAuthRepo.On("GetUserByID", 9).Return(entity.User{}, sql.ErrNoRows)
+
+	sanctionUsecase := NewSanctionUsecase(new(mocks.SanctionRepository), mockAuthRepo, userRole(), zap.NewNop())
+
+	_, err := sanctionUsecase.Issue(moderator, 9, entity.SanctionBan, "спам", 0)
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestSanctionUsecase_Issue_TargetRank(t *testing.T) {
+	helper := entity.Principal{UserID: 1, Role: "helper", Permissions: []string{entity.PermChatMute, entity.PermReportReview}}
+
+	for _, tc := range []struct {
+		name        string
+		role        string
+		permissions []string
+		expectErr   error
+	}{
+		{"holder of user.ban", "admin", []string{entity.PermUserBan}, ErrSanctionTargetRank},
+		{"same role", "helper", []string{entity.PermChatMute, entity.PermReportReview}, ErrSanctionTargetRank},
+		{"higher role", "moderator", []string{entity.PermChatMute, entity.PermReportReview, entity.PermPostDeleteAny}, ErrSanctionTargetRank},
+		{"lower role", "editor", []string{entity.PermPostUpdateAny, entity.PermChatMute}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSanctionRepo := new(mocks.SanctionRepository)
+			mockAuthRepo := new(mocks.AuthRepository)
+			mockRoleRepo := new(mocks.RoleRepository)
+			mockAuthRepo.On("GetUserByID", 5).Return(entity.User{ID: 5, Role: tc.role}, nil)
+			mockRoleRepo.On("GetRolePermissions", tc.role).Return(tc.permissions, nil)
+			mockSanctionRepo.On("CreateSanction", mock.Anything).Return(4, nil).Maybe()
+
+			sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, mockAuthRepo, mockRoleRepo, zap.NewNop())
+
+			_, err := sanctionUsecase.Issue(helper, 5, entity.SanctionMute, "флуд", 0)
+
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				mockSanctionRepo.AssertNotCalled(t, "CreateSanction", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSanctionUsecase_ListUserSanctions_MarksActive(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	past := time.Now().Add(-time.Hour)
+	revokedBy := 1
+
+	mockSanctionRepo.On("ListUserSanctions", 5).Return([]entity.Sanction{
+		{ID: 3, Type: entity.SanctionMute},
+		{ID: 2, Type: entity.SanctionBan, RevokedAt: &past, RevokedBy: &revokedBy},
+		{ID: 1, Type: entity.SanctionSuspension, ExpiresAt: &past},
+	}, nil)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, new(mocks.AuthRepository), new(mocks.RoleRepository), zap.NewNop())
+
+	sanctions, err := sanctionUsecase.ListUserSanctions(5)
+
+	assert.NoError(t, err)
+	assert.True(t, sanctions[0].Active)
+	assert.False(t, sanctions[1].Active)
+	assert.False(t, sanctions[2].Active)
+}
+
+func TestSanctionUsecase_Revoke(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+
+	mockSanctionRepo.On("GetSanction", 3).Return(entity.Sanction{ID: 3, UserID: 5, Type: entity.SanctionBan}, nil)
+	mockSanctionRepo.On("RevokeSanction", 3, 1).Return(true, nil)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, new(mocks.AuthRepository), new(mocks.RoleRepository), zap.NewNop())
+
+	revoked, err := sanctionUsecase.Revoke(moderator, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, revoked.ID)
+	assert.NotNil(t, revoked.RevokedAt)
+	assert.Equal(t, moderator.UserID, *revoked.RevokedBy)
+	mockSanctionRepo.AssertExpectations(t)
+}
+
+func TestSanctionUsecase_Revoke_Expired(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	past := time.Now().Add(-time.Minute)
+
+	mockSanctionRepo.On("GetSanction", 3).Return(entity.Sanction{ID: 3, Type: entity.SanctionMute, ExpiresAt: &past}, nil)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, new(mocks.AuthRepository), new(mocks.RoleRepository), zap.NewNop())
+
+	_, err := sanctionUsecase.Revoke(moderator, 3)
+	assert.ErrorIs(t, err, ErrSanctionNotRevocable)
+	mockSanctionRepo.AssertNotCalled(t, "RevokeSanction", mock.Anything, mock.Anything)
+}
+
+func TestSanctionUsecase_Revoke_NotFound(t *testing.T) {
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	mockSanctionRepo.On("GetSanction", 8).Return(entity.Sanction{}, sql.ErrNoRows)
+
+	sanctionUsecase := NewSanctionUsecase(mockSanctionRepo, new(mocks.AuthRepository), new(mocks.RoleRepository), zap.NewNop())
+
+	_, err := sanctionUsecase.Revoke(moderator, 8)
+	assert.ErrorIs(t, err, ErrSanctionNotFound)
+}
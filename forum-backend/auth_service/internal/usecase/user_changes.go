@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"sync"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// userChangesBuffer — сколько непрочитанных событий держится для одного
+// подписчика. Подписчик, который не успевает их забирать, отключается:
+// пропущенное событие означало бы устаревший кэш на его стороне.
+const userChangesBuffer = 64
+
+// UserChanges рассылает подписчикам события о смене имени или роли
+// пользователя. В событии передаются только id, имя и роль.
+type UserChanges struct {
+	mu     sync.Mutex
+	subs   map[chan entity.User]struct{}
+	logger *zap.Logger
+}
+
+func NewUserChanges(logger *zap.Logger) *UserChanges {
+	return &UserChanges{subs: make(map[chan entity.User]struct{}), logger: logger}
+}
+
+// Subscribe возвращает канал событий и функцию отписки. Канал закрывается
+// при отписке или если подписчик отстал больше чем на userChangesBuffer событий.
+func (c *UserChanges) Subscribe() (<-chan entity.User, func()) {
+	ch := make(chan entity.User, userChangesBuffer)
+	c.mu.Lock()
+	c.subs[ch] = struct{}{}
+	c.mu.Unlock()
+
+	return ch, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.subs[ch]; ok {
+			delete(c.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish не блокируется. Вызов на nil допустим и ничего не делает.
+func (c *UserChanges) Publish(user entity.User) {
+	if c == nil {
+		return
+	}
+	event := entity.User{ID: user.ID, Username: user.Username, Role: user.Role}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for ch := range c.subs {
+		select {
+		case ch <- event:
+		default:
+			c.logger.Warn("User changes subscriber is lagging, disconnecting", zap.Int("userID", user.ID))
+			delete(c.subs, ch)
+			close(ch)
+		}
+	}
+}
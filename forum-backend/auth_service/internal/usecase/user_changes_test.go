@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"testing"
+
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestUserChanges_PublishToAllSubscribers(t *testing.T) {
+	changes := NewUserChanges(zap.NewNop())
+	first, unsubscribeFirst := changes.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := changes.Subscribe()
+	defer unsubscribeSecond()
+
+	changes.Publish(entity.User{ID: 1, Username: "alice", Password: "hash", Role: "user"})
+
+	want := entity.User{ID: 1, Username: "alice", Role: "user"}
+	assert.Equal(t, want, <-first)
+	assert.Equal(t, want, <-second)
+}
+
+func TestUserChanges_Unsubscribe(t *testing.T) {
+	changes := NewUserChanges(zap.NewNop())
+	events, unsubscribe := changes.Subscribe()
+
+	unsubscribe()
+	unsubscribe()
+	changes.Publish(entity.User{ID: 1})
+
+	_, ok := <-events
+	assert.False(t, ok)
+}
+
+func TestUserChanges_LaggingSubscriberDisconnected(t *testing.T) {
+	changes := NewUserChanges(zap.NewNop())
+	events, unsubscribe := changes.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= userChangesBuffer; i++ {
+		changes.Publish(entity.User{ID: i})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, userChangesBuffer, received)
+}
+
+func TestUserChanges_NilPublish(t *testing.T) {
+	var changes *UserChanges
+	assert.NotPanics(t, func() { changes.Publish(entity.User{ID: 1}) })
+}
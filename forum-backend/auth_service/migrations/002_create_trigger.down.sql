@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS cleanup_old_messages;
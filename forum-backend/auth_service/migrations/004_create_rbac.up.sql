@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS roles (
+                                     name VARCHAR(50) PRIMARY KEY,
+                                     description TEXT NOT NULL DEFAULT '',
+                                     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+                                           name VARCHAR(100) PRIMARY KEY,
+                                           description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+                                                role VARCHAR(50) NOT NULL,
+                                                permission VARCHAR(100) NOT NULL,
+                                                PRIMARY KEY (role, permission),
+                                                FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE,
+                                                FOREIGN KEY (permission) REFERENCES permissions(name) ON DELETE CASCADE
+);
+
+INSERT OR IGNORE INTO roles (name, description) VALUES
+    ('user', 'Обычный пользователь'),
+    ('moderator', 'Модератор'),
+    ('admin', 'Администратор');
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('post.update.any', 'Редактирование любых постов'),
+    ('post.delete.any', 'Удаление любых постов'),
+    ('comment.delete.any', 'Удаление любых комментариев'),
+    ('user.ban', 'Блокировка пользователей'),
+    ('chat.mute', 'Запрет писать в чат'),
+    ('role.assign', 'Назначение ролей пользователям'),
+    ('role.manage', 'Управление ролями и правами');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'post.delete.any'),
+    ('moderator', 'comment.delete.any'),
+    ('moderator', 'chat.mute'),
+    ('admin', 'post.update.any'),
+    ('admin', 'post.delete.any'),
+    ('admin', 'comment.delete.any'),
+    ('admin', 'user.ban'),
+    ('admin', 'chat.mute'),
+    ('admin', 'role.assign'),
+    ('admin', 'role.manage');
+
+-- Роли, которые уже встречаются у пользователей, но не описаны выше.
+INSERT OR IGNORE INTO roles (name) SELECT DISTINCT role FROM users;
@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission = 'invite.manage';
+DELETE FROM permissions WHERE name = 'invite.manage';
+
+DROP TABLE IF EXISTS invites;
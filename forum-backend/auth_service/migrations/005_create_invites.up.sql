@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS invites (
+                                       id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                       code_hash VARCHAR(64) NOT NULL UNIQUE,
+                                       role VARCHAR(50) NOT NULL,
+                                       max_uses INTEGER NOT NULL DEFAULT 1,
+                                       uses INTEGER NOT NULL DEFAULT 0,
+                                       created_by INTEGER NOT NULL,
+                                       created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+                                       expires_at DATETIME NOT NULL,
+                                       revoked_at DATETIME,
+                                       FOREIGN KEY (role) REFERENCES roles(name),
+                                       FOREIGN KEY (created_by) REFERENCES users(id)
+);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('invite.manage', 'Управление кодами приглашений');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'invite.manage');
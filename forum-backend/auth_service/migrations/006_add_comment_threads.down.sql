@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_post_parent;
+DROP INDEX IF EXISTS idx_comments_parent_id;
+
+ALTER TABLE comments DROP COLUMN deleted_at;
+ALTER TABLE comments DROP COLUMN parent_id;
@@ -0,0 +1,6 @@
+ALTER TABLE comments ADD COLUMN parent_id INTEGER REFERENCES comments(id);
+-- Комментарий с ответами при удалении остается заглушкой "[deleted]"
+ALTER TABLE comments ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_comments_post_parent ON comments(post_id, parent_id);
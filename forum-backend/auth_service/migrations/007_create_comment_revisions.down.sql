@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission = 'comment.update.any';
+DELETE FROM permissions WHERE name = 'comment.update.any';
+
+DROP TRIGGER IF EXISTS save_comment_revision;
+DROP TABLE IF EXISTS comment_revisions;
+
+ALTER TABLE comments DROP COLUMN edited_by;
+ALTER TABLE comments DROP COLUMN updated_at;
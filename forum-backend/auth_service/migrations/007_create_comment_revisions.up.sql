@@ -0,0 +1,40 @@
+ALTER TABLE comments ADD COLUMN updated_at DATETIME;
+ALTER TABLE comments ADD COLUMN edited_by INTEGER REFERENCES users(id);
+
+-- Предыдущие версии комментариев. Версия N хранит текст, ее автора
+-- (автора комментария или редактора) и время, когда она появилась.
+-- Текущая версия — сама строка comments.
+CREATE TABLE IF NOT EXISTS comment_revisions (
+                                                 id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                                 comment_id INTEGER NOT NULL,
+                                                 version INTEGER NOT NULL,
+                                                 content TEXT NOT NULL,
+                                                 editor_id INTEGER NOT NULL,
+                                                 created_at DATETIME NOT NULL,
+                                                 UNIQUE (comment_id, version),
+                                                 FOREIGN KEY (comment_id) REFERENCES comments(id) ON DELETE CASCADE,
+                                                 FOREIGN KEY (editor_id) REFERENCES users(id)
+);
+
+-- Перед изменением текста комментария его прежняя версия сохраняется в
+-- comment_revisions. Замена текста заглушкой при удалении ревизией не считается.
+CREATE TRIGGER IF NOT EXISTS save_comment_revision
+    BEFORE UPDATE OF content ON comments
+    WHEN NEW.deleted_at IS NULL AND OLD.content IS NOT NEW.content
+BEGIN
+    INSERT INTO comment_revisions (comment_id, version, content, editor_id, created_at)
+    VALUES (
+        OLD.id,
+        (SELECT COUNT(*) + 1 FROM comment_revisions WHERE comment_id = OLD.id),
+        OLD.content,
+        COALESCE(OLD.edited_by, OLD.author_id),
+        COALESCE(OLD.updated_at, OLD.created_at)
+    );
+END;
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('comment.update.any', 'Редактирование любых комментариев');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'comment.update.any'),
+    ('admin', 'comment.update.any');
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS save_post_revision;
+DROP TABLE IF EXISTS post_revisions;
+
+ALTER TABLE posts DROP COLUMN edit_reason;
+ALTER TABLE posts DROP COLUMN edited_by;
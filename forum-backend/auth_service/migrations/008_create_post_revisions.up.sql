@@ -0,0 +1,36 @@
+-- Кто и зачем последним редактировал пост
+ALTER TABLE posts ADD COLUMN edited_by INTEGER REFERENCES users(id);
+ALTER TABLE posts ADD COLUMN edit_reason TEXT;
+
+-- Журнал правок постов. Каждая строка — одна правка: прежние заголовок и
+-- текст (версия version), кто ее сделал, когда и по какой причине.
+CREATE TABLE IF NOT EXISTS post_revisions (
+                                              id INTEGER PRIMARY KEY AUTOINCREMENT,
+                                              post_id INTEGER NOT NULL,
+                                              version INTEGER NOT NULL,
+                                              title TEXT,
+                                              content TEXT,
+                                              editor_id INTEGER NOT NULL,
+                                              reason TEXT,
+                                              created_at DATETIME NOT NULL,
+                                              UNIQUE (post_id, version),
+                                              FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+                                              FOREIGN KEY (editor_id) REFERENCES users(id)
+);
+
+-- Перед изменением заголовка или текста поста прежняя версия сохраняется в
+-- post_revisions вместе с редактором и причиной из обновленной строки.
+CREATE TRIGGER IF NOT EXISTS save_post_revision
+    BEFORE UPDATE OF title, content ON posts
+BEGIN
+    INSERT INTO post_revisions (post_id, version, title, content, editor_id, reason, created_at)
+    VALUES (
+        OLD.id,
+        (SELECT COUNT(*) + 1 FROM post_revisions WHERE post_id = OLD.id),
+        OLD.title,
+        OLD.content,
+        COALESCE(NEW.edited_by, OLD.author_id),
+        NEW.edit_reason,
+        COALESCE(NEW.updated_at, CURRENT_TIMESTAMP)
+    );
+END;
@@ -0,0 +1,74 @@
+-- Полнотекстовый поиск по постам и комментариям. Нужен SQLite с FTS5:
+-- оба сервиса собираются с тегом sqlite_fts5 (go build -tags sqlite_fts5).
+--
+-- porter стеммит английские слова, unicode61 приводит к нижнему регистру
+-- и латиницу, и кириллицу. Буква ё индексируется как е, поэтому "ёлка" и
+-- "елка" находят друг друга; окончания русских слов отбрасывает forum_service
+-- при разборе запроса.
+CREATE VIRTUAL TABLE IF NOT EXISTS posts_fts USING fts5(
+    title,
+    content,
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS comments_fts USING fts5(
+    content,
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_insert
+    AFTER INSERT ON posts
+BEGIN
+    INSERT INTO posts_fts (rowid, title, content)
+    VALUES (NEW.id,
+            replace(replace(NEW.title, 'ё', 'е'), 'Ё', 'Е'),
+            replace(replace(NEW.content, 'ё', 'е'), 'Ё', 'Е'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_update
+    AFTER UPDATE OF title, content ON posts
+BEGIN
+    DELETE FROM posts_fts WHERE rowid = OLD.id;
+    INSERT INTO posts_fts (rowid, title, content)
+    VALUES (NEW.id,
+            replace(replace(NEW.title, 'ё', 'е'), 'Ё', 'Е'),
+            replace(replace(NEW.content, 'ё', 'е'), 'Ё', 'Е'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS posts_fts_delete
+    AFTER DELETE ON posts
+BEGIN
+    DELETE FROM posts_fts WHERE rowid = OLD.id;
+END;
+
+-- Удаленный комментарий, оставленный заглушкой, из индекса убирается
+CREATE TRIGGER IF NOT EXISTS comments_fts_insert
+    AFTER INSERT ON comments
+BEGIN
+    INSERT INTO comments_fts (rowid, content)
+    VALUES (NEW.id, replace(replace(NEW.content, 'ё', 'е'), 'Ё', 'Е'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_update
+    AFTER UPDATE OF content, deleted_at ON comments
+BEGIN
+    DELETE FROM comments_fts WHERE rowid = OLD.id;
+    INSERT INTO comments_fts (rowid, content)
+    SELECT NEW.id, replace(replace(NEW.content, 'ё', 'е'), 'Ё', 'Е')
+    WHERE NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_delete
+    AFTER DELETE ON comments
+BEGIN
+    DELETE FROM comments_fts WHERE rowid = OLD.id;
+END;
+
+INSERT INTO posts_fts (rowid, title, content)
+SELECT id, replace(replace(title, 'ё', 'е'), 'Ё', 'Е'), replace(replace(content, 'ё', 'е'), 'Ё', 'Е')
+FROM posts;
+
+INSERT INTO comments_fts (rowid, content)
+SELECT id, replace(replace(content, 'ё', 'е'), 'Ё', 'Е')
+FROM comments
+WHERE deleted_at IS NULL;
@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission = 'category.manage';
+DELETE FROM permissions WHERE name = 'category.manage';
+
+DROP INDEX IF EXISTS idx_posts_category;
+ALTER TABLE posts DROP COLUMN category_id;
+
+DROP INDEX IF EXISTS idx_categories_parent;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,33 @@
+-- Разделы форума. read_roles, post_roles и comment_roles — роли через
+-- запятую, которым разрешено действие; пустая строка — разрешено всем
+-- (читать — в том числе гостям). Архивный раздел доступен только для чтения.
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    parent_id INTEGER REFERENCES categories(id),
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    read_roles TEXT NOT NULL DEFAULT '',
+    post_roles TEXT NOT NULL DEFAULT '',
+    comment_roles TEXT NOT NULL DEFAULT '',
+    archived_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id, position);
+
+-- Раздел по умолчанию: в нем оказываются все существующие посты.
+INSERT OR IGNORE INTO categories (id, slug, name, description)
+VALUES (1, 'general', 'Общее', 'Обсуждения на любые темы');
+
+ALTER TABLE posts ADD COLUMN category_id INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_posts_category ON posts(category_id, created_at);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('category.manage', 'Управление разделами форума');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'category.manage');
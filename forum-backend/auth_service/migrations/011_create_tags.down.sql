@@ -0,0 +1,8 @@
+DELETE FROM role_permissions WHERE permission = 'tag.manage';
+DELETE FROM permissions WHERE name = 'tag.manage';
+
+DROP INDEX IF EXISTS idx_tag_aliases_tag;
+DROP INDEX IF EXISTS idx_post_tags_tag;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS tags;
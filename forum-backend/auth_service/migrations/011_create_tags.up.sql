@@ -0,0 +1,31 @@
+-- Метки постов. name хранится в нижнем регистре и уникально; синонимы из
+-- tag_aliases при назначении заменяются основной меткой.
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(32) NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    alias VARCHAR(32) PRIMARY KEY,
+    tag_id INTEGER NOT NULL,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (post_id, tag_id),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag ON post_tags(tag_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_tag_aliases_tag ON tag_aliases(tag_id);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('tag.manage', 'Переименование, слияние и удаление меток');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'tag.manage'),
+    ('admin', 'tag.manage');
@@ -0,0 +1,16 @@
+DROP TRIGGER IF EXISTS comments_reactions_delete;
+DROP TRIGGER IF EXISTS posts_reactions_delete;
+DROP TRIGGER IF EXISTS reactions_count_delete;
+DROP TRIGGER IF EXISTS reactions_count_insert;
+DROP TRIGGER IF EXISTS reactions_replace_vote;
+
+DROP TABLE IF EXISTS reaction_counts;
+
+ALTER TABLE comments DROP COLUMN downvotes;
+ALTER TABLE comments DROP COLUMN upvotes;
+ALTER TABLE posts DROP COLUMN downvotes;
+ALTER TABLE posts DROP COLUMN upvotes;
+
+DROP INDEX IF EXISTS idx_reactions_user;
+DROP INDEX IF EXISTS idx_reactions_target;
+DROP TABLE IF EXISTS reactions;
@@ -0,0 +1,87 @@
+-- Реакции на посты и комментарии: голоса up/down и эмодзи из настроенного
+-- набора. Каждый пользователь ставит не больше одной реакции каждого вида
+-- на цель; голоса up и down взаимоисключающие.
+CREATE TABLE IF NOT EXISTS reactions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('post', 'comment')),
+    target_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (target_type, target_id, user_id, kind),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reactions_target ON reactions(target_type, target_id, kind, created_at);
+CREATE INDEX IF NOT EXISTS idx_reactions_user ON reactions(user_id);
+
+-- Счетчики голосов хранятся в самих постах и комментариях, чтобы по ним
+-- можно было сортировать; счетчики эмодзи — в reaction_counts. Все счетчики
+-- меняют триггеры в той же операции, что и реакцию.
+ALTER TABLE posts ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN upvotes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN downvotes INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS reaction_counts (
+    target_type VARCHAR(16) NOT NULL,
+    target_id INTEGER NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (target_type, target_id, kind)
+);
+
+-- Новый голос снимает противоположный голос того же пользователя
+CREATE TRIGGER IF NOT EXISTS reactions_replace_vote
+    BEFORE INSERT ON reactions
+    WHEN NEW.kind IN ('up', 'down')
+BEGIN
+    DELETE FROM reactions
+    WHERE target_type = NEW.target_type
+      AND target_id = NEW.target_id
+      AND user_id = NEW.user_id
+      AND kind = CASE NEW.kind WHEN 'up' THEN 'down' ELSE 'up' END;
+END;
+
+CREATE TRIGGER IF NOT EXISTS reactions_count_insert
+    AFTER INSERT ON reactions
+BEGIN
+    UPDATE posts
+    SET upvotes = upvotes + (NEW.kind = 'up'), downvotes = downvotes + (NEW.kind = 'down')
+    WHERE NEW.target_type = 'post' AND NEW.kind IN ('up', 'down') AND id = NEW.target_id;
+    UPDATE comments
+    SET upvotes = upvotes + (NEW.kind = 'up'), downvotes = downvotes + (NEW.kind = 'down')
+    WHERE NEW.target_type = 'comment' AND NEW.kind IN ('up', 'down') AND id = NEW.target_id;
+    INSERT INTO reaction_counts (target_type, target_id, kind, count)
+    SELECT NEW.target_type, NEW.target_id, NEW.kind, 1
+    WHERE NEW.kind NOT IN ('up', 'down')
+    ON CONFLICT (target_type, target_id, kind) DO UPDATE SET count = count + 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS reactions_count_delete
+    AFTER DELETE ON reactions
+BEGIN
+    UPDATE posts
+    SET upvotes = upvotes - (OLD.kind = 'up'), downvotes = downvotes - (OLD.kind = 'down')
+    WHERE OLD.target_type = 'post' AND OLD.kind IN ('up', 'down') AND id = OLD.target_id;
+    UPDATE comments
+    SET upvotes = upvotes - (OLD.kind = 'up'), downvotes = downvotes - (OLD.kind = 'down')
+    WHERE OLD.target_type = 'comment' AND OLD.kind IN ('up', 'down') AND id = OLD.target_id;
+    UPDATE reaction_counts SET count = count - 1
+    WHERE target_type = OLD.target_type AND target_id = OLD.target_id AND kind = OLD.kind;
+    DELETE FROM reaction_counts
+    WHERE target_type = OLD.target_type AND target_id = OLD.target_id AND kind = OLD.kind AND count <= 0;
+END;
+
+-- Реакции удаленных постов и комментариев больше не нужны
+CREATE TRIGGER IF NOT EXISTS posts_reactions_delete
+    AFTER DELETE ON posts
+BEGIN
+    DELETE FROM reactions WHERE target_type = 'post' AND target_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_reactions_delete
+    AFTER DELETE ON comments
+BEGIN
+    DELETE FROM reactions WHERE target_type = 'comment' AND target_id = OLD.id;
+END;
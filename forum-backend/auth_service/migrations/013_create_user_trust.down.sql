@@ -0,0 +1,6 @@
+DELETE FROM role_permissions WHERE permission IN ('trust.manage', 'trust.exempt');
+DELETE FROM permissions WHERE name IN ('trust.manage', 'trust.exempt');
+
+DROP INDEX IF EXISTS idx_comments_author;
+DROP INDEX IF EXISTS idx_posts_author;
+DROP TABLE IF EXISTS user_trust;
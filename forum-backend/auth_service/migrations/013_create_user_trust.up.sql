@@ -0,0 +1,27 @@
+-- Репутация и уровень доверия пользователей. reputation и trust_level
+-- считает forum_service по активности пользователя и сохраняет здесь, чтобы
+-- их можно было показать без пересчета; override — уровень, назначенный
+-- администратором вручную, он заменяет вычисленный.
+CREATE TABLE IF NOT EXISTS user_trust (
+    user_id INTEGER PRIMARY KEY,
+    reputation INTEGER NOT NULL DEFAULT 0,
+    trust_level VARCHAR(16) NOT NULL DEFAULT 'new',
+    override VARCHAR(16),
+    override_by INTEGER,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Репутация считается по постам и комментариям автора, лимит постов — по
+-- постам автора за последние сутки.
+CREATE INDEX IF NOT EXISTS idx_posts_author ON posts(author_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_comments_author ON comments(author_id);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('trust.manage', 'Назначение уровня доверия пользователям'),
+    ('trust.exempt', 'Действия без ограничений уровня доверия');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'trust.exempt'),
+    ('admin', 'trust.manage'),
+    ('admin', 'trust.exempt');
@@ -0,0 +1,13 @@
+DELETE FROM role_permissions WHERE permission = 'report.review';
+DELETE FROM permissions WHERE name = 'report.review';
+
+ALTER TABLE chat_messages DROP COLUMN hidden_at;
+ALTER TABLE comments DROP COLUMN hidden_at;
+ALTER TABLE posts DROP COLUMN hidden_at;
+
+DROP INDEX IF EXISTS idx_reports_reason;
+DROP TABLE IF EXISTS reports;
+DROP INDEX IF EXISTS idx_moderation_cases_author;
+DROP INDEX IF EXISTS idx_moderation_cases_status;
+DROP INDEX IF EXISTS idx_moderation_cases_pending;
+DROP TABLE IF EXISTS moderation_cases;
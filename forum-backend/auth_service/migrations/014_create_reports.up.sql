@@ -0,0 +1,53 @@
+-- Жалобы на посты, комментарии и сообщения чата. Жалобы на одну цель
+-- собираются в дело модерации; пока дело не решено, новые жалобы попадают в
+-- него же. Каждый пользователь жалуется на цель в одном деле один раз.
+CREATE TABLE IF NOT EXISTS moderation_cases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('post', 'comment', 'chat_message')),
+    target_id INTEGER NOT NULL,
+    -- Пост цели (для комментария — пост, к которому он оставлен), автор и
+    -- текст цели на момент первой жалобы: после удаления цели дело остается
+    -- понятным.
+    post_id INTEGER,
+    target_author_id INTEGER NOT NULL,
+    content TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'claimed', 'resolved')),
+    claimed_by INTEGER,
+    claimed_at DATETIME,
+    resolution VARCHAR(16) CHECK (resolution IN ('dismiss', 'delete', 'warn', 'ban')),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    resolved_by INTEGER,
+    resolved_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_cases_pending
+    ON moderation_cases(target_type, target_id) WHERE status != 'resolved';
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_status ON moderation_cases(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_author ON moderation_cases(target_author_id, resolution);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    case_id INTEGER NOT NULL,
+    reporter_id INTEGER NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (case_id, reporter_id),
+    FOREIGN KEY (case_id) REFERENCES moderation_cases(id) ON DELETE CASCADE,
+    FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_reason ON reports(reason, case_id);
+
+-- Цель, набравшая достаточно жалоб, скрывается до решения модератора
+ALTER TABLE posts ADD COLUMN hidden_at DATETIME;
+ALTER TABLE comments ADD COLUMN hidden_at DATETIME;
+ALTER TABLE chat_messages ADD COLUMN hidden_at DATETIME;
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('report.review', 'Разбор жалоб');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'report.review'),
+    ('admin', 'report.review');
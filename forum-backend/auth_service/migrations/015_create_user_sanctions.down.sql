@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission = 'sanction.view';
+DELETE FROM permissions WHERE name = 'sanction.view';
+
+DROP INDEX IF EXISTS idx_user_sanctions_user;
+DROP TABLE IF EXISTS user_sanctions;
@@ -0,0 +1,26 @@
+-- Санкции против пользователей: ban — вход запрещен, все сессии
+-- завершаются; suspension — пользователь входит и читает, но не пишет ни на
+-- форуме, ни в чате; mute — запрет писать только в чат. expires_at NULL —
+-- бессрочная санкция. Снятая досрочно санкция остается в истории.
+CREATE TABLE IF NOT EXISTS user_sanctions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('ban', 'suspension', 'mute')),
+    reason TEXT NOT NULL,
+    issued_by INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME,
+    revoked_at DATETIME,
+    revoked_by INTEGER,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (issued_by) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sanctions_user ON user_sanctions(user_id, revoked_at);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('sanction.view', 'Просмотр истории санкций пользователей');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'sanction.view'),
+    ('admin', 'sanction.view');
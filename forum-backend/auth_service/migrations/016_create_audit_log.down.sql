@@ -0,0 +1,9 @@
+DELETE FROM role_permissions WHERE permission = 'audit.view';
+DELETE FROM permissions WHERE name = 'audit.view';
+
+DROP TRIGGER IF EXISTS audit_log_no_delete;
+DROP TRIGGER IF EXISTS audit_log_no_update;
+DROP INDEX IF EXISTS idx_audit_log_created;
+DROP INDEX IF EXISTS idx_audit_log_target;
+DROP INDEX IF EXISTS idx_audit_log_actor;
+DROP TABLE IF EXISTS audit_log;
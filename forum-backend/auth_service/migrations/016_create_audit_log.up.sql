@@ -0,0 +1,39 @@
+-- Журнал привилегированных действий обоих сервисов. Записи только
+-- добавляются: изменить или удалить их не дают триггеры. before/after —
+-- JSON-снимки объекта до и после действия, target_id — строка, потому что
+-- у ролей, прав и меток идентификатор — имя.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id INTEGER NOT NULL,
+    actor_role VARCHAR(50) NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    before TEXT,
+    after TEXT,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_target ON audit_log(target_type, target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update
+    BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete
+    BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('audit.view', 'Просмотр и выгрузка журнала действий администрации');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'audit.view');
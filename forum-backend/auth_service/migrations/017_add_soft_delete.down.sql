@@ -0,0 +1,13 @@
+DELETE FROM role_permissions WHERE permission = 'trash.manage';
+DELETE FROM permissions WHERE name = 'trash.manage';
+
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+
+-- Строки, лежавшие в корзине, при откате удаляются насовсем
+DELETE FROM comments WHERE post_id IN (SELECT id FROM posts WHERE deleted_at IS NOT NULL);
+DELETE FROM posts WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE comments DROP COLUMN deleted_by;
+ALTER TABLE posts DROP COLUMN deleted_by;
+ALTER TABLE posts DROP COLUMN deleted_at;
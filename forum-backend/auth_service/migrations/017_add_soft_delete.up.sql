@@ -0,0 +1,17 @@
+-- Посты и комментарии удаляются мягко: строка остается в корзине, пока ее
+-- не восстановят или не удалит насовсем очистка по сроку хранения.
+-- Комментарии удаленного поста отдельно не помечаются и возвращаются
+-- вместе с ним.
+ALTER TABLE posts ADD COLUMN deleted_at DATETIME;
+ALTER TABLE posts ADD COLUMN deleted_by INTEGER;
+ALTER TABLE comments ADD COLUMN deleted_by INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments(deleted_at) WHERE deleted_at IS NOT NULL;
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('trash.manage', 'Просмотр корзины и восстановление постов и комментариев');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'trash.manage'),
+    ('admin', 'trash.manage');
@@ -0,0 +1,10 @@
+DELETE FROM role_permissions WHERE permission = 'chat.room.manage';
+DELETE FROM permissions WHERE name = 'chat.room.manage';
+
+DROP INDEX IF EXISTS idx_chat_messages_room;
+DELETE FROM chat_messages WHERE room_id != 1;
+ALTER TABLE chat_messages DROP COLUMN room_id;
+
+DROP INDEX IF EXISTS idx_chat_room_members_user;
+DROP TABLE IF EXISTS chat_room_members;
+DROP TABLE IF EXISTS chat_rooms;
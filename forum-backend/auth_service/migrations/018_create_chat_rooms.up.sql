@@ -0,0 +1,36 @@
+-- Комнаты чата. public открыта всем, private — только участникам, которых
+-- пригласил владелец, post — обсуждение поста, доступное всем, кто может
+-- читать пост. Сообщения, написанные до появления комнат, остаются в
+-- общей комнате.
+CREATE TABLE IF NOT EXISTS chat_rooms (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL DEFAULT 'public',
+    name VARCHAR(100) NOT NULL,
+    post_id INTEGER UNIQUE REFERENCES posts(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Участники закрытых комнат. role — owner или member.
+CREATE TABLE IF NOT EXISTS chat_room_members (
+    room_id INTEGER NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'member',
+    joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_room_members_user ON chat_room_members(user_id);
+
+INSERT OR IGNORE INTO chat_rooms (id, kind, name) VALUES (1, 'public', 'Общий чат');
+
+ALTER TABLE chat_messages ADD COLUMN room_id INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_room ON chat_messages(room_id, timestamp);
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('chat.room.manage', 'Создание открытых комнат чата и управление участниками любых комнат');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'chat.room.manage'),
+    ('admin', 'chat.room.manage');
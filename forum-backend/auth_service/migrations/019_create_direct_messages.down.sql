@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_user_blocks_blocked;
+DROP TABLE IF EXISTS user_blocks;
+
+DROP INDEX IF EXISTS idx_dm_messages_conversation;
+DROP TABLE IF EXISTS dm_messages;
+
+DROP INDEX IF EXISTS idx_dm_participants_user;
+DROP TABLE IF EXISTS dm_participants;
+DROP TABLE IF EXISTS dm_conversations;
@@ -0,0 +1,45 @@
+-- Личные сообщения. Беседа на двоих одна на пару пользователей: direct_key
+-- хранит их ID через двоеточие по возрастанию; у групповой беседы он NULL.
+-- last_message_id упорядочивает входящие и служит их курсором.
+CREATE TABLE IF NOT EXISTS dm_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    direct_key TEXT UNIQUE,
+    title VARCHAR(100) NOT NULL DEFAULT '',
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_message_id INTEGER NOT NULL DEFAULT 0
+);
+
+-- Участники беседы. last_read_message_id — последнее прочитанное
+-- участником сообщение; все сообщения новее него непрочитаны.
+CREATE TABLE IF NOT EXISTS dm_participants (
+    conversation_id INTEGER NOT NULL REFERENCES dm_conversations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_read_message_id INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dm_participants_user ON dm_participants(user_id);
+
+-- Сообщения бесед. В отличие от чата они не удаляются по времени.
+CREATE TABLE IF NOT EXISTS dm_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL REFERENCES dm_conversations(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id),
+    content TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_dm_messages_conversation ON dm_messages(conversation_id, id);
+
+-- Черный список: blocker_id не получает личных сообщений от blocked_id и не
+-- пишет ему сам.
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id);
@@ -0,0 +1,8 @@
+-- Старые сообщения чата больше не удаляются триггером при каждой вставке:
+-- их по сроку хранения удаляет фоновая очистка forum_service, при
+-- необходимости сначала выгружая в архив.
+DROP TRIGGER IF EXISTS cleanup_old_messages;
+
+-- Срок хранения сообщений комнаты в секундах. NULL — срок по умолчанию из
+-- настроек сервиса, 0 — хранить бессрочно.
+ALTER TABLE chat_rooms ADD COLUMN retention_seconds INTEGER;
@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission = 'chat.message.delete.any';
+DELETE FROM permissions WHERE name = 'chat.message.delete.any';
+
+ALTER TABLE chat_messages DROP COLUMN edited_at;
@@ -0,0 +1,10 @@
+-- Автор может исправить или удалить свое сообщение чата в течение
+-- настраиваемого окна после отправки; модераторы удаляют любые сообщения.
+ALTER TABLE chat_messages ADD COLUMN edited_at DATETIME;
+
+INSERT OR IGNORE INTO permissions (name, description) VALUES
+    ('chat.message.delete.any', 'Удаление любых сообщений чата');
+
+INSERT OR IGNORE INTO role_permissions (role, permission) VALUES
+    ('moderator', 'chat.message.delete.any'),
+    ('admin', 'chat.message.delete.any');
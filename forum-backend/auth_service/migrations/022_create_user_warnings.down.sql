@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_warnings_user;
+DROP TABLE IF EXISTS user_warnings;
@@ -0,0 +1,15 @@
+-- Предупреждения, вынесенные автору при решении дела о жалобах. На дело —
+-- одно предупреждение: повторное решение дела после сбоя его не дублирует.
+CREATE TABLE IF NOT EXISTS user_warnings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    case_id INTEGER NOT NULL UNIQUE,
+    reason TEXT NOT NULL,
+    issued_by INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (case_id) REFERENCES moderation_cases(id) ON DELETE CASCADE,
+    FOREIGN KEY (issued_by) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_warnings_user ON user_warnings(user_id, created_at);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS moderation_cases_purge_dependents;
+DROP TRIGGER IF EXISTS chat_rooms_purge_dependents;
+DROP TRIGGER IF EXISTS comments_purge_dependents;
+DROP TRIGGER IF EXISTS posts_purge_dependents;
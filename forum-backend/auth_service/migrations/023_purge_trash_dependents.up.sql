@@ -0,0 +1,40 @@
+-- Внешние ключи в SQLite по умолчанию не проверяются, и ON DELETE CASCADE
+-- не срабатывает. Когда очистка корзины удаляет пост или комментарий
+-- насовсем, зависимые строки удаляют триггеры. Решенные дела модерации
+-- остаются: они хранят текст цели и нужны для истории автора, а
+-- нерешенные удаляются вместе с жалобами.
+CREATE TRIGGER IF NOT EXISTS posts_purge_dependents
+    AFTER DELETE ON posts
+BEGIN
+    DELETE FROM comments WHERE post_id = OLD.id;
+    DELETE FROM post_revisions WHERE post_id = OLD.id;
+    DELETE FROM post_tags WHERE post_id = OLD.id;
+    DELETE FROM chat_rooms WHERE post_id = OLD.id;
+    DELETE FROM moderation_cases
+    WHERE target_type = 'post' AND target_id = OLD.id AND status != 'resolved';
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_purge_dependents
+    AFTER DELETE ON comments
+BEGIN
+    DELETE FROM comment_revisions WHERE comment_id = OLD.id;
+    DELETE FROM moderation_cases
+    WHERE target_type = 'comment' AND target_id = OLD.id AND status != 'resolved';
+END;
+
+CREATE TRIGGER IF NOT EXISTS chat_rooms_purge_dependents
+    AFTER DELETE ON chat_rooms
+BEGIN
+    DELETE FROM moderation_cases
+    WHERE target_type = 'chat_message' AND status != 'resolved'
+      AND target_id IN (SELECT id FROM chat_messages WHERE room_id = OLD.id);
+    DELETE FROM chat_messages WHERE room_id = OLD.id;
+    DELETE FROM chat_room_members WHERE room_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS moderation_cases_purge_dependents
+    AFTER DELETE ON moderation_cases
+BEGIN
+    DELETE FROM reports WHERE case_id = OLD.id;
+    DELETE FROM user_warnings WHERE case_id = OLD.id;
+END;
@@ -0,0 +1 @@
+ALTER TABLE moderation_cases DROP COLUMN ban_issued_at;
@@ -0,0 +1,3 @@
+-- Когда по делу вынесен бан автору. Отметка ставится до обращения к
+-- auth_service: повторное решение дела после сбоя бан не дублирует.
+ALTER TABLE moderation_cases ADD COLUMN ban_issued_at DATETIME;
@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS chat_messages_purge_dependents;
@@ -0,0 +1,10 @@
+-- Сообщения чата удаляются насовсем. Нерешенные дела модерации о сообщении
+-- удаляются вместе с жалобами в той же транзакции. Дело, которое модератор
+-- уже взял в работу, остается: решение по нему само удаляет сообщение, а
+-- текст сообщения хранится в деле.
+CREATE TRIGGER IF NOT EXISTS chat_messages_purge_dependents
+    AFTER DELETE ON chat_messages
+BEGIN
+    DELETE FROM moderation_cases
+    WHERE target_type = 'chat_message' AND target_id = OLD.id AND status = 'open';
+END;
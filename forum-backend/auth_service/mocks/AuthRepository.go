@@ -7,6 +7,8 @@ import (
 
 	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // AuthRepository is an autogenerated mock type for the AuthRepository type
@@ -14,6 +16,90 @@ type AuthRepository struct {
 	mock.Mock
 }
 
+// GetRefreshToken provides a mock function with given fields: tokenHash
+func (_m *AuthRepository) GetRefreshToken(tokenHash string) (entity.RefreshToken, error) {
+	ret := _m.Called(tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefreshToken")
+	}
+
+	var r0 entity.RefreshToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (entity.RefreshToken, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) entity.RefreshToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.RefreshToken)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTokenFamily provides a mock function with given fields: token
+func (_m *AuthRepository) GetTokenFamily(token string) (string, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenFamily")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: userID
+func (_m *AuthRepository) GetUserByID(userID int) (entity.User, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (entity.User, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(int) entity.User); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(entity.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: username
 func (_m *AuthRepository) GetUserByUsername(username string) (entity.User, error) {
 	ret := _m.Called(username)
@@ -70,6 +156,64 @@ func (_m *AuthRepository) GetUsernameByID(ctx context.Context, userID int) (stri
 	return r0, r1
 }
 
+// GetUsersByIDs provides a mock function with given fields: userIDs
+func (_m *AuthRepository) GetUsersByIDs(userIDs []int) ([]entity.User, error) {
+	ret := _m.Called(userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIDs")
+	}
+
+	var r0 []entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]int) ([]entity.User, error)); ok {
+		return rf(userIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]int) []entity.User); ok {
+		r0 = rf(userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]int) error); ok {
+		r1 = rf(userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsTokenRevoked provides a mock function with given fields: token
+func (_m *AuthRepository) IsTokenRevoked(token string) (bool, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTokenRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: user
 func (_m *AuthRepository) Register(user entity.User) error {
 	ret := _m.Called(user)
@@ -88,17 +232,99 @@ func (_m *AuthRepository) Register(user entity.User) error {
 	return r0
 }
 
-// SaveToken provides a mock function with given fields: userID, token
-func (_m *AuthRepository) SaveToken(userID int, token string) error {
-	ret := _m.Called(userID, token)
+// RevokeRefreshToken provides a mock function with given fields: id
+func (_m *AuthRepository) RevokeRefreshToken(id int) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshToken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeTokenFamily provides a mock function with given fields: familyID
+func (_m *AuthRepository) RevokeTokenFamily(familyID string) error {
+	ret := _m.Called(familyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeTokenFamily")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(familyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokens provides a mock function with given fields: userID
+func (_m *AuthRepository) RevokeUserTokens(userID int) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeUserTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRefreshToken provides a mock function with given fields: token
+func (_m *AuthRepository) SaveRefreshToken(token entity.RefreshToken) error {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRefreshToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(entity.RefreshToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveToken provides a mock function with given fields: userID, token, familyID, expiresAt
+func (_m *AuthRepository) SaveToken(userID int, token string, familyID string, expiresAt time.Time) error {
+	ret := _m.Called(userID, token, familyID, expiresAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveToken")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(userID, token)
+	if rf, ok := ret.Get(0).(func(int, string, string, time.Time) error); ok {
+		r0 = rf(userID, token, familyID, expiresAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,13 +2,45 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	common "github.com/miqxzz/commonmiqx"
+	entity "github.com/miqxzz/miqxzzforum/auth_service/internal/entity"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // AuthUsecase is an autogenerated mock type for the AuthUsecase type
 type AuthUsecase struct {
 	mock.Mock
 }
 
+// BootstrapAdmin provides a mock function with given fields: username, password
+func (_m *AuthUsecase) BootstrapAdmin(username string, password string) (bool, error) {
+	ret := _m.Called(username, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BootstrapAdmin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(username, password)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(username, password)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(username, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserRole provides a mock function with given fields: username
 func (_m *AuthUsecase) GetUserRole(username string) (string, error) {
 	ret := _m.Called(username)
@@ -38,22 +70,22 @@ func (_m *AuthUsecase) GetUserRole(username string) (string, error) {
 }
 
 // Login provides a mock function with given fields: username, password
-func (_m *AuthUsecase) Login(username string, password string) (string, error) {
+func (_m *AuthUsecase) Login(username string, password string) (entity.TokenPair, error) {
 	ret := _m.Called(username, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
 	}
 
-	var r0 string
+	var r0 entity.TokenPair
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (entity.TokenPair, error)); ok {
 		return rf(username, password)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(string, string) entity.TokenPair); ok {
 		r0 = rf(username, password)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(entity.TokenPair)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
@@ -65,9 +97,73 @@ func (_m *AuthUsecase) Login(username string, password string) (string, error) {
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: username, password, role
-func (_m *AuthUsecase) Register(username string, password string, role string) error {
-	ret := _m.Called(username, password, role)
+// Logout provides a mock function with given fields: accessToken
+func (_m *AuthUsecase) Logout(accessToken string) error {
+	ret := _m.Called(accessToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(accessToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields: userID
+func (_m *AuthUsecase) LogoutAll(userID int) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LogoutAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: refreshToken
+func (_m *AuthUsecase) Refresh(refreshToken string) (entity.TokenPair, error) {
+	ret := _m.Called(refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 entity.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (entity.TokenPair, error)); ok {
+		return rf(refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) entity.TokenPair); ok {
+		r0 = rf(refreshToken)
+	} else {
+		r0 = ret.Get(0).(entity.TokenPair)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: username, password, inviteCode
+func (_m *AuthUsecase) Register(username string, password string, inviteCode string) error {
+	ret := _m.Called(username, password, inviteCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Register")
@@ -75,7 +171,7 @@ func (_m *AuthUsecase) Register(username string, password string, role string) e
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(username, password, role)
+		r0 = rf(username, password, inviteCode)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -84,21 +180,61 @@ func (_m *AuthUsecase) Register(username string, password string, role string) e
 }
 
 // UpdateUserRole provides a mock function with given fields: userID, newRole
-func (_m *AuthUsecase) UpdateUserRole(userID int, newRole string) error {
+func (_m *AuthUsecase) UpdateUserRole(userID int, newRole string) (string, error) {
 	ret := _m.Called(userID, newRole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUserRole")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (string, error)); ok {
+		return rf(userID, newRole)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) string); ok {
 		r0 = rf(userID, newRole)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(userID, newRole)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateAccessToken provides a mock function with given fields: accessToken
+func (_m *AuthUsecase) ValidateAccessToken(accessToken string) (*common.Claims, error) {
+	ret := _m.Called(accessToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateAccessToken")
+	}
+
+	var r0 *common.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*common.Claims, error)); ok {
+		return rf(accessToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) *common.Claims); ok {
+		r0 = rf(accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*common.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(accessToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewAuthUsecase creates a new instance of AuthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
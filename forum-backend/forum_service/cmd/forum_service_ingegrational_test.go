@@ -51,14 +51,63 @@ func setupTestDB(t *testing.T) *sqlx.DB {
 			content TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			edited_by INTEGER,
+			edit_reason TEXT,
+			category_id INTEGER NOT NULL DEFAULT 1,
+			upvotes INTEGER NOT NULL DEFAULT 0,
+			downvotes INTEGER NOT NULL DEFAULT 0,
+			hidden_at DATETIME,
 			FOREIGN KEY (author_id) REFERENCES users(id)
 		);
+		CREATE TABLE IF NOT EXISTS categories (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			parent_id INTEGER REFERENCES categories(id),
+			slug TEXT NOT NULL UNIQUE,
+			name TEXT NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			read_roles TEXT NOT NULL DEFAULT '',
+			post_roles TEXT NOT NULL DEFAULT '',
+			comment_roles TEXT NOT NULL DEFAULT '',
+			archived_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO categories (id, slug, name) VALUES (1, 'general', 'Общее');
+		CREATE TABLE IF NOT EXISTS tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		CREATE TABLE IF NOT EXISTS tag_aliases (
+			alias TEXT PRIMARY KEY,
+			tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE
+		);
+		CREATE TABLE IF NOT EXISTS post_tags (
+			post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+			tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+			PRIMARY KEY (post_id, tag_id)
+		);
+		CREATE TABLE IF NOT EXISTS reaction_counts (
+			target_type TEXT NOT NULL,
+			target_id INTEGER NOT NULL,
+			kind TEXT NOT NULL,
+			count INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (target_type, target_id, kind)
+		);
 		CREATE TABLE IF NOT EXISTS comments (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			author_id INTEGER,
 			post_id INTEGER,
+			parent_id INTEGER REFERENCES comments(id),
 			content TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			deleted_at DATETIME,
+			updated_at DATETIME,
+			edited_by INTEGER,
+			upvotes INTEGER NOT NULL DEFAULT 0,
+			downvotes INTEGER NOT NULL DEFAULT 0,
+			hidden_at DATETIME,
 			FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
 			FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE
 		);
@@ -68,12 +117,54 @@ func setupTestDB(t *testing.T) *sqlx.DB {
 			username TEXT NOT NULL,
 			content TEXT NOT NULL,
 			timestamp DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL,
+			hidden_at DATETIME,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		);
+		CREATE TABLE IF NOT EXISTS role_permissions (
+			role TEXT NOT NULL,
+			permission TEXT NOT NULL,
+			PRIMARY KEY (role, permission)
+		);
+		CREATE TABLE IF NOT EXISTS user_trust (
+			user_id INTEGER PRIMARY KEY,
+			reputation INTEGER NOT NULL DEFAULT 0,
+			trust_level TEXT NOT NULL DEFAULT 'new',
+			override TEXT,
+			override_by INTEGER,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+		CREATE TABLE IF NOT EXISTS user_sanctions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			type VARCHAR(16) NOT NULL,
+			reason TEXT NOT NULL,
+			issued_by INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			expires_at DATETIME,
+			revoked_at DATETIME,
+			revoked_by INTEGER
+		);
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor_id INTEGER NOT NULL,
+			actor_role VARCHAR(50) NOT NULL,
+			action VARCHAR(64) NOT NULL,
+			target_type VARCHAR(32) NOT NULL,
+			target_id VARCHAR(255) NOT NULL,
+			before TEXT,
+			after TEXT,
+			ip VARCHAR(64) NOT NULL DEFAULT '',
+			user_agent TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
 		CREATE TABLE IF NOT EXISTS tokens (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			user_id INTEGER NOT NULL,
 			token TEXT NOT NULL,
+			family_id TEXT,
+			expires_at DATETIME,
+			revoked_at DATETIME,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		);
 	`)
@@ -93,17 +184,33 @@ func TestForumService_Integration(t *testing.T) {
 
 	postRepo := repository.NewPostRepository(db, logger)
 	commentRepo := repository.NewCommentsRepository(db, logger)
+	categoryRepo := repository.NewCategoryRepository(db, logger)
+	tagRepo := repository.NewTagRepository(db, logger)
+	tokenRepo := repository.NewTokenRepository(db, logger)
 	chatRepo := repository.NewChatRepository(db, logger)
-	postUsecase := usecase.NewPostUsecase(postRepo, logger)
+	trustRepo := repository.NewTrustRepository(db, logger)
+	postUsecase := usecase.NewPostUsecase(postRepo, tagRepo, logger)
 	commentUsecase := usecase.NewCommentsUsecases(commentRepo, logger)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, logger)
+	trustUsecase := usecase.NewTrustUsecase(trustRepo, usecase.TrustPolicy{
+		Thresholds:  map[string]int{entity.TrustBasic: 20},
+		LinksLevel:  entity.TrustBasic,
+		ChatLevel:   entity.TrustBasic,
+		PostsPerDay: map[string]int{entity.TrustNew: 3},
+	}, logger)
 	hub := chat.NewHub()
-	chatUsecase := usecase.NewChatUsecase(chatRepo, logger)
+	sanctionRepo := repository.NewSanctionRepository(db, logger)
+	chatUsecase := usecase.NewChatUsecase(chatRepo, sanctionRepo, 15*time.Minute, logger)
+	chatRoomUsecase := usecase.NewChatRoomUsecase(repository.NewChatRoomRepository(db, logger), postRepo, categoryUsecase, logger)
 	jwtUtil := commonmiqx.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := http2.NewPostHandler(postUsecase, postRepo, jwtUtil, logger, mockUserClient)
-	commentHandler := http2.NewCommentHandler(commentUsecase, jwtUtil, logger, mockUserClient)
-	chatHandler := http2.NewChatHandler(hub, chatUsecase, jwtUtil, logger)
+	permissionRepo := repository.NewPermissionRepository(db, logger)
+	authMiddleware := http2.NewAuthMiddleware(tokenRepo, permissionRepo, sanctionRepo, jwtUtil, logger)
+	auditor := http2.NewAuditor(repository.NewAuditRepository(db, logger), logger)
+	postHandler := http2.NewPostHandler(postUsecase, categoryUsecase, trustUsecase, authMiddleware, auditor, logger, mockUserClient)
+	commentHandler := http2.NewCommentHandler(commentUsecase, categoryUsecase, trustUsecase, authMiddleware, auditor, logger, mockUserClient)
+	chatHandler := http2.NewChatHandler(hub, chatUsecase, chatRoomUsecase, trustUsecase, authMiddleware, auditor, logger, mockUserClient)
 
 	router := gin.Default()
 	router.Use(cors.New(cors.Config{
@@ -116,17 +223,19 @@ func TestForumService_Integration(t *testing.T) {
 	}))
 
 	router.GET("/ws", chatHandler.ServeWS)
-	router.POST("/posts", postHandler.CreatePost)
-	router.GET("/posts", postHandler.GetPosts)
-	router.DELETE("/posts/:id", postHandler.DeletePost)
-	router.POST("/posts/:id/comments", commentHandler.CreateComment)
-	router.GET("/posts/:id/comments", commentHandler.GetComments)
+	postHandler.Register(router)
+	commentHandler.Register(router)
 
 	token, err := jwtUtil.GenerateToken(1, "user")
 	if err != nil {
 		t.Fatalf("Failed to generate token: %s", err)
 	}
 
+	_, err = db.Exec("INSERT INTO users (id, username, password, role) VALUES (1, 'alice', 'hash', 'user')")
+	if err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
 	_, err = db.Exec("INSERT INTO tokens (user_id, token) VALUES (?, ?)", 1, token)
 	if err != nil {
 		t.Fatalf("Failed to save token: %s", err)
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
@@ -11,16 +13,22 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
 	commonmiqx "github.com/miqxzz/commonmiqx"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/adapters"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/config"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/http"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"go.uber.org/zap"
 )
 
 func main() {
+	reindex := flag.Bool("reindex", false, "rebuild the search index and exit")
+	recomputeTrust := flag.Bool("recompute-trust", false, "recompute reputation and trust levels of all users and exit")
+	flag.Parse()
+
 	// Инициализация логгера
 	logger, err := commonmiqx.InitLogger()
 	if err != nil {
@@ -40,30 +48,108 @@ func main() {
 		logger.Fatal("Failed to connect to database", zap.Error(err))
 	}
 	defer db.Close()
+	requireFTS5(db, logger)
 
 	// Инициализация репозиториев
 	postRepo := repository.NewPostRepository(db, logger)
 	commentRepo := repository.NewCommentsRepository(db, logger)
+	tokenRepo := repository.NewTokenRepository(db, logger)
+	searchRepo := repository.NewSearchRepository(db, logger)
+	categoryRepo := repository.NewCategoryRepository(db, logger)
+	tagRepo := repository.NewTagRepository(db, logger)
+	reactionRepo := repository.NewReactionRepository(db, logger)
+	trustRepo := repository.NewTrustRepository(db, logger)
+	moderationRepo := repository.NewModerationRepository(db, logger)
+	authSanctionClient := adapters.NewAuthSanctionClient(cfg.AuthServiceURL, logger)
+	chatRepo := repository.NewChatRepository(db, logger)
+	chatRoomRepo := repository.NewChatRoomRepository(db, logger)
+	dmRepo := repository.NewDirectMessageRepository(db, logger)
+	userBlockRepo := repository.NewUserBlockRepository(db, logger)
+	trashRepo := repository.NewTrashRepository(db, logger)
 
 	jwtUtil := commonmiqx.NewJWTUtil("your-secret-key")
 	// Инициализация use cases
-	postUsecase := usecase.NewPostUsecase(postRepo, logger)
+	postUsecase := usecase.NewPostUsecase(postRepo, tagRepo, logger)
 	commentUsecase := usecase.NewCommentsUsecases(commentRepo, logger)
+	searchUsecase := usecase.NewSearchUsecase(searchRepo, logger)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, logger)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, logger)
+	reactionUsecase := usecase.NewReactionUsecase(reactionRepo, postRepo, commentRepo, cfg.ReactionEmojis, logger)
+	trustUsecase := usecase.NewTrustUsecase(trustRepo, usecase.TrustPolicy{
+		Thresholds: map[string]int{
+			entity.TrustBasic:   cfg.TrustBasicReputation,
+			entity.TrustMember:  cfg.TrustMemberReputation,
+			entity.TrustRegular: cfg.TrustRegularReputation,
+		},
+		LinksLevel: cfg.TrustLinksLevel,
+		ChatLevel:  cfg.TrustChatLevel,
+		PostsPerDay: map[string]int{
+			entity.TrustNew:     cfg.TrustPostsPerDayNew,
+			entity.TrustBasic:   cfg.TrustPostsPerDayBasic,
+			entity.TrustMember:  cfg.TrustPostsPerDayMember,
+			entity.TrustRegular: cfg.TrustPostsPerDayRegular,
+		},
+	}, logger)
+	moderationUsecase := usecase.NewModerationUsecase(moderationRepo, postRepo, commentUsecase, chatRepo, authSanctionClient, cfg.ReportHideThreshold, logger)
+	trashUsecase := usecase.NewTrashUsecase(trashRepo, cfg.TrashRetention, logger)
+
+	// Перестроение поискового индекса: forum_service -reindex
+	if *reindex {
+		if err := searchUsecase.Reindex(context.Background()); err != nil {
+			logger.Fatal("Failed to rebuild search index", zap.Error(err))
+		}
+		return
+	}
+
+	// Пересчет репутации и уровней доверия с нуля: forum_service -recompute-trust
+	if *recomputeTrust {
+		if _, err := trustUsecase.Recompute(context.Background()); err != nil {
+			logger.Fatal("Failed to recompute trust levels", zap.Error(err))
+		}
+		return
+	}
 
 	// --- ЧАТ ---
-	chatRepo := repository.NewChatRepository(db, logger)
-	chatUsecase := usecase.NewChatUsecase(chatRepo, logger)
+	sanctionRepo := repository.NewSanctionRepository(db, logger)
+	chatUsecase := usecase.NewChatUsecase(chatRepo, sanctionRepo, cfg.ChatEditWindow, logger)
+	chatRoomUsecase := usecase.NewChatRoomUsecase(chatRoomRepo, postRepo, categoryUsecase, logger)
+	var chatArchiveRepo repository.ChatArchiveRepository
+	if cfg.ChatArchiveDir != "" {
+		chatArchiveRepo = repository.NewChatArchiveRepository(cfg.ChatArchiveDir, logger)
+	}
+	chatRetentionUsecase := usecase.NewChatRetentionUsecase(chatRepo, chatArchiveRepo, cfg.ChatRetention, logger)
 	chatHub := chat.NewHub()
 	go chatHub.Run()
-	chatHandler := http.NewChatHandler(chatHub, chatUsecase, jwtUtil, logger)
 
 	// Инициализация gRPC клиента для пользователей
-	userClient, err := grpc.NewUserClient(cfg.AuthServiceAddr)
+	grpcUserClient, err := grpc.NewUserClient(cfg.AuthServiceAddr)
 	if err != nil {
 		logger.Fatal("Failed to create user client", zap.Error(err))
 	}
+	userClient := grpc.NewCachedUserClient(grpcUserClient, grpc.CacheConfig{
+		Size:        cfg.UserCacheSize,
+		TTL:         cfg.UserCacheTTL,
+		NegativeTTL: cfg.UserCacheNegativeTTL,
+	}, logger)
 	defer userClient.Close()
 
+	// Изменения имен и ролей приходят из auth_service и сбрасывают кэш
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go userClient.Watch(watchCtx, grpcUserClient)
+
+	dmUsecase := usecase.NewDirectMessageUsecase(dmRepo, userBlockRepo, sanctionRepo, userClient, logger)
+
+	// Очистка корзины от того, что пролежало в ней дольше срока хранения
+	go trashUsecase.RunPurger(watchCtx, cfg.TrashPurgeInterval)
+	// Удаление сообщений чата с истекшим сроком хранения
+	go chatRetentionUsecase.RunJanitor(watchCtx, cfg.ChatJanitorInterval)
+
+	permissionRepo := repository.NewPermissionRepository(db, logger)
+	authMiddleware := http.NewAuthMiddleware(tokenRepo, permissionRepo, sanctionRepo, jwtUtil, logger)
+	auditor := http.NewAuditor(repository.NewAuditRepository(db, logger), logger)
+	chatHandler := http.NewChatHandler(chatHub, chatUsecase, chatRoomUsecase, trustUsecase, authMiddleware, auditor, logger, userClient)
+
 	// Инициализация HTTP сервера
 	router := gin.Default()
 	router.Use(cors.New(cors.Config{
@@ -73,9 +159,22 @@ func main() {
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 	}))
-	http.NewPostHandler(postUsecase, postRepo, jwtUtil, logger, userClient).Register(router)
-	http.NewCommentHandler(commentUsecase, jwtUtil, logger, userClient).Register(router)
+	http.NewPostHandler(postUsecase, categoryUsecase, trustUsecase, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewCommentHandler(commentUsecase, categoryUsecase, trustUsecase, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewCategoryHandler(categoryUsecase, postUsecase, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewTagHandler(tagUsecase, postUsecase, categoryUsecase, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewReactionHandler(reactionUsecase, categoryUsecase, authMiddleware, logger, userClient).Register(router)
+	http.NewTrustHandler(trustUsecase, authMiddleware, auditor, logger).Register(router)
+	http.NewModerationHandler(moderationUsecase, categoryUsecase, authMiddleware, auditor, chatHub, logger, userClient).Register(router)
+	http.NewTrashHandler(trashUsecase, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewChatRoomHandler(chatRoomUsecase, chatHub, authMiddleware, auditor, logger, userClient).Register(router)
+	http.NewDirectMessageHandler(dmUsecase, chatHub, authMiddleware, logger, userClient).Register(router)
+	http.NewSearchHandler(searchUsecase, categoryUsecase, authMiddleware, logger, userClient).Register(router)
+	http.NewMetricsHandler(userClient).Register(router)
 	router.GET("/ws", chatHandler.ServeWS)
+	router.GET("/chat/messages", authMiddleware.OptionalAuth(), chatHandler.GetMessages)
+	router.PUT("/chat/messages/:id", authMiddleware.RequireAuth(), chatHandler.EditMessage)
+	router.DELETE("/chat/messages/:id", authMiddleware.RequireAuth(), chatHandler.DeleteMessage)
 
 	// Запуск HTTP сервера
 	go func() {
@@ -91,3 +190,14 @@ func main() {
 
 	logger.Info("Shutting down server...")
 }
+
+// requireFTS5 останавливает сервис, если SQLite собран без FTS5: без него
+// не работают поиск и вставка постов и комментариев, которые индексируются
+// триггерами. Сервис собирается с тегом sqlite_fts5 (make build или go
+// build -tags sqlite_fts5).
+func requireFTS5(db *sqlx.DB, logger *zap.Logger) {
+	var enabled bool
+	if err := db.Get(&enabled, `SELECT sqlite_compileoption_used('ENABLE_FTS5')`); err != nil || !enabled {
+		logger.Fatal("SQLite is built without FTS5, build the service with -tags sqlite_fts5", zap.Error(err))
+	}
+}
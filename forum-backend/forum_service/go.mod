@@ -17,6 +17,7 @@ require (
 	github.com/stretchr/testify v1.10.0
 	github.com/swaggo/swag v1.16.4
 	go.uber.org/zap v1.27.0
+	golang.org/x/sync v0.13.0
 	google.golang.org/grpc v1.64.1
 	google.golang.org/protobuf v1.36.6
 )
@@ -0,0 +1,84 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type AuthSanctionClient interface {
+	// IssueSanction накладывает санкцию на пользователя userID через
+	// auth_service от имени владельца token. auth_service сам проверяет
+	// права, завершает сессии заблокированного и пишет журнал аудита. Отказ
+	// auth_service возвращается как *SanctionRejectedError.
+	IssueSanction(ctx context.Context, token string, userID int, req entity.CreateSanctionRequest) error
+}
+
+// SanctionRejectedError — auth_service отказался наложить санкцию: Status —
+// код ответа, Message — текст ошибки из него.
+type SanctionRejectedError struct {
+	Status  int
+	Message string
+}
+
+func (e *SanctionRejectedError) Error() string {
+	return fmt.Sprintf("auth service rejected the sanction with status %d: %s", e.Status, e.Message)
+}
+
+type authSanctionClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewAuthSanctionClient создает клиент санкций HTTP API auth_service
+// по адресу baseURL, например http://localhost:8080.
+func NewAuthSanctionClient(baseURL string, logger *zap.Logger) AuthSanctionClient {
+	return &authSanctionClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		logger:  logger,
+	}
+}
+
+func (c *authSanctionClient) IssueSanction(ctx context.Context, token string, userID int, req entity.CreateSanctionRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/admin/users/%d/sanctions", c.baseURL, userID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		c.logger.Error("Failed to call auth service", zap.Error(err), zap.String("url", url))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusCreated {
+		c.logger.Info("Sanction issued via auth service", zap.Int("userID", userID), zap.String("type", req.Type))
+		return nil
+	}
+	var errResp entity.ErrorResponse
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&errResp)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.logger.Error("Auth service failed to issue sanction", zap.Int("status", resp.StatusCode), zap.String("error", errResp.Error), zap.Int("userID", userID))
+		return fmt.Errorf("auth service responded with status %d: %s", resp.StatusCode, errResp.Error)
+	}
+	c.logger.Warn("Auth service rejected sanction", zap.Int("status", resp.StatusCode), zap.String("error", errResp.Error), zap.Int("userID", userID))
+	return &SanctionRejectedError{Status: resp.StatusCode, Message: errResp.Error}
+}
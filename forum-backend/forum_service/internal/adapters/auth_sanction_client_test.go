@@ -0,0 +1,52 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAuthSanctionClient_IssueSanction(t *testing.T) {
+	status := http.StatusCreated
+	var got entity.CreateSanctionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/admin/users/7/sanctions", r.URL.Path)
+		assert.Equal(t, "Bearer moderator-token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(status)
+		switch status {
+		case http.StatusCreated:
+			w.Write([]byte(`{"id":1,"user_id":7,"type":"ban"}`))
+		default:
+			w.Write([]byte(`{"error":"недостаточно прав для этой санкции"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAuthSanctionClient(server.URL+"/", zap.NewNop())
+	req := entity.CreateSanctionRequest{Type: entity.SanctionBan, Reason: "спам", DurationHours: 24}
+
+	require.NoError(t, client.IssueSanction(context.Background(), "moderator-token", 7, req))
+	assert.Equal(t, req, got)
+
+	status = http.StatusForbidden
+	err := client.IssueSanction(context.Background(), "moderator-token", 7, req)
+	var rejected *SanctionRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, http.StatusForbidden, rejected.Status)
+	assert.Equal(t, "недостаточно прав для этой санкции", rejected.Message)
+
+	// Сбой auth_service — не отказ
+	status = http.StatusInternalServerError
+	err = client.IssueSanction(context.Background(), "moderator-token", 7, req)
+	assert.Error(t, err)
+	assert.NotErrorAs(t, err, &rejected)
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +16,46 @@ type Config struct {
 	JWTSecret       string
 	HTTPAddr        string
 	AuthServiceAddr string
+	// AuthServiceURL — адрес HTTP API auth_service, через который модератор
+	// блокирует автора при решении дела
+	AuthServiceURL string
+	// Кэш пользователей, получаемых из auth_service
+	UserCacheSize        int
+	UserCacheTTL         time.Duration
+	UserCacheNegativeTTL time.Duration
+	// ReactionEmojis — эмодзи, которые можно ставить на посты и комментарии
+	// кроме голосов up и down
+	ReactionEmojis []string
+	// Уровни доверия: репутация, с которой начинается уровень, уровни, с
+	// которых можно публиковать ссылки и писать в чат, и лимиты постов в
+	// сутки по уровням (0 — без лимита)
+	TrustBasicReputation    int
+	TrustMemberReputation   int
+	TrustRegularReputation  int
+	TrustLinksLevel         string
+	TrustChatLevel          string
+	TrustPostsPerDayNew     int
+	TrustPostsPerDayBasic   int
+	TrustPostsPerDayMember  int
+	TrustPostsPerDayRegular int
+	// ReportHideThreshold — после скольких жалоб цель скрывается до решения
+	// модератора (0 — не скрывать)
+	ReportHideThreshold int
+	// Удаленные посты и комментарии лежат в корзине TrashRetention, затем
+	// удаляются насовсем (0 — хранить бессрочно). Корзина проверяется раз в
+	// TrashPurgeInterval.
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+	// Сообщения чата хранятся ChatRetention, если у комнаты не задан свой
+	// срок (0 — бессрочно), и проверяются раз в ChatJanitorInterval. Если
+	// задан ChatArchiveDir, перед удалением они выгружаются туда в сжатые
+	// файлы NDJSON.
+	ChatRetention       time.Duration
+	ChatJanitorInterval time.Duration
+	ChatArchiveDir      string
+	// ChatEditWindow — сколько после отправки автор может исправить или
+	// удалить сообщение чата (0 — без ограничения)
+	ChatEditWindow time.Duration
 }
 
 func LoadConfig() (Config, error) {
@@ -29,6 +72,33 @@ func LoadConfig() (Config, error) {
 		JWTSecret:       getEnv("JWT_SECRET", "your-secret-key"),
 		HTTPAddr:        getEnv("HTTP_ADDR", ":8081"),
 		AuthServiceAddr: getEnv("AUTH_SERVICE_ADDR", "localhost:50052"),
+		AuthServiceURL:  getEnv("AUTH_SERVICE_URL", "http://localhost:8080"),
+
+		UserCacheSize:        getInt("USER_CACHE_SIZE", 10000),
+		UserCacheTTL:         getDuration("USER_CACHE_TTL", 10*time.Minute),
+		UserCacheNegativeTTL: getDuration("USER_CACHE_NEGATIVE_TTL", time.Minute),
+
+		ReactionEmojis: getList("REACTION_EMOJIS", []string{"👍", "❤️", "😂", "😮", "😢", "🎉"}),
+
+		TrustBasicReputation:    getInt("TRUST_BASIC_REPUTATION", 20),
+		TrustMemberReputation:   getInt("TRUST_MEMBER_REPUTATION", 100),
+		TrustRegularReputation:  getInt("TRUST_REGULAR_REPUTATION", 500),
+		TrustLinksLevel:         getEnv("TRUST_LINKS_LEVEL", "basic"),
+		TrustChatLevel:          getEnv("TRUST_CHAT_LEVEL", "basic"),
+		TrustPostsPerDayNew:     getInt("TRUST_POSTS_PER_DAY_NEW", 3),
+		TrustPostsPerDayBasic:   getInt("TRUST_POSTS_PER_DAY_BASIC", 10),
+		TrustPostsPerDayMember:  getInt("TRUST_POSTS_PER_DAY_MEMBER", 30),
+		TrustPostsPerDayRegular: getInt("TRUST_POSTS_PER_DAY_REGULAR", 0),
+
+		ReportHideThreshold: getInt("REPORT_HIDE_THRESHOLD", 3),
+
+		TrashRetention:     getDuration("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getDuration("TRASH_PURGE_INTERVAL", time.Hour),
+
+		ChatRetention:       getDuration("CHAT_RETENTION", 30*24*time.Hour),
+		ChatJanitorInterval: getDuration("CHAT_JANITOR_INTERVAL", 10*time.Minute),
+		ChatArchiveDir:      getEnv("CHAT_ARCHIVE_DIR", ""),
+		ChatEditWindow:      getDuration("CHAT_EDIT_WINDOW", 15*time.Minute),
 	}
 	return cfg, nil
 }
@@ -40,3 +110,33 @@ func getEnv(key string, defaultValue string) string {
 	}
 	return value
 }
+
+func getInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getList читает список значений через запятую; пустые элементы пропускает.
+func getList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
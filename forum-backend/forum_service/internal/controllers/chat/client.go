@@ -2,7 +2,9 @@ package chat
 
 import (
 	"context"
+	"errors"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -18,7 +20,65 @@ type Client struct {
 	UserID          int
 	Username        string
 	IsAuthenticated bool
-	ChatUC          usecase.ChatUsecase
+	ReadOnly        bool // пользователь вошел, но писать в чат ему нельзя
+	// Principal — пользователь соединения; nil для анонимного подключения.
+	Principal *entity.Principal
+	ChatUC    usecase.ChatUsecase
+	RoomsUC   usecase.ChatRoomUsecase
+
+	// joined — комнаты, в которые вошел клиент. Заполняет ReadPump, а
+	// исключение из комнаты снимает Hub.
+	mu     sync.Mutex
+	joined map[int]bool
+}
+
+// Join входит в комнату без проверки доступа. Используется для общей
+// комнаты при подключении; остальные комнаты проверяются в join.
+func (c *Client) Join(roomID int) {
+	c.JoinSince(roomID, 0)
+}
+
+// JoinSince входит в комнату, как Join, но вместо истории присылает
+// сообщения, пришедшие после since. История читается в горутине клиента,
+// а не в Hub.Run.
+func (c *Client) JoinSince(roomID, since int) {
+	c.setJoined(roomID, true)
+	c.Hub.Join <- Subscription{Client: c, RoomID: roomID, Since: since}
+	c.Hub.Loaded <- c.loadHistory(roomID, since)
+}
+
+// loadHistory читает последние сообщения комнаты или, если since больше 0,
+// пропущенные после since.
+func (c *Client) loadHistory(roomID, since int) History {
+	ctx, cancel := context.WithTimeout(context.Background(), historyTimeout)
+	defer cancel()
+
+	history := History{Client: c, RoomID: roomID, LastID: since}
+	if since > 0 {
+		history.Messages, history.Gap, history.Err = c.ChatUC.GetMissedMessages(ctx, roomID, since, historySize)
+	} else {
+		history.Messages, history.Err = c.ChatUC.GetRecentMessages(ctx, roomID, historySize)
+	}
+	return history
+}
+
+func (c *Client) setJoined(roomID int, joined bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.joined == nil {
+		c.joined = make(map[int]bool)
+	}
+	if joined {
+		c.joined[roomID] = true
+	} else {
+		delete(c.joined, roomID)
+	}
+}
+
+func (c *Client) isJoined(roomID int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.joined[roomID]
 }
 
 func (c *Client) ReadPump() {
@@ -58,47 +118,96 @@ func (c *Client) handleIncomingMessage(rawMessage []byte) error {
 		return nil
 	}
 
-	var msg entity.ChatMessage
-	if err := json.Unmarshal(rawMessage, &msg); err != nil {
-		log.Printf("[CLIENT %d] Failed to unmarshal message, creating default: %v", c.UserID, err)
-		msg = entity.ChatMessage{
-			UserID:    c.UserID,
-			Username:  c.Username,
-			Content:   string(rawMessage),
-			Timestamp: time.Now(),
+	// Поля userID/username от клиента игнорируются: автор берется из токена.
+	var envelope entity.ChatEnvelope
+	if err := json.Unmarshal(rawMessage, &envelope); err != nil {
+		log.Printf("[CLIENT %d] Failed to unmarshal message, using raw content: %v", c.UserID, err)
+		envelope = entity.ChatEnvelope{Content: string(rawMessage)}
+	}
+	if envelope.RoomID == 0 {
+		envelope.RoomID = entity.DefaultChatRoomID
+	}
+
+	switch envelope.Type {
+	case entity.ChatEventJoin:
+		return c.join(envelope.RoomID, envelope.Since)
+	case entity.ChatEventLeave:
+		if c.isJoined(envelope.RoomID) {
+			c.setJoined(envelope.RoomID, false)
+			c.Hub.Leave <- Subscription{Client: c, RoomID: envelope.RoomID}
+		}
+		return nil
+	case "", entity.ChatEventMessage:
+		return c.sendMessage(envelope)
+	default:
+		c.notify(envelope.RoomID, "unknown message type")
+		return nil
+	}
+}
+
+// join проверяет доступ к комнате и входит в нее. Недоступная комната для
+// клиента не существует.
+func (c *Client) join(roomID, since int) error {
+	if c.isJoined(roomID) {
+		return nil
+	}
+	if _, err := c.RoomsUC.GetRoom(context.Background(), roomID, c.Principal); err != nil {
+		if errors.Is(err, usecase.ErrChatRoomNotFound) {
+			c.notify(roomID, err.Error())
+			return nil
 		}
+		c.notify(roomID, "failed to join the room")
+		return err
 	}
+	c.JoinSince(roomID, since)
+	return nil
+}
 
-	if msg.Content == "" {
+func (c *Client) sendMessage(envelope entity.ChatEnvelope) error {
+	if !c.IsAuthenticated || c.ReadOnly {
+		log.Printf("[CLIENT %d] Read-only connection, message dropped", c.UserID)
+		return nil
+	}
+	if envelope.Content == "" {
 		log.Printf("[CLIENT %d] Empty content in message", c.UserID)
 		return nil
 	}
-	log.Println(c.IsAuthenticated)
-	if c.IsAuthenticated {
-		log.Printf("[CLIENT %d] Saving message to DB: %s", c.UserID, msg.Content)
-		if err := c.ChatUC.HandleMessage(context.Background(), msg.UserID, msg.Username, msg.Content); err != nil {
-			log.Printf("[CLIENT %d] DB save error: %v", c.UserID, err)
-		}
+	if !c.isJoined(envelope.RoomID) {
+		c.notify(envelope.RoomID, "join the room before writing to it")
+		return nil
 	}
 
-	outMsg := map[string]interface{}{
-		"userID":    msg.UserID,
-		"username":  msg.Username,
-		"content":   msg.Content,
-		"timestamp": time.Now().Format(time.RFC3339),
+	log.Printf("[CLIENT %d] Saving message to DB: %s", c.UserID, envelope.Content)
+	msg, err := c.ChatUC.HandleMessage(context.Background(), envelope.RoomID, c.UserID, c.Username, envelope.Content)
+	if err != nil {
+		if errors.Is(err, usecase.ErrChatMuted) {
+			// Мут мог быть наложен уже после подключения
+			log.Printf("[CLIENT %d] User is muted, message dropped", c.UserID)
+			c.notify(envelope.RoomID, err.Error())
+			return nil
+		}
+		log.Printf("[CLIENT %d] DB save error: %v", c.UserID, err)
+		c.notify(envelope.RoomID, "failed to send message")
+		return err
 	}
 
-	jsonMsg, err := json.Marshal(outMsg)
+	jsonMsg, err := json.Marshal(entity.NewChatMessageEvent(msg))
 	if err != nil {
 		log.Printf("[CLIENT %d] Marshal error: %v", c.UserID, err)
 		return err
 	}
 
-	log.Printf("[CLIENT %d] Broadcasting message: %s", c.UserID, string(jsonMsg))
-	c.Hub.Broadcast <- jsonMsg
+	log.Printf("[CLIENT %d] Broadcasting message to room %d: %s", c.UserID, envelope.RoomID, string(jsonMsg))
+	c.Hub.Broadcast <- RoomMessage{RoomID: envelope.RoomID, Data: jsonMsg, MessageID: msg.ID}
 	return nil
 }
 
+// notify отправляет служебное сообщение только этому клиенту. Очередь
+// клиента закрывает Hub, поэтому писать в нее может только он.
+func (c *Client) notify(roomID int, text string) {
+	c.Hub.Notify <- Notice{Client: c, RoomID: roomID, Text: text}
+}
+
 func (c *Client) WritePump() {
 	log.Printf("[CLIENT %d] Starting write pump", c.UserID)
 	ticker := time.NewTicker(50 * time.Second)
@@ -0,0 +1,31 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SendMessage_BroadcastsStoredID(t *testing.T) {
+	hub := NewHub()
+	chatUC := new(mocks.ChatUsecase)
+	client := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: 5, Username: "user", IsAuthenticated: true, ChatUC: chatUC}
+	client.setJoined(2, true)
+
+	chatUC.On("HandleMessage", mock.Anything, 2, 5, "user", "привет").
+		Return(entity.ChatMessage{ID: 42, RoomID: 2, UserID: 5, Username: "user", Content: "привет"}, nil)
+
+	require.NoError(t, client.handleIncomingMessage([]byte(`{"type":"message","room_id":2,"content":"привет"}`)))
+
+	broadcast := <-hub.Broadcast
+	assert.Equal(t, 2, broadcast.RoomID)
+	var event entity.ChatEvent
+	require.NoError(t, json.Unmarshal(broadcast.Data, &event))
+	assert.Equal(t, entity.ChatEventMessage, event.Type)
+	assert.Equal(t, 42, event.ID)
+}
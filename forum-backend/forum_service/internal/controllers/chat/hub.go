@@ -1,24 +1,108 @@
 package chat
 
 import (
-	"context"
 	"encoding/json"
 	"log"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 )
 
+// historySize — сколько последних сообщений получает клиент при входе в комнату.
+const historySize = 50
+
+// historyTimeout ограничивает чтение истории при входе в комнату.
+const historyTimeout = 5 * time.Second
+
+// loadingLimit — сколько сообщений комнаты Hub копит для клиента, пока тот
+// читает ее историю. Клиент, который не успел, отключается.
+const loadingLimit = 100
+
+// Subscription — вход клиента в комнату или выход из нее.
+type Subscription struct {
+	Client *Client
+	RoomID int
+	// Since — ID последнего сообщения комнаты, которое клиент видел до
+	// переподключения; 0 — клиент входит впервые и получает историю.
+	Since int
+}
+
+// History — история комнаты, прочитанная клиентом для входа в нее.
+type History struct {
+	Client   *Client
+	RoomID   int
+	Messages []entity.ChatMessage
+	// Gap — пропущенных сообщений больше, чем вошло в Messages.
+	Gap bool
+	// LastID — ID последнего сообщения, которое клиент видел до входа.
+	LastID int
+	Err    error
+}
+
+// RoomMessage — сообщение для всех клиентов, вошедших в комнату.
+type RoomMessage struct {
+	RoomID int
+	Data   []byte
+	// MessageID — ID сообщения чата в Data, 0 для остальных событий. По нему
+	// входящий в комнату клиент не получает сообщение дважды: в истории и
+	// после нее.
+	MessageID int
+}
+
+// Notice — служебное сообщение об ошибке одному клиенту.
+type Notice struct {
+	Client *Client
+	RoomID int
+	Text   string
+}
+
+// Eviction — исключение пользователя из комнаты: его соединения выходят из
+// нее и перестают получать ее сообщения.
+type Eviction struct {
+	RoomID int
+	UserID int
+	Reason string
+}
+
+// Delivery — сообщение для всех соединений пользователей UserIDs, в каких
+// бы комнатах они ни были.
+type Delivery struct {
+	UserIDs []int
+	Data    []byte
+}
+
 type Hub struct {
-	Clients    map[*Client]bool
-	Broadcast  chan []byte
+	Clients map[*Client]bool
+	// Rooms — клиенты, вошедшие в комнату. Меняется только в Run.
+	Rooms map[int]map[*Client]bool
+	// loading — сообщения комнат, накопленные для клиентов, которые еще
+	// читают историю этих комнат. Меняется только в Run.
+	loading    map[*Client]map[int][]RoomMessage
+	Broadcast  chan RoomMessage
 	Register   chan *Client
 	Unregister chan *Client
+	Join       chan Subscription
+	Loaded     chan History
+	Leave      chan Subscription
+	Evict      chan Eviction
+	Direct     chan Delivery
+	Notify     chan Notice
 }
 
 func NewHub() *Hub {
 	return &Hub{
 		Clients:    make(map[*Client]bool),
-		Broadcast:  make(chan []byte, 100), // Буферизованный канал
+		Rooms:      make(map[int]map[*Client]bool),
+		loading:    make(map[*Client]map[int][]RoomMessage),
+		Broadcast:  make(chan RoomMessage, 100), // Буферизованный канал
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
+		Join:       make(chan Subscription),
+		Loaded:     make(chan History),
+		Leave:      make(chan Subscription),
+		Evict:      make(chan Eviction, 16),
+		Direct:     make(chan Delivery, 100),
+		Notify:     make(chan Notice),
 	}
 }
 
@@ -30,53 +114,209 @@ func (h *Hub) Run() {
 			log.Printf("[HUB] Registering new client: UserID=%d, Username=%s", client.UserID, client.Username)
 			h.Clients[client] = true
 
-			messages, err := client.ChatUC.GetRecentMessages(context.Background(), 50)
-			if err != nil {
-				log.Printf("[HUB] Error getting messages: %v", err)
-				continue
+		case client := <-h.Unregister:
+			log.Printf("[HUB] Unregistering client: UserID=%d", client.UserID)
+			h.remove(client)
+
+		case sub := <-h.Join:
+			h.join(sub)
+
+		case history := <-h.Loaded:
+			h.loaded(history)
+
+		case sub := <-h.Leave:
+			if members, ok := h.Rooms[sub.RoomID]; ok && members[sub.Client] {
+				log.Printf("[HUB] Client %d left room %d", sub.Client.UserID, sub.RoomID)
+				h.leave(sub.Client, sub.RoomID)
+				h.send(sub.Client, entity.ChatEvent{Type: entity.ChatEventLeft, RoomID: sub.RoomID})
 			}
-			log.Printf("[HUB] Sending %d historical messages to client %d", len(messages), client.UserID)
 
-			for _, msg := range messages {
-				jsonMsg, err := json.Marshal(msg)
-				if err != nil {
-					log.Printf("[HUB] Error marshaling message: %v", err)
+		case eviction := <-h.Evict:
+			for client := range h.Rooms[eviction.RoomID] {
+				if client.UserID != eviction.UserID {
 					continue
 				}
-				select {
-				case client.Send <- jsonMsg:
-					log.Printf("[HUB] Historical message sent to %d", client.UserID)
-				default:
-					log.Printf("[HUB] Client %d send channel blocked, closing", client.UserID)
-					close(client.Send)
-					delete(h.Clients, client)
-				}
+				log.Printf("[HUB] Client %d evicted from room %d", client.UserID, eviction.RoomID)
+				client.setJoined(eviction.RoomID, false)
+				h.leave(client, eviction.RoomID)
+				h.send(client, entity.ChatEvent{Type: entity.ChatEventLeft, RoomID: eviction.RoomID, Error: eviction.Reason})
 			}
 
-		case client := <-h.Unregister:
-			log.Printf("[HUB] Unregistering client: UserID=%d", client.UserID)
-			if _, ok := h.Clients[client]; ok {
-				delete(h.Clients, client)
-				close(client.Send)
+		case delivery := <-h.Direct:
+			h.deliver(delivery)
+
+		case notice := <-h.Notify:
+			if h.Clients[notice.Client] {
+				h.send(notice.Client, entity.ChatEvent{Type: entity.ChatEventError, RoomID: notice.RoomID, Error: notice.Text})
 			}
 
 		case message := <-h.Broadcast:
-			log.Printf("[HUB] Broadcasting message to %d clients: %s", len(h.Clients), string(message))
-			if len(message) == 0 {
-				log.Println("[HUB] Warning: empty message received")
+			h.broadcast(message)
+		}
+	}
+}
+
+// broadcast отправляет сообщение клиентам комнаты, а тем, кто еще читает
+// ее историю, откладывает его.
+func (h *Hub) broadcast(message RoomMessage) {
+	members := h.Rooms[message.RoomID]
+	log.Printf("[HUB] Broadcasting message to %d clients in room %d: %s", len(members), message.RoomID, string(message.Data))
+	if len(message.Data) == 0 {
+		log.Println("[HUB] Warning: empty message received")
+		return
+	}
+
+	for client := range members {
+		if pending, ok := h.loading[client][message.RoomID]; ok {
+			if len(pending) >= loadingLimit {
+				log.Printf("[HUB] Client %d is too slow to load room %d, disconnecting", client.UserID, message.RoomID)
+				h.remove(client)
 				continue
 			}
+			h.loading[client][message.RoomID] = append(pending, message)
+			continue
+		}
+		select {
+		case client.Send <- message.Data:
+			log.Printf("[HUB] Message sent to client %d", client.UserID)
+		default:
+			log.Printf("[HUB] Client %d channel blocked, disconnecting", client.UserID)
+			h.remove(client)
+		}
+	}
+}
 
-			for client := range h.Clients {
-				select {
-				case client.Send <- message:
-					log.Printf("[HUB] Message sent to client %d", client.UserID)
-				default:
-					log.Printf("[HUB] Client %d channel blocked, disconnecting", client.UserID)
-					close(client.Send)
-					delete(h.Clients, client)
-				}
-			}
+// join добавляет клиента в комнату. Историю комнаты клиент читает сам и
+// присылает в Loaded, а сообщения, пришедшие в комнату до этого, копятся в
+// loading: так база не читается в Run, а между историей и новыми
+// сообщениями нет пропусков.
+func (h *Hub) join(sub Subscription) {
+	client := sub.Client
+	if !h.Clients[client] {
+		return
+	}
+	log.Printf("[HUB] Client %d joined room %d", client.UserID, sub.RoomID)
+
+	if h.Rooms[sub.RoomID] == nil {
+		h.Rooms[sub.RoomID] = make(map[*Client]bool)
+	}
+	h.Rooms[sub.RoomID][client] = true
+	if h.loading[client] == nil {
+		h.loading[client] = make(map[int][]RoomMessage)
+	}
+	h.loading[client][sub.RoomID] = nil
+}
+
+// loaded отправляет клиенту историю комнаты, а за ней — накопленные за
+// время ее чтения сообщения, которых в истории нет. Если история не
+// прочиталась, клиент выходит из комнаты.
+func (h *Hub) loaded(history History) {
+	client := history.Client
+	pending, ok := h.loading[client][history.RoomID]
+	if !ok {
+		// Клиент успел выйти из комнаты, быть исключенным или отключиться
+		return
+	}
+	h.stopLoading(client, history.RoomID)
+
+	if history.Err != nil {
+		log.Printf("[HUB] Error getting messages: %v", history.Err)
+		client.setJoined(history.RoomID, false)
+		h.leave(client, history.RoomID)
+		h.send(client, entity.ChatEvent{Type: entity.ChatEventError, RoomID: history.RoomID, Error: "failed to load room history"})
+		return
+	}
+
+	if !h.send(client, entity.ChatEvent{Type: entity.ChatEventJoined, RoomID: history.RoomID, Gap: history.Gap}) {
+		return
+	}
+	log.Printf("[HUB] Sending %d historical messages to client %d", len(history.Messages), client.UserID)
+	lastID := history.LastID
+	for _, msg := range history.Messages {
+		if !h.send(client, entity.NewChatMessageEvent(msg)) {
+			return
+		}
+		lastID = max(lastID, msg.ID)
+	}
+	for _, message := range pending {
+		if message.MessageID != 0 && message.MessageID <= lastID {
+			continue
+		}
+		if !h.sendData(client, message.Data) {
+			return
+		}
+	}
+}
+
+func (h *Hub) stopLoading(client *Client, roomID int) {
+	delete(h.loading[client], roomID)
+	if len(h.loading[client]) == 0 {
+		delete(h.loading, client)
+	}
+}
+
+// deliver отправляет сообщение всем соединениям вошедших пользователей
+// delivery.UserIDs.
+func (h *Hub) deliver(delivery Delivery) {
+	recipients := make(map[int]bool, len(delivery.UserIDs))
+	for _, userID := range delivery.UserIDs {
+		recipients[userID] = true
+	}
+	for client := range h.Clients {
+		if !client.IsAuthenticated || !recipients[client.UserID] {
+			continue
+		}
+		select {
+		case client.Send <- delivery.Data:
+			log.Printf("[HUB] Direct message sent to client %d", client.UserID)
+		default:
+			log.Printf("[HUB] Client %d channel blocked, disconnecting", client.UserID)
+			h.remove(client)
+		}
+	}
+}
+
+// send отправляет событие одному клиенту. Клиент с переполненной очередью
+// отключается; тогда send возвращает false.
+func (h *Hub) send(client *Client, event entity.ChatEvent) bool {
+	jsonMsg, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[HUB] Error marshaling message: %v", err)
+		return true
+	}
+	return h.sendData(client, jsonMsg)
+}
+
+// sendData отправляет клиенту готовое сообщение, как send.
+func (h *Hub) sendData(client *Client, data []byte) bool {
+	select {
+	case client.Send <- data:
+		return true
+	default:
+		log.Printf("[HUB] Client %d send channel blocked, closing", client.UserID)
+		h.remove(client)
+		return false
+	}
+}
+
+func (h *Hub) leave(client *Client, roomID int) {
+	h.stopLoading(client, roomID)
+	delete(h.Rooms[roomID], client)
+	if len(h.Rooms[roomID]) == 0 {
+		delete(h.Rooms, roomID)
+	}
+}
+
+// remove отключает клиента от всех комнат и закрывает его очередь.
+func (h *Hub) remove(client *Client) {
+	if _, ok := h.Clients[client]; !ok {
+		return
+	}
+	for roomID, members := range h.Rooms {
+		if members[client] {
+			h.leave(client, roomID)
 		}
 	}
+	delete(h.Clients, client)
+	close(client.Send)
 }
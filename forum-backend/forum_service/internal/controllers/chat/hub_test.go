@@ -0,0 +1,175 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(hub *Hub, userID int, chatUC *mocks.ChatUsecase) *Client {
+	client := &Client{Hub: hub, Send: make(chan []byte, 16), UserID: userID, ChatUC: chatUC}
+	hub.Register <- client
+	return client
+}
+
+func receive(t *testing.T, client *Client) entity.ChatEvent {
+	t.Helper()
+	select {
+	case data := <-client.Send:
+		var event entity.ChatEvent
+		require.NoError(t, json.Unmarshal(data, &event))
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("client %d received nothing", client.UserID)
+		return entity.ChatEvent{}
+	}
+}
+
+func assertNothingReceived(t *testing.T, client *Client) {
+	t.Helper()
+	select {
+	case data := <-client.Send:
+		t.Fatalf("client %d received unexpected %s", client.UserID, data)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_RoomScopedBroadcast(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	chatUC := new(mocks.ChatUsecase)
+	chatUC.On("GetRecentMessages", mock.Anything, 1, historySize).Return(nil, nil)
+	chatUC.On("GetRecentMessages", mock.Anything, 2, historySize).
+		Return([]entity.ChatMessage{{ID: 3, RoomID: 2, UserID: 5, Username: "owner", Content: "раньше"}}, nil)
+
+	general := newTestClient(hub, 1, chatUC)
+	member := newTestClient(hub, 5, chatUC)
+	general.Join(1)
+	member.Join(1)
+	member.Join(2)
+
+	assert.Equal(t, entity.ChatEventJoined, receive(t, general).Type)
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventJoined, RoomID: 1}, receive(t, member))
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventJoined, RoomID: 2}, receive(t, member))
+	history := receive(t, member)
+	assert.Equal(t, entity.ChatEventMessage, history.Type)
+	assert.Equal(t, "раньше", history.Content)
+
+	data, err := json.Marshal(entity.NewChatMessageEvent(entity.ChatMessage{ID: 4, RoomID: 2, UserID: 5, Content: "только своим"}))
+	require.NoError(t, err)
+	hub.Broadcast <- RoomMessage{RoomID: 2, Data: data}
+
+	assert.Equal(t, "только своим", receive(t, member).Content)
+	assertNothingReceived(t, general)
+}
+
+func TestHub_JoinSince_SendsMissedMessages(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	chatUC := new(mocks.ChatUsecase)
+	chatUC.On("GetMissedMessages", mock.Anything, 2, 40, historySize).
+		Return([]entity.ChatMessage{{ID: 95, RoomID: 2, Content: "пропущенное"}}, true, nil)
+
+	client := newTestClient(hub, 5, chatUC)
+	client.JoinSince(2, 40)
+
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventJoined, RoomID: 2, Gap: true}, receive(t, client))
+	missed := receive(t, client)
+	assert.Equal(t, 95, missed.ID)
+	assert.Equal(t, "пропущенное", missed.Content)
+	assertNothingReceived(t, client)
+	chatUC.AssertNotCalled(t, "GetRecentMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHub_Evict(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	chatUC := new(mocks.ChatUsecase)
+	chatUC.On("GetRecentMessages", mock.Anything, 2, historySize).Return(nil, nil)
+
+	client := newTestClient(hub, 6, chatUC)
+	client.Join(2)
+	assert.Equal(t, entity.ChatEventJoined, receive(t, client).Type)
+
+	hub.Evict <- Eviction{RoomID: 2, UserID: 6, Reason: "removed from the room"}
+
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventLeft, RoomID: 2, Error: "removed from the room"}, receive(t, client))
+	assert.False(t, client.isJoined(2))
+	hub.Broadcast <- RoomMessage{RoomID: 2, Data: []byte(`{"type":"message"}`)}
+	assertNothingReceived(t, client)
+}
+
+func TestHub_DirectDelivery(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	recipient := newTestClient(hub, 2, nil)
+	recipient.IsAuthenticated = true
+	other := newTestClient(hub, 3, nil)
+	other.IsAuthenticated = true
+	// Анонимные соединения имеют UserID 0 и личных сообщений не получают
+	anonymous := newTestClient(hub, 0, nil)
+
+	hub.Direct <- Delivery{UserIDs: []int{0, 1, 2}, Data: []byte(`{"type":"dm"}`)}
+
+	assert.Equal(t, entity.ChatEventDirect, receive(t, recipient).Type)
+	assertNothingReceived(t, other)
+	assertNothingReceived(t, anonymous)
+}
+
+func TestHub_Loaded_SendsMessagesArrivedDuringHistory(t *testing.T) {
+	hub := NewHub()
+	client := &Client{Hub: hub, Send: make(chan []byte, 16), UserID: 5}
+	hub.Clients[client] = true
+
+	hub.join(Subscription{Client: client, RoomID: 2})
+	// Пока клиент читает историю, сообщения комнаты откладываются
+	hub.broadcast(RoomMessage{RoomID: 2, Data: []byte(`{"type":"message","id":4,"content":"уже в истории"}`), MessageID: 4})
+	hub.broadcast(RoomMessage{RoomID: 2, Data: []byte(`{"type":"message","id":5,"content":"новое"}`), MessageID: 5})
+	hub.broadcast(RoomMessage{RoomID: 2, Data: []byte(`{"type":"message.deleted","message_id":4}`)})
+	assertNothingReceived(t, client)
+
+	hub.loaded(History{Client: client, RoomID: 2, Messages: []entity.ChatMessage{{ID: 4, RoomID: 2, Content: "уже в истории"}}})
+
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventJoined, RoomID: 2}, receive(t, client))
+	assert.Equal(t, 4, receive(t, client).ID)
+	assert.Equal(t, 5, receive(t, client).ID)
+	assert.Equal(t, entity.ChatEventMessageDeleted, receive(t, client).Type)
+	assertNothingReceived(t, client)
+	assert.Empty(t, hub.loading)
+}
+
+func TestHub_Loaded_HistoryErrorLeavesRoom(t *testing.T) {
+	hub := NewHub()
+	client := &Client{Hub: hub, Send: make(chan []byte, 16), UserID: 5}
+	hub.Clients[client] = true
+	client.setJoined(2, true)
+
+	hub.join(Subscription{Client: client, RoomID: 2})
+	hub.loaded(History{Client: client, RoomID: 2, Err: assert.AnError})
+
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventError, RoomID: 2, Error: "failed to load room history"}, receive(t, client))
+	assert.False(t, client.isJoined(2))
+	assert.Empty(t, hub.Rooms)
+}
+
+func TestHub_Notify_AfterUnregister(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+
+	client := newTestClient(hub, 5, nil)
+	client.notify(2, "unknown message type")
+	assert.Equal(t, entity.ChatEvent{Type: entity.ChatEventError, RoomID: 2, Error: "unknown message type"}, receive(t, client))
+
+	hub.Unregister <- client
+	_, open := <-client.Send
+	require.False(t, open)
+	// Очередь закрыта Hub, и уведомление отключенному клиенту не пишется в нее
+	assert.NotPanics(t, func() { client.notify(2, "unknown message type") })
+}
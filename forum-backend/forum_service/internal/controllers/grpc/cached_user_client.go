@@ -0,0 +1,333 @@
+package grpc
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// fetchTimeout ограничивает общий для всех ожидающих запрос в auth_service:
+	// он не отменяется вместе с контекстом того, кто его начал.
+	fetchTimeout = 5 * time.Second
+
+	watchMinBackoff = time.Second
+	watchMaxBackoff = 30 * time.Second
+)
+
+type CacheConfig struct {
+	// Size — максимальное число записей, включая отрицательные.
+	Size int
+	// TTL — время жизни записи о найденном пользователе.
+	TTL time.Duration
+	// NegativeTTL — время жизни записи об отсутствующем пользователе.
+	NegativeTTL time.Duration
+}
+
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	NegativeHits  uint64 `json:"negative_hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`
+	Invalidations uint64 `json:"invalidations"`
+	Entries       int    `json:"entries"`
+}
+
+type cacheEntry struct {
+	id        int
+	user      entity.UserInfo
+	missing   bool
+	expiresAt time.Time
+}
+
+// CachedUserClient — кэширующая обертка над UserClientInterface: LRU с
+// ограничением размера и TTL, кэширование отсутствующих пользователей и
+// объединение одновременных запросов одних и тех же пользователей.
+type CachedUserClient struct {
+	next   UserClientInterface
+	cfg    CacheConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[int]*list.Element
+	order   *list.List // в начале — недавно использованные
+	// generation растет при каждой инвалидации; результат запроса,
+	// начатого до инвалидации, в кэш не попадает.
+	generation uint64
+
+	group singleflight.Group
+
+	hits          atomic.Uint64
+	negativeHits  atomic.Uint64
+	misses        atomic.Uint64
+	evictions     atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func NewCachedUserClient(next UserClientInterface, cfg CacheConfig, logger *zap.Logger) *CachedUserClient {
+	if cfg.Size <= 0 {
+		cfg.Size = 1
+	}
+	return &CachedUserClient{
+		next:    next,
+		cfg:     cfg,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[int]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *CachedUserClient) GetUsername(ctx context.Context, userID int) (string, error) {
+	u, err := c.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func (c *CachedUserClient) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	if e, ok := c.lookup(userID); ok {
+		if e.missing {
+			return entity.UserInfo{}, userNotFound(userID)
+		}
+		return e.user, nil
+	}
+
+	v, err := c.do(ctx, "user:"+strconv.Itoa(userID), func(ctx context.Context) (interface{}, error) {
+		generation := c.currentGeneration()
+		u, err := c.next.GetUser(ctx, userID)
+		if status.Code(err) == codes.NotFound {
+			c.store(generation, cacheEntry{id: userID, missing: true})
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.store(generation, cacheEntry{id: userID, user: u})
+		return u, nil
+	})
+	if err != nil {
+		return entity.UserInfo{}, err
+	}
+	return v.(entity.UserInfo), nil
+}
+
+// GetUsers отдает найденных в кэше пользователей сразу, а остальных
+// запрашивает одним вызовом GetUsers. Отсутствующих пользователей в ответе нет.
+func (c *CachedUserClient) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	users := make(map[int]entity.UserInfo, len(userIDs))
+	var missing []int
+	seen := make(map[int]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		e, ok := c.lookup(id)
+		switch {
+		case !ok:
+			missing = append(missing, id)
+		case !e.missing:
+			users[id] = e.user
+		}
+	}
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	sort.Ints(missing)
+	v, err := c.do(ctx, "users:"+joinIDs(missing), func(ctx context.Context) (interface{}, error) {
+		generation := c.currentGeneration()
+		fetched, err := c.next.GetUsers(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range missing {
+			if u, ok := fetched[id]; ok {
+				c.store(generation, cacheEntry{id: id, user: u})
+			} else {
+				c.store(generation, cacheEntry{id: id, missing: true})
+			}
+		}
+		return fetched, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for id, u := range v.(map[int]entity.UserInfo) {
+		users[id] = u
+	}
+	return users, nil
+}
+
+func (c *CachedUserClient) Close() error {
+	return c.next.Close()
+}
+
+// Invalidate удаляет запись о пользователе.
+func (c *CachedUserClient) Invalidate(userID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if el, ok := c.entries[userID]; ok {
+		c.order.Remove(el)
+		delete(c.entries, userID)
+	}
+	c.invalidations.Add(1)
+}
+
+// Purge удаляет все записи.
+func (c *CachedUserClient) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[int]*list.Element)
+	c.order.Init()
+}
+
+func (c *CachedUserClient) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.order.Len()
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		NegativeHits:  c.negativeHits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Entries:       entries,
+	}
+}
+
+// Watch подписывается на изменения пользователей и сбрасывает устаревшие
+// записи. Работает до отмены ctx и переподключается после обрыва потока.
+// После каждого подключения кэш очищается целиком: пока потока не было,
+// события могли быть пропущены.
+func (c *CachedUserClient) Watch(ctx context.Context, watcher UserChangeWatcher) {
+	backoff := watchMinBackoff
+	for {
+		err := watcher.WatchUserChanges(ctx, func() {
+			c.Purge()
+			backoff = watchMinBackoff
+			c.logger.Info("Subscribed to user changes")
+		}, func(u entity.UserInfo) {
+			c.Invalidate(u.ID)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.Warn("User changes stream interrupted", zap.Error(err), zap.Duration("retryIn", backoff))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, watchMaxBackoff)
+	}
+}
+
+func (c *CachedUserClient) lookup(userID int) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[userID]
+	if !ok {
+		c.misses.Add(1)
+		return cacheEntry{}, false
+	}
+	e := el.Value.(cacheEntry)
+	if !c.now().Before(e.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, userID)
+		c.misses.Add(1)
+		return cacheEntry{}, false
+	}
+
+	c.order.MoveToFront(el)
+	if e.missing {
+		c.negativeHits.Add(1)
+	} else {
+		c.hits.Add(1)
+	}
+	return e, true
+}
+
+func (c *CachedUserClient) store(generation uint64, e cacheEntry) {
+	ttl := c.cfg.TTL
+	if e.missing {
+		ttl = c.cfg.NegativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	e.expiresAt = c.now().Add(ttl)
+
+	if el, ok := c.entries[e.id]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[e.id] = c.order.PushFront(e)
+	for c.order.Len() > c.cfg.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).id)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *CachedUserClient) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// do выполняет fn один раз для всех одновременных вызовов с одним ключом.
+// Каждый вызывающий может перестать ждать по своему ctx, не прерывая запрос
+// для остальных.
+func (c *CachedUserClient) do(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+		return fn(fetchCtx)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		return res.Val, res.Err
+	}
+}
+
+func userNotFound(userID int) error {
+	return status.Errorf(codes.NotFound, "user %d not found", userID)
+}
+
+func joinIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,325 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeUserClient struct {
+	mu      sync.Mutex
+	users   map[int]entity.UserInfo
+	calls   atomic.Int32
+	batches [][]int
+	release chan struct{}
+	during  func()
+}
+
+func newFakeUserClient(users ...entity.UserInfo) *fakeUserClient {
+	f := &fakeUserClient{users: make(map[int]entity.UserInfo)}
+	for _, u := range users {
+		f.users[u.ID] = u
+	}
+	return f
+}
+
+func (f *fakeUserClient) wait() {
+	if f.during != nil {
+		f.during()
+	}
+	if f.release != nil {
+		<-f.release
+	}
+}
+
+func (f *fakeUserClient) GetUsername(ctx context.Context, userID int) (string, error) {
+	u, err := f.GetUser(ctx, userID)
+	return u.Username, err
+}
+
+func (f *fakeUserClient) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	f.calls.Add(1)
+	f.wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return entity.UserInfo{}, status.Error(codes.NotFound, "not found")
+	}
+	return u, nil
+}
+
+func (f *fakeUserClient) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	f.calls.Add(1)
+	f.wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, userIDs)
+	users := make(map[int]entity.UserInfo)
+	for _, id := range userIDs {
+		if u, ok := f.users[id]; ok {
+			users[id] = u
+		}
+	}
+	return users, nil
+}
+
+func (f *fakeUserClient) Close() error { return nil }
+
+func (f *fakeUserClient) setUser(u entity.UserInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.users[u.ID] = u
+}
+
+func newTestCache(next UserClientInterface, size int) (*CachedUserClient, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewCachedUserClient(next, CacheConfig{Size: size, TTL: time.Minute, NegativeTTL: 10 * time.Second}, zap.NewNop())
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCachedUserClient_GetUser_Hit(t *testing.T) {
+	next := newFakeUserClient(entity.UserInfo{ID: 1, Username: "alice", Role: "user"})
+	c, _ := newTestCache(next, 10)
+
+	for i := 0; i < 3; i++ {
+		u, err := c.GetUser(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", u.Username)
+	}
+
+	assert.Equal(t, int32(1), next.calls.Load())
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+}
+
+func TestCachedUserClient_GetUser_Expired(t *testing.T) {
+	next := newFakeUserClient(entity.UserInfo{ID: 1, Username: "alice"})
+	c, now := newTestCache(next, 10)
+
+	_, _ = c.GetUser(context.Background(), 1)
+	*now = now.Add(time.Minute)
+	_, _ = c.GetUser(context.Background(), 1)
+
+	assert.Equal(t, int32(2), next.calls.Load())
+}
+
+func TestCachedUserClient_GetUser_NegativeCache(t *testing.T) {
+	next := newFakeUserClient()
+	c, now := newTestCache(next, 10)
+
+	_, err := c.GetUser(context.Background(), 5)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = c.GetUser(context.Background(), 5)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), next.calls.Load())
+	assert.Equal(t, uint64(1), c.Stats().NegativeHits)
+
+	// Отрицательная запись живет меньше обычной
+	next.setUser(entity.UserInfo{ID: 5, Username: "late"})
+	*now = now.Add(10 * time.Second)
+	u, err := c.GetUser(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "late", u.Username)
+}
+
+func TestCachedUserClient_GetUser_ErrorNotCached(t *testing.T) {
+	next := &failingUserClient{err: errors.New("unavailable")}
+	c, _ := newTestCache(next, 10)
+
+	_, err := c.GetUser(context.Background(), 1)
+	assert.Error(t, err)
+	_, err = c.GetUser(context.Background(), 1)
+	assert.Error(t, err)
+
+	assert.Equal(t, 2, next.calls)
+	assert.Equal(t, 0, c.Stats().Entries)
+}
+
+func TestCachedUserClient_GetUsers_FetchesOnlyMisses(t *testing.T) {
+	next := newFakeUserClient(
+		entity.UserInfo{ID: 1, Username: "alice"},
+		entity.UserInfo{ID: 2, Username: "bob"},
+	)
+	c, _ := newTestCache(next, 10)
+
+	_, err := c.GetUser(context.Background(), 1)
+	assert.NoError(t, err)
+
+	users, err := c.GetUsers(context.Background(), []int{3, 1, 2, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]entity.UserInfo{1: {ID: 1, Username: "alice"}, 2: {ID: 2, Username: "bob"}}, users)
+	assert.Equal(t, [][]int{{2, 3}}, next.batches)
+
+	// Теперь все в кэше, включая отсутствующего пользователя 3
+	users, err = c.GetUsers(context.Background(), []int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Len(t, next.batches, 1)
+}
+
+func TestCachedUserClient_Eviction(t *testing.T) {
+	next := newFakeUserClient(
+		entity.UserInfo{ID: 1, Username: "a"},
+		entity.UserInfo{ID: 2, Username: "b"},
+		entity.UserInfo{ID: 3, Username: "c"},
+	)
+	c, _ := newTestCache(next, 2)
+	ctx := context.Background()
+
+	_, _ = c.GetUser(ctx, 1)
+	_, _ = c.GetUser(ctx, 2)
+	_, _ = c.GetUser(ctx, 1) // 1 становится самым свежим
+	_, _ = c.GetUser(ctx, 3) // вытесняет 2
+
+	calls := next.calls.Load()
+	_, _ = c.GetUser(ctx, 1)
+	assert.Equal(t, calls, next.calls.Load())
+	_, _ = c.GetUser(ctx, 2)
+	assert.Equal(t, calls+1, next.calls.Load())
+	assert.Equal(t, uint64(2), c.Stats().Evictions)
+}
+
+func TestCachedUserClient_ConcurrentLookupsShareRequest(t *testing.T) {
+	next := newFakeUserClient(entity.UserInfo{ID: 1, Username: "alice"})
+	next.release = make(chan struct{})
+	c, _ := newTestCache(next, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := c.GetUser(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "alice", u.Username)
+		}()
+	}
+	// Даем всем горутинам дойти до ожидания общего запроса
+	assert.Eventually(t, func() bool { return next.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), next.calls.Load())
+}
+
+func TestCachedUserClient_CallerCancelDoesNotAbortSharedRequest(t *testing.T) {
+	next := newFakeUserClient(entity.UserInfo{ID: 1, Username: "alice"})
+	next.release = make(chan struct{})
+	c, _ := newTestCache(next, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.GetUser(ctx, 1)
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return next.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	close(next.release)
+	assert.Eventually(t, func() bool { return c.Stats().Entries == 1 }, time.Second, time.Millisecond)
+}
+
+func TestCachedUserClient_InvalidateDuringFetch(t *testing.T) {
+	next := newFakeUserClient(entity.UserInfo{ID: 1, Username: "old"})
+	c, _ := newTestCache(next, 10)
+	// Изменение приходит, пока запрос в auth_service еще выполняется
+	next.during = func() { c.Invalidate(1) }
+
+	u, err := c.GetUser(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", u.Username)
+	assert.Equal(t, 0, c.Stats().Entries)
+}
+
+func TestCachedUserClient_Watch(t *testing.T) {
+	next := newFakeUserClient(
+		entity.UserInfo{ID: 1, Username: "alice", Role: "user"},
+		entity.UserInfo{ID: 2, Username: "bob", Role: "user"},
+	)
+	c, _ := newTestCache(next, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, _ = c.GetUsers(ctx, []int{1, 2})
+
+	watcher := &fakeWatcher{ready: make(chan struct{}), changes: make(chan entity.UserInfo)}
+	done := make(chan struct{})
+	go func() {
+		c.Watch(ctx, watcher)
+		close(done)
+	}()
+
+	<-watcher.ready
+	// После подписки кэш сбрасывается: события до нее могли быть пропущены
+	assert.Equal(t, 0, c.Stats().Entries)
+
+	_, _ = c.GetUsers(ctx, []int{1, 2})
+	next.setUser(entity.UserInfo{ID: 1, Username: "alice", Role: "moderator"})
+	watcher.changes <- entity.UserInfo{ID: 1, Role: "moderator"}
+	watcher.changes <- entity.UserInfo{} // дожидаемся обработки первого события
+
+	u, err := c.GetUser(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "moderator", u.Role)
+	assert.Equal(t, uint64(2), c.Stats().Invalidations)
+
+	cancel()
+	<-done
+}
+
+type fakeWatcher struct {
+	ready   chan struct{}
+	changes chan entity.UserInfo
+}
+
+func (w *fakeWatcher) WatchUserChanges(ctx context.Context, onReady func(), onChange func(entity.UserInfo)) error {
+	onReady()
+	close(w.ready)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case u := <-w.changes:
+			onChange(u)
+		}
+	}
+}
+
+type failingUserClient struct {
+	err   error
+	calls int
+}
+
+func (f *failingUserClient) GetUsername(ctx context.Context, userID int) (string, error) {
+	f.calls++
+	return "", f.err
+}
+
+func (f *failingUserClient) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	f.calls++
+	return entity.UserInfo{}, f.err
+}
+
+func (f *failingUserClient) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func (f *failingUserClient) Close() error { return nil }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	user "github.com/miqxzz/miqxzzforum/forum_service/internal/proto"
 
 	"google.golang.org/grpc"
@@ -12,9 +13,18 @@ import (
 
 type UserClientInterface interface {
 	GetUsername(ctx context.Context, userID int) (string, error)
+	GetUser(ctx context.Context, userID int) (entity.UserInfo, error)
+	GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error)
 	Close() error
 }
 
+// UserChangeWatcher сообщает об изменениях пользователей в auth_service.
+// WatchUserChanges вызывает onReady, когда подписка на сервере оформлена,
+// и блокируется, пока поток не оборвется или ctx не будет отменен.
+type UserChangeWatcher interface {
+	WatchUserChanges(ctx context.Context, onReady func(), onChange func(entity.UserInfo)) error
+}
+
 type UserClient struct {
 	conn   *grpc.ClientConn
 	client user.UserServiceClient
@@ -41,6 +51,63 @@ func (c *UserClient) GetUsername(ctx context.Context, userID int) (string, error
 	return resp.Username, nil
 }
 
+func (c *UserClient) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	resp, err := c.client.GetUser(ctx, &user.UserRequest{UserId: int32(userID)})
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		return entity.UserInfo{}, err
+	}
+	return entity.UserInfo{ID: int(resp.UserId), Username: resp.Username, Role: resp.Role}, nil
+}
+
+// GetUsers получает пользователей одним запросом. Повторяющиеся id
+// отправляются один раз; отсутствующих пользователей в ответе нет.
+func (c *UserClient) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	users := make(map[int]entity.UserInfo, len(userIDs))
+	ids := make([]int32, 0, len(userIDs))
+	seen := make(map[int]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, int32(id))
+	}
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	resp, err := c.client.GetUsers(ctx, &user.UsersRequest{UserIds: ids})
+	if err != nil {
+		log.Printf("Failed to get users: %v", err)
+		return nil, err
+	}
+	for _, u := range resp.Users {
+		users[int(u.UserId)] = entity.UserInfo{ID: int(u.UserId), Username: u.Username, Role: u.Role}
+	}
+	return users, nil
+}
+
+func (c *UserClient) WatchUserChanges(ctx context.Context, onReady func(), onChange func(entity.UserInfo)) error {
+	stream, err := c.client.WatchUserChanges(ctx, &user.WatchUserChangesRequest{})
+	if err != nil {
+		return err
+	}
+	// Сервер отправляет заголовки сразу после подписки
+	if _, err := stream.Header(); err != nil {
+		return err
+	}
+	onReady()
+
+	for {
+		change, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		onChange(entity.UserInfo{ID: int(change.UserId), Username: change.Username, Role: change.Role})
+	}
+}
+
 func (c *UserClient) Close() error {
 	return c.conn.Close()
 }
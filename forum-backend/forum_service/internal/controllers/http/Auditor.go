@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Auditor записывает привилегированные действия в общий журнал audit_log
+// вместе с пользователем из контекста, IP и User-Agent запроса. Действие к
+// моменту записи уже выполнено, поэтому ошибка записи только логируется.
+// Nil-Auditor ничего не пишет.
+type Auditor struct {
+	auditRepo repository.AuditRepository
+	logger    *zap.Logger
+}
+
+func NewAuditor(auditRepo repository.AuditRepository, logger *zap.Logger) *Auditor {
+	return &Auditor{auditRepo: auditRepo, logger: logger}
+}
+
+// Record пишет действие action над объектом targetType/targetID. before и
+// after — состояния объекта до и после действия, nil — состояния нет.
+func (a *Auditor) Record(c *gin.Context, action, targetType string, targetID interface{}, before, after interface{}) {
+	if a == nil {
+		return
+	}
+	principal, _ := PrincipalFromContext(c)
+	entry := entity.AuditEntry{
+		ActorID:    principal.UserID,
+		ActorRole:  principal.Role,
+		Action:     action,
+		TargetType: targetType,
+		Before:     a.snapshot(action, before),
+		After:      a.snapshot(action, after),
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if targetID != nil {
+		entry.TargetID = fmt.Sprint(targetID)
+	}
+	if err := a.auditRepo.Append(c.Request.Context(), entry); err != nil {
+		a.logger.Error("Failed to write audit entry",
+			zap.Error(err),
+			zap.String("action", action),
+			zap.String("targetType", targetType),
+			zap.String("targetID", entry.TargetID),
+			zap.Int("actorID", principal.UserID))
+	}
+}
+
+func (a *Auditor) snapshot(action string, state interface{}) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		a.logger.Error("Failed to marshal audit snapshot", zap.Error(err), zap.String("action", action))
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	utils "github.com/miqxzz/commonmiqx"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+const principalKey = "principal"
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenRevoked = errors.New("token has been revoked")
+)
+
+// AuthMiddleware проверяет access-токен (подпись, срок, отзыв) и кладет
+// entity.Principal с правами роли и действующими санкциями в контекст Gin.
+// Все защищенные маршруты идут через него.
+type AuthMiddleware struct {
+	tokenRepo      repository.TokenRepository
+	permissionRepo repository.PermissionRepository
+	sanctionRepo   repository.SanctionRepository
+	jwtUtil        *utils.JWTUtil
+	logger         *zap.Logger
+}
+
+func NewAuthMiddleware(tokenRepo repository.TokenRepository, permissionRepo repository.PermissionRepository, sanctionRepo repository.SanctionRepository, jwtUtil *utils.JWTUtil, logger *zap.Logger) *AuthMiddleware {
+	return &AuthMiddleware{tokenRepo: tokenRepo, permissionRepo: permissionRepo, sanctionRepo: sanctionRepo, jwtUtil: jwtUtil, logger: logger}
+}
+
+// Authenticate проверяет токен и возвращает пользователя. Для невалидного
+// или отозванного токена возвращает ErrInvalidToken / ErrTokenRevoked.
+func (m *AuthMiddleware) Authenticate(ctx context.Context, token string) (entity.Principal, error) {
+	claims, err := m.jwtUtil.ValidateToken(token)
+	if err != nil {
+		m.logger.Warn("Invalid token", zap.Error(err))
+		return entity.Principal{}, ErrInvalidToken
+	}
+
+	revoked, err := m.tokenRepo.IsTokenRevoked(ctx, token)
+	if err != nil {
+		m.logger.Error("Failed to check token revocation", zap.Error(err))
+		return entity.Principal{}, err
+	}
+	if revoked {
+		m.logger.Warn("Revoked token used", zap.Int("userID", claims.UserID))
+		return entity.Principal{}, ErrTokenRevoked
+	}
+
+	permissions, err := m.permissionRepo.GetRolePermissions(ctx, claims.Role)
+	if err != nil {
+		m.logger.Error("Failed to load role permissions", zap.String("role", claims.Role), zap.Error(err))
+		return entity.Principal{}, err
+	}
+
+	sanctions, err := m.sanctionRepo.GetActiveSanctions(ctx, claims.UserID)
+	if err != nil {
+		m.logger.Error("Failed to load user sanctions", zap.Int("userID", claims.UserID), zap.Error(err))
+		return entity.Principal{}, err
+	}
+
+	return entity.Principal{UserID: claims.UserID, Role: claims.Role, Permissions: permissions, Token: token, Sanctions: sanctions}, nil
+}
+
+// RequireAuth пропускает запрос только с валидным токеном в заголовке
+// Authorization. Заблокированному или ограниченному пользователю доступно
+// только чтение: любой изменяющий запрос получает 403 с причиной санкции.
+func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			m.logger.Warn("Authorization header required")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			return
+		}
+
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if tokenString == authHeader || tokenString == "" {
+			m.logger.Warn("Invalid Authorization header format", zap.String("header", authHeader))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+			return
+		}
+
+		principal, err := m.Authenticate(c.Request.Context(), tokenString)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		if sanction := principal.Sanctions.WriteBlock(); sanction != nil && !isReadOnlyMethod(c.Request.Method) {
+			m.logger.Warn("Write attempt by sanctioned user",
+				zap.Int("userID", principal.UserID),
+				zap.String("sanction", sanction.Type),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.FullPath()))
+			abortWithSanction(c, sanction)
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+// OptionalAuth пропускает запросы без заголовка Authorization как гостевые,
+// а с заголовком — только с валидным токеном. Нужен публичным маршрутам,
+// которые показывают разным пользователям разное.
+func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	requireAuth := m.RequireAuth()
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		requireAuth(c)
+	}
+}
+
+// RequireRole пропускает запрос, только если роль пользователя входит в roles.
+// Ставится после RequireAuth.
+func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		if !principal.HasRole(roles...) {
+			m.logger.Warn("Insufficient role",
+				zap.Int("userID", principal.UserID),
+				zap.String("role", principal.Role),
+				zap.Strings("required", roles))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission пропускает запрос, только если у роли пользователя есть
+// право permission. Ставится после RequireAuth.
+func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		if !principal.Can(permission) {
+			m.logger.Warn("Permission denied",
+				zap.Int("userID", principal.UserID),
+				zap.String("role", principal.Role),
+				zap.String("permission", permission))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// PrincipalFromContext возвращает пользователя, положенного RequireAuth.
+func PrincipalFromContext(c *gin.Context) (entity.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return entity.Principal{}, false
+	}
+	principal, ok := value.(entity.Principal)
+	return principal, ok
+}
+
+func abortWithAuthError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidToken):
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+	case errors.Is(err, ErrTokenRevoked):
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to check token"})
+	}
+}
+
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func abortWithSanction(c *gin.Context, sanction *entity.Sanction) {
+	body := gin.H{"error": "Account is restricted", "sanction": sanction.Type, "reason": sanction.Reason}
+	if sanction.ExpiresAt != nil {
+		body["expires_at"] = sanction.ExpiresAt
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, body)
+}
+
+// optionalPrincipal возвращает пользователя, положенного OptionalAuth, или
+// nil для гостя.
+func optionalPrincipal(c *gin.Context) *entity.Principal {
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal
+}
+
+// requirePrincipal достает пользователя из контекста и отвечает 401, если
+// маршрут по ошибке зарегистрирован без RequireAuth.
+func requirePrincipal(c *gin.Context) (entity.Principal, bool) {
+	principal, ok := PrincipalFromContext(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+	}
+	return principal, ok
+}
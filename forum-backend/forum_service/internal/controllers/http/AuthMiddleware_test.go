@@ -0,0 +1,320 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	utils "github.com/miqxzz/commonmiqx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newAuthTestRouter(auth *AuthMiddleware, handlers ...gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	handlers = append(handlers, func(c *gin.Context) {
+		principal, _ := PrincipalFromContext(c)
+		c.JSON(http.StatusOK, principal)
+	})
+	router.GET("/protected", handlers...)
+	return router
+}
+
+// noSanctions возвращает репозиторий санкций без действующих санкций.
+func noSanctions() *mocks.SanctionRepository {
+	repo := new(mocks.SanctionRepository)
+	repo.On("GetActiveSanctions", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+func TestAuthMiddleware_RequireAuth_Success(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(7, "moderator")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "moderator").Return([]string{entity.PermPostDeleteAny}, nil)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user_id":7,"role":"moderator","permissions":["post.delete.any"]}`, w.Body.String())
+
+	mockTokenRepo.AssertExpectations(t)
+	mockPermissionRepo.AssertExpectations(t)
+}
+
+func TestAuthMiddleware_RequireAuth_SuspendedUserReadOnly(t *testing.T) {
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, mockSanctionRepo, jwtUtil, zap.NewNop())
+
+	token, err := jwtUtil.GenerateToken(7, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+	mockSanctionRepo.On("GetActiveSanctions", mock.Anything, 7).
+		Return(entity.Sanctions{{ID: 3, Type: entity.SanctionSuspension, Reason: "оскорбления"}}, nil)
+
+	router := newAuthTestRouter(auth, auth.RequireAuth())
+	router.POST("/protected", auth.RequireAuth(), func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error":"Account is restricted","sanction":"suspension","reason":"оскорбления"}`, w.Body.String())
+}
+
+func TestAuthMiddleware_RequireAuth_MutedUserCanWrite(t *testing.T) {
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockSanctionRepo := new(mocks.SanctionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, mockSanctionRepo, jwtUtil, zap.NewNop())
+
+	token, err := jwtUtil.GenerateToken(7, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+	mockSanctionRepo.On("GetActiveSanctions", mock.Anything, 7).Return(entity.Sanctions{{ID: 4, Type: entity.SanctionMute}}, nil)
+
+	router := gin.New()
+	router.POST("/protected", auth.RequireAuth(), func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	req, _ := http.NewRequest(http.MethodPost, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestAuthMiddleware_RequireAuth_MissingAuthorizationHeader(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), utils.NewJWTUtil("secret"), logger)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Authorization header required")
+}
+
+func TestAuthMiddleware_RequireAuth_InvalidToken(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), utils.NewJWTUtil("secret"), logger)
+
+	token, err := utils.NewJWTUtil("other-secret").GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Invalid token")
+	mockTokenRepo.AssertNotCalled(t, "IsTokenRevoked", mock.Anything, mock.Anything)
+}
+
+func TestAuthMiddleware_RequireAuth_RevocationCheckFailed(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, errors.New("db error"))
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to check token")
+}
+
+func TestAuthMiddleware_RequireAuth_PermissionsLoadFailed(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return(nil, errors.New("db error"))
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to check token")
+}
+
+func TestAuthMiddleware_RequireRole_Allowed(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "moderator")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "moderator").Return([]string{}, nil)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth(), auth.RequireRole("admin", "moderator")).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthMiddleware_RequireRole_Forbidden(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth(), auth.RequireRole("admin", "moderator")).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Insufficient permissions")
+}
+
+func TestAuthMiddleware_RequireRole_WithoutPrincipal(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	auth := NewAuthMiddleware(new(mocks.TokenRepository), new(mocks.PermissionRepository), noSanctions(), utils.NewJWTUtil("secret"), logger)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireRole("admin")).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Unauthorized")
+}
+
+func TestAuthMiddleware_RequirePermission_Allowed(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "moderator")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "moderator").Return([]string{entity.PermCommentDeleteAny, entity.PermChatMute}, nil)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth(), auth.RequirePermission(entity.PermChatMute)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthMiddleware_RequirePermission_Forbidden(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	auth := NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger)
+
+	token, err := jwtUtil.GenerateToken(1, "admin")
+	assert.NoError(t, err)
+
+	// Право определяется таблицей role_permissions, а не названием роли
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "admin").Return([]string{entity.PermPostDeleteAny}, nil)
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	newAuthTestRouter(auth, auth.RequireAuth(), auth.RequirePermission(entity.PermUserBan)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Insufficient permissions")
+}
@@ -0,0 +1,336 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+	postUsecase     usecase.PostUsecase
+	auth            *AuthMiddleware
+	audit           *Auditor
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase, postUsecase usecase.PostUsecase, auth *AuthMiddleware, audit *Auditor, logger *zap.Logger, userClient grpc.UserClientInterface) *CategoryHandler {
+	return &CategoryHandler{categoryUsecase: categoryUsecase, postUsecase: postUsecase, auth: auth, audit: audit, logger: logger, userClient: userClient}
+}
+
+func (h *CategoryHandler) Register(router *gin.Engine) {
+	router.GET("/categories", h.auth.OptionalAuth(), h.GetCategories)
+	router.GET("/categories/:slug/posts", h.auth.OptionalAuth(), h.GetCategoryPosts)
+
+	router.POST("/categories", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.CreateCategory)
+	router.PUT("/categories/:id", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.UpdateCategory)
+	router.POST("/categories/reorder", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.ReorderCategories)
+	router.POST("/categories/:id/merge", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.MergeCategory)
+	router.POST("/categories/:id/archive", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.ArchiveCategory)
+	router.DELETE("/categories/:id/archive", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermCategoryManage), h.UnarchiveCategory)
+}
+
+// GetCategories godoc
+// @Summary Список разделов
+// @Description Возвращает дерево разделов, которые может читать пользователь, в заданном порядке. Архивные разделы скрыты, если не передан include_archived=true
+// @Tags Разделы
+// @Produce json
+// @Param include_archived query bool false "Показать архивные разделы"
+// @Success 200 {object} map[string]interface{} "categories"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories [get]
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	includeArchived := c.Query("include_archived") == "true"
+
+	categories, err := h.categoryUsecase.GetCategories(c.Request.Context(), optionalPrincipal(c), includeArchived)
+	if err != nil {
+		h.logger.Error("Failed to get categories", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get categories"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// GetCategoryPosts godoc
+// @Summary Посты раздела
+// @Description Возвращает раздел и его посты постранично, новые первыми. Подразделы не включаются
+// @Tags Разделы
+// @Produce json
+// @Param slug path string true "Slug раздела"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Постов на странице" default(10)
+// @Success 200 {object} map[string]interface{} "category, posts и pagination"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/{slug}/posts [get]
+func (h *CategoryHandler) GetCategoryPosts(c *gin.Context) {
+	category, err := h.categoryUsecase.GetCategoryBySlug(c.Request.Context(), c.Param("slug"), optionalPrincipal(c))
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to get category")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	filter := entity.PostFilter{CategoryID: category.ID, Limit: limit, Offset: (page - 1) * limit}
+
+	posts, err := h.postUsecase.GetPosts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	total, err := h.postUsecase.GetTotalPostsCount(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	postsWithUsernames := postListItems(c, h.logger, h.userClient, posts)
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+		"posts":    postsWithUsernames,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// CreateCategory godoc
+// @Summary Создать раздел
+// @Description Создает раздел или подраздел (parent_id). В permissions перечисляются роли, которым разрешено читать, создавать посты и комментировать; пустой список — разрешено всем. Требуется право category.manage
+// @Tags Разделы
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param category body entity.CategoryRequest true "Раздел"
+// @Success 201 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Родительский раздел не найден"
+// @Failure 409 {object} entity.ErrorResponse "Slug занят"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req entity.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.categoryUsecase.CreateCategory(c.Request.Context(), req)
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to create category")
+		return
+	}
+	h.audit.Record(c, entity.AuditCategoryCreate, entity.AuditTargetCategory, category.ID, nil, category)
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Summary Изменить раздел
+// @Description Заменяет название, slug, описание, родителя, позицию и права раздела. Требуется право category.manage
+// @Tags Разделы
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID раздела"
+// @Param category body entity.CategoryRequest true "Раздел"
+// @Success 200 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Slug занят"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	id, ok := categoryID(c)
+	if !ok {
+		return
+	}
+	var req entity.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.categoryUsecase.UpdateCategory(c.Request.Context(), id, req)
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to update category")
+		return
+	}
+	h.audit.Record(c, entity.AuditCategoryUpdate, entity.AuditTargetCategory, id, nil, category)
+	c.JSON(http.StatusOK, category)
+}
+
+// ReorderCategories godoc
+// @Summary Изменить порядок разделов
+// @Description Задает порядок подразделов parent_id (без parent_id — корневых разделов). В ids должны быть перечислены все эти разделы. Требуется право category.manage
+// @Tags Разделы
+// @Accept json
+// @Security BearerAuth
+// @Param order body entity.ReorderCategoriesRequest true "Родитель и новый порядок"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/reorder [post]
+func (h *CategoryHandler) ReorderCategories(c *gin.Context) {
+	var req entity.ReorderCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categoryUsecase.ReorderCategories(c.Request.Context(), req.ParentID, req.IDs); err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to reorder categories")
+		return
+	}
+	var parentID interface{}
+	if req.ParentID != nil {
+		parentID = *req.ParentID
+	}
+	h.audit.Record(c, entity.AuditCategoryReorder, entity.AuditTargetCategory, parentID, nil, req)
+	c.Status(http.StatusNoContent)
+}
+
+// MergeCategory godoc
+// @Summary Слить разделы
+// @Description Переносит посты и подразделы раздела в target_id и удаляет раздел. Раздел по умолчанию слить нельзя. Требуется право category.manage
+// @Tags Разделы
+// @Accept json
+// @Security BearerAuth
+// @Param id path int true "ID раздела, который сливается"
+// @Param merge body entity.MergeCategoryRequest true "Раздел, в который переносятся посты"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/{id}/merge [post]
+func (h *CategoryHandler) MergeCategory(c *gin.Context) {
+	id, ok := categoryID(c)
+	if !ok {
+		return
+	}
+	var req entity.MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categoryUsecase.MergeCategory(c.Request.Context(), id, req.TargetID); err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to merge category")
+		return
+	}
+	h.audit.Record(c, entity.AuditCategoryMerge, entity.AuditTargetCategory, id, nil, req)
+	h.logger.Info("Category merged", zap.Int("sourceID", id), zap.Int("targetID", req.TargetID))
+	c.Status(http.StatusNoContent)
+}
+
+// ArchiveCategory godoc
+// @Summary Архивировать раздел
+// @Description Раздел и его подразделы становятся доступны только для чтения и скрываются из списка разделов. Раздел по умолчанию архивировать нельзя. Требуется право category.manage
+// @Tags Разделы
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID раздела"
+// @Success 200 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/{id}/archive [post]
+func (h *CategoryHandler) ArchiveCategory(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveCategory godoc
+// @Summary Вернуть раздел из архива
+// @Description Требуется право category.manage
+// @Tags Разделы
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID раздела"
+// @Success 200 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /categories/{id}/archive [delete]
+func (h *CategoryHandler) UnarchiveCategory(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *CategoryHandler) setArchived(c *gin.Context, archived bool) {
+	id, ok := categoryID(c)
+	if !ok {
+		return
+	}
+	category, err := h.categoryUsecase.SetArchived(c.Request.Context(), id, archived)
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to archive category")
+		return
+	}
+	action := entity.AuditCategoryArchive
+	if !archived {
+		action = entity.AuditCategoryRestore
+	}
+	h.audit.Record(c, action, entity.AuditTargetCategory, id, gin.H{"archived": !archived}, category)
+	c.JSON(http.StatusOK, category)
+}
+
+func categoryID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// abortCategoryError отвечает на ошибки разделов, в том числе на ошибки
+// проверки доступа в обработчиках постов и комментариев.
+func abortCategoryError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrCategoryNotFound), errors.Is(err, usecase.ErrPostNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCategoryForbidden):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCategoryArchived), errors.Is(err, usecase.ErrCategorySlugTaken):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidCategorySlug),
+		errors.Is(err, usecase.ErrInvalidCategoryRole),
+		errors.Is(err, usecase.ErrCategoryCycle),
+		errors.Is(err, usecase.ErrDefaultCategory),
+		errors.Is(err, usecase.ErrInvalidCategoryOrder):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
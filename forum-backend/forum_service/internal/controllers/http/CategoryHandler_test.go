@@ -0,0 +1,236 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// openCategories разрешает все действия во всех разделах — для тестов
+// обработчиков, которые не проверяют доступ к разделам.
+func openCategories() *mocks.CategoryUsecase {
+	categories := new(mocks.CategoryUsecase)
+	categories.On("CheckAccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	categories.On("CheckPostAccess", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	categories.On("HiddenCategoryIDs", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return categories
+}
+
+// serveGuestRoute выполняет запрос без пользователя в контексте.
+func serveGuestRoute(h gin.HandlerFunc, method, route, target string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, h)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestCategoryHandler_GetCategories_Guest(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCategoryHandler(mockCategoryUsecase, new(mocks.PostUsecase), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	tree := []*entity.Category{{ID: 1, Slug: "general", Children: []*entity.Category{{ID: 2, Slug: "news"}}}}
+	mockCategoryUsecase.On("GetCategories", mock.Anything, (*entity.Principal)(nil), true).Return(tree, nil)
+
+	w := serveGuestRoute(handler.GetCategories, http.MethodGet, "/categories", "/categories?include_archived=true")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Categories []*entity.Category `json:"categories"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "news", body.Categories[0].Children[0].Slug)
+	mockCategoryUsecase.AssertExpectations(t)
+}
+
+func TestCategoryHandler_GetCategoryPosts_Success(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewCategoryHandler(mockCategoryUsecase, mockPostUsecase, nil, nil, zap.NewNop(), mockUserClient)
+
+	principal := entity.Principal{UserID: 1, Role: "user"}
+	filter := entity.PostFilter{CategoryID: 2, Limit: 5, Offset: 5}
+	mockCategoryUsecase.On("GetCategoryBySlug", mock.Anything, "news", &principal).Return(entity.Category{ID: 2, Slug: "news"}, nil)
+	mockPostUsecase.On("GetPosts", mock.Anything, filter).Return([]entity.Post{{ID: 7, AuthorId: 3, CategoryID: 2}}, nil)
+	mockPostUsecase.On("GetTotalPostsCount", mock.Anything, filter).Return(6, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{3}).Return(map[int]entity.UserInfo{3: {ID: 3, Username: "carol"}}, nil)
+
+	w := servePostRoute(handler.GetCategoryPosts, http.MethodGet, "/categories/:slug/posts", "/categories/news/posts?page=2&limit=5", "", principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Category   entity.Category          `json:"category"`
+		Posts      []map[string]interface{} `json:"posts"`
+		Pagination map[string]int           `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "news", body.Category.Slug)
+	assert.Equal(t, "carol", body.Posts[0]["username"])
+	assert.Equal(t, map[string]int{"page": 2, "limit": 5, "total": 6}, body.Pagination)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestCategoryHandler_GetCategoryPosts_Hidden(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	mockPostUsecase := new(mocks.PostUsecase)
+	handler := NewCategoryHandler(mockCategoryUsecase, mockPostUsecase, nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCategoryUsecase.On("GetCategoryBySlug", mock.Anything, "staff", (*entity.Principal)(nil)).Return(entity.Category{}, usecase.ErrCategoryNotFound)
+
+	w := serveGuestRoute(handler.GetCategoryPosts, http.MethodGet, "/categories/:slug/posts", "/categories/staff/posts")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "GetPosts", mock.Anything, mock.Anything)
+}
+
+func TestCategoryHandler_CreateCategory_Errors(t *testing.T) {
+	admin := entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermCategoryManage}}
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrInvalidCategorySlug, http.StatusBadRequest},
+		{usecase.ErrCategorySlugTaken, http.StatusConflict},
+		{usecase.ErrCategoryNotFound, http.StatusNotFound},
+	} {
+		mockCategoryUsecase := new(mocks.CategoryUsecase)
+		handler := NewCategoryHandler(mockCategoryUsecase, new(mocks.PostUsecase), nil, nil, zap.NewNop(), new(mocks.UserClient))
+		mockCategoryUsecase.On("CreateCategory", mock.Anything, mock.Anything).Return(entity.Category{}, tc.err)
+
+		w := servePostRoute(handler.CreateCategory, http.MethodPost, "/categories", "/categories",
+			`{"slug":"News!","name":"Новости","parent_id":9}`, admin)
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestCategoryHandler_CreateCategory_Success(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCategoryHandler(mockCategoryUsecase, new(mocks.PostUsecase), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	req := entity.CategoryRequest{
+		Slug:        "announcements",
+		Name:        "Объявления",
+		Permissions: entity.CategoryPermissions{Post: []string{"admin"}},
+	}
+	mockCategoryUsecase.On("CreateCategory", mock.Anything, req).
+		Return(entity.Category{ID: 2, Slug: "announcements", Permissions: req.Permissions}, nil)
+
+	w := servePostRoute(handler.CreateCategory, http.MethodPost, "/categories", "/categories",
+		`{"slug":"announcements","name":"Объявления","permissions":{"post":["admin"]}}`, entity.Principal{UserID: 1, Role: "admin"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockCategoryUsecase.AssertExpectations(t)
+}
+
+func TestCategoryHandler_MergeCategory_Default(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCategoryHandler(mockCategoryUsecase, new(mocks.PostUsecase), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCategoryUsecase.On("MergeCategory", mock.Anything, 1, 2).Return(usecase.ErrDefaultCategory)
+
+	w := servePostRoute(handler.MergeCategory, http.MethodPost, "/categories/:id/merge", "/categories/1/merge",
+		`{"target_id":2}`, entity.Principal{UserID: 1, Role: "admin"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCategoryHandler_ArchiveCategory(t *testing.T) {
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCategoryHandler(mockCategoryUsecase, new(mocks.PostUsecase), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCategoryUsecase.On("SetArchived", mock.Anything, 3, true).Return(entity.Category{ID: 3, Archived: true}, nil)
+	mockCategoryUsecase.On("SetArchived", mock.Anything, 3, false).Return(entity.Category{ID: 3}, nil)
+
+	w := servePostRoute(handler.ArchiveCategory, http.MethodPost, "/categories/:id/archive", "/categories/3/archive", "", entity.Principal{UserID: 1})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"archived":true`)
+
+	w = servePostRoute(handler.UnarchiveCategory, http.MethodDelete, "/categories/:id/archive", "/categories/3/archive", "", entity.Principal{UserID: 1})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"archived":false`)
+}
+
+func TestPostHandler_CreatePost_CategoryForbidden(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewPostHandler(mockPostUsecase, mockCategoryUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	principal := entity.Principal{UserID: 1, Role: "user"}
+	mockCategoryUsecase.On("CheckAccess", mock.Anything, 2, &principal, entity.CategoryActionPost).Return(usecase.ErrCategoryForbidden)
+
+	w := servePostRoute(handler.CreatePost, http.MethodPost, "/posts", "/posts",
+		`{"title":"Новости","content":"Текст","category_id":2}`, principal)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_CreatePost_DefaultCategory(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewPostHandler(mockPostUsecase, mockCategoryUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	principal := entity.Principal{UserID: 1, Role: "user"}
+	post := entity.Post{AuthorId: 1, Title: "Привет", Content: "Текст", CategoryID: entity.DefaultCategoryID}
+	mockCategoryUsecase.On("CheckAccess", mock.Anything, entity.DefaultCategoryID, &principal, entity.CategoryActionPost).Return(nil)
+	mockPostUsecase.On("CreatePost", mock.Anything, post).Return(&post, nil)
+
+	w := servePostRoute(handler.CreatePost, http.MethodPost, "/posts", "/posts", `{"title":"Привет","content":"Текст"}`, principal)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPost_HiddenCategory(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewPostHandler(mockPostUsecase, mockCategoryUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostDetails", mock.Anything, 5).Return(&entity.PostDetails{Post: entity.Post{ID: 5, CategoryID: 4}}, nil)
+	mockCategoryUsecase.On("CheckAccess", mock.Anything, 4, (*entity.Principal)(nil), entity.CategoryActionRead).Return(usecase.ErrCategoryNotFound)
+
+	w := serveGuestRoute(handler.GetPost, http.MethodGet, "/posts/:id", "/posts/5")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":"Post not found"}`, w.Body.String())
+}
+
+func TestCommentHandler_CreateComment_ArchivedCategory(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCommentHandler(mockCommentUsecase, mockCategoryUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	principal := entity.Principal{UserID: 1, Role: "user"}
+	mockCategoryUsecase.On("CheckPostAccess", mock.Anything, 5, &principal, entity.CategoryActionComment).Return(usecase.ErrCategoryArchived)
+
+	w := servePostRoute(handler.CreateComment, http.MethodPost, "/posts/:id/comments", "/posts/5/comments", `{"content":"Привет"}`, principal)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockCommentUsecase.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+}
+
+func TestCommentHandler_GetCommentThreads_HiddenPost(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	handler := NewCommentHandler(mockCommentUsecase, mockCategoryUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCategoryUsecase.On("CheckPostAccess", mock.Anything, 5, (*entity.Principal)(nil), entity.CategoryActionRead).Return(usecase.ErrPostNotFound)
+
+	w := serveGuestRoute(handler.GetCommentThreads, http.MethodGet, "/posts/:id/comments/tree", "/posts/5/comments/tree")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockCommentUsecase.AssertNotCalled(t, "GetCommentThreads", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
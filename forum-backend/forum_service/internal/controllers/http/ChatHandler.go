@@ -1,59 +1,308 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
-	utils "github.com/miqxzz/commonmiqx"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"go.uber.org/zap"
 )
 
+// wsBearerProtocol — подпротокол, после которого клиент передает токен:
+// new WebSocket(url, ["bearer", token]).
+const wsBearerProtocol = "bearer"
+
+// wsModeAnonymous — явный режим подключения без токена, только для чтения.
+const wsModeAnonymous = "anonymous"
+
+const maxChatMessagesPageLimit = 100
+
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool { return true },
+	CheckOrigin:  func(r *http.Request) bool { return true },
+	Subprotocols: []string{wsBearerProtocol},
 }
 
 type ChatHandler struct {
-	hub         *chat.Hub
-	chatUsecase usecase.ChatUsecase
-	jwtUtil     *utils.JWTUtil
-	logger      *zap.Logger
+	hub          *chat.Hub
+	chatUsecase  usecase.ChatUsecase
+	roomUsecase  usecase.ChatRoomUsecase
+	trustUsecase usecase.TrustUsecase
+	auth         *AuthMiddleware
+	audit        *Auditor
+	logger       *zap.Logger
+	userClient   grpc.UserClientInterface
 }
 
-func NewChatHandler(hub *chat.Hub, chatUsecase usecase.ChatUsecase, jwtUtil *utils.JWTUtil, logger *zap.Logger) *ChatHandler {
+func NewChatHandler(hub *chat.Hub, chatUsecase usecase.ChatUsecase, roomUsecase usecase.ChatRoomUsecase, trustUsecase usecase.TrustUsecase, auth *AuthMiddleware, audit *Auditor, logger *zap.Logger, userClient grpc.UserClientInterface) *ChatHandler {
 	return &ChatHandler{
-		hub:         hub,
-		chatUsecase: chatUsecase,
-		jwtUtil:     jwtUtil,
-		logger:      logger,
+		hub:          hub,
+		chatUsecase:  chatUsecase,
+		roomUsecase:  roomUsecase,
+		trustUsecase: trustUsecase,
+		auth:         auth,
+		audit:        audit,
+		logger:       logger,
+		userClient:   userClient,
 	}
 }
 
+// ServeWS godoc
+// @Summary Подключение к чату
+// @Description Открывает WebSocket-соединение с чатом. Сразу после подключения клиент входит в общую комнату и получает ее историю. Клиент присылает JSON вида {"type": "join"|"leave"|"message", "room_id": 2, "content": "...", "since": 120}: join входит в комнату и присылает ее историю, leave выходит из нее, message пишет в комнату, в которую клиент вошел; без type сообщение считается message, без room_id — адресованным общей комнате. Переподключившийся клиент передает в since (параметром подключения для общей комнаты, полем join для остальных) ID последнего увиденного сообщения комнаты и вместо истории получает только пропущенные; если их больше 50, приходят последние 50, а в joined выставлен gap, и более ранние загружаются через GET /chat/messages. Сервер присылает события joined, left, message (с полями сообщения, в том числе id), message.updated (исправленное сообщение целиком), message.deleted (room_id и message_id удаленного сообщения) и error; сообщения комнаты получают только вошедшие в нее. Вошедший пользователь, кроме того, получает событие dm с новым личным сообщением в любой своей беседе. Токен передается в заголовке Authorization, подпротоколом ("bearer", <token>) или параметром token. Без токена подключение возможно только с mode=anonymous и только для чтения. Пользователи с уровнем доверия ниже нужного для чата и пользователи с действующим мутом, ограничением или блокировкой подключаются только для чтения.
+// @Tags chat
+// @Param token query string false "JWT токен"
+// @Param mode query string false "anonymous — подключение без токена только для чтения"
+// @Param since query int false "ID последнего увиденного сообщения общей комнаты"
+// @Success 101 "Switching Protocols"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /ws [get]
 func (h *ChatHandler) ServeWS(c *gin.Context) {
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		h.logger.Error("WebSocket upgrade failed", zap.Error(err))
+	var req entity.WSAuthRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid WebSocket query", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters"})
 		return
 	}
 
-	userID, _ := strconv.Atoi(c.Query("userID"))
-	username := c.Query("username")
-	isAuth := c.Query("auth") == "true"
-
 	client := &chat.Client{
-		Hub:             h.hub,
-		Conn:            conn,
-		Send:            make(chan []byte, 256),
-		UserID:          userID,
-		Username:        username,
-		IsAuthenticated: isAuth,
-		ChatUC:          h.chatUsecase,
+		Hub:     h.hub,
+		Send:    make(chan []byte, 256),
+		ChatUC:  h.chatUsecase,
+		RoomsUC: h.roomUsecase,
+	}
+
+	token := wsToken(c.Request, req.Token)
+	switch {
+	case token != "":
+		principal, err := h.auth.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			abortWithAuthError(c, err)
+			return
+		}
+		username, err := h.userClient.GetUsername(c.Request.Context(), principal.UserID)
+		if err != nil {
+			h.logger.Error("Failed to get username", zap.Int("userID", principal.UserID), zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+			return
+		}
+		client.UserID = principal.UserID
+		client.Username = username
+		client.Principal = &principal
+		client.IsAuthenticated = true
+		if sanction := principal.Sanctions.ChatBlock(); sanction != nil {
+			h.logger.Info("User is muted in chat, read-only connection", zap.Int("userID", principal.UserID), zap.String("sanction", sanction.Type))
+			client.ReadOnly = true
+		} else if err := h.trustUsecase.CheckChat(c.Request.Context(), principal); err != nil {
+			if !errors.Is(err, usecase.ErrTrustLevelTooLow) {
+				abortTrustError(c, h.logger, err, "Failed to check trust level")
+				return
+			}
+			h.logger.Info("Trust level too low for chat, read-only connection", zap.Int("userID", principal.UserID))
+			client.ReadOnly = true
+		}
+	case req.Mode == wsModeAnonymous:
+		h.logger.Info("Anonymous read-only WebSocket connection")
+	default:
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization token required"})
+		return
 	}
 
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("WebSocket upgrade failed", zap.Error(err))
+		return
+	}
+	client.Conn = conn
+
 	h.hub.Register <- client
+	client.JoinSince(entity.DefaultChatRoomID, req.Since)
 	go client.WritePump()
 	client.ReadPump()
 }
+
+// GetMessages godoc
+// @Summary История комнаты чата
+// @Description Возвращает сообщения комнаты от старых к новым: без before — последние, с before — более ранние. Следующая (более ранняя) страница запрашивается с before=next_before; без next_before это начало истории. Закрытая комната доступна только участникам, комната поста — тем, кто может читать пост
+// @Tags chat
+// @Produce json
+// @Param room_id query int false "ID комнаты; по умолчанию общая" default(1)
+// @Param before query int false "Курсор: сообщения с ID меньше before"
+// @Param limit query int false "Сообщений на странице (до 100)" default(50)
+// @Success 200 {object} map[string]interface{} "messages, next_before"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/messages [get]
+func (h *ChatHandler) GetMessages(c *gin.Context) {
+	roomID := entity.DefaultChatRoomID
+	if value := c.Query("room_id"); value != "" {
+		var err error
+		if roomID, err = strconv.Atoi(value); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+			return
+		}
+	}
+	before, _ := strconv.Atoi(c.Query("before"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > maxChatMessagesPageLimit {
+		limit = 50
+	}
+
+	if _, err := h.roomUsecase.GetRoom(c.Request.Context(), roomID, optionalPrincipal(c)); err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get chat room")
+		return
+	}
+	messages, next, err := h.chatUsecase.GetMessages(c.Request.Context(), roomID, before, limit)
+	if err != nil {
+		h.logger.Error("Failed to get chat messages", zap.Int("roomID", roomID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get messages"})
+		return
+	}
+	if messages == nil {
+		messages = []entity.ChatMessage{}
+	}
+
+	resp := gin.H{"messages": messages}
+	if next > 0 {
+		resp["next_before"] = next
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// EditMessage godoc
+// @Summary Исправить сообщение чата
+// @Description Заменяет текст своего сообщения. Исправить сообщение можно в течение окна правки после отправки (CHAT_EDIT_WINDOW). Вошедшие в комнату клиенты получают событие {"type": "message.updated", ...} с сообщением целиком
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID сообщения"
+// @Param message body entity.UpdateChatMessageRequest true "Новый текст"
+// @Success 200 {object} entity.ChatMessage
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse "Чужое сообщение, окно правки истекло или мут"
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/messages/{id} [put]
+func (h *ChatHandler) EditMessage(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := chatMessageID(c)
+	if !ok {
+		return
+	}
+	var req entity.UpdateChatMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	msg, err := h.chatUsecase.EditMessage(c.Request.Context(), id, req.Content, principal)
+	if err != nil {
+		abortChatMessageError(c, h.logger, err, "Failed to edit chat message")
+		return
+	}
+	h.broadcast(msg.RoomID, entity.NewChatMessageUpdatedEvent(msg))
+	c.JSON(http.StatusOK, msg)
+}
+
+// DeleteMessage godoc
+// @Summary Удалить сообщение чата
+// @Description Удаляет сообщение. Автор удаляет свое сообщение в течение окна правки, пользователь с правом chat.message.delete.any — любое. Вошедшие в комнату клиенты получают событие {"type": "message.deleted", "room_id": 1, "message_id": 42}
+// @Tags chat
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID сообщения"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/messages/{id} [delete]
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := chatMessageID(c)
+	if !ok {
+		return
+	}
+
+	msg, err := h.chatUsecase.DeleteMessage(c.Request.Context(), id, principal)
+	if err != nil {
+		abortChatMessageError(c, h.logger, err, "Failed to delete chat message")
+		return
+	}
+	if msg.UserID != principal.UserID {
+		h.audit.Record(c, entity.AuditChatMessageDelete, entity.AuditTargetChatMessage, id, msg, nil)
+	}
+	h.broadcast(msg.RoomID, entity.NewChatMessageDeletedEvent(msg.RoomID, id))
+	c.Status(http.StatusNoContent)
+}
+
+// broadcast отправляет событие всем, кто вошел в комнату roomID.
+func (h *ChatHandler) broadcast(roomID int, event entity.ChatEvent) {
+	if h.hub == nil {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		h.logger.Error("Failed to marshal chat event", zap.Error(err), zap.String("type", event.Type))
+		return
+	}
+	h.hub.Broadcast <- chat.RoomMessage{RoomID: roomID, Data: data}
+}
+
+func chatMessageID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid message ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func abortChatMessageError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrChatMessageNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrChatMessageForbidden),
+		errors.Is(err, usecase.ErrChatMessageEditExpired),
+		errors.Is(err, usecase.ErrChatMuted):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidChatMessage):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// wsToken достает токен из заголовка Authorization, подпротокола
+// ("bearer", <token>) или параметра token — в этом порядке.
+func wsToken(r *http.Request, queryToken string) string {
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	protocols := websocket.Subprotocols(r)
+	for i := 0; i+1 < len(protocols); i++ {
+		if protocols[i] == wsBearerProtocol {
+			return protocols[i+1]
+		}
+	}
+	return queryToken
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -9,8 +11,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
 
@@ -19,37 +24,56 @@ func TestChatHandler_ServeWS_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
 	jwtUtil := utils.NewJWTUtil("secret")
 	hub := chat.NewHub()
 
-	chatHandler := NewChatHandler(hub, mockChatUsecase, jwtUtil, logger)
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	token, err := jwtUtil.GenerateToken(1, "user")
 	assert.NoError(t, err)
 
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+	mockUserClient.On("GetUsername", mock.Anything, 1).Return("user", nil)
+
 	router := gin.Default()
 	router.GET("/ws/chat", chatHandler.ServeWS)
 
 	server := httptest.NewServer(router)
 	defer server.Close()
 
-	url := "ws" + server.URL[4:] + "/ws/chat?token=" + token + "&userID=1&username=user&auth=true"
+	url := "ws" + server.URL[4:] + "/ws/chat?token=" + token
 	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
 	assert.NoError(t, err)
 	defer ws.Close()
 
 	assert.NotNil(t, ws)
+	mockTokenRepo.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
 }
 
-func TestChatHandler_ServeWS_InvalidToken(t *testing.T) {
+func TestChatHandler_ServeWS_AuthorizationHeader(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
 	jwtUtil := utils.NewJWTUtil("secret")
 	hub := chat.NewHub()
 
-	chatHandler := NewChatHandler(hub, mockChatUsecase, jwtUtil, logger)
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+	mockUserClient.On("GetUsername", mock.Anything, 1).Return("user", nil)
 
 	router := gin.Default()
 	router.GET("/ws/chat", chatHandler.ServeWS)
@@ -57,37 +81,236 @@ func TestChatHandler_ServeWS_InvalidToken(t *testing.T) {
 	server := httptest.NewServer(router)
 	defer server.Close()
 
-	url := "ws" + server.URL[4:] + "/ws/chat?token=invalid_token&userID=1&username=user&auth=true"
-	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	url := "ws" + server.URL[4:] + "/ws/chat"
+	ws, _, err := websocket.DefaultDialer.Dial(url, header)
 	assert.NoError(t, err)
 	defer ws.Close()
 
 	assert.NotNil(t, ws)
+	mockTokenRepo.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
 }
 
-func TestChatHandler_ServeWS_InvalidUserID(t *testing.T) {
+func TestChatHandler_ServeWS_Subprotocol(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
 	jwtUtil := utils.NewJWTUtil("secret")
 	hub := chat.NewHub()
 
-	chatHandler := NewChatHandler(hub, mockChatUsecase, jwtUtil, logger)
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	token, err := jwtUtil.GenerateToken(1, "user")
 	assert.NoError(t, err)
 
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(false, nil)
+	mockPermissionRepo.On("GetRolePermissions", mock.Anything, "user").Return([]string{}, nil)
+	mockUserClient.On("GetUsername", mock.Anything, 1).Return("user", nil)
+
+	router := gin.Default()
+	router.GET("/ws/chat", chatHandler.ServeWS)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{"bearer", token}}
+	url := "ws" + server.URL[4:] + "/ws/chat"
+	ws, _, err := dialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer ws.Close()
+
+	assert.Equal(t, "bearer", ws.Subprotocol())
+	mockTokenRepo.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
+}
+
+func TestChatHandler_ServeWS_InvalidToken(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
+	jwtUtil := utils.NewJWTUtil("secret")
+	hub := chat.NewHub()
+
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	router := gin.Default()
+	router.GET("/ws/chat", chatHandler.ServeWS)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + server.URL[4:] + "/ws/chat?token=invalid_token&userID=1&username=user&auth=true"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestChatHandler_ServeWS_RevokedToken(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
+	jwtUtil := utils.NewJWTUtil("secret")
+	hub := chat.NewHub()
+
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(true, nil)
+
+	router := gin.Default()
+	router.GET("/ws/chat", chatHandler.ServeWS)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + server.URL[4:] + "/ws/chat?token=" + token
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	mockUserClient.AssertNotCalled(t, "GetUsername", mock.Anything, mock.Anything)
+}
+
+func TestChatHandler_ServeWS_MissingToken(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
+	jwtUtil := utils.NewJWTUtil("secret")
+	hub := chat.NewHub()
+
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
 	router := gin.Default()
 	router.GET("/ws/chat", chatHandler.ServeWS)
 
 	server := httptest.NewServer(router)
 	defer server.Close()
 
-	url := "ws" + server.URL[4:] + "/ws/chat?token=" + token + "&userID=invalid&username=user&auth=true"
+	url := "ws" + server.URL[4:] + "/ws/chat?userID=1&username=user&auth=true"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestChatHandler_ServeWS_Anonymous(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockChatUsecase := new(mocks.ChatUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	mockUserClient := new(mocks.UserClient)
+	jwtUtil := utils.NewJWTUtil("secret")
+	hub := chat.NewHub()
+
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	router := gin.Default()
+	router.GET("/ws/chat", chatHandler.ServeWS)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + server.URL[4:] + "/ws/chat?mode=anonymous"
 	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
 	assert.NoError(t, err)
 	defer ws.Close()
 
 	assert.NotNil(t, ws)
 }
+
+func TestChatHandler_GetMessages(t *testing.T) {
+	mockChatUsecase := new(mocks.ChatUsecase)
+	mockRoomUsecase := new(mocks.ChatRoomUsecase)
+	chatHandler := NewChatHandler(nil, mockChatUsecase, mockRoomUsecase, openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+	user := entity.Principal{UserID: 5, Role: "user"}
+
+	mockRoomUsecase.On("GetRoom", mock.Anything, 2, &user).Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate}, nil)
+	mockRoomUsecase.On("GetRoom", mock.Anything, 3, &user).Return(entity.ChatRoom{}, usecase.ErrChatRoomNotFound)
+	mockChatUsecase.On("GetMessages", mock.Anything, 2, 100, 2).
+		Return([]entity.ChatMessage{{ID: 60, RoomID: 2}, {ID: 70, RoomID: 2}}, 60, nil)
+
+	w := servePostRoute(chatHandler.GetMessages, http.MethodGet, "/chat/messages", "/chat/messages?room_id=2&before=100&limit=2", "", user)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Messages   []entity.ChatMessage `json:"messages"`
+		NextBefore int                  `json:"next_before"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []int{60, 70}, []int{resp.Messages[0].ID, resp.Messages[1].ID})
+	assert.Equal(t, 60, resp.NextBefore)
+
+	w = servePostRoute(chatHandler.GetMessages, http.MethodGet, "/chat/messages", "/chat/messages?room_id=3", "", user)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockChatUsecase.AssertNumberOfCalls(t, "GetMessages", 1)
+}
+
+func TestChatHandler_EditMessage(t *testing.T) {
+	hub := chat.NewHub()
+	mockChatUsecase := new(mocks.ChatUsecase)
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+	user := entity.Principal{UserID: 5, Role: "user"}
+
+	edited := entity.ChatMessage{ID: 42, RoomID: 2, UserID: 5, Content: "hello"}
+	mockChatUsecase.On("EditMessage", mock.Anything, 42, "hello", user).Return(edited, nil)
+	mockChatUsecase.On("EditMessage", mock.Anything, 43, "hello", user).Return(entity.ChatMessage{}, usecase.ErrChatMessageEditExpired)
+
+	w := servePostRoute(chatHandler.EditMessage, http.MethodPut, "/chat/messages/:id", "/chat/messages/42", `{"content":"hello"}`, user)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	update := <-hub.Broadcast
+	assert.Equal(t, 2, update.RoomID)
+	var event entity.ChatEvent
+	assert.NoError(t, json.Unmarshal(update.Data, &event))
+	assert.Equal(t, entity.ChatEventMessageUpdated, event.Type)
+	assert.Equal(t, 42, event.ID)
+	assert.Equal(t, "hello", event.Content)
+
+	w = servePostRoute(chatHandler.EditMessage, http.MethodPut, "/chat/messages/:id", "/chat/messages/43", `{"content":"hello"}`, user)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = servePostRoute(chatHandler.EditMessage, http.MethodPut, "/chat/messages/:id", "/chat/messages/42", `{}`, user)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, hub.Broadcast)
+}
+
+func TestChatHandler_DeleteMessage(t *testing.T) {
+	hub := chat.NewHub()
+	mockChatUsecase := new(mocks.ChatUsecase)
+	chatHandler := NewChatHandler(hub, mockChatUsecase, new(mocks.ChatRoomUsecase), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatMessageDeleteAny}}
+
+	mockChatUsecase.On("DeleteMessage", mock.Anything, 42, moderator).Return(entity.ChatMessage{ID: 42, RoomID: 2, UserID: 5}, nil)
+	mockChatUsecase.On("DeleteMessage", mock.Anything, 43, moderator).Return(entity.ChatMessage{}, usecase.ErrChatMessageNotFound)
+
+	w := servePostRoute(chatHandler.DeleteMessage, http.MethodDelete, "/chat/messages/:id", "/chat/messages/42", "", moderator)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	removal := <-hub.Broadcast
+	assert.Equal(t, 2, removal.RoomID)
+	assert.JSONEq(t, `{"type":"message.deleted","room_id":2,"message_id":42}`, string(removal.Data))
+
+	w = servePostRoute(chatHandler.DeleteMessage, http.MethodDelete, "/chat/messages/:id", "/chat/messages/43", "", moderator)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, hub.Broadcast)
+}
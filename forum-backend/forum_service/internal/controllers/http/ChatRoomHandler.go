@@ -0,0 +1,363 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type ChatRoomHandler struct {
+	roomUsecase usecase.ChatRoomUsecase
+	hub         *chat.Hub
+	auth        *AuthMiddleware
+	audit       *Auditor
+	logger      *zap.Logger
+	userClient  grpc.UserClientInterface
+}
+
+func NewChatRoomHandler(roomUsecase usecase.ChatRoomUsecase, hub *chat.Hub, auth *AuthMiddleware, audit *Auditor, logger *zap.Logger, userClient grpc.UserClientInterface) *ChatRoomHandler {
+	return &ChatRoomHandler{roomUsecase: roomUsecase, hub: hub, auth: auth, audit: audit, logger: logger, userClient: userClient}
+}
+
+func (h *ChatRoomHandler) Register(router *gin.Engine) {
+	router.GET("/chat/rooms", h.auth.OptionalAuth(), h.GetRooms)
+	router.GET("/chat/rooms/:id", h.auth.OptionalAuth(), h.GetRoom)
+	router.GET("/posts/:id/chat-room", h.auth.OptionalAuth(), h.GetPostRoom)
+
+	router.POST("/chat/rooms", h.auth.RequireAuth(), h.CreateRoom)
+	router.GET("/chat/rooms/:id/members", h.auth.RequireAuth(), h.GetMembers)
+	router.POST("/chat/rooms/:id/members", h.auth.RequireAuth(), h.AddMember)
+	router.DELETE("/chat/rooms/:id/members/:userID", h.auth.RequireAuth(), h.RemoveMember)
+	router.PUT("/chat/rooms/:id/retention", h.auth.RequireAuth(), h.SetRetention)
+}
+
+// GetRooms godoc
+// @Summary Комнаты чата
+// @Description Возвращает открытые комнаты и закрытые комнаты, в которых состоит пользователь. Комнаты постов не перечисляются: их возвращает /posts/{id}/chat-room
+// @Tags chat
+// @Produce json
+// @Success 200 {object} map[string]interface{} "rooms"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms [get]
+func (h *ChatRoomHandler) GetRooms(c *gin.Context) {
+	rooms, err := h.roomUsecase.GetRooms(c.Request.Context(), optionalPrincipal(c))
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get chat rooms")
+		return
+	}
+	if rooms == nil {
+		rooms = []entity.ChatRoom{}
+	}
+	c.JSON(http.StatusOK, gin.H{"rooms": rooms})
+}
+
+// GetRoom godoc
+// @Summary Комната чата
+// @Description Возвращает комнату, в которую может войти пользователь. Закрытая комната, в которой он не состоит, для него не существует
+// @Tags chat
+// @Produce json
+// @Param id path int true "ID комнаты"
+// @Success 200 {object} entity.ChatRoom
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms/{id} [get]
+func (h *ChatRoomHandler) GetRoom(c *gin.Context) {
+	roomID, ok := chatRoomID(c)
+	if !ok {
+		return
+	}
+	room, err := h.roomUsecase.GetRoom(c.Request.Context(), roomID, optionalPrincipal(c))
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get chat room")
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// GetPostRoom godoc
+// @Summary Комната обсуждения поста
+// @Description Возвращает комнату чата поста, создавая ее при первом обращении. Комната доступна всем, кто может читать пост
+// @Tags chat
+// @Produce json
+// @Param id path int true "ID поста"
+// @Success 200 {object} entity.ChatRoom
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/chat-room [get]
+func (h *ChatRoomHandler) GetPostRoom(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+	room, err := h.roomUsecase.GetPostRoom(c.Request.Context(), postID, optionalPrincipal(c))
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get post chat room")
+		return
+	}
+	c.JSON(http.StatusOK, room)
+}
+
+// CreateRoom godoc
+// @Summary Создать комнату
+// @Description Создает закрытую комнату, владельцем которой становится создатель, или открытую комнату — с правом chat.room.manage. Создание открытой комнаты пишется в журнал аудита
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param room body entity.CreateChatRoomRequest true "Комната"
+// @Success 201 {object} entity.ChatRoom
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms [post]
+func (h *ChatRoomHandler) CreateRoom(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	var req entity.CreateChatRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	room, err := h.roomUsecase.CreateRoom(c.Request.Context(), req, principal)
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to create chat room")
+		return
+	}
+	if room.Kind == entity.ChatRoomPublic {
+		h.audit.Record(c, entity.AuditChatRoomCreate, entity.AuditTargetChatRoom, room.ID, nil, room)
+	}
+	c.JSON(http.StatusCreated, room)
+}
+
+// GetMembers godoc
+// @Summary Участники комнаты
+// @Description Возвращает участников закрытой комнаты. Доступно участникам комнаты и пользователям с правом chat.room.manage
+// @Tags chat
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комнаты"
+// @Success 200 {object} map[string]interface{} "members"
+// @Failure 400 {object} entity.ErrorResponse "Комната не закрытая"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms/{id}/members [get]
+func (h *ChatRoomHandler) GetMembers(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	roomID, ok := chatRoomID(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.roomUsecase.GetMembers(c.Request.Context(), roomID, principal)
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get chat room members")
+		return
+	}
+	if members == nil {
+		members = []entity.ChatRoomMember{}
+	}
+
+	userIDs := make([]int, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	users, err := h.userClient.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", userIDs), zap.Error(err))
+	}
+	for i := range members {
+		members[i].Username = users[members[i].UserID].Username
+	}
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddMember godoc
+// @Summary Пригласить в комнату
+// @Description Добавляет пользователя в закрытую комнату. Приглашают владелец комнаты и пользователи с правом chat.room.manage
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комнаты"
+// @Param member body entity.AddChatRoomMemberRequest true "Пользователь"
+// @Success 201 {object} entity.ChatRoomMember
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Комната или пользователь не найдены"
+// @Failure 409 {object} entity.ErrorResponse "Пользователь уже в комнате"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms/{id}/members [post]
+func (h *ChatRoomHandler) AddMember(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	roomID, ok := chatRoomID(c)
+	if !ok {
+		return
+	}
+	var req entity.AddChatRoomMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, err := h.userClient.GetUsers(c.Request.Context(), []int{req.UserID})
+	if err != nil {
+		h.logger.Error("Failed to get user", zap.Int("userID", req.UserID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+	user, found := users[req.UserID]
+	if !found {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": usecase.ErrUserNotFound.Error()})
+		return
+	}
+
+	member, err := h.roomUsecase.AddMember(c.Request.Context(), roomID, req.UserID, principal)
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to add chat room member")
+		return
+	}
+	member.Username = user.Username
+	c.JSON(http.StatusCreated, member)
+}
+
+// RemoveMember godoc
+// @Summary Исключить из комнаты
+// @Description Исключает пользователя из закрытой комнаты; свой ID — выход из комнаты. Открытые соединения пользователя сразу выходят из комнаты. Исключать других может владелец комнаты, владельца — только пользователь с правом chat.room.manage. Исключение пользователем с этим правом пишется в журнал аудита
+// @Tags chat
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комнаты"
+// @Param userID path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms/{id}/members/{userID} [delete]
+func (h *ChatRoomHandler) RemoveMember(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	roomID, ok := chatRoomID(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.roomUsecase.RemoveMember(c.Request.Context(), roomID, userID, principal); err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to remove chat room member")
+		return
+	}
+	if userID != principal.UserID && principal.Can(entity.PermChatRoomManage) {
+		h.audit.Record(c, entity.AuditChatMemberRemove, entity.AuditTargetChatRoom, roomID, gin.H{"user_id": userID}, nil)
+	}
+	if h.hub != nil {
+		h.hub.Evict <- chat.Eviction{RoomID: roomID, UserID: userID, Reason: "removed from the room"}
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetRetention godoc
+// @Summary Срок хранения сообщений комнаты
+// @Description Задает, сколько секунд хранятся сообщения комнаты: null — срок по умолчанию, 0 — бессрочно. Сообщения старше срока удаляет фоновая очистка, при включенном архиве сначала выгружая их. Требует права chat.room.manage; изменение пишется в журнал аудита
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комнаты"
+// @Param retention body entity.SetChatRoomRetentionRequest true "Срок хранения"
+// @Success 200 {object} entity.ChatRoom
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /chat/rooms/{id}/retention [put]
+func (h *ChatRoomHandler) SetRetention(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	roomID, ok := chatRoomID(c)
+	if !ok {
+		return
+	}
+	var req entity.SetChatRoomRetentionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before, err := h.roomUsecase.GetRoom(c.Request.Context(), roomID, &principal)
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to get chat room")
+		return
+	}
+	room, err := h.roomUsecase.SetRetention(c.Request.Context(), roomID, req.RetentionSeconds, principal)
+	if err != nil {
+		abortChatRoomError(c, h.logger, err, "Failed to set chat room retention")
+		return
+	}
+	h.audit.Record(c, entity.AuditChatRoomRetention, entity.AuditTargetChatRoom, roomID, before, room)
+	c.JSON(http.StatusOK, room)
+}
+
+func chatRoomID(c *gin.Context) (int, bool) {
+	roomID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return 0, false
+	}
+	return roomID, true
+}
+
+func abortChatRoomError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrChatRoomNotFound),
+		errors.Is(err, usecase.ErrPostNotFound),
+		errors.Is(err, usecase.ErrNotChatRoomMember):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrChatRoomForbidden), errors.Is(err, usecase.ErrChatMuted):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAlreadyChatRoomMember):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrChatRoomNotPrivate),
+		errors.Is(err, usecase.ErrInvalidChatRoomName),
+		errors.Is(err, usecase.ErrInvalidChatRetention):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
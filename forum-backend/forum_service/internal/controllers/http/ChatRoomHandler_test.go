@@ -0,0 +1,197 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestChatRoomHandler(hub *chat.Hub) (*ChatRoomHandler, *mocks.ChatRoomUsecase, *mocks.UserClient) {
+	mockRoomUsecase := new(mocks.ChatRoomUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewChatRoomHandler(mockRoomUsecase, hub, nil, nil, zap.NewNop(), mockUserClient)
+	return handler, mockRoomUsecase, mockUserClient
+}
+
+func TestChatRoomHandler_CreateRoom(t *testing.T) {
+	handler, mockRoomUsecase, _ := newTestChatRoomHandler(nil)
+	user := entity.Principal{UserID: 5, Role: "user"}
+	ownerID := 5
+
+	mockRoomUsecase.On("CreateRoom", mock.Anything, entity.CreateChatRoomRequest{Name: "Друзья", Kind: entity.ChatRoomPrivate}, user).
+		Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate, Name: "Друзья", CreatedBy: &ownerID, MembersCount: 1}, nil)
+
+	w := servePostRoute(handler.CreateRoom, http.MethodPost, "/chat/rooms", "/chat/rooms",
+		`{"name":"Друзья","kind":"private"}`, user)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var room entity.ChatRoom
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &room))
+	assert.Equal(t, 2, room.ID)
+	assert.Equal(t, 1, room.MembersCount)
+}
+
+func TestChatRoomHandler_CreateRoom_InvalidKind(t *testing.T) {
+	handler, mockRoomUsecase, _ := newTestChatRoomHandler(nil)
+
+	w := servePostRoute(handler.CreateRoom, http.MethodPost, "/chat/rooms", "/chat/rooms",
+		`{"name":"Пост","kind":"post"}`, entity.Principal{UserID: 5, Role: "user"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRoomUsecase.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatRoomHandler_CreateRoom_PublicAudited(t *testing.T) {
+	mockRoomUsecase := new(mocks.ChatRoomUsecase)
+	mockAuditRepo := new(mocks.AuditRepository)
+	handler := NewChatRoomHandler(mockRoomUsecase, nil, nil, NewAuditor(mockAuditRepo, zap.NewNop()), zap.NewNop(), new(mocks.UserClient))
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}}
+
+	mockRoomUsecase.On("CreateRoom", mock.Anything, entity.CreateChatRoomRequest{Name: "Новости", Kind: entity.ChatRoomPublic}, moderator).
+		Return(entity.ChatRoom{ID: 3, Kind: entity.ChatRoomPublic, Name: "Новости"}, nil)
+	mockAuditRepo.On("Append", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == 7 && entry.Action == entity.AuditChatRoomCreate &&
+			entry.TargetType == entity.AuditTargetChatRoom && entry.TargetID == "3" &&
+			entry.Before == nil && bytes.Contains(entry.After, []byte(`"name":"Новости"`))
+	})).Return(nil)
+
+	w := servePostRoute(handler.CreateRoom, http.MethodPost, "/chat/rooms", "/chat/rooms",
+		`{"name":"Новости","kind":"public"}`, moderator)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestChatRoomHandler_GetMembers(t *testing.T) {
+	handler, mockRoomUsecase, mockUserClient := newTestChatRoomHandler(nil)
+	user := entity.Principal{UserID: 5, Role: "user"}
+
+	mockRoomUsecase.On("GetMembers", mock.Anything, 2, user).Return([]entity.ChatRoomMember{
+		{RoomID: 2, UserID: 5, Role: entity.ChatRoleOwner},
+		{RoomID: 2, UserID: 6, Role: entity.ChatRoleMember},
+	}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{5, 6}).
+		Return(map[int]entity.UserInfo{5: {ID: 5, Username: "owner"}, 6: {ID: 6, Username: "friend"}}, nil)
+
+	w := servePostRoute(handler.GetMembers, http.MethodGet, "/chat/rooms/:id/members", "/chat/rooms/2/members", "", user)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Members []entity.ChatRoomMember `json:"members"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Members, 2)
+	assert.Equal(t, "friend", resp.Members[1].Username)
+}
+
+func TestChatRoomHandler_AddMember_UnknownUser(t *testing.T) {
+	handler, mockRoomUsecase, mockUserClient := newTestChatRoomHandler(nil)
+
+	mockUserClient.On("GetUsers", mock.Anything, []int{42}).Return(map[int]entity.UserInfo{}, nil)
+
+	w := servePostRoute(handler.AddMember, http.MethodPost, "/chat/rooms/:id/members", "/chat/rooms/2/members",
+		`{"user_id":42}`, entity.Principal{UserID: 5, Role: "user"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockRoomUsecase.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChatRoomHandler_RemoveMember_EvictsConnections(t *testing.T) {
+	hub := chat.NewHub()
+	handler, mockRoomUsecase, _ := newTestChatRoomHandler(hub)
+	owner := entity.Principal{UserID: 5, Role: "user"}
+
+	mockRoomUsecase.On("RemoveMember", mock.Anything, 2, 6, owner).Return(nil)
+
+	w := servePostRoute(handler.RemoveMember, http.MethodDelete, "/chat/rooms/:id/members/:userID", "/chat/rooms/2/members/6", "", owner)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	eviction := <-hub.Evict
+	assert.Equal(t, 2, eviction.RoomID)
+	assert.Equal(t, 6, eviction.UserID)
+}
+
+func TestChatRoomHandler_RemoveMember_ModeratorAudited(t *testing.T) {
+	mockRoomUsecase := new(mocks.ChatRoomUsecase)
+	mockAuditRepo := new(mocks.AuditRepository)
+	handler := NewChatRoomHandler(mockRoomUsecase, nil, nil, NewAuditor(mockAuditRepo, zap.NewNop()), zap.NewNop(), new(mocks.UserClient))
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}}
+
+	mockRoomUsecase.On("RemoveMember", mock.Anything, 2, 6, moderator).Return(nil)
+	mockAuditRepo.On("Append", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == 7 && entry.Action == entity.AuditChatMemberRemove &&
+			entry.TargetType == entity.AuditTargetChatRoom && entry.TargetID == "2" &&
+			string(entry.Before) == `{"user_id":6}` && entry.After == nil
+	})).Return(nil)
+
+	w := servePostRoute(handler.RemoveMember, http.MethodDelete, "/chat/rooms/:id/members/:userID", "/chat/rooms/2/members/6", "", moderator)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockAuditRepo.AssertExpectations(t)
+
+	// Выход модератора из комнаты — не привилегированное действие
+	mockRoomUsecase.On("RemoveMember", mock.Anything, 2, 7, moderator).Return(nil)
+	w = servePostRoute(handler.RemoveMember, http.MethodDelete, "/chat/rooms/:id/members/:userID", "/chat/rooms/2/members/7", "", moderator)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockAuditRepo.AssertNumberOfCalls(t, "Append", 1)
+}
+
+func TestChatRoomHandler_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrChatRoomNotFound, http.StatusNotFound},
+		{usecase.ErrChatRoomForbidden, http.StatusForbidden},
+		{usecase.ErrChatRoomNotPrivate, http.StatusBadRequest},
+		{usecase.ErrNotChatRoomMember, http.StatusNotFound},
+		{errors.New("database is locked"), http.StatusInternalServerError},
+	} {
+		handler, mockRoomUsecase, _ := newTestChatRoomHandler(nil)
+		mockRoomUsecase.On("RemoveMember", mock.Anything, 2, 6, mock.Anything).Return(tc.err)
+
+		w := servePostRoute(handler.RemoveMember, http.MethodDelete, "/chat/rooms/:id/members/:userID", "/chat/rooms/2/members/6", "",
+			entity.Principal{UserID: 5, Role: "user"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestChatRoomHandler_SetRetention(t *testing.T) {
+	mockRoomUsecase := new(mocks.ChatRoomUsecase)
+	mockAuditRepo := new(mocks.AuditRepository)
+	handler := NewChatRoomHandler(mockRoomUsecase, nil, nil, NewAuditor(mockAuditRepo, zap.NewNop()), zap.NewNop(), new(mocks.UserClient))
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}}
+	week := 604800
+
+	mockRoomUsecase.On("GetRoom", mock.Anything, 2, &moderator).
+		Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPublic, RetentionSeconds: &week}, nil)
+	mockRoomUsecase.On("SetRetention", mock.Anything, 2, (*int)(nil), moderator).
+		Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPublic}, nil)
+	mockAuditRepo.On("Append", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == 7 && entry.Action == entity.AuditChatRoomRetention &&
+			entry.TargetType == entity.AuditTargetChatRoom && entry.TargetID == "2" &&
+			bytes.Contains(entry.Before, []byte(`"retention_seconds":604800`)) && !bytes.Contains(entry.After, []byte(`retention_seconds`))
+	})).Return(nil)
+
+	w := servePostRoute(handler.SetRetention, http.MethodPut, "/chat/rooms/:id/retention", "/chat/rooms/2/retention",
+		`{"retention_seconds":null}`, moderator)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = servePostRoute(handler.SetRetention, http.MethodPut, "/chat/rooms/:id/retention", "/chat/rooms/2/retention",
+		`{"retention_seconds":-5}`, moderator)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockRoomUsecase.AssertNumberOfCalls(t, "SetRetention", 1)
+	mockAuditRepo.AssertNumberOfCalls(t, "Append", 1)
+}
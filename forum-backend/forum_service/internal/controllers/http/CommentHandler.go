@@ -1,12 +1,11 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	utils "github.com/miqxzz/commonmiqx"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
@@ -14,25 +13,32 @@ import (
 )
 
 type CommentHandler struct {
-	commentUsecase usecase.CommentsUsecases
-	jwtUtil        *utils.JWTUtil
-	logger         *zap.Logger
-	userClient     grpc.UserClientInterface
+	commentUsecase  usecase.CommentsUsecases
+	categoryUsecase usecase.CategoryUsecase
+	trustUsecase    usecase.TrustUsecase
+	auth            *AuthMiddleware
+	audit           *Auditor
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
 }
 
-func NewCommentHandler(commentUsecase usecase.CommentsUsecases, jwtUtil *utils.JWTUtil, logger *zap.Logger, userClient grpc.UserClientInterface) *CommentHandler {
-	return &CommentHandler{commentUsecase: commentUsecase, jwtUtil: jwtUtil, logger: logger, userClient: userClient}
+func NewCommentHandler(commentUsecase usecase.CommentsUsecases, categoryUsecase usecase.CategoryUsecase, trustUsecase usecase.TrustUsecase, auth *AuthMiddleware, audit *Auditor, logger *zap.Logger, userClient grpc.UserClientInterface) *CommentHandler {
+	return &CommentHandler{commentUsecase: commentUsecase, categoryUsecase: categoryUsecase, trustUsecase: trustUsecase, auth: auth, audit: audit, logger: logger, userClient: userClient}
 }
 
 func (h *CommentHandler) Register(router *gin.Engine) {
-	router.POST("/posts/:id/comments", h.CreateComment)
-	router.GET("/posts/:id/comments", h.GetComments)
-	router.DELETE("/comments/:id", h.DeleteComment)
+	router.POST("/posts/:id/comments", h.auth.RequireAuth(), h.CreateComment)
+	router.GET("/posts/:id/comments", h.auth.OptionalAuth(), h.GetComments)
+	router.GET("/posts/:id/comments/tree", h.auth.OptionalAuth(), h.GetCommentThreads)
+	router.PUT("/comments/:id", h.auth.RequireAuth(), h.UpdateComment)
+	router.DELETE("/comments/:id", h.auth.RequireAuth(), h.DeleteComment)
+	router.GET("/comments/:id/revisions", h.auth.OptionalAuth(), h.GetCommentRevisions)
+	router.GET("/comments/:id/revisions/diff", h.auth.OptionalAuth(), h.DiffCommentRevisions)
 }
 
 // CreateComment godoc
 // @Summary Создать новый комментарий
-// @Description Создает новый комментарий к указанному посту
+// @Description Создает новый комментарий к указанному посту. Чтобы ответить на комментарий, передайте его id в parent_id. Комментировать может только роль, которой это разрешено в разделе поста. Ссылки и изображения доступны с уровня доверия basic
 // @Tags Комментарии
 // @Accept json
 // @Produce json
@@ -42,9 +48,17 @@ func (h *CommentHandler) Register(router *gin.Engine) {
 // @Success 201 {object} entity.Comment
 // @Failure 400 {object} entity.ErrorResponse
 // @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Раздел в архиве"
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /posts/{id}/comments [post]
 func (h *CommentHandler) CreateComment(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+
 	postIDStr := c.Param("id")
 	postID, err := strconv.Atoi(postIDStr)
 	if err != nil {
@@ -60,38 +74,32 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 		return
 	}
 
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		h.logger.Error("Authorization header required")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-		return
-	}
+	comment.PostId = postID
+	comment.AuthorId = principal.UserID
+	comment.Deleted = false
 
-	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-	if tokenString == authHeader {
-		h.logger.Error("Invalid Authorization header format")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+	err = h.categoryUsecase.CheckPostAccess(c.Request.Context(), postID, &principal, entity.CategoryActionComment)
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to check category access")
 		return
 	}
-
-	userID, err := h.jwtUtil.GetUserIDFromToken(tokenString)
-	if err != nil {
-		h.logger.Error("Invalid token or user ID", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user ID"})
+	if err := h.trustUsecase.CheckContent(c.Request.Context(), principal, comment.Content); err != nil {
+		abortTrustError(c, h.logger, err, "Failed to check trust level")
 		return
 	}
 
-	comment.PostId = postID
-	comment.AuthorId = userID
-
 	createdComment, err := h.commentUsecase.CreateComment(c.Request.Context(), comment)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidParentComment) || errors.Is(err, usecase.ErrParentCommentDeleted) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to create comment", zap.Error(err))
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	h.logger.Info("Comment created successfully", zap.Int("postID", postID), zap.Int("userID", userID))
+	h.logger.Info("Comment created successfully", zap.Int("postID", postID), zap.Int("userID", principal.UserID))
 	c.JSON(http.StatusCreated, createdComment)
 }
 
@@ -113,6 +121,10 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 		return
 	}
 
+	if !h.checkPostRead(c, postID) {
+		return
+	}
+
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset := (page - 1) * limit
@@ -128,22 +140,40 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	// Имена авторов и редакторов получаем одним запросом; если не удалось, отдаем пустые
+	authorIDs := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.Deleted {
+			authorIDs = append(authorIDs, comment.AuthorId)
+			if comment.EditedBy != nil {
+				authorIDs = append(authorIDs, *comment.EditedBy)
+			}
+		}
+	}
+	authors, err := h.userClient.GetUsers(c.Request.Context(), authorIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", authorIDs), zap.Error(err))
+	}
+
 	commentsWithUsernames := make([]map[string]interface{}, len(comments))
 	for i, comment := range comments {
-		username, err := h.userClient.GetUsername(c.Request.Context(), comment.AuthorId)
-		if err != nil {
-			h.logger.Warn("Failed to get username",
-				zap.Int("userID", comment.AuthorId),
-				zap.Error(err))
-			username = "" // Используем пустое имя, если не удалось получить
+		username := authors[comment.AuthorId].Username
+		var editorUsername string
+		if comment.EditedBy != nil {
+			editorUsername = authors[*comment.EditedBy].Username
 		}
 
 		commentsWithUsernames[i] = map[string]interface{}{
-			"id":        comment.ID,
-			"author_id": comment.AuthorId,
-			"post_id":   comment.PostId,
-			"content":   comment.Content,
-			"username":  username, // Добавляем имя пользователя
+			"id":                 comment.ID,
+			"author_id":          comment.AuthorId,
+			"post_id":            comment.PostId,
+			"parent_id":          comment.ParentId,
+			"content":            comment.Content,
+			"deleted":            comment.Deleted,
+			"username":           username, // Добавляем имя пользователя
+			"updated_at":         comment.UpdatedAt,
+			"edited_by":          comment.EditedBy,
+			"edited_by_username": editorUsername,
 		}
 	}
 
@@ -157,9 +187,92 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 	})
 }
 
+// GetCommentThreads godoc
+// @Summary Получить дерево комментариев
+// @Description Возвращает ветки обсуждения поста: корневые комментарии постранично и ответы на них до глубины max_depth. С format=flat ветки возвращаются плоским списком с depth и path. Удаленные комментарии с ответами отображаются как "[deleted]"
+// @Tags Комментарии
+// @Produce json
+// @Param id path int true "ID поста"
+// @Param page query int false "Номер страницы веток" default(1)
+// @Param limit query int false "Веток на странице" default(10)
+// @Param max_depth query int false "Максимальная глубина ответов" default(5)
+// @Param format query string false "tree или flat" default(tree)
+// @Success 200 {object} map[string]interface{} "threads (или comments) и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/comments/tree [get]
+func (h *CommentHandler) GetCommentThreads(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	maxDepth, err := strconv.Atoi(c.DefaultQuery("max_depth", strconv.Itoa(usecase.DefaultCommentDepth)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid max_depth"})
+		return
+	}
+	format := c.DefaultQuery("format", "tree")
+	if format != "tree" && format != "flat" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be tree or flat"})
+		return
+	}
+	if !h.checkPostRead(c, postID) {
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	threads, err := h.commentUsecase.GetCommentThreads(c.Request.Context(), postID, limit, offset, maxDepth)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	total, err := h.commentUsecase.GetTotalThreadsCount(c.Request.Context(), postID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var authorIDs []int
+	usecase.WalkCommentTree(threads, func(node *entity.CommentNode) {
+		if !node.Deleted {
+			authorIDs = append(authorIDs, node.AuthorId)
+		}
+	})
+	authors, err := h.userClient.GetUsers(c.Request.Context(), authorIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", authorIDs), zap.Error(err))
+	}
+	usecase.WalkCommentTree(threads, func(node *entity.CommentNode) {
+		if !node.Deleted {
+			node.Username = authors[node.AuthorId].Username
+		}
+	})
+
+	pagination := gin.H{
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	}
+	if format == "flat" {
+		c.JSON(http.StatusOK, gin.H{"comments": usecase.FlattenCommentTree(threads), "pagination": pagination})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"threads": threads, "pagination": pagination})
+}
+
 // DeleteComment godoc
 // @Summary Удалить комментарий
-// @Description Удаляет комментарий по ID (доступно автору или администратору)
+// @Description Переносит комментарий в корзину (доступно автору или с правом comment.delete.any). Если на комментарий есть ответы, вместо него показывается заглушка
 // @Tags Комментарии
 // @Accept json
 // @Produce json
@@ -170,20 +283,72 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 // @Failure 401 {object} entity.ErrorResponse
 // @Failure 403 {object} entity.ErrorResponse
 // @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Комментарий уже удален"
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /comments/{id} [delete]
 func (h *CommentHandler) DeleteComment(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		h.logger.Warn("Authorization header required")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+
+	commentIDStr := c.Param("id")
+	commentID, err := strconv.Atoi(commentIDStr)
+	if err != nil {
+		h.logger.Warn("Invalid comment ID", zap.String("commentID", commentIDStr), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	comment, err := h.commentUsecase.GetCommentByID(c.Request.Context(), commentID)
+	if err != nil {
+		h.abortCommentError(c, commentID, err, "Failed to get comment")
+		return
+	}
+
+	if comment.AuthorId != principal.UserID && !principal.Can(entity.PermCommentDeleteAny) {
+		h.logger.Warn("Unauthorized attempt to delete comment",
+			zap.Int("userID", principal.UserID),
+			zap.Int("commentAuthorID", comment.AuthorId),
+			zap.Int("commentID", commentID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this comment"})
+		return
+	}
+
+	h.logger.Info("Deleting comment", zap.Int("commentID", commentID))
+	err = h.commentUsecase.DeleteComment(c.Request.Context(), commentID, principal.UserID)
+	if err != nil {
+		h.abortCommentError(c, commentID, err, "Failed to delete comment")
 		return
 	}
 
-	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-	if tokenString == authHeader {
-		h.logger.Warn("Invalid Authorization header format", zap.String("header", authHeader))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+	if comment.AuthorId != principal.UserID {
+		h.audit.Record(c, entity.AuditCommentDelete, entity.AuditTargetComment, commentID, comment, nil)
+	}
+	h.logger.Info("Comment deleted successfully", zap.Int("commentID", commentID))
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateComment godoc
+// @Summary Редактировать комментарий
+// @Description Заменяет текст комментария (доступно автору или с правом comment.update.any). Прежний текст сохраняется в истории версий. Ссылки и изображения доступны с уровня доверия basic
+// @Tags Комментарии
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комментария"
+// @Param comment body entity.UpdateCommentRequest true "Новый текст"
+// @Success 200 {object} entity.Comment
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
 		return
 	}
 
@@ -195,46 +360,182 @@ func (h *CommentHandler) DeleteComment(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.jwtUtil.GetUserIDFromToken(tokenString)
+	var req entity.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Failed to bind JSON", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before, err := h.commentUsecase.GetCommentByID(c.Request.Context(), commentID)
 	if err != nil {
-		h.logger.Warn("Invalid token or user ID", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user ID"})
+		h.abortCommentError(c, commentID, err, "Failed to get comment")
+		return
+	}
+
+	if before.AuthorId != principal.UserID && !principal.Can(entity.PermCommentUpdateAny) {
+		h.logger.Warn("Unauthorized attempt to update comment",
+			zap.Int("userID", principal.UserID),
+			zap.Int("commentAuthorID", before.AuthorId),
+			zap.Int("commentID", commentID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update this comment"})
+		return
+	}
+	if err := h.trustUsecase.CheckContent(c.Request.Context(), principal, req.Content); err != nil {
+		abortTrustError(c, h.logger, err, "Failed to check trust level")
 		return
 	}
 
-	userRole, err := h.jwtUtil.GetRoleFromToken(tokenString)
+	updated, err := h.commentUsecase.UpdateComment(c.Request.Context(), commentID, req.Content, principal.UserID)
 	if err != nil {
-		h.logger.Warn("Invalid token or user role", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user role"})
+		h.abortCommentError(c, commentID, err, "Failed to update comment")
 		return
 	}
 
-	if userRole != "admin" {
-		comment, err := h.commentUsecase.GetCommentByID(c.Request.Context(), commentID)
-		if err != nil {
-			h.logger.Error("Failed to get comment", zap.Int("commentID", commentID), zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get comment"})
-			return
-		}
+	if before.AuthorId != principal.UserID {
+		h.audit.Record(c, entity.AuditCommentUpdate, entity.AuditTargetComment, commentID, before, updated)
+	}
+	h.logger.Info("Comment updated successfully", zap.Int("commentID", commentID), zap.Int("userID", principal.UserID))
+	c.JSON(http.StatusOK, updated)
+}
 
-		if comment.AuthorId != userID {
-			h.logger.Warn("Unauthorized attempt to delete comment",
-				zap.Int("userID", userID),
-				zap.Int("commentAuthorID", comment.AuthorId),
-				zap.Int("commentID", commentID))
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this comment"})
-			return
-		}
+// GetCommentRevisions godoc
+// @Summary История версий комментария
+// @Description Возвращает все версии комментария от первой до текущей. Текущая версия идет последней и помечена current
+// @Tags Комментарии
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Success 200 {object} map[string]interface{} "revisions"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id}/revisions [get]
+func (h *CommentHandler) GetCommentRevisions(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	if !h.checkCommentRead(c, commentID) {
+		return
 	}
 
-	h.logger.Info("Deleting comment", zap.Int("commentID", commentID))
-	err = h.commentUsecase.DeleteComment(c.Request.Context(), commentID)
+	revisions, err := h.commentUsecase.GetCommentRevisions(c.Request.Context(), commentID)
 	if err != nil {
-		h.logger.Error("Failed to delete comment", zap.Int("commentID", commentID), zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment"})
+		h.abortCommentError(c, commentID, err, "Failed to get comment revisions")
 		return
 	}
 
-	h.logger.Info("Comment deleted successfully", zap.Int("commentID", commentID))
-	c.Status(http.StatusNoContent)
+	editorIDs := make([]int, len(revisions))
+	for i, rev := range revisions {
+		editorIDs[i] = rev.EditorID
+	}
+	editors, err := h.userClient.GetUsers(c.Request.Context(), editorIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", editorIDs), zap.Error(err))
+	}
+	for i := range revisions {
+		revisions[i].EditorUsername = editors[revisions[i].EditorID].Username
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// DiffCommentRevisions godoc
+// @Summary Сравнить версии комментария
+// @Description Возвращает построчную разницу между версиями from и to. По умолчанию to — текущая версия, from — предыдущая перед to
+// @Tags Комментарии
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Param from query int false "Номер исходной версии"
+// @Param to query int false "Номер итоговой версии"
+// @Success 200 {object} entity.RevisionDiff
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id}/revisions/diff [get]
+func (h *CommentHandler) DiffCommentRevisions(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	from, to, ok := revisionRange(c)
+	if !ok || !h.checkCommentRead(c, commentID) {
+		return
+	}
+
+	diff, err := h.commentUsecase.DiffCommentRevisions(c.Request.Context(), commentID, from, to)
+	if err != nil {
+		h.abortCommentError(c, commentID, err, "Failed to diff comment revisions")
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// revisionRange читает необязательные параметры from и to. Отсутствующий
+// параметр возвращается как 0.
+func revisionRange(c *gin.Context) (from, to int, ok bool) {
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"from", &from}, {"to", &to}} {
+		raw := c.Query(p.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid " + p.name + " version"})
+			return 0, 0, false
+		}
+		*p.dst = v
+	}
+	return from, to, true
+}
+
+// checkPostRead отвечает 404, если пост находится в разделе, который
+// пользователь не может читать.
+func (h *CommentHandler) checkPostRead(c *gin.Context, postID int) bool {
+	err := h.categoryUsecase.CheckPostAccess(c.Request.Context(), postID, optionalPrincipal(c), entity.CategoryActionRead)
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to check category access")
+		return false
+	}
+	return true
+}
+
+// checkCommentRead — то же для поста, к которому оставлен комментарий.
+func (h *CommentHandler) checkCommentRead(c *gin.Context, commentID int) bool {
+	comment, err := h.commentUsecase.GetCommentByID(c.Request.Context(), commentID)
+	if err != nil {
+		h.abortCommentError(c, commentID, err, "Failed to get comment")
+		return false
+	}
+	err = h.categoryUsecase.CheckPostAccess(c.Request.Context(), comment.PostId, optionalPrincipal(c), entity.CategoryActionRead)
+	if errors.Is(err, usecase.ErrPostNotFound) {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": usecase.ErrCommentNotFound.Error()})
+		return false
+	}
+	if err != nil {
+		abortCategoryError(c, h.logger, err, "Failed to check category access")
+		return false
+	}
+	return true
+}
+
+func (h *CommentHandler) abortCommentError(c *gin.Context, commentID int, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrCommentNotFound), errors.Is(err, usecase.ErrRevisionNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCommentDeleted):
+		if c.Request.Method == http.MethodGet {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Int("commentID", commentID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
 }
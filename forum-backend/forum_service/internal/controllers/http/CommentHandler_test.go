@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	utils "github.com/miqxzz/commonmiqx"
 
 	"github.com/gin-gonic/gin"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -23,13 +25,12 @@ func TestCommentHandler_CreateComment_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
-
-	token, err := jwtUtil.GenerateToken(1, "user")
-	assert.NoError(t, err)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -40,18 +41,18 @@ func TestCommentHandler_CreateComment_Success(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "/posts/1/comments", bytes.NewBuffer(commentJSON))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
 
 	commentHandler.CreateComment(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	var responseComment entity.Comment
-	err = json.Unmarshal(w.Body.Bytes(), &responseComment)
+	err := json.Unmarshal(w.Body.Bytes(), &responseComment)
 	assert.NoError(t, err)
 	assert.Equal(t, comment, responseComment)
 
@@ -63,13 +64,12 @@ func TestCommentHandler_CreateComment_InvalidPostID(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
-
-	token, err := jwtUtil.GenerateToken(1, "user")
-	assert.NoError(t, err)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -78,11 +78,11 @@ func TestCommentHandler_CreateComment_InvalidPostID(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "/posts/invalid/comments", bytes.NewBuffer(commentJSON))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "invalid"}}
 
 	commentHandler.CreateComment(c)
@@ -96,10 +96,12 @@ func TestCommentHandler_CreateComment_MissingAuthorizationHeader(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -110,11 +112,9 @@ func TestCommentHandler_CreateComment_MissingAuthorizationHeader(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-
-	commentHandler.CreateComment(c)
+	router := gin.New()
+	commentHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Authorization header required")
@@ -125,10 +125,12 @@ func TestCommentHandler_CreateComment_InvalidAuthorizationHeaderFormat(t *testin
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -140,11 +142,9 @@ func TestCommentHandler_CreateComment_InvalidAuthorizationHeaderFormat(t *testin
 	req.Header.Set("Authorization", "InvalidFormat")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-
-	commentHandler.CreateComment(c)
+	router := gin.New()
+	commentHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Invalid Authorization header format")
@@ -155,10 +155,12 @@ func TestCommentHandler_CreateComment_InvalidToken(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -170,14 +172,12 @@ func TestCommentHandler_CreateComment_InvalidToken(t *testing.T) {
 	req.Header.Set("Authorization", "Bearer invalid.jwt.token")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-
-	commentHandler.CreateComment(c)
+	router := gin.New()
+	commentHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
-	assert.Contains(t, w.Body.String(), "Invalid token or user ID")
+	assert.Contains(t, w.Body.String(), "Invalid token")
 }
 
 func TestCommentHandler_CreateComment_FailedToCreateComment(t *testing.T) {
@@ -185,13 +185,12 @@ func TestCommentHandler_CreateComment_FailedToCreateComment(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
-
-	token, err := jwtUtil.GenerateToken(1, "user")
-	assert.NoError(t, err)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comment := entity.Comment{
 		Content: "This is a test comment",
@@ -202,11 +201,11 @@ func TestCommentHandler_CreateComment_FailedToCreateComment(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "/posts/1/comments", bytes.NewBuffer(commentJSON))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
 
 	commentHandler.CreateComment(c)
@@ -222,10 +221,12 @@ func TestCommentHandler_GetCommentsByPostID_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	comments := []entity.Comment{
 		{ID: 1, PostId: 1, Content: "Comment 1"},
@@ -258,10 +259,12 @@ func TestCommentHandler_GetCommentsByPostID_InvalidPostID(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	req, _ := http.NewRequest("GET", "/posts/invalid/comments", nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -282,10 +285,12 @@ func TestCommentHandler_GetCommentsByPostID_FailedToGetComments(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := new(mocks.UserClient)
 
-	commentHandler := NewCommentHandler(mockCommentUsecase, jwtUtil, logger, mockUserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	mockCommentUsecase.On("GetCommentByPostID", mock.Anything, 1).Return(nil, errors.New("failed to get comments"))
 
@@ -304,3 +309,351 @@ func TestCommentHandler_GetCommentsByPostID_FailedToGetComments(t *testing.T) {
 
 	mockCommentUsecase.AssertExpectations(t)
 }
+
+func TestCommentHandler_GetComments_BatchesUsernames(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockUserClient := new(mocks.UserClient)
+
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	comments := []entity.Comment{
+		{ID: 1, PostId: 1, AuthorId: 4, Content: "Comment 1"},
+		{ID: 2, PostId: 1, AuthorId: 5, Content: "Comment 2"},
+	}
+	mockCommentUsecase.On("GetComments", mock.Anything, 1, 10, 0).Return(comments, nil)
+	mockCommentUsecase.On("GetTotalCommentsCount", mock.Anything, 1).Return(2, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{4, 5}).Return(map[int]entity.UserInfo{
+		4: {ID: 4, Username: "carol"},
+	}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/posts/1/comments", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetComments(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Comments, 2)
+	assert.Equal(t, "carol", resp.Comments[0]["username"])
+	assert.Equal(t, "", resp.Comments[1]["username"])
+
+	mockUserClient.AssertNumberOfCalls(t, "GetUsers", 1)
+	mockUserClient.AssertNotCalled(t, "GetUsername", mock.Anything, mock.Anything)
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func TestCommentHandler_CreateComment_InvalidParent(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, logger, new(mocks.UserClient))
+
+	parentID := 99
+	commentJSON, _ := json.Marshal(entity.Comment{Content: "reply", ParentId: &parentID})
+
+	mockCommentUsecase.On("CreateComment", mock.Anything, mock.MatchedBy(func(comment entity.Comment) bool {
+		return comment.ParentId != nil && *comment.ParentId == parentID && comment.PostId == 1
+	})).Return(entity.Comment{}, usecase.ErrInvalidParentComment)
+
+	req, _ := http.NewRequest("POST", "/posts/1/comments", bytes.NewBuffer(commentJSON))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.CreateComment(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), usecase.ErrInvalidParentComment.Error())
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func commentThreadsFixture() []*entity.CommentNode {
+	parentID := 1
+	return usecase.BuildCommentTree([]entity.CommentNode{
+		{Comment: entity.Comment{ID: 1, PostId: 1, AuthorId: 4, Content: entity.DeletedCommentContent, Deleted: true}, RepliesCount: 1},
+		{Comment: entity.Comment{ID: 2, PostId: 1, AuthorId: 5, ParentId: &parentID, Content: "reply"}, Depth: 1},
+	})
+}
+
+func TestCommentHandler_GetCommentThreads_Tree(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockUserClient := new(mocks.UserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	mockCommentUsecase.On("GetCommentThreads", mock.Anything, 1, 10, 0, 3).Return(commentThreadsFixture(), nil)
+	mockCommentUsecase.On("GetTotalThreadsCount", mock.Anything, 1).Return(1, nil)
+	// Автор удаленного комментария не запрашивается
+	mockUserClient.On("GetUsers", mock.Anything, []int{5}).Return(map[int]entity.UserInfo{5: {ID: 5, Username: "bob"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/posts/1/comments/tree?max_depth=3", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetCommentThreads(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Threads []*entity.CommentNode `json:"threads"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Threads, 1)
+	assert.Equal(t, entity.DeletedCommentContent, resp.Threads[0].Content)
+	assert.Equal(t, "", resp.Threads[0].Username)
+	assert.Len(t, resp.Threads[0].Replies, 1)
+	assert.Equal(t, "bob", resp.Threads[0].Replies[0].Username)
+	assert.Equal(t, []int{1, 2}, resp.Threads[0].Replies[0].Path)
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func TestCommentHandler_GetCommentThreads_Flat(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockUserClient := new(mocks.UserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	mockCommentUsecase.On("GetCommentThreads", mock.Anything, 1, 5, 5, usecase.DefaultCommentDepth).Return(commentThreadsFixture(), nil)
+	mockCommentUsecase.On("GetTotalThreadsCount", mock.Anything, 1).Return(6, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{5}).Return(map[int]entity.UserInfo{5: {ID: 5, Username: "bob"}}, nil)
+
+	req, _ := http.NewRequest("GET", "/posts/1/comments/tree?format=flat&page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetCommentThreads(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Comments   []*entity.CommentNode `json:"comments"`
+		Pagination map[string]int        `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Comments, 2)
+	assert.Equal(t, 1, resp.Comments[1].Depth)
+	assert.Nil(t, resp.Comments[0].Replies)
+	assert.Equal(t, 6, resp.Pagination["total"])
+}
+
+func TestCommentHandler_GetCommentThreads_InvalidFormat(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	commentHandler := NewCommentHandler(new(mocks.CommentsUsecases), openCategories(), openTrust(), nil, nil, logger, new(mocks.UserClient))
+
+	req, _ := http.NewRequest("GET", "/posts/1/comments/tree?format=xml", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetCommentThreads(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func newUpdateCommentContext(w *httptest.ResponseRecorder, body string, principal entity.Principal) *gin.Context {
+	req, _ := http.NewRequest("PUT", "/comments/1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set(principalKey, principal)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+	return c
+}
+
+func TestCommentHandler_UpdateComment_Author(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	updated := entity.Comment{ID: 1, AuthorId: 3, Content: "edited", EditedBy: intPtr(3)}
+	mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, AuthorId: 3}, nil)
+	mockCommentUsecase.On("UpdateComment", mock.Anything, 1, "edited", 3).Return(updated, nil)
+
+	w := httptest.NewRecorder()
+	commentHandler.UpdateComment(newUpdateCommentContext(w, `{"content":"edited"}`, entity.Principal{UserID: 3, Role: "user"}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp entity.Comment
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, updated, resp)
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func TestCommentHandler_UpdateComment_NotAuthor(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, AuthorId: 3}, nil)
+
+	w := httptest.NewRecorder()
+	commentHandler.UpdateComment(newUpdateCommentContext(w, `{"content":"edited"}`, entity.Principal{UserID: 4, Role: "user"}))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockCommentUsecase.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentHandler_UpdateComment_Moderator(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, AuthorId: 3, Content: "original"}, nil)
+	mockCommentUsecase.On("UpdateComment", mock.Anything, 1, "edited", 7).Return(entity.Comment{ID: 1, AuthorId: 3, EditedBy: intPtr(7)}, nil)
+
+	w := httptest.NewRecorder()
+	principal := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermCommentUpdateAny}}
+	commentHandler.UpdateComment(newUpdateCommentContext(w, `{"content":"edited"}`, principal))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockCommentUsecase.AssertExpectations(t)
+}
+
+func TestCommentHandler_UpdateComment_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		code int
+	}{
+		{name: "empty content", body: `{"content":""}`, code: http.StatusBadRequest},
+		{name: "not found", body: `{"content":"edited"}`, err: usecase.ErrCommentNotFound, code: http.StatusNotFound},
+		{name: "deleted", body: `{"content":"edited"}`, err: usecase.ErrCommentDeleted, code: http.StatusConflict},
+		{name: "failure", body: `{"content":"edited"}`, err: errors.New("db down"), code: http.StatusInternalServerError},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCommentUsecase := new(mocks.CommentsUsecases)
+			commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+			mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, AuthorId: 3}, nil)
+			mockCommentUsecase.On("UpdateComment", mock.Anything, 1, "edited", 7).Return(entity.Comment{}, tc.err)
+
+			w := httptest.NewRecorder()
+			principal := entity.Principal{UserID: 7, Permissions: []string{entity.PermCommentUpdateAny}}
+			commentHandler.UpdateComment(newUpdateCommentContext(w, tc.body, principal))
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestCommentHandler_GetCommentRevisions(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockUserClient := new(mocks.UserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, PostId: 1}, nil)
+	mockCommentUsecase.On("GetCommentRevisions", mock.Anything, 1).Return([]entity.CommentRevision{
+		{Version: 1, CommentID: 1, Content: "first", EditorID: 3},
+		{Version: 2, CommentID: 1, Content: "second", EditorID: 7, Current: true},
+	}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{3, 7}).Return(map[int]entity.UserInfo{
+		3: {ID: 3, Username: "carol"},
+		7: {ID: 7, Username: "mod"},
+	}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/comments/1/revisions", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetCommentRevisions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Revisions []entity.CommentRevision `json:"revisions"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Revisions, 2)
+	assert.Equal(t, "carol", resp.Revisions[0].EditorUsername)
+	assert.Equal(t, "mod", resp.Revisions[1].EditorUsername)
+	assert.True(t, resp.Revisions[1].Current)
+}
+
+func TestCommentHandler_DiffCommentRevisions(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockCommentUsecase.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, PostId: 1}, nil)
+	mockCommentUsecase.On("DiffCommentRevisions", mock.Anything, 1, 1, 0).Return(entity.RevisionDiff{From: 1, To: 2, Changed: true}, nil)
+	mockCommentUsecase.On("DiffCommentRevisions", mock.Anything, 1, 1, 5).Return(entity.RevisionDiff{}, usecase.ErrRevisionNotFound)
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{query: "from=1", code: http.StatusOK},
+		{query: "from=1&to=5", code: http.StatusNotFound},
+		{query: "from=abc", code: http.StatusBadRequest},
+		{query: "to=0", code: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		req, _ := http.NewRequest("GET", "/comments/1/revisions/diff?"+tc.query, nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+		commentHandler.DiffCommentRevisions(c)
+
+		assert.Equal(t, tc.code, w.Code, tc.query)
+	}
+}
+
+func TestCommentHandler_GetComments_ShowsEditor(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	mockUserClient := new(mocks.UserClient)
+	commentHandler := NewCommentHandler(mockCommentUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockCommentUsecase.On("GetComments", mock.Anything, 1, 10, 0).Return([]entity.Comment{
+		{ID: 1, PostId: 1, AuthorId: 4, Content: "edited", UpdatedAt: &updatedAt, EditedBy: intPtr(7)},
+	}, nil)
+	mockCommentUsecase.On("GetTotalCommentsCount", mock.Anything, 1).Return(1, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{4, 7}).Return(map[int]entity.UserInfo{
+		4: {ID: 4, Username: "carol"},
+		7: {ID: 7, Username: "mod"},
+	}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/posts/1/comments", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	commentHandler.GetComments(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Comments []map[string]interface{} `json:"comments"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2025-01-02T03:04:05Z", resp.Comments[0]["updated_at"])
+	assert.Equal(t, float64(7), resp.Comments[0]["edited_by"])
+	assert.Equal(t, "mod", resp.Comments[0]["edited_by_username"])
+}
+
+func intPtr(v int) *int {
+	return &v
+}
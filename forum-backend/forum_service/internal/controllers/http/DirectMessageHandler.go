@@ -0,0 +1,496 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const (
+	maxConversationsPageLimit  = 50
+	maxDirectMessagesPageLimit = 100
+)
+
+type DirectMessageHandler struct {
+	dmUsecase  usecase.DirectMessageUsecase
+	hub        *chat.Hub
+	auth       *AuthMiddleware
+	logger     *zap.Logger
+	userClient grpc.UserClientInterface
+}
+
+func NewDirectMessageHandler(dmUsecase usecase.DirectMessageUsecase, hub *chat.Hub, auth *AuthMiddleware, logger *zap.Logger, userClient grpc.UserClientInterface) *DirectMessageHandler {
+	return &DirectMessageHandler{dmUsecase: dmUsecase, hub: hub, auth: auth, logger: logger, userClient: userClient}
+}
+
+func (h *DirectMessageHandler) Register(router *gin.Engine) {
+	router.GET("/dm/conversations", h.auth.RequireAuth(), h.GetInbox)
+	router.POST("/dm/conversations", h.auth.RequireAuth(), h.StartConversation)
+	router.GET("/dm/conversations/:id", h.auth.RequireAuth(), h.GetConversation)
+	router.GET("/dm/conversations/:id/messages", h.auth.RequireAuth(), h.GetMessages)
+	router.POST("/dm/conversations/:id/messages", h.auth.RequireAuth(), h.SendMessage)
+	router.POST("/dm/conversations/:id/read", h.auth.RequireAuth(), h.MarkRead)
+
+	router.GET("/users/me/blocks", h.auth.RequireAuth(), h.GetBlockedUsers)
+	router.PUT("/users/me/blocks/:userID", h.auth.RequireAuth(), h.BlockUser)
+	router.DELETE("/users/me/blocks/:userID", h.auth.RequireAuth(), h.UnblockUser)
+}
+
+// GetInbox godoc
+// @Summary Входящие личные сообщения
+// @Description Возвращает беседы пользователя с сообщениями, начиная с самой свежей, с последним сообщением и числом непрочитанных. Следующая страница запрашивается с before=next_before; без next_before страниц больше нет
+// @Tags dm
+// @Produce json
+// @Security BearerAuth
+// @Param before query int false "Курсор: next_before предыдущей страницы"
+// @Param limit query int false "Бесед на странице (до 50)" default(20)
+// @Success 200 {object} map[string]interface{} "conversations, unread_total, next_before"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations [get]
+func (h *DirectMessageHandler) GetInbox(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	before, _ := strconv.Atoi(c.Query("before"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > maxConversationsPageLimit {
+		limit = 20
+	}
+
+	conversations, next, err := h.dmUsecase.GetInbox(c.Request.Context(), principal.UserID, before, limit)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to get conversations")
+		return
+	}
+	unread, err := h.dmUsecase.GetUnreadCount(c.Request.Context(), principal.UserID)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to get conversations")
+		return
+	}
+	if conversations == nil {
+		conversations = []entity.Conversation{}
+	}
+	h.fillConversationUsernames(c, conversations)
+
+	resp := gin.H{"conversations": conversations, "unread_total": unread}
+	if next > 0 {
+		resp["next_before"] = next
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// StartConversation godoc
+// @Summary Начать беседу
+// @Description Создает беседу с пользователями user_ids: с одним — беседу на двоих (повторный запрос вернет существующую), с несколькими — групповую беседу до 10 участников. content, если задан, отправляется первым сообщением. Нельзя писать с мутом, ограничением или блокировкой, забаненным пользователям и тем, кто занес вас в черный список или кого занесли вы
+// @Tags dm
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param conversation body entity.CreateConversationRequest true "Беседа"
+// @Success 201 {object} entity.Conversation
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Пользователь не найден"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations [post]
+func (h *DirectMessageHandler) StartConversation(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	var req entity.CreateConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	conv, first, err := h.dmUsecase.StartConversation(c.Request.Context(), req, principal)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to start conversation")
+		return
+	}
+	if first != nil {
+		userIDs := make([]int, 0, len(conv.Participants))
+		for _, participant := range conv.Participants {
+			userIDs = append(userIDs, participant.UserID)
+		}
+		h.push(*first, userIDs)
+	}
+
+	conversations := []entity.Conversation{conv}
+	h.fillConversationUsernames(c, conversations)
+	c.JSON(http.StatusCreated, conversations[0])
+}
+
+// GetConversation godoc
+// @Summary Беседа
+// @Description Возвращает беседу с участниками и отметками прочтения. Беседы, в которых пользователь не участвует, для него не существуют
+// @Tags dm
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID беседы"
+// @Success 200 {object} entity.Conversation
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations/{id} [get]
+func (h *DirectMessageHandler) GetConversation(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := conversationID(c)
+	if !ok {
+		return
+	}
+
+	conv, err := h.dmUsecase.GetConversation(c.Request.Context(), id, principal.UserID)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to get conversation")
+		return
+	}
+	conversations := []entity.Conversation{conv}
+	h.fillConversationUsernames(c, conversations)
+	c.JSON(http.StatusOK, conversations[0])
+}
+
+// GetMessages godoc
+// @Summary История беседы
+// @Description Возвращает сообщения беседы от старых к новым: без before — последние, с before — более ранние. Следующая (более ранняя) страница запрашивается с before=next_before; без next_before это начало беседы
+// @Tags dm
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID беседы"
+// @Param before query int false "Курсор: сообщения с ID меньше before"
+// @Param limit query int false "Сообщений на странице (до 100)" default(50)
+// @Success 200 {object} map[string]interface{} "messages, next_before"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations/{id}/messages [get]
+func (h *DirectMessageHandler) GetMessages(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := conversationID(c)
+	if !ok {
+		return
+	}
+	before, _ := strconv.Atoi(c.Query("before"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > maxDirectMessagesPageLimit {
+		limit = 50
+	}
+
+	messages, next, err := h.dmUsecase.GetMessages(c.Request.Context(), id, principal.UserID, before, limit)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to get messages")
+		return
+	}
+	if messages == nil {
+		messages = []entity.DirectMessage{}
+	}
+	h.fillSenderUsernames(c, messages)
+
+	resp := gin.H{"messages": messages}
+	if next > 0 {
+		resp["next_before"] = next
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// SendMessage godoc
+// @Summary Отправить личное сообщение
+// @Description Пишет в беседу. Участники беседы, подключенные к /ws, сразу получают событие {"type": "dm", "dm": {...}}
+// @Tags dm
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID беседы"
+// @Param message body entity.SendDirectMessageRequest true "Сообщение"
+// @Success 201 {object} entity.DirectMessage
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations/{id}/messages [post]
+func (h *DirectMessageHandler) SendMessage(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := conversationID(c)
+	if !ok {
+		return
+	}
+	var req entity.SendDirectMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	msg, userIDs, err := h.dmUsecase.SendMessage(c.Request.Context(), id, req.Content, principal)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to send message")
+		return
+	}
+	messages := []entity.DirectMessage{msg}
+	h.fillSenderUsernames(c, messages)
+	h.push(messages[0], userIDs)
+	c.JSON(http.StatusCreated, messages[0])
+}
+
+// MarkRead godoc
+// @Summary Отметить прочитанным
+// @Description Отмечает прочитанными сообщения беседы до message_id включительно. Отметка не сдвигается назад
+// @Tags dm
+// @Accept json
+// @Security BearerAuth
+// @Param id path int true "ID беседы"
+// @Param read body entity.MarkConversationReadRequest true "Последнее прочитанное сообщение"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /dm/conversations/{id}/read [post]
+func (h *DirectMessageHandler) MarkRead(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := conversationID(c)
+	if !ok {
+		return
+	}
+	var req entity.MarkConversationReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.dmUsecase.MarkRead(c.Request.Context(), id, principal.UserID, req.MessageID); err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to mark conversation read")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetBlockedUsers godoc
+// @Summary Черный список
+// @Description Возвращает пользователей, которых текущий пользователь занес в черный список
+// @Tags dm
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "users"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/me/blocks [get]
+func (h *DirectMessageHandler) GetBlockedUsers(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+
+	blocked, err := h.dmUsecase.GetBlockedUsers(c.Request.Context(), principal.UserID)
+	if err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to get blocked users")
+		return
+	}
+	if blocked == nil {
+		blocked = []entity.BlockedUser{}
+	}
+
+	userIDs := make([]int, 0, len(blocked))
+	for _, user := range blocked {
+		userIDs = append(userIDs, user.UserID)
+	}
+	users := h.usernames(c, userIDs)
+	for i := range blocked {
+		blocked[i].Username = users[blocked[i].UserID].Username
+	}
+	c.JSON(http.StatusOK, gin.H{"users": blocked})
+}
+
+// BlockUser godoc
+// @Summary Занести в черный список
+// @Description Запрещает личные сообщения между текущим пользователем и userID в обе стороны, в том числе в общих групповых беседах
+// @Tags dm
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Пользователь не найден"
+// @Failure 409 {object} entity.ErrorResponse "Уже в черном списке"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/me/blocks/{userID} [put]
+func (h *DirectMessageHandler) BlockUser(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	users, err := h.userClient.GetUsers(c.Request.Context(), []int{userID})
+	if err != nil {
+		h.logger.Error("Failed to get user", zap.Int("userID", userID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+		return
+	}
+	if _, found := users[userID]; !found {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": usecase.ErrUserNotFound.Error()})
+		return
+	}
+
+	if err := h.dmUsecase.BlockUser(c.Request.Context(), principal.UserID, userID); err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to block user")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UnblockUser godoc
+// @Summary Убрать из черного списка
+// @Tags dm
+// @Security BearerAuth
+// @Param userID path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Пользователя нет в черном списке"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/me/blocks/{userID} [delete]
+func (h *DirectMessageHandler) UnblockUser(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.dmUsecase.UnblockUser(c.Request.Context(), principal.UserID, userID); err != nil {
+		abortDirectMessageError(c, h.logger, err, "Failed to unblock user")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// push отправляет сообщение подключенным к чату участникам беседы.
+func (h *DirectMessageHandler) push(msg entity.DirectMessage, userIDs []int) {
+	if h.hub == nil {
+		return
+	}
+	data, err := json.Marshal(entity.ChatEvent{Type: entity.ChatEventDirect, DirectMessage: &msg})
+	if err != nil {
+		h.logger.Error("Failed to marshal direct message", zap.Int("messageID", msg.ID), zap.Error(err))
+		return
+	}
+	h.hub.Direct <- chat.Delivery{UserIDs: userIDs, Data: data}
+}
+
+// usernames возвращает пользователей по ID. Ошибка auth_service не мешает
+// ответу: имена просто остаются пустыми.
+func (h *DirectMessageHandler) usernames(c *gin.Context, userIDs []int) map[int]entity.UserInfo {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	users, err := h.userClient.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", userIDs), zap.Error(err))
+	}
+	return users
+}
+
+func (h *DirectMessageHandler) fillConversationUsernames(c *gin.Context, conversations []entity.Conversation) {
+	seen := make(map[int]bool)
+	var userIDs []int
+	for _, conv := range conversations {
+		for _, participant := range conv.Participants {
+			if !seen[participant.UserID] {
+				seen[participant.UserID] = true
+				userIDs = append(userIDs, participant.UserID)
+			}
+		}
+	}
+	users := h.usernames(c, userIDs)
+	for i := range conversations {
+		conv := &conversations[i]
+		for j := range conv.Participants {
+			conv.Participants[j].Username = users[conv.Participants[j].UserID].Username
+		}
+		if conv.LastMessage != nil {
+			conv.LastMessage.SenderUsername = users[conv.LastMessage.SenderID].Username
+		}
+	}
+}
+
+func (h *DirectMessageHandler) fillSenderUsernames(c *gin.Context, messages []entity.DirectMessage) {
+	seen := make(map[int]bool)
+	var userIDs []int
+	for _, msg := range messages {
+		if !seen[msg.SenderID] {
+			seen[msg.SenderID] = true
+			userIDs = append(userIDs, msg.SenderID)
+		}
+	}
+	users := h.usernames(c, userIDs)
+	for i := range messages {
+		messages[i].SenderUsername = users[messages[i].SenderID].Username
+	}
+}
+
+func conversationID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid conversation ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func abortDirectMessageError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrConversationNotFound),
+		errors.Is(err, usecase.ErrUserNotBlocked),
+		errors.Is(err, usecase.ErrUserNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrChatMuted),
+		errors.Is(err, usecase.ErrDirectMessageBlocked),
+		errors.Is(err, usecase.ErrRecipientUnavailable):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrUserAlreadyBlocked):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidConversation),
+		errors.Is(err, usecase.ErrTooManyParticipants),
+		errors.Is(err, usecase.ErrInvalidConversationTitle),
+		errors.Is(err, usecase.ErrInvalidDirectMessage),
+		errors.Is(err, usecase.ErrCannotBlockSelf):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestDirectMessageHandler(hub *chat.Hub) (*DirectMessageHandler, *mocks.DirectMessageUsecase, *mocks.UserClient) {
+	mockDMUsecase := new(mocks.DirectMessageUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewDirectMessageHandler(mockDMUsecase, hub, nil, zap.NewNop(), mockUserClient)
+	return handler, mockDMUsecase, mockUserClient
+}
+
+func TestDirectMessageHandler_GetInbox(t *testing.T) {
+	handler, mockDMUsecase, mockUserClient := newTestDirectMessageHandler(nil)
+	user := entity.Principal{UserID: 1, Role: "user"}
+
+	mockDMUsecase.On("GetInbox", mock.Anything, 1, 50, 2).Return([]entity.Conversation{{
+		ID:           3,
+		Participants: []entity.ConversationParticipant{{UserID: 1}, {UserID: 2}},
+		LastMessage:  &entity.DirectMessage{ID: 42, SenderID: 2, Content: "привет"},
+		UnreadCount:  1,
+	}}, 42, nil)
+	mockDMUsecase.On("GetUnreadCount", mock.Anything, 1).Return(4, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{1, 2}).
+		Return(map[int]entity.UserInfo{1: {ID: 1, Username: "me"}, 2: {ID: 2, Username: "friend"}}, nil)
+
+	w := servePostRoute(handler.GetInbox, http.MethodGet, "/dm/conversations", "/dm/conversations?before=50&limit=2", "", user)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Conversations []entity.Conversation `json:"conversations"`
+		UnreadTotal   int                   `json:"unread_total"`
+		NextBefore    int                   `json:"next_before"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Conversations, 1)
+	assert.Equal(t, "friend", resp.Conversations[0].LastMessage.SenderUsername)
+	assert.Equal(t, "friend", resp.Conversations[0].Participants[1].Username)
+	assert.Equal(t, 4, resp.UnreadTotal)
+	assert.Equal(t, 42, resp.NextBefore)
+}
+
+func TestDirectMessageHandler_StartConversation_UnknownUser(t *testing.T) {
+	handler, mockDMUsecase, _ := newTestDirectMessageHandler(nil)
+	user := entity.Principal{UserID: 1, Role: "user"}
+
+	mockDMUsecase.On("StartConversation", mock.Anything, entity.CreateConversationRequest{UserIDs: []int{2, 42}}, user).
+		Return(entity.Conversation{}, nil, usecase.ErrUserNotFound)
+
+	w := servePostRoute(handler.StartConversation, http.MethodPost, "/dm/conversations", "/dm/conversations",
+		`{"user_ids":[2,42]}`, user)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDirectMessageHandler_SendMessage_PushesToParticipants(t *testing.T) {
+	hub := chat.NewHub()
+	handler, mockDMUsecase, mockUserClient := newTestDirectMessageHandler(hub)
+	user := entity.Principal{UserID: 1, Role: "user"}
+
+	mockDMUsecase.On("SendMessage", mock.Anything, 3, "привет", user).
+		Return(entity.DirectMessage{ID: 43, ConversationID: 3, SenderID: 1, Content: "привет"}, []int{1, 2}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{1}).Return(map[int]entity.UserInfo{1: {ID: 1, Username: "me"}}, nil)
+
+	w := servePostRoute(handler.SendMessage, http.MethodPost, "/dm/conversations/:id/messages", "/dm/conversations/3/messages",
+		`{"content":"привет"}`, user)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	delivery := <-hub.Direct
+	assert.Equal(t, []int{1, 2}, delivery.UserIDs)
+	var event entity.ChatEvent
+	assert.NoError(t, json.Unmarshal(delivery.Data, &event))
+	assert.Equal(t, entity.ChatEventDirect, event.Type)
+	assert.Equal(t, 43, event.DirectMessage.ID)
+	assert.Equal(t, "me", event.DirectMessage.SenderUsername)
+}
+
+func TestDirectMessageHandler_SendMessage_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrConversationNotFound, http.StatusNotFound},
+		{usecase.ErrDirectMessageBlocked, http.StatusForbidden},
+		{usecase.ErrRecipientUnavailable, http.StatusForbidden},
+		{usecase.ErrChatMuted, http.StatusForbidden},
+		{usecase.ErrInvalidDirectMessage, http.StatusBadRequest},
+		{errors.New("database is locked"), http.StatusInternalServerError},
+	} {
+		hub := chat.NewHub()
+		handler, mockDMUsecase, _ := newTestDirectMessageHandler(hub)
+		mockDMUsecase.On("SendMessage", mock.Anything, 3, "привет", mock.Anything).Return(entity.DirectMessage{}, nil, tc.err)
+
+		w := servePostRoute(handler.SendMessage, http.MethodPost, "/dm/conversations/:id/messages", "/dm/conversations/3/messages",
+			`{"content":"привет"}`, entity.Principal{UserID: 1, Role: "user"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+		assert.Empty(t, hub.Direct, tc.err.Error())
+	}
+}
+
+func TestDirectMessageHandler_BlockUser(t *testing.T) {
+	handler, mockDMUsecase, mockUserClient := newTestDirectMessageHandler(nil)
+	user := entity.Principal{UserID: 1, Role: "user"}
+
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).Return(map[int]entity.UserInfo{2: {ID: 2}}, nil)
+	mockDMUsecase.On("BlockUser", mock.Anything, 1, 2).Return(nil).Once()
+	mockDMUsecase.On("BlockUser", mock.Anything, 1, 2).Return(usecase.ErrUserAlreadyBlocked).Once()
+
+	w := servePostRoute(handler.BlockUser, http.MethodPut, "/users/me/blocks/:userID", "/users/me/blocks/2", "", user)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = servePostRoute(handler.BlockUser, http.MethodPut, "/users/me/blocks/:userID", "/users/me/blocks/2", "", user)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
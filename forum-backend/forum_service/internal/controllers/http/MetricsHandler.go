@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+)
+
+// UserCacheStatsProvider отдает статистику кэша пользователей.
+type UserCacheStatsProvider interface {
+	Stats() grpc.CacheStats
+}
+
+type MetricsHandler struct {
+	userCache UserCacheStatsProvider
+}
+
+func NewMetricsHandler(userCache UserCacheStatsProvider) *MetricsHandler {
+	return &MetricsHandler{userCache: userCache}
+}
+
+func (h *MetricsHandler) Register(router *gin.Engine) {
+	router.GET("/metrics/user-cache", h.UserCacheStats)
+}
+
+// UserCacheStats godoc
+// @Summary Статистика кэша пользователей
+// @Description Попадания, промахи, вытеснения и инвалидации кэша пользователей, получаемых из auth_service
+// @Tags Мониторинг
+// @Produce json
+// @Success 200 {object} grpc.CacheStats
+// @Router /metrics/user-cache [get]
+func (h *MetricsHandler) UserCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.userCache.Stats())
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCacheStats grpc.CacheStats
+
+func (s stubCacheStats) Stats() grpc.CacheStats { return grpc.CacheStats(s) }
+
+func TestMetricsHandler_UserCacheStats(t *testing.T) {
+	router := gin.New()
+	NewMetricsHandler(stubCacheStats{Hits: 5, Misses: 2, Entries: 2}).Register(router)
+
+	req, _ := http.NewRequest("GET", "/metrics/user-cache", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stats grpc.CacheStats
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, grpc.CacheStats{Hits: 5, Misses: 2, Entries: 2}, stats)
+}
@@ -0,0 +1,449 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const maxCasesPageLimit = 100
+
+type ModerationHandler struct {
+	moderationUsecase usecase.ModerationUsecase
+	categoryUsecase   usecase.CategoryUsecase
+	auth              *AuthMiddleware
+	audit             *Auditor
+	hub               *chat.Hub
+	logger            *zap.Logger
+	userClient        grpc.UserClientInterface
+}
+
+func NewModerationHandler(
+	moderationUsecase usecase.ModerationUsecase,
+	categoryUsecase usecase.CategoryUsecase,
+	auth *AuthMiddleware,
+	audit *Auditor,
+	hub *chat.Hub,
+	logger *zap.Logger,
+	userClient grpc.UserClientInterface,
+) *ModerationHandler {
+	return &ModerationHandler{
+		moderationUsecase: moderationUsecase,
+		categoryUsecase:   categoryUsecase,
+		auth:              auth,
+		audit:             audit,
+		hub:               hub,
+		logger:            logger,
+		userClient:        userClient,
+	}
+}
+
+func (h *ModerationHandler) Register(router *gin.Engine) {
+	router.GET("/reports/reasons", h.GetReportReasons)
+	router.POST("/reports", h.auth.RequireAuth(), h.CreateReport)
+	router.GET("/warnings", h.auth.RequireAuth(), h.GetWarnings)
+
+	router.GET("/moderation/cases", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermReportReview), h.GetCases)
+	router.GET("/moderation/cases/:id", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermReportReview), h.GetCase)
+	router.POST("/moderation/cases/:id/claim", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermReportReview), h.ClaimCase)
+	router.DELETE("/moderation/cases/:id/claim", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermReportReview), h.ReleaseCase)
+	router.POST("/moderation/cases/:id/resolve", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermReportReview), h.ResolveCase)
+}
+
+// GetReportReasons godoc
+// @Summary Причины жалоб
+// @Description Возвращает причины, которые можно указать в жалобе. Для other нужен комментарий
+// @Tags Модерация
+// @Produce json
+// @Success 200 {object} map[string][]string "reasons"
+// @Router /reports/reasons [get]
+func (h *ModerationHandler) GetReportReasons(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"reasons": h.moderationUsecase.Reasons()})
+}
+
+// CreateReport godoc
+// @Summary Пожаловаться
+// @Description Жалоба на пост, комментарий или сообщение чата. На одну цель пользователь жалуется один раз, пока модератор не решил дело. Цель, набравшая порог жалоб, скрывается до решения модератора
+// @Tags Модерация
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body entity.CreateReportRequest true "Цель (post, comment или chat_message), причина и комментарий"
+// @Success 201 {object} entity.Report
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse "Жалоба на себя"
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Жалоба уже подана или комментарий удален"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /reports [post]
+func (h *ModerationHandler) CreateReport(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	var req entity.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	target, err := h.moderationUsecase.GetTarget(c.Request.Context(), req.TargetType, req.TargetID)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to get report target")
+		return
+	}
+	// Пожаловаться можно только на то, что пользователь может прочитать
+	if target.PostID != 0 {
+		err = h.categoryUsecase.CheckPostAccess(c.Request.Context(), target.PostID, &principal, entity.CategoryActionRead)
+		if err != nil {
+			if errors.Is(err, usecase.ErrCategoryNotFound) {
+				err = usecase.ErrPostNotFound
+				if target.Type == entity.ReactionTargetComment {
+					err = usecase.ErrCommentNotFound
+				}
+			}
+			h.abortModerationError(c, err, "Failed to check category access")
+			return
+		}
+	}
+
+	report, err := h.moderationUsecase.Report(c.Request.Context(), target, principal.UserID, req.Reason, req.Note)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to create report")
+		return
+	}
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetCases godoc
+// @Summary Очередь модерации
+// @Description Возвращает дела модерации постранично: нерешенные — от старых к новым, решенные — начиная с последних решений. Требуется право report.review
+// @Tags Модерация
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending (открытые и взятые в работу), open, claimed, resolved или all" default(pending)
+// @Param target_type query string false "post, comment или chat_message"
+// @Param reason query string false "Только дела с жалобами по этой причине"
+// @Param claimed_by query string false "ID модератора, взявшего дело, или me"
+// @Param author_id query int false "ID автора цели"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Дел на странице (не больше 100)" default(20)
+// @Success 200 {object} map[string]interface{} "cases и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /moderation/cases [get]
+func (h *ModerationHandler) GetCases(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxCasesPageLimit {
+		limit = 20
+	}
+
+	filter := entity.CaseFilter{
+		Status:     c.Query("status"),
+		TargetType: c.Query("target_type"),
+		Reason:     c.Query("reason"),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	}
+	if claimedBy := c.Query("claimed_by"); claimedBy == "me" {
+		filter.ClaimedBy = principal.UserID
+	} else if claimedBy != "" {
+		id, err := strconv.Atoi(claimedBy)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid claimed_by"})
+			return
+		}
+		filter.ClaimedBy = id
+	}
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid author_id"})
+			return
+		}
+		filter.AuthorID = id
+	}
+
+	cases, total, err := h.moderationUsecase.GetCases(c.Request.Context(), filter)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to get moderation cases")
+		return
+	}
+	if cases == nil {
+		cases = []entity.ModerationCase{}
+	}
+	h.fillUsernames(c, cases)
+
+	c.JSON(http.StatusOK, gin.H{
+		"cases": cases,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// GetCase godoc
+// @Summary Дело модерации
+// @Description Возвращает дело вместе со всеми жалобами на цель. Требуется право report.review
+// @Tags Модерация
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID дела"
+// @Success 200 {object} entity.ModerationCase
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /moderation/cases/{id} [get]
+func (h *ModerationHandler) GetCase(c *gin.Context) {
+	id, ok := parseCaseID(c)
+	if !ok {
+		return
+	}
+	mc, err := h.moderationUsecase.GetCase(c.Request.Context(), id)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to get moderation case")
+		return
+	}
+	h.respondCase(c, mc)
+}
+
+// ClaimCase godoc
+// @Summary Взять дело в работу
+// @Description Закрепляет дело за модератором, чтобы другие модераторы его не решали. Требуется право report.review
+// @Tags Модерация
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID дела"
+// @Success 200 {object} entity.ModerationCase
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Дело взял другой модератор или оно решено"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /moderation/cases/{id}/claim [post]
+func (h *ModerationHandler) ClaimCase(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := parseCaseID(c)
+	if !ok {
+		return
+	}
+	mc, err := h.moderationUsecase.Claim(c.Request.Context(), id, principal)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to claim moderation case")
+		return
+	}
+	h.audit.Record(c, entity.AuditCaseClaim, entity.AuditTargetCase, id, nil, gin.H{"status": mc.Status, "claimed_by": mc.ClaimedBy})
+	h.respondCase(c, mc)
+}
+
+// ReleaseCase godoc
+// @Summary Вернуть дело в очередь
+// @Description Снимает дело с модератора. Чужое дело может вернуть только администратор. Требуется право report.review
+// @Tags Модерация
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID дела"
+// @Success 200 {object} entity.ModerationCase
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Дело взял другой модератор или оно решено"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /moderation/cases/{id}/claim [delete]
+func (h *ModerationHandler) ReleaseCase(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := parseCaseID(c)
+	if !ok {
+		return
+	}
+	mc, err := h.moderationUsecase.Release(c.Request.Context(), id, principal)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to release moderation case")
+		return
+	}
+	h.audit.Record(c, entity.AuditCaseRelease, entity.AuditTargetCase, id, nil, gin.H{"status": mc.Status, "claimed_by": mc.ClaimedBy})
+	h.respondCase(c, mc)
+}
+
+// ResolveCase godoc
+// @Summary Решить дело
+// @Description Закрывает дело решением: dismiss — жалобы отклонены и цель снова видна, delete — цель удаляется, warn — автор получает предупреждение (GET /warnings), ban — автор блокируется через auth_service на ban_duration_hours (0 — бессрочно) и его сессии завершаются. Для warn и ban нужен комментарий note — его автор увидит как причину; цель удаляется, если передан delete_content. Для ban нужно право user.ban. Требуется право report.review
+// @Tags Модерация
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID дела"
+// @Param request body entity.ResolveCaseRequest true "Решение и комментарий модератора"
+// @Success 200 {object} entity.ModerationCase
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Дело взял другой модератор или оно решено"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /moderation/cases/{id}/resolve [post]
+func (h *ModerationHandler) ResolveCase(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	id, ok := parseCaseID(c)
+	if !ok {
+		return
+	}
+	var req entity.ResolveCaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before, err := h.moderationUsecase.GetCase(c.Request.Context(), id)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to get moderation case")
+		return
+	}
+	mc, err := h.moderationUsecase.Resolve(c.Request.Context(), id, principal, req)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to resolve moderation case")
+		return
+	}
+	h.audit.Record(c, entity.AuditCaseResolve, entity.AuditTargetCase, id, before, mc)
+	h.notifyChatRemoval(before, mc)
+	h.respondCase(c, mc)
+}
+
+// notifyChatRemoval сообщает комнате, что модератор удалил сообщение чата:
+// у дела на сообщение комната известна, только пока сообщение существует.
+func (h *ModerationHandler) notifyChatRemoval(before, after entity.ModerationCase) {
+	if h.hub == nil || before.RoomID == nil || after.RoomID != nil {
+		return
+	}
+	data, err := json.Marshal(entity.NewChatMessageDeletedEvent(*before.RoomID, before.TargetID))
+	if err != nil {
+		h.logger.Error("Failed to marshal chat event", zap.Error(err))
+		return
+	}
+	h.hub.Broadcast <- chat.RoomMessage{RoomID: *before.RoomID, Data: data}
+}
+
+// GetWarnings godoc
+// @Summary Мои предупреждения
+// @Description Возвращает предупреждения, вынесенные текущему пользователю модераторами при решении дел по жалобам, начиная с последнего
+// @Tags Модерация
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} entity.Warning
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /warnings [get]
+func (h *ModerationHandler) GetWarnings(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	warnings, err := h.moderationUsecase.GetWarnings(c.Request.Context(), principal.UserID)
+	if err != nil {
+		h.abortModerationError(c, err, "Failed to get warnings")
+		return
+	}
+	c.JSON(http.StatusOK, warnings)
+}
+
+func (h *ModerationHandler) respondCase(c *gin.Context, mc entity.ModerationCase) {
+	cases := []entity.ModerationCase{mc}
+	h.fillUsernames(c, cases)
+	c.JSON(http.StatusOK, cases[0])
+}
+
+// fillUsernames подставляет имена авторов целей и жалующихся. Дела
+// показываются и без имен, если auth_service недоступен.
+func (h *ModerationHandler) fillUsernames(c *gin.Context, cases []entity.ModerationCase) {
+	var userIDs []int
+	for _, mc := range cases {
+		userIDs = append(userIDs, mc.TargetAuthorID)
+		for _, report := range mc.Reports {
+			userIDs = append(userIDs, report.ReporterID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	users, err := h.userClient.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", userIDs), zap.Error(err))
+	}
+	for i := range cases {
+		cases[i].TargetAuthorUsername = users[cases[i].TargetAuthorID].Username
+		for j := range cases[i].Reports {
+			cases[i].Reports[j].ReporterUsername = users[cases[i].Reports[j].ReporterID].Username
+		}
+	}
+}
+
+func parseCaseID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid case ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *ModerationHandler) abortModerationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrCommentNotFound),
+		errors.Is(err, usecase.ErrChatMessageNotFound),
+		errors.Is(err, usecase.ErrCaseNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidReportTarget),
+		errors.Is(err, usecase.ErrInvalidReportReason),
+		errors.Is(err, usecase.ErrReportNoteRequired),
+		errors.Is(err, usecase.ErrReportNoteTooLong),
+		errors.Is(err, usecase.ErrInvalidCaseStatus),
+		errors.Is(err, usecase.ErrInvalidResolution),
+		errors.Is(err, usecase.ErrResolutionNote),
+		errors.Is(err, usecase.ErrBanRejected):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSelfReport), errors.Is(err, usecase.ErrBanForbidden):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrAlreadyReported),
+		errors.Is(err, usecase.ErrCommentDeleted),
+		errors.Is(err, usecase.ErrCaseClaimed),
+		errors.Is(err, usecase.ErrCaseResolved):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		abortCategoryError(c, h.logger, err, message)
+	}
+}
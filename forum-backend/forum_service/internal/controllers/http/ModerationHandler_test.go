@@ -0,0 +1,168 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/chat"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestModerationHandler(categories *mocks.CategoryUsecase) (*ModerationHandler, *mocks.ModerationUsecase, *mocks.UserClient) {
+	mockModerationUsecase := new(mocks.ModerationUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewModerationHandler(mockModerationUsecase, categories, nil, nil, nil, zap.NewNop(), mockUserClient)
+	return handler, mockModerationUsecase, mockUserClient
+}
+
+func TestModerationHandler_CreateReport(t *testing.T) {
+	handler, mockModerationUsecase, _ := newTestModerationHandler(openCategories())
+	target := entity.ReportTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7, AuthorID: 1}
+
+	mockModerationUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetComment, 5).Return(target, nil)
+	mockModerationUsecase.On("Report", mock.Anything, target, 2, entity.ReportReasonSpam, "казино").
+		Return(entity.Report{ID: 1, CaseID: 3, ReporterID: 2, Reason: entity.ReportReasonSpam, Note: "казино"}, nil)
+
+	w := servePostRoute(handler.CreateReport, http.MethodPost, "/reports", "/reports",
+		`{"target_type":"comment","target_id":5,"reason":"spam","note":"казино"}`, entity.Principal{UserID: 2, Role: "user"})
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var report entity.Report
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, 3, report.CaseID)
+	mockModerationUsecase.AssertExpectations(t)
+}
+
+func TestModerationHandler_CreateReport_Errors(t *testing.T) {
+	target := entity.ReportTarget{Type: entity.ReactionTargetPost, ID: 7, PostID: 7, AuthorID: 1}
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrInvalidReportReason, http.StatusBadRequest},
+		{usecase.ErrReportNoteRequired, http.StatusBadRequest},
+		{usecase.ErrSelfReport, http.StatusForbidden},
+		{usecase.ErrAlreadyReported, http.StatusConflict},
+	} {
+		handler, mockModerationUsecase, _ := newTestModerationHandler(openCategories())
+		mockModerationUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetPost, 7).Return(target, nil)
+		mockModerationUsecase.On("Report", mock.Anything, target, 2, "other", "").Return(entity.Report{}, tc.err)
+
+		w := servePostRoute(handler.CreateReport, http.MethodPost, "/reports", "/reports",
+			`{"target_type":"post","target_id":7,"reason":"other"}`, entity.Principal{UserID: 2, Role: "user"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestModerationHandler_CreateReport_HiddenCategory(t *testing.T) {
+	categories := new(mocks.CategoryUsecase)
+	handler, mockModerationUsecase, _ := newTestModerationHandler(categories)
+	target := entity.ReportTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7, AuthorID: 1}
+	principal := entity.Principal{UserID: 2, Role: "user"}
+
+	mockModerationUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetComment, 5).Return(target, nil)
+	categories.On("CheckPostAccess", mock.Anything, 7, &principal, entity.CategoryActionRead).Return(usecase.ErrCategoryNotFound)
+
+	w := servePostRoute(handler.CreateReport, http.MethodPost, "/reports", "/reports",
+		`{"target_type":"comment","target_id":5,"reason":"spam"}`, principal)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), usecase.ErrCommentNotFound.Error())
+	mockModerationUsecase.AssertNotCalled(t, "Report", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationHandler_GetCases(t *testing.T) {
+	handler, mockModerationUsecase, mockUserClient := newTestModerationHandler(nil)
+
+	filter := entity.CaseFilter{Status: "pending", Reason: entity.ReportReasonSpam, ClaimedBy: 5, Limit: 10, Offset: 10}
+	mockModerationUsecase.On("GetCases", mock.Anything, filter).
+		Return([]entity.ModerationCase{{ID: 1, TargetType: entity.ReactionTargetPost, TargetID: 7, TargetAuthorID: 2, Status: entity.CaseStatusClaimed, ReportsCount: 3}}, 11, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).Return(map[int]entity.UserInfo{2: {ID: 2, Username: "spammer"}}, nil)
+
+	w := servePostRoute(handler.GetCases, http.MethodGet, "/moderation/cases",
+		"/moderation/cases?status=pending&reason=spam&claimed_by=me&page=2&limit=10", "",
+		entity.Principal{UserID: 5, Role: "moderator"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Cases      []entity.ModerationCase `json:"cases"`
+		Pagination struct{ Total int }     `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Cases, 1)
+	assert.Equal(t, "spammer", resp.Cases[0].TargetAuthorUsername)
+	assert.Equal(t, 11, resp.Pagination.Total)
+}
+
+func TestModerationHandler_ResolveCase(t *testing.T) {
+	handler, mockModerationUsecase, mockUserClient := newTestModerationHandler(nil)
+	principal := entity.Principal{UserID: 5, Role: "moderator"}
+	resolution := entity.ResolutionWarn
+
+	req := entity.ResolveCaseRequest{Action: entity.ResolutionWarn, Note: "реклама", DeleteContent: true}
+	mockModerationUsecase.On("GetCase", mock.Anything, 1).Return(entity.ModerationCase{ID: 1, TargetAuthorID: 2, Status: entity.CaseStatusPending}, nil)
+	mockModerationUsecase.On("Resolve", mock.Anything, 1, principal, req).
+		Return(entity.ModerationCase{ID: 1, TargetAuthorID: 2, Status: entity.CaseStatusResolved, Resolution: &resolution}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).Return(map[int]entity.UserInfo{}, nil)
+
+	w := servePostRoute(handler.ResolveCase, http.MethodPost, "/moderation/cases/:id/resolve", "/moderation/cases/1/resolve",
+		`{"action":"warn","note":"реклама","delete_content":true}`, principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"resolution":"warn"`)
+	mockModerationUsecase.AssertExpectations(t)
+}
+
+func TestModerationHandler_ResolveCase_ChatMessageRemoved(t *testing.T) {
+	hub := chat.NewHub()
+	mockModerationUsecase := new(mocks.ModerationUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewModerationHandler(mockModerationUsecase, nil, nil, nil, hub, zap.NewNop(), mockUserClient)
+	principal := entity.Principal{UserID: 5, Role: "moderator"}
+	roomID := 2
+
+	req := entity.ResolveCaseRequest{Action: entity.ResolutionWarn, DeleteContent: true}
+	mockModerationUsecase.On("GetCase", mock.Anything, 1).
+		Return(entity.ModerationCase{ID: 1, TargetType: entity.ReportTargetChatMessage, TargetID: 42, RoomID: &roomID, TargetAuthorID: 2}, nil)
+	mockModerationUsecase.On("Resolve", mock.Anything, 1, principal, req).
+		Return(entity.ModerationCase{ID: 1, TargetType: entity.ReportTargetChatMessage, TargetID: 42, TargetAuthorID: 2, Status: entity.CaseStatusResolved}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).Return(map[int]entity.UserInfo{}, nil)
+
+	w := servePostRoute(handler.ResolveCase, http.MethodPost, "/moderation/cases/:id/resolve", "/moderation/cases/1/resolve",
+		`{"action":"warn","delete_content":true}`, principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	removal := <-hub.Broadcast
+	assert.Equal(t, 2, removal.RoomID)
+	assert.JSONEq(t, `{"type":"message.deleted","room_id":2,"message_id":42}`, string(removal.Data))
+}
+
+func TestModerationHandler_ResolveCase_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrCaseNotFound, http.StatusNotFound},
+		{usecase.ErrInvalidResolution, http.StatusBadRequest},
+		{usecase.ErrBanForbidden, http.StatusForbidden},
+		{usecase.ErrCaseClaimed, http.StatusConflict},
+		{usecase.ErrCaseResolved, http.StatusConflict},
+	} {
+		handler, mockModerationUsecase, _ := newTestModerationHandler(nil)
+		mockModerationUsecase.On("GetCase", mock.Anything, 1).Return(entity.ModerationCase{ID: 1}, nil)
+		mockModerationUsecase.On("Resolve", mock.Anything, 1, mock.Anything, mock.Anything).Return(entity.ModerationCase{}, tc.err)
+
+		w := servePostRoute(handler.ResolveCase, http.MethodPost, "/moderation/cases/:id/resolve", "/moderation/cases/1/resolve",
+			`{"action":"ban"}`, entity.Principal{UserID: 5, Role: "moderator"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
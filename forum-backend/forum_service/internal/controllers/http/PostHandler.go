@@ -1,53 +1,62 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	utils "github.com/miqxzz/commonmiqx"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
-	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"go.uber.org/zap"
 )
 
 type PostHandler struct {
-	postUsecase usecase.PostUsecase
-	postRepo    repository.PostRepository
-	jwtUtil     *utils.JWTUtil
-	logger      *zap.Logger
-	userClient  grpc.UserClientInterface
+	postUsecase     usecase.PostUsecase
+	categoryUsecase usecase.CategoryUsecase
+	trustUsecase    usecase.TrustUsecase
+	auth            *AuthMiddleware
+	audit           *Auditor
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
 }
 
 func NewPostHandler(
 	postUsecase usecase.PostUsecase,
-	postRepo repository.PostRepository,
-	jwtUtil *utils.JWTUtil,
+	categoryUsecase usecase.CategoryUsecase,
+	trustUsecase usecase.TrustUsecase,
+	auth *AuthMiddleware,
+	audit *Auditor,
 	logger *zap.Logger,
 	userClient grpc.UserClientInterface,
 ) *PostHandler {
 	return &PostHandler{
-		postUsecase: postUsecase,
-		postRepo:    postRepo,
-		jwtUtil:     jwtUtil,
-		logger:      logger,
-		userClient:  userClient,
+		postUsecase:     postUsecase,
+		categoryUsecase: categoryUsecase,
+		trustUsecase:    trustUsecase,
+		auth:            auth,
+		audit:           audit,
+		logger:          logger,
+		userClient:      userClient,
 	}
 }
 
 func (h *PostHandler) Register(router *gin.Engine) {
-	router.POST("/posts", h.CreatePost)
-	router.GET("/posts", h.GetPosts)
-	router.DELETE("/posts/:id", h.DeletePost)
-	router.PUT("/posts/:id", h.UpdatePost)
+	router.POST("/posts", h.auth.RequireAuth(), h.CreatePost)
+	router.GET("/posts", h.auth.OptionalAuth(), h.GetPosts)
+	router.GET("/posts/:id", h.auth.OptionalAuth(), h.GetPost)
+	router.DELETE("/posts/:id", h.auth.RequireAuth(), h.DeletePost)
+	router.PUT("/posts/:id", h.auth.RequireAuth(), h.UpdatePost)
+	router.GET("/posts/:id/revisions", h.auth.RequireAuth(), h.GetPostRevisions)
+	router.GET("/posts/:id/revisions/diff", h.auth.RequireAuth(), h.DiffPostRevisions)
+	router.GET("/posts/:id/revisions/:version", h.auth.RequireAuth(), h.GetPostRevision)
+	router.POST("/posts/:id/revisions/:version/rollback", h.auth.RequireAuth(), h.RollbackPost)
 }
 
 // CreatePost godoc
 // @Summary Создать новый пост
-// @Description Создает новый пост в системе
+// @Description Создает новый пост в разделе category_id (по умолчанию — в разделе general). Писать в раздел может только роль, которой это разрешено в настройках раздела. Метки (не больше 5) приводятся к нижнему регистру, синонимы заменяются основной меткой. Ссылки и изображения доступны с уровня доверия basic, число постов в сутки ограничено по уровню доверия
 // @Tags Посты
 // @Accept json
 // @Produce json
@@ -56,42 +65,47 @@ func (h *PostHandler) Register(router *gin.Engine) {
 // @Success 201 {object} entity.Post
 // @Failure 400 {object} entity.ErrorResponse
 // @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Раздел в архиве"
+// @Failure 429 {object} entity.ErrorResponse "Исчерпан лимит постов в сутки"
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /posts [post]
 func (h *PostHandler) CreatePost(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		h.logger.Warn("Authorization header required")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+	principal, ok := requirePrincipal(c)
+	if !ok {
 		return
 	}
 
-	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-	if tokenString == authHeader {
-		h.logger.Warn("Invalid Authorization header format", zap.String("header", authHeader))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+	var post entity.Post
+	if err := c.BindJSON(&post); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	userID, err := h.postRepo.GetUserIDByToken(c.Request.Context(), tokenString)
+	post.AuthorId = principal.UserID
+	if post.CategoryID == 0 {
+		post.CategoryID = entity.DefaultCategoryID
+	}
+
+	err := h.categoryUsecase.CheckAccess(c.Request.Context(), post.CategoryID, &principal, entity.CategoryActionPost)
 	if err != nil {
-		h.logger.Warn("Invalid token", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		abortCategoryError(c, h.logger, err, "Failed to check category access")
 		return
 	}
-
-	var post entity.Post
-	if err := c.BindJSON(&post); err != nil {
-		h.logger.Error("Failed to bind JSON", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.trustUsecase.CheckPost(c.Request.Context(), principal, post.Title+"\n"+post.Content); err != nil {
+		abortTrustError(c, h.logger, err, "Failed to check trust level")
 		return
 	}
 
-	post.AuthorId = userID
-
 	h.logger.Info("Creating post", zap.Any("post", post))
 	createdPost, err := h.postUsecase.CreatePost(c.Request.Context(), post)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTag) || errors.Is(err, usecase.ErrTooManyTags) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to create post", zap.Error(err))
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -103,52 +117,60 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 
 // GetPosts returns paginated list of posts with usernames
 // @Summary Получить посты
-// @Description Получить посты с юзернеймами
+// @Description Получить посты с юзернеймами из всех разделов, которые может читать пользователь
 // @Tags Посты
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param tag query []string false "Метки; параметр можно повторять" collectionFormat(multi)
+// @Param tag_mode query string false "all — посты со всеми метками, any — хотя бы с одной" Enums(all, any) default(all)
+// @Param sort query string false "new — новые, top — по счету голосов, hot — по счету с поправкой на возраст, controversial — спорные" Enums(new, top, hot, controversial) default(new)
+// @Param t query string false "Только посты за последний час, день, неделю, месяц или год" Enums(hour, day, week, month, year, all) default(all)
 // @Success 200 {object} map[string]interface{} "posts with usernames and total count"
+// @Failure 400 {object} entity.ErrorResponse
 // @Router /posts [get]
 func (h *PostHandler) GetPosts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset := (page - 1) * limit
 
+	hidden, err := h.categoryUsecase.HiddenCategoryIDs(c.Request.Context(), optionalPrincipal(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	filter := entity.PostFilter{
+		HiddenCategoryIDs: hidden,
+		Tags:              c.QueryArray("tag"),
+		TagMode:           c.Query("tag_mode"),
+		Sort:              c.Query("sort"),
+		Window:            c.Query("t"),
+		Limit:             limit,
+		Offset:            offset,
+	}
+
 	// Получаем посты с пагинацией
-	posts, err := h.postUsecase.GetPosts(c.Request.Context(), limit, offset)
+	posts, err := h.postUsecase.GetPosts(c.Request.Context(), filter)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTag) || errors.Is(err, usecase.ErrInvalidTagMode) ||
+			errors.Is(err, usecase.ErrInvalidPostSort) || errors.Is(err, usecase.ErrInvalidPostWindow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Получаем общее количество постов
-	total, err := h.postUsecase.GetTotalPostsCount(c.Request.Context())
+	total, err := h.postUsecase.GetTotalPostsCount(c.Request.Context(), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	// Добавляем имена пользователей к постам
-	postsWithUsernames := make([]map[string]interface{}, len(posts))
-	for i, post := range posts {
-		username, err := h.userClient.GetUsername(c.Request.Context(), post.AuthorId)
-		if err != nil {
-			h.logger.Warn("Failed to get username",
-				zap.Int("userID", post.AuthorId),
-				zap.Error(err))
-			username = "" // Используем пустое имя, если не удалось получить
-		}
-
-		postsWithUsernames[i] = map[string]interface{}{
-			"id":        post.ID,
-			"title":     post.Title,
-			"content":   post.Content,
-			"author_id": post.AuthorId,
-			"username":  username, // Добавляем имя пользователя
-		}
-	}
+	postsWithUsernames := postListItems(c, h.logger, h.userClient, posts)
 
 	c.JSON(http.StatusOK, gin.H{
 		"posts": postsWithUsernames,
@@ -158,9 +180,82 @@ func (h *PostHandler) GetPosts(c *gin.Context) {
 	})
 }
 
+// GetPost godoc
+// @Summary Получить пост
+// @Description Возвращает пост с датами создания и изменения, автором, количеством комментариев и признаком редактирования. Скрытый по жалобам пост видят только автор и модераторы
+// @Tags Посты
+// @Produce json
+// @Param id path int true "ID поста"
+// @Success 200 {object} entity.PostDetails
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id} [get]
+func (h *PostHandler) GetPost(c *gin.Context) {
+	postIDStr := c.Param("id")
+	postID, err := strconv.Atoi(postIDStr)
+	if err != nil {
+		h.logger.Warn("Invalid post ID", zap.String("postID", postIDStr), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+
+	post, err := h.postUsecase.GetPostDetails(c.Request.Context(), postID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrPostNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		h.logger.Error("Failed to get post", zap.Int("postID", postID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get post"})
+		return
+	}
+
+	// Скрытый по жалобам пост до решения модератора видят только автор и
+	// модераторы
+	viewer := optionalPrincipal(c)
+	if post.Hidden && (viewer == nil || viewer.UserID != post.AuthorId && !viewer.Can(entity.PermReportReview)) {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+		return
+	}
+
+	// Пост из раздела, который пользователь не может читать, для него не существует
+	err = h.categoryUsecase.CheckAccess(c.Request.Context(), post.CategoryID, viewer, entity.CategoryActionRead)
+	if err != nil {
+		if errors.Is(err, usecase.ErrCategoryNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+			return
+		}
+		abortCategoryError(c, h.logger, err, "Failed to get post")
+		return
+	}
+
+	author, err := h.userClient.GetUser(c.Request.Context(), post.AuthorId)
+	if err != nil {
+		// Пост показываем и без сведений об авторе
+		h.logger.Warn("Failed to get post author", zap.Int("userID", post.AuthorId), zap.Error(err))
+	}
+	post.AuthorUsername = author.Username
+	post.AuthorRole = author.Role
+
+	// Правку модератора или администратора показываем с его именем
+	if post.EditedBy != nil {
+		post.EditorUsername = author.Username
+		if *post.EditedBy != post.AuthorId {
+			editor, err := h.userClient.GetUser(c.Request.Context(), *post.EditedBy)
+			if err != nil {
+				h.logger.Warn("Failed to get post editor", zap.Int("userID", *post.EditedBy), zap.Error(err))
+			}
+			post.EditorUsername = editor.Username
+		}
+	}
+
+	c.JSON(http.StatusOK, post)
+}
+
 // DeletePost godoc
 // @Summary Удалить пост
-// @Description Удаляет пост по ID (доступно автору или администратору)
+// @Description Переносит пост в корзину вместе с комментариями (доступно автору или с правом post.delete.any). Модератор может восстановить его, пока не истек срок хранения
 // @Tags Посты
 // @Accept json
 // @Produce json
@@ -174,17 +269,8 @@ func (h *PostHandler) GetPosts(c *gin.Context) {
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /posts/{id} [delete]
 func (h *PostHandler) DeletePost(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		h.logger.Warn("Authorization header required")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-		return
-	}
-
-	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-	if tokenString == authHeader {
-		h.logger.Warn("Invalid Authorization header format", zap.String("header", authHeader))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+	principal, ok := requirePrincipal(c)
+	if !ok {
 		return
 	}
 
@@ -196,76 +282,54 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.jwtUtil.GetUserIDFromToken(tokenString)
+	post, err := h.postUsecase.GetPostByID(c.Request.Context(), postID)
 	if err != nil {
-		h.logger.Warn("Invalid token or user ID", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user ID"})
+		h.abortPostError(c, postID, err, "Failed to get post")
 		return
 	}
 
-	userRole, err := h.jwtUtil.GetRoleFromToken(tokenString)
-	if err != nil {
-		h.logger.Warn("Invalid token or user role", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user role"})
+	if post.AuthorId != principal.UserID && !principal.Can(entity.PermPostDeleteAny) {
+		h.logger.Warn("Unauthorized attempt to delete post",
+			zap.Int("userID", principal.UserID),
+			zap.Int("postAuthorID", post.AuthorId),
+			zap.Int("postID", postID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this post"})
 		return
 	}
 
-	if userRole != "admin" {
-		post, err := h.postRepo.GetPostByID(c.Request.Context(), postID)
-		if err != nil {
-			h.logger.Error("Failed to get post", zap.Int("postID", postID), zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get post"})
-			return
-		}
-
-		if post.AuthorId != userID {
-			h.logger.Warn("Unauthorized attempt to delete post",
-				zap.Int("userID", userID),
-				zap.Int("postAuthorID", post.AuthorId),
-				zap.Int("postID", postID))
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this post"})
-			return
-		}
-	}
-
 	h.logger.Info("Deleting post", zap.Int("postID", postID))
-	err = h.postUsecase.DeletePost(c.Request.Context(), postID)
+	err = h.postUsecase.DeletePost(c.Request.Context(), postID, principal.UserID)
 	if err != nil {
-		h.logger.Error("Failed to delete post", zap.Int("postID", postID), zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+		h.abortPostError(c, postID, err, "Failed to delete post")
 		return
 	}
 
+	if post.AuthorId != principal.UserID {
+		h.audit.Record(c, entity.AuditPostDelete, entity.AuditTargetPost, postID, post, nil)
+	}
 	h.logger.Info("Post deleted successfully", zap.Int("postID", postID))
 	c.Status(http.StatusNoContent)
 }
 
-// UpdatePost updates an existing po
+// UpdatePost godoc
 // @Summary Редактировать пост
-// @Description Редактировать пост(если ты админ или владелец поста)
+// @Description Редактировать пост (доступно автору или с правом post.update.any). Прежняя версия сохраняется в истории вместе с редактором и причиной. Если передано поле tags, метки поста заменяются. Ссылки и изображения доступны с уровня доверия basic
 // @Tags Посты
 // @Accept json
 // @Produce json
-// @Param id path int true "Post ID"
-// @Param Authorization header string true "Bearer token"
-// @Param post body entity.Post true "Post data"
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param post body entity.UpdatePostRequest true "Новые заголовок, текст и метки, причина правки"
 // @Success 200 {object} entity.Post
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
 // @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
 // @Failure 500 {object} entity.ErrorResponse
 // @Router /posts/{id} [put]
 func (h *PostHandler) UpdatePost(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		h.logger.Warn("Authorization header required")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-		return
-	}
-
-	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-	if tokenString == authHeader {
-		h.logger.Warn("Invalid Authorization header format", zap.String("header", authHeader))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+	principal, ok := requirePrincipal(c)
+	if !ok {
 		return
 	}
 
@@ -277,54 +341,296 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.jwtUtil.GetUserIDFromToken(tokenString)
+	var req entity.UpdatePostRequest
+	if err := c.BindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before, ok := h.authorizePostEdit(c, principal, postID, "update")
+	if !ok {
+		return
+	}
+	if err := h.trustUsecase.CheckContent(c.Request.Context(), principal, req.Title+"\n"+req.Content); err != nil {
+		abortTrustError(c, h.logger, err, "Failed to check trust level")
+		return
+	}
+
+	updatedPost, err := h.postUsecase.UpdatePost(c.Request.Context(), entity.Post{
+		ID:         postID,
+		Title:      req.Title,
+		Content:    req.Content,
+		Tags:       req.Tags,
+		EditedBy:   &principal.UserID,
+		EditReason: req.Reason,
+	})
 	if err != nil {
-		h.logger.Warn("Invalid token or user ID", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user ID"})
+		h.abortPostError(c, postID, err, "Failed to update post")
 		return
 	}
 
-	userRole, err := h.jwtUtil.GetRoleFromToken(tokenString)
+	if before.AuthorId != principal.UserID {
+		h.audit.Record(c, entity.AuditPostUpdate, entity.AuditTargetPost, postID, before, updatedPost)
+	}
+	h.logger.Info("Post updated successfully", zap.Int("postID", postID), zap.Int("editorID", principal.UserID))
+	c.JSON(http.StatusOK, updatedPost)
+}
+
+// GetPostRevisions godoc
+// @Summary История правок поста
+// @Description Возвращает все версии поста от первой до текущей: кто, когда и по какой причине сделал каждую правку. Доступно автору или с правом post.update.any
+// @Tags Посты
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} map[string]interface{} "revisions"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/revisions [get]
+func (h *PostHandler) GetPostRevisions(c *gin.Context) {
+	postID, ok := h.authorizeRevisionAccess(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.postUsecase.GetPostRevisions(c.Request.Context(), postID)
 	if err != nil {
-		h.logger.Warn("Invalid token or user role", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token or user role"})
+		h.abortPostError(c, postID, err, "Failed to get post revisions")
 		return
 	}
-	var newpost entity.Post
-	if err := c.BindJSON(&newpost); err != nil {
-		h.logger.Error("Failed to bind JSON", zap.Error(err))
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	h.fillEditorUsernames(c, revisions)
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// GetPostRevision godoc
+// @Summary Версия поста
+// @Description Возвращает заголовок и текст поста в версии version. Доступно автору или с правом post.update.any
+// @Tags Посты
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param version path int true "Номер версии"
+// @Success 200 {object} entity.PostRevision
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/revisions/{version} [get]
+func (h *PostHandler) GetPostRevision(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+	postID, ok := h.authorizeRevisionAccess(c)
+	if !ok {
 		return
 	}
-	var updatedpost entity.Post
-	if userRole != "admin" {
-		post, err := h.postRepo.GetPostByID(c.Request.Context(), postID)
-		post.Title = newpost.Title
-		post.Content = newpost.Content
-		if err != nil {
-			h.logger.Error("Failed to get post", zap.Int("postID", postID), zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to get post"})
-			return
-		}
 
-		if post.AuthorId != userID {
-			h.logger.Warn("Unauthorized attempt to update post",
-				zap.Int("userID", userID),
-				zap.Int("postAuthorID", post.AuthorId),
-				zap.Int("postID", postID))
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to delete this post"})
-			return
-		}
-		h.logger.Info("Deleting post", zap.Int("postID", postID))
-		updatedpost2, err := h.postUsecase.UpdatePost(c.Request.Context(), *post)
-		updatedpost = *updatedpost2
-		if err != nil {
-			h.logger.Error("Failed to delete post", zap.Int("postID", postID), zap.Error(err))
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+	revision, err := h.postUsecase.GetPostRevision(c.Request.Context(), postID, version)
+	if err != nil {
+		h.abortPostError(c, postID, err, "Failed to get post revision")
+		return
+	}
+	revisions := []entity.PostRevision{revision}
+	h.fillEditorUsernames(c, revisions)
+
+	c.JSON(http.StatusOK, revisions[0])
+}
+
+// DiffPostRevisions godoc
+// @Summary Сравнить версии поста
+// @Description Возвращает построчную разницу заголовков и текстов версий from и to. По умолчанию to — текущая версия, from — предыдущая перед to. Доступно автору или с правом post.update.any
+// @Tags Посты
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param from query int false "Номер исходной версии"
+// @Param to query int false "Номер итоговой версии"
+// @Success 200 {object} entity.PostRevisionDiff
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/revisions/diff [get]
+func (h *PostHandler) DiffPostRevisions(c *gin.Context) {
+	from, to, ok := revisionRange(c)
+	if !ok {
+		return
+	}
+	postID, ok := h.authorizeRevisionAccess(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.postUsecase.DiffPostRevisions(c.Request.Context(), postID, from, to)
+	if err != nil {
+		h.abortPostError(c, postID, err, "Failed to diff post revisions")
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// RollbackPost godoc
+// @Summary Откатить пост к версии
+// @Description Возвращает посту заголовок и текст версии version. Откат сохраняется в истории как обычная правка. Доступно автору или с правом post.update.any
+// @Tags Посты
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param version path int true "Номер версии"
+// @Param request body entity.RollbackPostRequest false "Причина отката"
+// @Success 200 {object} entity.Post
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/revisions/{version}/rollback [post]
+func (h *PostHandler) RollbackPost(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	// Тело необязательно: без него используется причина по умолчанию
+	var req entity.RollbackPostRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 	}
 
-	h.logger.Info("Post deleted successfully", zap.Int("postID", postID))
-	c.JSON(http.StatusOK, updatedpost)
+	before, ok := h.authorizePostEdit(c, principal, postID, "update")
+	if !ok {
+		return
+	}
+
+	post, err := h.postUsecase.RollbackPost(c.Request.Context(), postID, version, principal.UserID, req.Reason)
+	if err != nil {
+		h.abortPostError(c, postID, err, "Failed to roll back post")
+		return
+	}
+
+	if before.AuthorId != principal.UserID {
+		h.audit.Record(c, entity.AuditPostRollback, entity.AuditTargetPost, postID, before, post)
+	}
+	h.logger.Info("Post rolled back", zap.Int("postID", postID), zap.Int("version", version), zap.Int("editorID", principal.UserID))
+	c.JSON(http.StatusOK, post)
+}
+
+// authorizeRevisionAccess проверяет, что историю поста запрашивает его автор
+// или пользователь с правом post.update.any, и возвращает id поста.
+func (h *PostHandler) authorizeRevisionAccess(c *gin.Context) (int, bool) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return 0, false
+	}
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return 0, false
+	}
+	_, ok = h.authorizePostEdit(c, principal, postID, "view revisions of")
+	return postID, ok
+}
+
+// authorizePostEdit пропускает автора поста и пользователей с правом
+// post.update.any и возвращает пост до изменения: правка чужого поста
+// попадает в журнал. В остальных случаях отвечает ошибкой и возвращает false.
+func (h *PostHandler) authorizePostEdit(c *gin.Context, principal entity.Principal, postID int, action string) (*entity.Post, bool) {
+	post, err := h.postUsecase.GetPostByID(c.Request.Context(), postID)
+	if err != nil {
+		h.abortPostError(c, postID, err, "Failed to get post")
+		return nil, false
+	}
+	if post.AuthorId != principal.UserID && !principal.Can(entity.PermPostUpdateAny) {
+		h.logger.Warn("Unauthorized attempt to "+action+" post",
+			zap.Int("userID", principal.UserID),
+			zap.Int("postAuthorID", post.AuthorId),
+			zap.Int("postID", postID))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to " + action + " this post"})
+		return nil, false
+	}
+	return post, true
+}
+
+func (h *PostHandler) fillEditorUsernames(c *gin.Context, revisions []entity.PostRevision) {
+	editorIDs := make([]int, len(revisions))
+	for i, rev := range revisions {
+		editorIDs[i] = rev.EditorID
+	}
+	editors, err := h.userClient.GetUsers(c.Request.Context(), editorIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", editorIDs), zap.Error(err))
+	}
+	for i := range revisions {
+		revisions[i].EditorUsername = editors[revisions[i].EditorID].Username
+	}
+}
+
+func (h *PostHandler) abortPostError(c *gin.Context, postID int, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrPostNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+	case errors.Is(err, usecase.ErrRevisionNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidTag), errors.Is(err, usecase.ErrTooManyTags):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Int("postID", postID), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// postListItems добавляет к постам имена авторов для списков постов. Имена
+// получаем одним запросом; если не удалось, отдаем пустые.
+func postListItems(c *gin.Context, logger *zap.Logger, userClient grpc.UserClientInterface, posts []entity.Post) []map[string]interface{} {
+	authorIDs := make([]int, len(posts))
+	for i, post := range posts {
+		authorIDs[i] = post.AuthorId
+	}
+	authors, err := userClient.GetUsers(c.Request.Context(), authorIDs)
+	if err != nil {
+		logger.Warn("Failed to get usernames", zap.Ints("userIDs", authorIDs), zap.Error(err))
+	}
+
+	items := make([]map[string]interface{}, len(posts))
+	for i, post := range posts {
+		items[i] = map[string]interface{}{
+			"id":          post.ID,
+			"title":       post.Title,
+			"content":     post.Content,
+			"author_id":   post.AuthorId,
+			"category_id": post.CategoryID,
+			"tags":        post.Tags,
+			"upvotes":     post.Upvotes,
+			"downvotes":   post.Downvotes,
+			"score":       post.Score,
+			"reactions":   post.Reactions,
+			"created_at":  post.CreatedAt,
+			"username":    authors[post.AuthorId].Username,
+		}
+	}
+	return items
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,11 +25,12 @@ func TestPostHandler_CreatePost_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	post := &entity.Post{
 		Title:   "Test Post",
@@ -36,23 +38,21 @@ func TestPostHandler_CreatePost_Success(t *testing.T) {
 	}
 	postJSON, _ := json.Marshal(post)
 
-	mockPostRepo.On("GetUserIDByToken", mock.Anything, "valid.jwt.token").Return(1, nil)
 	mockPostUsecase.On("CreatePost", mock.Anything, mock.Anything).Return(post, nil)
 
 	req, _ := http.NewRequest("POST", "/posts", bytes.NewBuffer(postJSON))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer valid.jwt.token")
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 
 	postHandler.CreatePost(c)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	assert.Contains(t, w.Body.String(), "Test Post")
 
-	mockPostRepo.AssertExpectations(t)
 	mockPostUsecase.AssertExpectations(t)
 }
 
@@ -61,11 +61,12 @@ func TestPostHandler_CreatePost_MissingAuthorizationHeader(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	post := entity.Post{
 		Title:   "Test Post",
@@ -77,10 +78,9 @@ func TestPostHandler_CreatePost_MissingAuthorizationHeader(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-
-	postHandler.CreatePost(c)
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Authorization header required")
@@ -91,11 +91,12 @@ func TestPostHandler_CreatePost_InvalidAuthorizationHeaderFormat(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	post := entity.Post{
 		Title:   "Test Post",
@@ -108,10 +109,9 @@ func TestPostHandler_CreatePost_InvalidAuthorizationHeaderFormat(t *testing.T) {
 	req.Header.Set("Authorization", "InvalidFormat")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-
-	postHandler.CreatePost(c)
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Invalid Authorization header format")
@@ -122,11 +122,12 @@ func TestPostHandler_CreatePost_InvalidToken(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	post := entity.Post{
 		Title:   "Test Post",
@@ -134,22 +135,18 @@ func TestPostHandler_CreatePost_InvalidToken(t *testing.T) {
 	}
 	postJSON, _ := json.Marshal(post)
 
-	mockPostRepo.On("GetUserIDByToken", mock.Anything, "invalid.jwt.token\t").Return(0, errors.New("invalid token"))
-
 	req, _ := http.NewRequest("POST", "/posts", bytes.NewBuffer(postJSON))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer invalid.jwt.token	")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-
-	postHandler.CreatePost(c)
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Invalid token")
 
-	mockPostRepo.AssertExpectations(t)
 }
 
 func TestPostHandler_CreatePost_FailedToCreatePost(t *testing.T) {
@@ -157,11 +154,12 @@ func TestPostHandler_CreatePost_FailedToCreatePost(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	post := &entity.Post{
 		Title:   "Test Post",
@@ -169,23 +167,21 @@ func TestPostHandler_CreatePost_FailedToCreatePost(t *testing.T) {
 	}
 	postJSON, _ := json.Marshal(post)
 
-	mockPostRepo.On("GetUserIDByToken", mock.Anything, "valid.jwt.token").Return(1, nil)
 	mockPostUsecase.On("CreatePost", mock.Anything, mock.Anything).Return(post, errors.New("failed to create post"))
 
 	req, _ := http.NewRequest("POST", "/posts", bytes.NewBuffer(postJSON))
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer valid.jwt.token")
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 
 	postHandler.CreatePost(c)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Contains(t, w.Body.String(), "failed to create post")
 
-	mockPostRepo.AssertExpectations(t)
 	mockPostUsecase.AssertExpectations(t)
 }
 
@@ -195,17 +191,19 @@ func TestPostHandler_GetPosts_Success(t *testing.T) {
 
 	mockPostUsecase := new(mocks.PostUsecase)
 	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	posts := []entity.Post{
 		{ID: 1, Title: "Post 1", Content: "Content 1"},
 		{ID: 2, Title: "Post 2", Content: "Content 2"},
 	}
 
-	mockPostRepo.On("GetPosts", mock.Anything, 10, 0).Return(posts, nil)
+	mockPostRepo.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(posts, nil)
 
 	req, _ := http.NewRequest("GET", "/posts", nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -231,12 +229,14 @@ func TestPostHandler_GetPosts_FailedToGetPosts(t *testing.T) {
 
 	mockPostUsecase := new(mocks.PostUsecase)
 	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
-	mockPostRepo.On("GetPosts", mock.Anything, 10, 0).Return(nil, errors.New("failed to get posts"))
+	mockPostRepo.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(nil, errors.New("failed to get posts"))
 
 	req, _ := http.NewRequest("GET", "/posts", nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -253,26 +253,119 @@ func TestPostHandler_GetPosts_FailedToGetPosts(t *testing.T) {
 	mockPostRepo.AssertExpectations(t)
 }
 
+func TestPostHandler_GetPost_Success(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	details := &entity.PostDetails{
+		Post:          entity.Post{ID: 1, AuthorId: 2, Title: "Test Post", Content: "Text"},
+		CommentsCount: 5,
+		Edited:        true,
+	}
+	mockPostUsecase.On("GetPostDetails", mock.Anything, 1).Return(details, nil)
+	mockUserClient.On("GetUser", mock.Anything, 2).Return(entity.UserInfo{ID: 2, Username: "author", Role: "moderator"}, nil)
+
+	router := gin.New()
+	router.GET("/posts/:id", postHandler.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "author", resp["author_username"])
+	assert.Equal(t, "moderator", resp["author_role"])
+	assert.Equal(t, float64(5), resp["comments_count"])
+	assert.Equal(t, true, resp["edited"])
+	assert.Contains(t, resp, "created_at")
+	assert.Contains(t, resp, "updated_at")
+
+	mockPostUsecase.AssertExpectations(t)
+	mockUserClient.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPost_AuthorUnavailable(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	mockPostUsecase.On("GetPostDetails", mock.Anything, 1).Return(&entity.PostDetails{Post: entity.Post{ID: 1, AuthorId: 2}}, nil)
+	mockUserClient.On("GetUser", mock.Anything, 2).Return(entity.UserInfo{}, errors.New("auth service unavailable"))
+
+	router := gin.New()
+	router.GET("/posts/:id", postHandler.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"author_username":""`)
+}
+
+func TestPostHandler_GetPost_NotFound(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, logger, new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostDetails", mock.Anything, 404).Return(nil, usecase.ErrPostNotFound)
+
+	router := gin.New()
+	router.GET("/posts/:id", postHandler.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/404", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "Post not found")
+}
+
+func TestPostHandler_GetPost_InvalidID(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	postHandler := NewPostHandler(new(mocks.PostUsecase), openCategories(), openTrust(), nil, nil, logger, new(mocks.UserClient))
+
+	router := gin.New()
+	router.GET("/posts/:id", postHandler.GetPost)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/abc", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestPostHandler_DeletePost_MissingAuthorizationHeader(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-
-	postHandler.DeletePost(c)
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Authorization header required")
@@ -283,22 +376,21 @@ func TestPostHandler_DeletePost_InvalidAuthorizationHeaderFormat(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
 	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "InvalidFormat")
 
 	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-
-	postHandler.DeletePost(c)
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "Invalid Authorization header format")
@@ -309,32 +401,29 @@ func TestPostHandler_DeletePost_Success_Owner(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
-	token, err := jwtUtil.GenerateToken(1, "user")
-	assert.NoError(t, err)
-
-	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
-	mockPostUsecase.On("DeletePost", mock.Anything, 1).Return(nil)
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("DeletePost", mock.Anything, 1, 1).Return(nil)
 
 	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "user"})
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
 
 	postHandler.DeletePost(c)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	mockPostRepo.AssertExpectations(t)
 	mockPostUsecase.AssertExpectations(t)
 }
 
@@ -343,29 +432,379 @@ func TestPostHandler_DeletePost_Success_Admin(t *testing.T) {
 	logger, _ := zap.NewProduction()
 
 	mockPostUsecase := new(mocks.PostUsecase)
-	mockPostRepo := new(mocks.PostRepository)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
 	jwtUtil := utils.NewJWTUtil("secret")
 	mockUserClient := &grpc.UserClient{}
 
-	postHandler := NewPostHandler(mockPostUsecase, mockPostRepo, jwtUtil, logger, mockUserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
 
-	token, err := jwtUtil.GenerateToken(1, "admin")
-	assert.NoError(t, err)
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 2}, nil)
+	mockPostUsecase.On("DeletePost", mock.Anything, 1, 1).Return(nil)
 
-	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(entity.Post{ID: 1, AuthorId: 2}, nil)
-	mockPostUsecase.On("DeletePost", mock.Anything, 1).Return(nil)
+	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermPostDeleteAny}})
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	postHandler.DeletePost(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_DeletePost_ForbiddenWithoutPermission(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	mockUserClient := &grpc.UserClient{}
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 2}, nil)
 
 	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+token)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
+	c.Set(principalKey, entity.Principal{UserID: 1, Role: "moderator", Permissions: []string{entity.PermCommentDeleteAny}})
 	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
 
 	postHandler.DeletePost(c)
 
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	mockPostUsecase.AssertNotCalled(t, "DeletePost", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_DeletePost_RevokedToken(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockTokenRepo := new(mocks.TokenRepository)
+	mockPermissionRepo := new(mocks.PermissionRepository)
+	jwtUtil := utils.NewJWTUtil("secret")
+	mockUserClient := &grpc.UserClient{}
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), NewAuthMiddleware(mockTokenRepo, mockPermissionRepo, noSanctions(), jwtUtil, logger), nil, logger, mockUserClient)
+
+	token, err := jwtUtil.GenerateToken(1, "user")
+	assert.NoError(t, err)
+
+	mockTokenRepo.On("IsTokenRevoked", mock.Anything, token).Return(true, nil)
+
+	req, _ := http.NewRequest("DELETE", "/posts/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	router := gin.New()
+	postHandler.Register(router)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Token has been revoked")
+
+	mockTokenRepo.AssertExpectations(t)
+	mockPostUsecase.AssertNotCalled(t, "DeletePost", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_GetPosts_BatchesUsernames(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	posts := []entity.Post{
+		{ID: 1, AuthorId: 2, Title: "Post 1", Content: "Content 1"},
+		{ID: 2, AuthorId: 3, Title: "Post 2", Content: "Content 2"},
+		{ID: 3, AuthorId: 2, Title: "Post 3", Content: "Content 3"},
+	}
+	mockPostUsecase.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(posts, nil)
+	mockPostUsecase.On("GetTotalPostsCount", mock.Anything, entity.PostFilter{Limit: 10}).Return(3, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2, 3, 2}).Return(map[int]entity.UserInfo{
+		2: {ID: 2, Username: "alice"},
+		3: {ID: 3, Username: "bob"},
+	}, nil).Once()
+
+	req, _ := http.NewRequest("GET", "/posts", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	postHandler.GetPosts(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Posts []map[string]interface{} `json:"posts"`
+		Total int                      `json:"total"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 3, resp.Total)
+	assert.Len(t, resp.Posts, 3)
+	assert.Equal(t, "alice", resp.Posts[0]["username"])
+	assert.Equal(t, "bob", resp.Posts[1]["username"])
+	assert.Equal(t, "alice", resp.Posts[2]["username"])
+
+	mockUserClient.AssertNumberOfCalls(t, "GetUsers", 1)
+	mockUserClient.AssertNotCalled(t, "GetUsername", mock.Anything, mock.Anything)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPosts_UserServiceUnavailable(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, logger, mockUserClient)
+
+	posts := []entity.Post{{ID: 1, AuthorId: 2, Title: "Post 1", Content: "Content 1"}}
+	mockPostUsecase.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(posts, nil)
+	mockPostUsecase.On("GetTotalPostsCount", mock.Anything, entity.PostFilter{Limit: 10}).Return(1, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).Return(nil, errors.New("unavailable"))
+
+	req, _ := http.NewRequest("GET", "/posts", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	postHandler.GetPosts(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"username":""`)
+}
+
+func servePostRoute(h gin.HandlerFunc, method, route, target, body string, principal entity.Principal) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		c.Set(principalKey, principal)
+	}, h)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPostHandler_UpdatePost_Author(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	authorID := 1
+	update := entity.Post{ID: 1, Title: "New", Content: "Text", EditedBy: &authorID, EditReason: "typo"}
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("UpdatePost", mock.Anything, update).Return(&entity.Post{ID: 1, AuthorId: 1, Title: "New", Content: "Text", EditedBy: &authorID}, nil)
+
+	w := servePostRoute(postHandler.UpdatePost, http.MethodPut, "/posts/:id", "/posts/1",
+		`{"title":"New","content":"Text","reason":"typo"}`, entity.Principal{UserID: 1, Role: "user"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_UpdatePost_ModeratorEditsOthersPost(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockAuditRepo := new(mocks.AuditRepository)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, NewAuditor(mockAuditRepo, zap.NewNop()), zap.NewNop(), new(mocks.UserClient))
+
+	moderatorID := 7
+	update := entity.Post{ID: 1, Title: "New", Content: "Text", EditedBy: &moderatorID, EditReason: "rules"}
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1, Title: "Old", Content: "Spam"}, nil)
+	mockAuditRepo.On("Append", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == 7 && entry.ActorRole == "moderator" && entry.Action == entity.AuditPostUpdate &&
+			entry.TargetType == entity.AuditTargetPost && entry.TargetID == "1" &&
+			bytes.Contains(entry.Before, []byte(`"title":"Old"`)) && bytes.Contains(entry.After, []byte(`"title":"New"`))
+	})).Return(nil)
+	mockPostUsecase.On("UpdatePost", mock.Anything, update).Return(&entity.Post{ID: 1, AuthorId: 1, Title: "New", Content: "Text", EditedBy: &moderatorID, EditReason: "rules"}, nil)
+
+	principal := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermPostUpdateAny}}
+	w := servePostRoute(postHandler.UpdatePost, http.MethodPut, "/posts/:id", "/posts/1",
+		`{"title":"New","content":"Text","reason":"rules"}`, principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp entity.Post
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 7, *resp.EditedBy)
+	assert.Equal(t, "rules", resp.EditReason)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestPostHandler_UpdatePost_Forbidden(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+
+	w := servePostRoute(postHandler.UpdatePost, http.MethodPut, "/posts/:id", "/posts/1",
+		`{"title":"New","content":"Text"}`, entity.Principal{UserID: 2, Role: "user"})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "UpdatePost", mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_UpdatePost_NotFound(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 9).Return(nil, usecase.ErrPostNotFound)
+
+	w := servePostRoute(postHandler.UpdatePost, http.MethodPut, "/posts/:id", "/posts/9",
+		`{"title":"New","content":"Text"}`, entity.Principal{UserID: 2, Role: "user"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPostHandler_DeletePost_NotFound(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 9).Return(nil, usecase.ErrPostNotFound)
+
+	w := servePostRoute(postHandler.DeletePost, http.MethodDelete, "/posts/:id", "/posts/9", "", entity.Principal{UserID: 2, Role: "user"})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "DeletePost", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_GetPost_EditedByModerator(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	moderatorID := 7
+	details := &entity.PostDetails{
+		Post:   entity.Post{ID: 1, AuthorId: 2, EditedBy: &moderatorID, EditReason: "rules"},
+		Edited: true,
+	}
+	mockPostUsecase.On("GetPostDetails", mock.Anything, 1).Return(details, nil)
+	mockUserClient.On("GetUser", mock.Anything, 2).Return(entity.UserInfo{ID: 2, Username: "author"}, nil)
+	mockUserClient.On("GetUser", mock.Anything, 7).Return(entity.UserInfo{ID: 7, Username: "mod"}, nil)
+
+	router := gin.New()
+	router.GET("/posts/:id", postHandler.GetPost)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/posts/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, float64(7), resp["edited_by"])
+	assert.Equal(t, "mod", resp["edited_by_username"])
+	assert.Equal(t, "rules", resp["edit_reason"])
+}
+
+func TestPostHandler_GetPostRevisions(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("GetPostRevisions", mock.Anything, 1).Return([]entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "v1", EditorID: 1},
+		{Version: 2, PostID: 1, Title: "v2", EditorID: 7, Reason: "rules", Current: true},
+	}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{1, 7}).Return(map[int]entity.UserInfo{
+		1: {ID: 1, Username: "author"},
+		7: {ID: 7, Username: "mod"},
+	}, nil)
+
+	principal := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermPostUpdateAny}}
+	w := servePostRoute(postHandler.GetPostRevisions, http.MethodGet, "/posts/:id/revisions", "/posts/1/revisions", "", principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Revisions []entity.PostRevision `json:"revisions"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Revisions, 2)
+	assert.Equal(t, "author", resp.Revisions[0].EditorUsername)
+	assert.Equal(t, "mod", resp.Revisions[1].EditorUsername)
+}
+
+func TestPostHandler_GetPostRevisions_Forbidden(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+
+	w := servePostRoute(postHandler.GetPostRevisions, http.MethodGet, "/posts/:id/revisions", "/posts/1/revisions", "", entity.Principal{UserID: 2})
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "GetPostRevisions", mock.Anything, mock.Anything)
+}
+
+func TestPostHandler_GetPostRevision(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("GetPostRevision", mock.Anything, 1, 1).Return(entity.PostRevision{Version: 1, PostID: 1, Title: "v1", EditorID: 1}, nil)
+	mockPostUsecase.On("GetPostRevision", mock.Anything, 1, 5).Return(entity.PostRevision{}, usecase.ErrRevisionNotFound)
+	mockUserClient.On("GetUsers", mock.Anything, []int{1}).Return(map[int]entity.UserInfo{1: {ID: 1, Username: "author"}}, nil)
+
+	principal := entity.Principal{UserID: 1}
+	w := servePostRoute(postHandler.GetPostRevision, http.MethodGet, "/posts/:id/revisions/:version", "/posts/1/revisions/1", "", principal)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp entity.PostRevision
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "v1", resp.Title)
+	assert.Equal(t, "author", resp.EditorUsername)
+
+	w = servePostRoute(postHandler.GetPostRevision, http.MethodGet, "/posts/:id/revisions/:version", "/posts/1/revisions/5", "", principal)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = servePostRoute(postHandler.GetPostRevision, http.MethodGet, "/posts/:id/revisions/:version", "/posts/1/revisions/x", "", principal)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPostHandler_DiffPostRevisions(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("DiffPostRevisions", mock.Anything, 1, 1, 2).Return(entity.PostRevisionDiff{From: 1, To: 2, Changed: true}, nil)
+
+	principal := entity.Principal{UserID: 7, Permissions: []string{entity.PermPostUpdateAny}}
+	w := servePostRoute(postHandler.DiffPostRevisions, http.MethodGet, "/posts/:id/revisions/diff", "/posts/1/revisions/diff?from=1&to=2", "", principal)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp entity.PostRevisionDiff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Changed)
+}
+
+func TestPostHandler_RollbackPost(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	postHandler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	moderatorID := 7
+	mockPostUsecase.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 1}, nil)
+	mockPostUsecase.On("RollbackPost", mock.Anything, 1, 2, 7, "").Return(&entity.Post{ID: 1, EditedBy: &moderatorID, EditReason: "rollback to version 2"}, nil).Once()
+	mockPostUsecase.On("RollbackPost", mock.Anything, 1, 1, 7, "vandalism").Return(&entity.Post{ID: 1, EditedBy: &moderatorID, EditReason: "vandalism"}, nil).Once()
+
+	principal := entity.Principal{UserID: 7, Permissions: []string{entity.PermPostUpdateAny}}
+	w := servePostRoute(postHandler.RollbackPost, http.MethodPost, "/posts/:id/revisions/:version/rollback", "/posts/1/revisions/2/rollback", "", principal)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = servePostRoute(postHandler.RollbackPost, http.MethodPost, "/posts/:id/revisions/:version/rollback", "/posts/1/revisions/1/rollback", `{"reason":"vandalism"}`, principal)
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	mockPostUsecase.AssertExpectations(t)
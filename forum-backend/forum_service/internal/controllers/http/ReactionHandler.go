@@ -0,0 +1,303 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const maxReactionsPageLimit = 100
+
+type ReactionHandler struct {
+	reactionUsecase usecase.ReactionUsecase
+	categoryUsecase usecase.CategoryUsecase
+	auth            *AuthMiddleware
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
+}
+
+func NewReactionHandler(
+	reactionUsecase usecase.ReactionUsecase,
+	categoryUsecase usecase.CategoryUsecase,
+	auth *AuthMiddleware,
+	logger *zap.Logger,
+	userClient grpc.UserClientInterface,
+) *ReactionHandler {
+	return &ReactionHandler{
+		reactionUsecase: reactionUsecase,
+		categoryUsecase: categoryUsecase,
+		auth:            auth,
+		logger:          logger,
+		userClient:      userClient,
+	}
+}
+
+func (h *ReactionHandler) Register(router *gin.Engine) {
+	router.GET("/reactions/kinds", h.GetReactionKinds)
+
+	router.GET("/posts/:id/reactions", h.auth.OptionalAuth(), h.GetPostReactions)
+	router.PUT("/posts/:id/reactions/:kind", h.auth.RequireAuth(), h.AddPostReaction)
+	router.DELETE("/posts/:id/reactions/:kind", h.auth.RequireAuth(), h.RemovePostReaction)
+
+	router.GET("/comments/:id/reactions", h.auth.OptionalAuth(), h.GetCommentReactions)
+	router.PUT("/comments/:id/reactions/:kind", h.auth.RequireAuth(), h.AddCommentReaction)
+	router.DELETE("/comments/:id/reactions/:kind", h.auth.RequireAuth(), h.RemoveCommentReaction)
+}
+
+// GetReactionKinds godoc
+// @Summary Виды реакций
+// @Description Возвращает виды реакций, которые можно ставить: голоса up и down и эмодзи из настроек сервиса
+// @Tags Реакции
+// @Produce json
+// @Success 200 {object} map[string][]string "kinds"
+// @Router /reactions/kinds [get]
+func (h *ReactionHandler) GetReactionKinds(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"kinds": h.reactionUsecase.Kinds()})
+}
+
+// AddPostReaction godoc
+// @Summary Поставить реакцию на пост
+// @Description Ставит голос up или down либо эмодзи. Повторная реакция того же вида ничего не меняет, голос заменяет противоположный. Голосовать за свой пост нельзя
+// @Tags Реакции
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param kind path string true "Вид реакции: up, down или эмодзи"
+// @Success 200 {object} entity.Votes
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse "Голос за свой пост"
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/reactions/{kind} [put]
+func (h *ReactionHandler) AddPostReaction(c *gin.Context) {
+	h.react(c, entity.ReactionTargetPost)
+}
+
+// RemovePostReaction godoc
+// @Summary Снять реакцию с поста
+// @Description Снимает свою реакцию указанного вида; если ее не было, ничего не меняет
+// @Tags Реакции
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Param kind path string true "Вид реакции: up, down или эмодзи"
+// @Success 200 {object} entity.Votes
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/reactions/{kind} [delete]
+func (h *ReactionHandler) RemovePostReaction(c *gin.Context) {
+	h.unreact(c, entity.ReactionTargetPost)
+}
+
+// GetPostReactions godoc
+// @Summary Кто отреагировал на пост
+// @Description Возвращает реакции на пост постранично, новые первыми
+// @Tags Реакции
+// @Produce json
+// @Param id path int true "ID поста"
+// @Param kind query string false "Только реакции этого вида"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Реакций на странице (не больше 100)" default(50)
+// @Success 200 {object} map[string]interface{} "reactions и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /posts/{id}/reactions [get]
+func (h *ReactionHandler) GetPostReactions(c *gin.Context) {
+	h.getReactions(c, entity.ReactionTargetPost)
+}
+
+// AddCommentReaction godoc
+// @Summary Поставить реакцию на комментарий
+// @Description Ставит голос up или down либо эмодзи. Повторная реакция того же вида ничего не меняет, голос заменяет противоположный. Голосовать за свой комментарий нельзя, на удаленный — реагировать нельзя
+// @Tags Реакции
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комментария"
+// @Param kind path string true "Вид реакции: up, down или эмодзи"
+// @Success 200 {object} entity.Votes
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse "Голос за свой комментарий"
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Комментарий удален"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id}/reactions/{kind} [put]
+func (h *ReactionHandler) AddCommentReaction(c *gin.Context) {
+	h.react(c, entity.ReactionTargetComment)
+}
+
+// RemoveCommentReaction godoc
+// @Summary Снять реакцию с комментария
+// @Description Снимает свою реакцию указанного вида; если ее не было, ничего не меняет
+// @Tags Реакции
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комментария"
+// @Param kind path string true "Вид реакции: up, down или эмодзи"
+// @Success 200 {object} entity.Votes
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id}/reactions/{kind} [delete]
+func (h *ReactionHandler) RemoveCommentReaction(c *gin.Context) {
+	h.unreact(c, entity.ReactionTargetComment)
+}
+
+// GetCommentReactions godoc
+// @Summary Кто отреагировал на комментарий
+// @Description Возвращает реакции на комментарий постранично, новые первыми
+// @Tags Реакции
+// @Produce json
+// @Param id path int true "ID комментария"
+// @Param kind query string false "Только реакции этого вида"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Реакций на странице (не больше 100)" default(50)
+// @Success 200 {object} map[string]interface{} "reactions и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /comments/{id}/reactions [get]
+func (h *ReactionHandler) GetCommentReactions(c *gin.Context) {
+	h.getReactions(c, entity.ReactionTargetComment)
+}
+
+func (h *ReactionHandler) react(c *gin.Context, targetType string) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	target, ok := h.loadTarget(c, targetType)
+	if !ok {
+		return
+	}
+
+	votes, err := h.reactionUsecase.React(c.Request.Context(), target, principal.UserID, c.Param("kind"))
+	if err != nil {
+		h.abortReactionError(c, err, "Failed to add reaction")
+		return
+	}
+	c.JSON(http.StatusOK, votes)
+}
+
+func (h *ReactionHandler) unreact(c *gin.Context, targetType string) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	target, ok := h.loadTarget(c, targetType)
+	if !ok {
+		return
+	}
+
+	votes, err := h.reactionUsecase.Unreact(c.Request.Context(), target, principal.UserID, c.Param("kind"))
+	if err != nil {
+		h.abortReactionError(c, err, "Failed to delete reaction")
+		return
+	}
+	c.JSON(http.StatusOK, votes)
+}
+
+func (h *ReactionHandler) getReactions(c *gin.Context, targetType string) {
+	target, ok := h.loadTarget(c, targetType)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxReactionsPageLimit {
+		limit = 50
+	}
+
+	reactions, total, err := h.reactionUsecase.GetReactions(c.Request.Context(), entity.ReactionFilter{
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		Kind:       c.Query("kind"),
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+	})
+	if err != nil {
+		h.abortReactionError(c, err, "Failed to get reactions")
+		return
+	}
+
+	userIDs := make([]int, len(reactions))
+	for i, reaction := range reactions {
+		userIDs[i] = reaction.UserID
+	}
+	users, err := h.userClient.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", userIDs), zap.Error(err))
+	}
+	for i := range reactions {
+		reactions[i].Username = users[reactions[i].UserID].Username
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactions": reactions,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// loadTarget находит цель реакции и проверяет, что пользователь может читать
+// ее раздел. Цель из скрытого раздела для него не существует.
+func (h *ReactionHandler) loadTarget(c *gin.Context, targetType string) (entity.ReactionTarget, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return entity.ReactionTarget{}, false
+	}
+	target, err := h.reactionUsecase.GetTarget(c.Request.Context(), targetType, id)
+	if err != nil {
+		h.abortReactionError(c, err, "Failed to get reaction target")
+		return entity.ReactionTarget{}, false
+	}
+
+	err = h.categoryUsecase.CheckPostAccess(c.Request.Context(), target.PostID, optionalPrincipal(c), entity.CategoryActionRead)
+	if err != nil {
+		if errors.Is(err, usecase.ErrCategoryNotFound) {
+			err = usecase.ErrPostNotFound
+			if targetType == entity.ReactionTargetComment {
+				err = usecase.ErrCommentNotFound
+			}
+		}
+		h.abortReactionError(c, err, "Failed to check category access")
+		return entity.ReactionTarget{}, false
+	}
+	return target, true
+}
+
+func (h *ReactionHandler) abortReactionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrPostNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Post not found"})
+	case errors.Is(err, usecase.ErrCommentNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidReaction):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrSelfVote):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCommentDeleted):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		abortCategoryError(c, h.logger, err, message)
+	}
+}
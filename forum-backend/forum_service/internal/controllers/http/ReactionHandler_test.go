@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestReactionHandler(categories *mocks.CategoryUsecase) (*ReactionHandler, *mocks.ReactionUsecase, *mocks.UserClient) {
+	mockReactionUsecase := new(mocks.ReactionUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewReactionHandler(mockReactionUsecase, categories, nil, zap.NewNop(), mockUserClient)
+	return handler, mockReactionUsecase, mockUserClient
+}
+
+func TestReactionHandler_AddPostReaction(t *testing.T) {
+	handler, mockReactionUsecase, _ := newTestReactionHandler(openCategories())
+	target := entity.ReactionTarget{Type: entity.ReactionTargetPost, ID: 7, PostID: 7, AuthorID: 1}
+
+	mockReactionUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetPost, 7).Return(target, nil)
+	mockReactionUsecase.On("React", mock.Anything, target, 2, "🎉").
+		Return(entity.Votes{Upvotes: 3, Score: 3, Reactions: entity.ReactionCounts{"🎉": 1}}, nil)
+
+	w := servePostRoute(handler.AddPostReaction, http.MethodPut, "/posts/:id/reactions/:kind", "/posts/7/reactions/%F0%9F%8E%89", "",
+		entity.Principal{UserID: 2, Role: "user"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"upvotes":3,"downvotes":0,"score":3,"reactions":{"🎉":1}}`, w.Body.String())
+	mockReactionUsecase.AssertExpectations(t)
+}
+
+func TestReactionHandler_AddCommentReaction_Errors(t *testing.T) {
+	target := entity.ReactionTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7, AuthorID: 2}
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrSelfVote, http.StatusForbidden},
+		{usecase.ErrInvalidReaction, http.StatusBadRequest},
+		{usecase.ErrCommentDeleted, http.StatusConflict},
+	} {
+		handler, mockReactionUsecase, _ := newTestReactionHandler(openCategories())
+		mockReactionUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetComment, 5).Return(target, nil)
+		mockReactionUsecase.On("React", mock.Anything, target, 2, "up").Return(entity.Votes{}, tc.err)
+
+		w := servePostRoute(handler.AddCommentReaction, http.MethodPut, "/comments/:id/reactions/:kind", "/comments/5/reactions/up", "",
+			entity.Principal{UserID: 2, Role: "user"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestReactionHandler_HiddenComment(t *testing.T) {
+	categories := new(mocks.CategoryUsecase)
+	handler, mockReactionUsecase, _ := newTestReactionHandler(categories)
+	target := entity.ReactionTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7}
+
+	mockReactionUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetComment, 5).Return(target, nil)
+	categories.On("CheckPostAccess", mock.Anything, 7, (*entity.Principal)(nil), entity.CategoryActionRead).Return(usecase.ErrCategoryNotFound)
+
+	w := serveGuestRoute(handler.GetCommentReactions, http.MethodGet, "/comments/:id/reactions", "/comments/5/reactions")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), usecase.ErrCommentNotFound.Error())
+	mockReactionUsecase.AssertNotCalled(t, "GetReactions", mock.Anything, mock.Anything)
+}
+
+func TestReactionHandler_GetPostReactions(t *testing.T) {
+	handler, mockReactionUsecase, mockUserClient := newTestReactionHandler(openCategories())
+	target := entity.ReactionTarget{Type: entity.ReactionTargetPost, ID: 7, PostID: 7, AuthorID: 1}
+
+	mockReactionUsecase.On("GetTarget", mock.Anything, entity.ReactionTargetPost, 7).Return(target, nil)
+	filter := entity.ReactionFilter{TargetType: "post", TargetID: 7, Kind: "up", Limit: 20, Offset: 20}
+	mockReactionUsecase.On("GetReactions", mock.Anything, filter).
+		Return([]entity.Reaction{{TargetType: "post", TargetID: 7, UserID: 3, Kind: "up"}}, 21, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{3}).Return(map[int]entity.UserInfo{3: {ID: 3, Username: "carol"}}, nil)
+
+	w := serveGuestRoute(handler.GetPostReactions, http.MethodGet, "/posts/:id/reactions", "/posts/7/reactions?kind=up&page=2&limit=20")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Reactions  []entity.Reaction `json:"reactions"`
+		Pagination map[string]int    `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "carol", body.Reactions[0].Username)
+	assert.Equal(t, map[string]int{"page": 2, "limit": 20, "total": 21}, body.Pagination)
+	mockReactionUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPosts_Sort(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), mockUserClient)
+
+	filter := entity.PostFilter{Sort: entity.PostSortHot, Window: entity.PostWindowWeek, Limit: 10}
+	posts := []entity.Post{{ID: 1, AuthorId: 3, Votes: entity.Votes{Upvotes: 4, Downvotes: 1, Score: 3}}}
+	mockPostUsecase.On("GetPosts", mock.Anything, filter).Return(posts, nil)
+	mockPostUsecase.On("GetTotalPostsCount", mock.Anything, filter).Return(1, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{3}).Return(map[int]entity.UserInfo{}, nil)
+
+	w := serveGuestRoute(handler.GetPosts, http.MethodGet, "/posts", "/posts?sort=hot&t=week")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"score":3`)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPosts_InvalidSort(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	handler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("GetPosts", mock.Anything, entity.PostFilter{Sort: "best", Limit: 10}).Return(nil, usecase.ErrInvalidPostSort)
+
+	w := serveGuestRoute(handler.GetPosts, http.MethodGet, "/posts", "/posts?sort=best")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/search"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// maxSearchLimit ограничивает число результатов на странице поиска.
+const maxSearchLimit = 50
+
+type SearchHandler struct {
+	searchUsecase   usecase.SearchUsecase
+	categoryUsecase usecase.CategoryUsecase
+	auth            *AuthMiddleware
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
+}
+
+func NewSearchHandler(searchUsecase usecase.SearchUsecase, categoryUsecase usecase.CategoryUsecase, auth *AuthMiddleware, logger *zap.Logger, userClient grpc.UserClientInterface) *SearchHandler {
+	return &SearchHandler{searchUsecase: searchUsecase, categoryUsecase: categoryUsecase, auth: auth, logger: logger, userClient: userClient}
+}
+
+func (h *SearchHandler) Register(router *gin.Engine) {
+	router.GET("/search", h.auth.OptionalAuth(), h.Search)
+}
+
+// Search godoc
+// @Summary Поиск по постам и комментариям
+// @Description Полнотекстовый поиск по разделам, которые может читать пользователь. Слова ищутся все сразу, "фраза в кавычках" — целиком, слово* — по началу. Русские слова ищутся без учета окончаний. Результаты отсортированы по релевантности (BM25), совпадения в title и snippet обернуты в <mark>
+// @Tags Поиск
+// @Produce json
+// @Param q query string true "Поисковый запрос"
+// @Param type query string false "all, post или comment" default(all)
+// @Param author_id query int false "ID автора"
+// @Param from query string false "Не раньше даты (2006-01-02 или RFC3339)"
+// @Param to query string false "Не позже даты (2006-01-02 включительно или RFC3339)"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Результатов на странице (не больше 50)" default(10)
+// @Success 200 {object} map[string]interface{} "results и pagination"
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	filter := entity.SearchFilter{Query: c.Query("q")}
+
+	switch t := c.DefaultQuery("type", "all"); t {
+	case "all":
+	case entity.SearchTypePost, entity.SearchTypeComment:
+		filter.Type = t
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be all, post or comment"})
+		return
+	}
+
+	if authorID := c.Query("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil || id < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid author_id"})
+			return
+		}
+		filter.AuthorID = id
+	}
+
+	var ok bool
+	if filter.From, ok = parseSearchTime(c, "from", false); !ok {
+		return
+	}
+	if filter.To, ok = parseSearchTime(c, "to", true); !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	hidden, err := h.categoryUsecase.HiddenCategoryIDs(c.Request.Context(), optionalPrincipal(c))
+	if err != nil {
+		h.logger.Error("Failed to get hidden categories", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	filter.HiddenCategoryIDs = hidden
+
+	results, total, err := h.searchUsecase.Search(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, search.ErrEmptyQuery) || errors.Is(err, usecase.ErrInvalidSearchType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to search", zap.String("query", filter.Query), zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Имена авторов получаем одним запросом; если не удалось, отдаем пустые
+	authorIDs := make([]int, len(results))
+	for i, result := range results {
+		authorIDs[i] = result.AuthorID
+	}
+	authors, err := h.userClient.GetUsers(c.Request.Context(), authorIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", authorIDs), zap.Error(err))
+	}
+	for i := range results {
+		results[i].Username = authors[results[i].AuthorID].Username
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// parseSearchTime разбирает дату или время из параметра name. Дата без
+// времени в конце диапазона включает весь день.
+func parseSearchTime(c *gin.Context, name string, end bool) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, true
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ": expected 2006-01-02 or RFC3339"})
+		return nil, false
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, true
+}
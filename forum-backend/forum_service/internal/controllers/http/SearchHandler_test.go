@@ -0,0 +1,93 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/search"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func serveSearch(h *SearchHandler, target string) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/search", h.Search)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestSearchHandler_Search_Success(t *testing.T) {
+	mockSearchUsecase := new(mocks.SearchUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewSearchHandler(mockSearchUsecase, openCategories(), nil, zap.NewNop(), mockUserClient)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := entity.SearchFilter{
+		Query: "go", Type: entity.SearchTypeComment, AuthorID: 2,
+		From: &from, To: &to, Limit: 50, Offset: 50,
+	}
+	mockSearchUsecase.On("Search", mock.Anything, filter).
+		Return([]entity.SearchResult{{Type: entity.SearchTypeComment, ID: 5, PostID: 1, AuthorID: 2}}, 51, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2}).
+		Return(map[int]entity.UserInfo{2: {ID: 2, Username: "bob"}}, nil)
+
+	w := serveSearch(handler, "/search?q=go&type=comment&author_id=2&from=2024-01-01&to=2024-01-31&page=2&limit=100")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Results    []entity.SearchResult `json:"results"`
+		Pagination map[string]int        `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "bob", body.Results[0].Username)
+	assert.Equal(t, map[string]int{"page": 2, "limit": 50, "total": 51}, body.Pagination)
+	mockSearchUsecase.AssertExpectations(t)
+}
+
+func TestSearchHandler_Search_EmptyQuery(t *testing.T) {
+	mockSearchUsecase := new(mocks.SearchUsecase)
+	handler := NewSearchHandler(mockSearchUsecase, openCategories(), nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockSearchUsecase.On("Search", mock.Anything, mock.Anything).Return(nil, 0, search.ErrEmptyQuery)
+
+	w := serveSearch(handler, "/search?q=")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"search query is empty"}`, w.Body.String())
+}
+
+func TestSearchHandler_Search_InvalidParams(t *testing.T) {
+	handler := NewSearchHandler(new(mocks.SearchUsecase), openCategories(), nil, zap.NewNop(), new(mocks.UserClient))
+
+	for _, target := range []string{
+		"/search?q=go&type=user",
+		"/search?q=go&author_id=abc",
+		"/search?q=go&from=yesterday",
+		"/search?q=go&to=2024-13-01",
+	} {
+		w := serveSearch(handler, target)
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+}
+
+func TestSearchHandler_Search_Failure(t *testing.T) {
+	mockSearchUsecase := new(mocks.SearchUsecase)
+	handler := NewSearchHandler(mockSearchUsecase, openCategories(), nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockSearchUsecase.On("Search", mock.Anything, mock.Anything).Return(nil, 0, errors.New("database error"))
+
+	w := serveSearch(handler, "/search?q=go")
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,324 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const maxTagsPageLimit = 100
+
+type TagHandler struct {
+	tagUsecase      usecase.TagUsecase
+	postUsecase     usecase.PostUsecase
+	categoryUsecase usecase.CategoryUsecase
+	auth            *AuthMiddleware
+	audit           *Auditor
+	logger          *zap.Logger
+	userClient      grpc.UserClientInterface
+}
+
+func NewTagHandler(
+	tagUsecase usecase.TagUsecase,
+	postUsecase usecase.PostUsecase,
+	categoryUsecase usecase.CategoryUsecase,
+	auth *AuthMiddleware,
+	audit *Auditor,
+	logger *zap.Logger,
+	userClient grpc.UserClientInterface,
+) *TagHandler {
+	return &TagHandler{
+		tagUsecase:      tagUsecase,
+		postUsecase:     postUsecase,
+		categoryUsecase: categoryUsecase,
+		auth:            auth,
+		audit:           audit,
+		logger:          logger,
+		userClient:      userClient,
+	}
+}
+
+func (h *TagHandler) Register(router *gin.Engine) {
+	router.GET("/tags", h.auth.OptionalAuth(), h.GetTags)
+	router.GET("/tags/:name/posts", h.auth.OptionalAuth(), h.GetTagPosts)
+
+	router.PUT("/tags/:name", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTagManage), h.RenameTag)
+	router.DELETE("/tags/:name", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTagManage), h.DeleteTag)
+	router.POST("/tags/:name/merge", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTagManage), h.MergeTag)
+	router.POST("/tags/:name/aliases", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTagManage), h.AddTagAlias)
+	router.DELETE("/tags/:name/aliases/:alias", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTagManage), h.DeleteTagAlias)
+}
+
+// GetTags godoc
+// @Summary Список меток
+// @Description Возвращает метки с количеством постов, популярные первыми. Посты из разделов, которые пользователь не может читать, не учитываются
+// @Tags Метки
+// @Produce json
+// @Param q query string false "Начало имени метки"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Меток на странице (не больше 100)" default(50)
+// @Success 200 {object} map[string]interface{} "tags и pagination"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags [get]
+func (h *TagHandler) GetTags(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxTagsPageLimit {
+		limit = 50
+	}
+
+	hidden, err := h.categoryUsecase.HiddenCategoryIDs(c.Request.Context(), optionalPrincipal(c))
+	if err != nil {
+		h.logger.Error("Failed to get hidden categories", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tags"})
+		return
+	}
+
+	tags, total, err := h.tagUsecase.GetTags(c.Request.Context(), entity.TagFilter{
+		Prefix:            c.Query("q"),
+		HiddenCategoryIDs: hidden,
+		Limit:             limit,
+		Offset:            (page - 1) * limit,
+	})
+	if err != nil {
+		h.logger.Error("Failed to get tags", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tags"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags": tags,
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": total,
+		},
+	})
+}
+
+// GetTagPosts godoc
+// @Summary Страница метки
+// @Description Возвращает метку с синонимами и посты с ней постранично, новые первыми. Синоним открывает страницу основной метки
+// @Tags Метки
+// @Produce json
+// @Param name path string true "Метка или ее синоним"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Постов на странице" default(10)
+// @Success 200 {object} map[string]interface{} "tag, posts и pagination"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name}/posts [get]
+func (h *TagHandler) GetTagPosts(c *gin.Context) {
+	hidden, err := h.categoryUsecase.HiddenCategoryIDs(c.Request.Context(), optionalPrincipal(c))
+	if err != nil {
+		h.logger.Error("Failed to get hidden categories", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tag"})
+		return
+	}
+	tag, err := h.tagUsecase.GetTag(c.Request.Context(), c.Param("name"), hidden)
+	if err != nil {
+		h.abortTagError(c, err, "Failed to get tag")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	filter := entity.PostFilter{
+		HiddenCategoryIDs: hidden,
+		Tags:              []string{tag.Name},
+		Limit:             limit,
+		Offset:            (page - 1) * limit,
+	}
+
+	posts, err := h.postUsecase.GetPosts(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag":   tag,
+		"posts": postListItems(c, h.logger, h.userClient, posts),
+		"pagination": gin.H{
+			"page":  page,
+			"limit": limit,
+			"total": tag.PostsCount,
+		},
+	})
+}
+
+// RenameTag godoc
+// @Summary Переименовать метку
+// @Description Меняет имя метки у всех постов. Новое имя не должно совпадать с другой меткой или ее синонимом — для этого есть слияние. Требуется право tag.manage
+// @Tags Метки
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Метка"
+// @Param request body entity.RenameTagRequest true "Новое имя"
+// @Success 200 {object} entity.Tag
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Имя занято"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name} [put]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	var req entity.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := c.Param("name")
+	tag, err := h.tagUsecase.RenameTag(c.Request.Context(), name, req.Name)
+	if err != nil {
+		h.abortTagError(c, err, "Failed to rename tag")
+		return
+	}
+	h.audit.Record(c, entity.AuditTagRename, entity.AuditTargetTag, name, gin.H{"name": name}, tag)
+	c.JSON(http.StatusOK, tag)
+}
+
+// MergeTag godoc
+// @Summary Слить метки
+// @Description Переносит метку target на все посты с меткой name и удаляет name; имя name и его синонимы становятся синонимами target. Требуется право tag.manage
+// @Tags Метки
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Метка, которая сливается"
+// @Param request body entity.MergeTagRequest true "Метка, которая остается"
+// @Success 200 {object} entity.Tag
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name}/merge [post]
+func (h *TagHandler) MergeTag(c *gin.Context) {
+	var req entity.MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := c.Param("name")
+	tag, err := h.tagUsecase.MergeTags(c.Request.Context(), name, req.Target)
+	if err != nil {
+		h.abortTagError(c, err, "Failed to merge tags")
+		return
+	}
+	h.audit.Record(c, entity.AuditTagMerge, entity.AuditTargetTag, name, gin.H{"name": name}, tag)
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTag godoc
+// @Summary Удалить метку
+// @Description Снимает метку со всех постов и удаляет ее вместе с синонимами. Требуется право tag.manage
+// @Tags Метки
+// @Security BearerAuth
+// @Param name path string true "Метка"
+// @Success 204 "No Content"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	name := c.Param("name")
+	if err := h.tagUsecase.DeleteTag(c.Request.Context(), name); err != nil {
+		h.abortTagError(c, err, "Failed to delete tag")
+		return
+	}
+	h.audit.Record(c, entity.AuditTagDelete, entity.AuditTargetTag, name, gin.H{"name": name}, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// AddTagAlias godoc
+// @Summary Добавить синоним метки
+// @Description При назначении и фильтрации синоним заменяется меткой name. Требуется право tag.manage
+// @Tags Метки
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Метка"
+// @Param request body entity.TagAliasRequest true "Синоним"
+// @Success 200 {object} entity.Tag
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse "Имя занято"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name}/aliases [post]
+func (h *TagHandler) AddTagAlias(c *gin.Context) {
+	var req entity.TagAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.tagUsecase.AddAlias(c.Request.Context(), c.Param("name"), req.Alias)
+	if err != nil {
+		h.abortTagError(c, err, "Failed to add tag alias")
+		return
+	}
+	h.audit.Record(c, entity.AuditTagAliasCreate, entity.AuditTargetTag, tag.Name, nil, tag)
+	c.JSON(http.StatusOK, tag)
+}
+
+// DeleteTagAlias godoc
+// @Summary Удалить синоним метки
+// @Description Требуется право tag.manage
+// @Tags Метки
+// @Security BearerAuth
+// @Param name path string true "Метка"
+// @Param alias path string true "Синоним"
+// @Success 204 "No Content"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /tags/{name}/aliases/{alias} [delete]
+func (h *TagHandler) DeleteTagAlias(c *gin.Context) {
+	name, alias := c.Param("name"), c.Param("alias")
+	if err := h.tagUsecase.DeleteAlias(c.Request.Context(), name, alias); err != nil {
+		h.abortTagError(c, err, "Failed to delete tag alias")
+		return
+	}
+	h.audit.Record(c, entity.AuditTagAliasDelete, entity.AuditTargetTag, name, gin.H{"alias": alias}, nil)
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TagHandler) abortTagError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrTagNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrTagExists):
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidTag), errors.Is(err, usecase.ErrSameTag):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.String("tag", c.Param("name")), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
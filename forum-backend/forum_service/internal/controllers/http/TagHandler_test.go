@@ -0,0 +1,152 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestTagHandler() (*TagHandler, *mocks.TagUsecase, *mocks.PostUsecase, *mocks.CategoryUsecase, *mocks.UserClient) {
+	mockTagUsecase := new(mocks.TagUsecase)
+	mockPostUsecase := new(mocks.PostUsecase)
+	mockCategoryUsecase := new(mocks.CategoryUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewTagHandler(mockTagUsecase, mockPostUsecase, mockCategoryUsecase, nil, nil, zap.NewNop(), mockUserClient)
+	return handler, mockTagUsecase, mockPostUsecase, mockCategoryUsecase, mockUserClient
+}
+
+func TestTagHandler_GetTags(t *testing.T) {
+	handler, mockTagUsecase, _, mockCategoryUsecase, _ := newTestTagHandler()
+
+	mockCategoryUsecase.On("HiddenCategoryIDs", mock.Anything, (*entity.Principal)(nil)).Return([]int{3}, nil)
+	filter := entity.TagFilter{Prefix: "go", HiddenCategoryIDs: []int{3}, Limit: 50, Offset: 50}
+	mockTagUsecase.On("GetTags", mock.Anything, filter).Return([]entity.Tag{{ID: 1, Name: "go", PostsCount: 12}}, 51, nil)
+
+	w := serveGuestRoute(handler.GetTags, http.MethodGet, "/tags", "/tags?q=go&page=2&limit=500")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Tags       []entity.Tag   `json:"tags"`
+		Pagination map[string]int `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 12, body.Tags[0].PostsCount)
+	assert.Equal(t, map[string]int{"page": 2, "limit": 50, "total": 51}, body.Pagination)
+	mockTagUsecase.AssertExpectations(t)
+}
+
+func TestTagHandler_GetTagPosts_Success(t *testing.T) {
+	handler, mockTagUsecase, mockPostUsecase, mockCategoryUsecase, mockUserClient := newTestTagHandler()
+
+	mockCategoryUsecase.On("HiddenCategoryIDs", mock.Anything, (*entity.Principal)(nil)).Return([]int(nil), nil)
+	mockTagUsecase.On("GetTag", mock.Anything, "golang", []int(nil)).
+		Return(entity.Tag{ID: 1, Name: "go", Aliases: []string{"golang"}, PostsCount: 1}, nil)
+	filter := entity.PostFilter{Tags: []string{"go"}, Limit: 10}
+	mockPostUsecase.On("GetPosts", mock.Anything, filter).Return([]entity.Post{{ID: 7, AuthorId: 3, Tags: []string{"go"}}}, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{3}).Return(map[int]entity.UserInfo{3: {ID: 3, Username: "carol"}}, nil)
+
+	w := serveGuestRoute(handler.GetTagPosts, http.MethodGet, "/tags/:name/posts", "/tags/golang/posts")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Tag        entity.Tag               `json:"tag"`
+		Posts      []map[string]interface{} `json:"posts"`
+		Pagination map[string]int           `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "go", body.Tag.Name)
+	assert.Equal(t, []interface{}{"go"}, body.Posts[0]["tags"])
+	assert.Equal(t, "carol", body.Posts[0]["username"])
+	assert.Equal(t, 1, body.Pagination["total"])
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestTagHandler_GetTagPosts_NotFound(t *testing.T) {
+	handler, mockTagUsecase, mockPostUsecase, mockCategoryUsecase, _ := newTestTagHandler()
+
+	mockCategoryUsecase.On("HiddenCategoryIDs", mock.Anything, (*entity.Principal)(nil)).Return([]int(nil), nil)
+	mockTagUsecase.On("GetTag", mock.Anything, "rust", []int(nil)).Return(entity.Tag{}, usecase.ErrTagNotFound)
+
+	w := serveGuestRoute(handler.GetTagPosts, http.MethodGet, "/tags/:name/posts", "/tags/rust/posts")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockPostUsecase.AssertNotCalled(t, "GetPosts", mock.Anything, mock.Anything)
+}
+
+func TestTagHandler_RenameTag_Errors(t *testing.T) {
+	moderator := entity.Principal{UserID: 2, Role: "moderator", Permissions: []string{entity.PermTagManage}}
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrTagExists, http.StatusConflict},
+		{usecase.ErrInvalidTag, http.StatusBadRequest},
+		{usecase.ErrTagNotFound, http.StatusNotFound},
+	} {
+		handler, mockTagUsecase, _, _, _ := newTestTagHandler()
+		mockTagUsecase.On("RenameTag", mock.Anything, "golang", "go").Return(entity.Tag{}, tc.err)
+
+		w := servePostRoute(handler.RenameTag, http.MethodPut, "/tags/:name", "/tags/golang", `{"name":"go"}`, moderator)
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
+
+func TestTagHandler_MergeTag(t *testing.T) {
+	handler, mockTagUsecase, _, _, _ := newTestTagHandler()
+	moderator := entity.Principal{UserID: 2, Role: "moderator", Permissions: []string{entity.PermTagManage}}
+
+	mockTagUsecase.On("MergeTags", mock.Anything, "golang", "go").
+		Return(entity.Tag{ID: 1, Name: "go", Aliases: []string{"golang"}, PostsCount: 5}, nil)
+
+	w := servePostRoute(handler.MergeTag, http.MethodPost, "/tags/:name/merge", "/tags/golang/merge", `{"target":"go"}`, moderator)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"aliases":["golang"]`)
+	mockTagUsecase.AssertExpectations(t)
+}
+
+func TestTagHandler_DeleteTagAlias(t *testing.T) {
+	handler, mockTagUsecase, _, _, _ := newTestTagHandler()
+	moderator := entity.Principal{UserID: 2, Role: "moderator", Permissions: []string{entity.PermTagManage}}
+
+	mockTagUsecase.On("DeleteAlias", mock.Anything, "go", "golang").Return(nil)
+
+	w := servePostRoute(handler.DeleteTagAlias, http.MethodDelete, "/tags/:name/aliases/:alias", "/tags/go/aliases/golang", "", moderator)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockTagUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_GetPosts_InvalidTagMode(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	handler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	filter := entity.PostFilter{Tags: []string{"go", "grpc"}, TagMode: "xor", Limit: 10}
+	mockPostUsecase.On("GetPosts", mock.Anything, filter).Return(nil, usecase.ErrInvalidTagMode)
+
+	w := serveGuestRoute(handler.GetPosts, http.MethodGet, "/posts", "/posts?tag=go&tag=grpc&tag_mode=xor")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockPostUsecase.AssertExpectations(t)
+}
+
+func TestPostHandler_CreatePost_TooManyTags(t *testing.T) {
+	mockPostUsecase := new(mocks.PostUsecase)
+	handler := NewPostHandler(mockPostUsecase, openCategories(), openTrust(), nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	mockPostUsecase.On("CreatePost", mock.Anything, mock.Anything).Return(nil, usecase.ErrTooManyTags)
+
+	w := servePostRoute(handler.CreatePost, http.MethodPost, "/posts", "/posts",
+		`{"title":"Go","content":"Текст","tags":["a","b","c","d","e","f"]}`, entity.Principal{UserID: 1, Role: "user"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const maxTrashPageLimit = 100
+
+type TrashHandler struct {
+	trashUsecase usecase.TrashUsecase
+	auth         *AuthMiddleware
+	audit        *Auditor
+	logger       *zap.Logger
+	userClient   grpc.UserClientInterface
+}
+
+func NewTrashHandler(
+	trashUsecase usecase.TrashUsecase,
+	auth *AuthMiddleware,
+	audit *Auditor,
+	logger *zap.Logger,
+	userClient grpc.UserClientInterface,
+) *TrashHandler {
+	return &TrashHandler{
+		trashUsecase: trashUsecase,
+		auth:         auth,
+		audit:        audit,
+		logger:       logger,
+		userClient:   userClient,
+	}
+}
+
+func (h *TrashHandler) Register(router *gin.Engine) {
+	router.GET("/trash/posts", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrashManage), h.GetDeletedPosts)
+	router.GET("/trash/comments", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrashManage), h.GetDeletedComments)
+	router.POST("/trash/posts/:id/restore", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrashManage), h.RestorePost)
+	router.POST("/trash/comments/:id/restore", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrashManage), h.RestoreComment)
+}
+
+// GetDeletedPosts godoc
+// @Summary Удаленные посты
+// @Description Возвращает посты из корзины постранично, начиная с удаленных последними. Пролежавшее в корзине дольше срока хранения удаляется насовсем. Требуется право trash.manage
+// @Tags Корзина
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Постов на странице (не больше 100)" default(20)
+// @Success 200 {object} map[string]interface{} "posts и pagination"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /trash/posts [get]
+func (h *TrashHandler) GetDeletedPosts(c *gin.Context) {
+	page, limit := trashPage(c)
+	posts, total, err := h.trashUsecase.GetDeletedPosts(c.Request.Context(), limit, (page-1)*limit)
+	if err != nil {
+		h.abortTrashError(c, err, "Failed to get deleted posts")
+		return
+	}
+	if posts == nil {
+		posts = []entity.TrashedPost{}
+	}
+
+	var userIDs []int
+	for _, post := range posts {
+		userIDs = append(userIDs, post.AuthorId)
+		if post.DeletedBy != nil {
+			userIDs = append(userIDs, *post.DeletedBy)
+		}
+	}
+	users := h.getUsers(c, userIDs)
+	for i := range posts {
+		posts[i].AuthorUsername = users[posts[i].AuthorId].Username
+		if posts[i].DeletedBy != nil {
+			posts[i].DeletedByUsername = users[*posts[i].DeletedBy].Username
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":      posts,
+		"pagination": gin.H{"page": page, "limit": limit, "total": total},
+	})
+}
+
+// GetDeletedComments godoc
+// @Summary Удаленные комментарии
+// @Description Возвращает комментарии из корзины с исходным текстом постранично, начиная с удаленных последними. Комментарии удаленных постов сюда не попадают: они восстанавливаются вместе с постом. Требуется право trash.manage
+// @Tags Корзина
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Комментариев на странице (не больше 100)" default(20)
+// @Success 200 {object} map[string]interface{} "comments и pagination"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /trash/comments [get]
+func (h *TrashHandler) GetDeletedComments(c *gin.Context) {
+	page, limit := trashPage(c)
+	comments, total, err := h.trashUsecase.GetDeletedComments(c.Request.Context(), limit, (page-1)*limit)
+	if err != nil {
+		h.abortTrashError(c, err, "Failed to get deleted comments")
+		return
+	}
+	if comments == nil {
+		comments = []entity.TrashedComment{}
+	}
+
+	var userIDs []int
+	for _, comment := range comments {
+		userIDs = append(userIDs, comment.AuthorId)
+		if comment.DeletedBy != nil {
+			userIDs = append(userIDs, *comment.DeletedBy)
+		}
+	}
+	users := h.getUsers(c, userIDs)
+	for i := range comments {
+		comments[i].AuthorUsername = users[comments[i].AuthorId].Username
+		if comments[i].DeletedBy != nil {
+			comments[i].DeletedByUsername = users[*comments[i].DeletedBy].Username
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments":   comments,
+		"pagination": gin.H{"page": page, "limit": limit, "total": total},
+	})
+}
+
+// RestorePost godoc
+// @Summary Восстановить пост
+// @Description Возвращает пост из корзины вместе с комментариями, удаленными вместе с ним. Комментарии, удаленные до поста, остаются в корзине. Требуется право trash.manage
+// @Tags Корзина
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID поста"
+// @Success 200 {object} entity.TrashedPost
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Поста нет в корзине"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /trash/posts/{id}/restore [post]
+func (h *TrashHandler) RestorePost(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid post ID"})
+		return
+	}
+	post, err := h.trashUsecase.RestorePost(c.Request.Context(), id)
+	if err != nil {
+		h.abortTrashError(c, err, "Failed to restore post")
+		return
+	}
+	h.audit.Record(c, entity.AuditPostRestore, entity.AuditTargetPost, id, post, nil)
+	c.JSON(http.StatusOK, post)
+}
+
+// RestoreComment godoc
+// @Summary Восстановить комментарий
+// @Description Возвращает комментарий из корзины. Комментарий удаленного поста восстанавливается только вместе с постом. Требуется право trash.manage
+// @Tags Корзина
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID комментария"
+// @Success 200 {object} entity.TrashedComment
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse "Комментария нет в корзине"
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /trash/comments/{id}/restore [post]
+func (h *TrashHandler) RestoreComment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+	comment, err := h.trashUsecase.RestoreComment(c.Request.Context(), id)
+	if err != nil {
+		h.abortTrashError(c, err, "Failed to restore comment")
+		return
+	}
+	h.audit.Record(c, entity.AuditCommentRestore, entity.AuditTargetComment, id, comment, nil)
+	c.JSON(http.StatusOK, comment)
+}
+
+func trashPage(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxTrashPageLimit {
+		limit = 20
+	}
+	return page, limit
+}
+
+// getUsers возвращает авторов и удаливших. Корзина показывается и без
+// имен, если auth_service недоступен.
+func (h *TrashHandler) getUsers(c *gin.Context, userIDs []int) map[int]entity.UserInfo {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	users, err := h.userClient.GetUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		h.logger.Warn("Failed to get usernames", zap.Ints("userIDs", userIDs), zap.Error(err))
+	}
+	return users
+}
+
+func (h *TrashHandler) abortTrashError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrPostNotFound), errors.Is(err, usecase.ErrCommentNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestTrashHandler() (*TrashHandler, *mocks.TrashUsecase, *mocks.UserClient) {
+	mockTrashUsecase := new(mocks.TrashUsecase)
+	mockUserClient := new(mocks.UserClient)
+	handler := NewTrashHandler(mockTrashUsecase, nil, nil, zap.NewNop(), mockUserClient)
+	return handler, mockTrashUsecase, mockUserClient
+}
+
+func TestTrashHandler_GetDeletedPosts(t *testing.T) {
+	handler, mockTrashUsecase, mockUserClient := newTestTrashHandler()
+	moderatorID := 5
+
+	mockTrashUsecase.On("GetDeletedPosts", mock.Anything, 10, 10).
+		Return([]entity.TrashedPost{{ID: 1, AuthorId: 2, Title: "Елки", CommentsCount: 3, DeletedBy: &moderatorID}}, 11, nil)
+	mockUserClient.On("GetUsers", mock.Anything, []int{2, 5}).
+		Return(map[int]entity.UserInfo{2: {ID: 2, Username: "author"}, 5: {ID: 5, Username: "moderator"}}, nil)
+
+	w := servePostRoute(handler.GetDeletedPosts, http.MethodGet, "/trash/posts", "/trash/posts?page=2&limit=10", "",
+		entity.Principal{UserID: 5, Role: "moderator"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Posts      []entity.TrashedPost `json:"posts"`
+		Pagination struct{ Total int }  `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Posts, 1)
+	assert.Equal(t, "author", resp.Posts[0].AuthorUsername)
+	assert.Equal(t, "moderator", resp.Posts[0].DeletedByUsername)
+	assert.Equal(t, 11, resp.Pagination.Total)
+}
+
+func TestTrashHandler_GetDeletedComments_Empty(t *testing.T) {
+	handler, mockTrashUsecase, mockUserClient := newTestTrashHandler()
+
+	mockTrashUsecase.On("GetDeletedComments", mock.Anything, 20, 0).Return(nil, 0, nil)
+
+	w := servePostRoute(handler.GetDeletedComments, http.MethodGet, "/trash/comments", "/trash/comments", "",
+		entity.Principal{UserID: 5, Role: "moderator"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"comments":[]`)
+	mockUserClient.AssertNotCalled(t, "GetUsers", mock.Anything, mock.Anything)
+}
+
+func TestTrashHandler_RestorePost(t *testing.T) {
+	handler, mockTrashUsecase, _ := newTestTrashHandler()
+
+	mockTrashUsecase.On("RestorePost", mock.Anything, 1).Return(entity.TrashedPost{ID: 1, CommentsCount: 3}, nil)
+
+	w := servePostRoute(handler.RestorePost, http.MethodPost, "/trash/posts/:id/restore", "/trash/posts/1/restore", "",
+		entity.Principal{UserID: 5, Role: "moderator"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"comments_count":3`)
+	mockTrashUsecase.AssertExpectations(t)
+}
+
+func TestTrashHandler_RestoreComment_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrCommentNotFound, http.StatusNotFound},
+		{errors.New("database is locked"), http.StatusInternalServerError},
+	} {
+		handler, mockTrashUsecase, _ := newTestTrashHandler()
+		mockTrashUsecase.On("RestoreComment", mock.Anything, 4).Return(entity.TrashedComment{}, tc.err)
+
+		w := servePostRoute(handler.RestoreComment, http.MethodPost, "/trash/comments/:id/restore", "/trash/comments/4/restore", "",
+			entity.Principal{UserID: 5, Role: "moderator"})
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+	}
+}
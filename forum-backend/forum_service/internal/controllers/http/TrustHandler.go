@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type TrustHandler struct {
+	trustUsecase usecase.TrustUsecase
+	auth         *AuthMiddleware
+	audit        *Auditor
+	logger       *zap.Logger
+}
+
+func NewTrustHandler(trustUsecase usecase.TrustUsecase, auth *AuthMiddleware, audit *Auditor, logger *zap.Logger) *TrustHandler {
+	return &TrustHandler{trustUsecase: trustUsecase, auth: auth, audit: audit, logger: logger}
+}
+
+func (h *TrustHandler) Register(router *gin.Engine) {
+	router.GET("/users/:id/trust", h.GetTrust)
+
+	router.PUT("/users/:id/trust", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrustManage), h.SetTrustLevel)
+	router.DELETE("/users/:id/trust", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrustManage), h.ClearTrustLevel)
+	router.POST("/trust/recompute", h.auth.RequireAuth(), h.auth.RequirePermission(entity.PermTrustManage), h.RecomputeTrust)
+}
+
+// GetTrust godoc
+// @Summary Репутация и уровень доверия
+// @Description Возвращает репутацию пользователя, активность, из которой она сложилась, и уровень доверия: new, basic, member или regular. Уровень повышается и понижается вместе с репутацией, если администратор не назначил его вручную
+// @Tags Доверие
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} entity.UserTrust
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/{id}/trust [get]
+func (h *TrustHandler) GetTrust(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	trust, err := h.trustUsecase.GetTrust(c.Request.Context(), userID)
+	if err != nil {
+		abortTrustError(c, h.logger, err, "Failed to get user trust")
+		return
+	}
+	c.JSON(http.StatusOK, trust)
+}
+
+// SetTrustLevel godoc
+// @Summary Назначить уровень доверия
+// @Description Закрепляет за пользователем уровень доверия независимо от репутации. Требуется право trust.manage
+// @Tags Доверие
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Param request body entity.SetTrustLevelRequest true "Уровень: new, basic, member или regular"
+// @Success 200 {object} entity.UserTrust
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/{id}/trust [put]
+func (h *TrustHandler) SetTrustLevel(c *gin.Context) {
+	principal, ok := requirePrincipal(c)
+	if !ok {
+		return
+	}
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	var req entity.SetTrustLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	before, err := h.trustUsecase.GetTrust(c.Request.Context(), userID)
+	if err != nil {
+		abortTrustError(c, h.logger, err, "Failed to get trust level")
+		return
+	}
+	trust, err := h.trustUsecase.SetOverride(c.Request.Context(), userID, req.Level, principal.UserID)
+	if err != nil {
+		abortTrustError(c, h.logger, err, "Failed to set trust level")
+		return
+	}
+	h.audit.Record(c, entity.AuditTrustSet, entity.AuditTargetUser, userID, before, trust)
+	c.JSON(http.StatusOK, trust)
+}
+
+// ClearTrustLevel godoc
+// @Summary Снять назначенный уровень доверия
+// @Description Возвращает пользователю уровень, вычисленный по репутации. Требуется право trust.manage
+// @Tags Доверие
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} entity.UserTrust
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /users/{id}/trust [delete]
+func (h *TrustHandler) ClearTrustLevel(c *gin.Context) {
+	userID, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+	before, err := h.trustUsecase.GetTrust(c.Request.Context(), userID)
+	if err != nil {
+		abortTrustError(c, h.logger, err, "Failed to get trust level")
+		return
+	}
+	trust, err := h.trustUsecase.ClearOverride(c.Request.Context(), userID)
+	if err != nil {
+		abortTrustError(c, h.logger, err, "Failed to clear trust level")
+		return
+	}
+	h.audit.Record(c, entity.AuditTrustClear, entity.AuditTargetUser, userID, before, trust)
+	c.JSON(http.StatusOK, trust)
+}
+
+// RecomputeTrust godoc
+// @Summary Пересчитать репутацию
+// @Description Пересчитывает с нуля репутацию и уровни доверия всех пользователей. Требуется право trust.manage
+// @Tags Доверие
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "users — сколько пользователей пересчитано"
+// @Failure 401 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 500 {object} entity.ErrorResponse
+// @Router /trust/recompute [post]
+func (h *TrustHandler) RecomputeTrust(c *gin.Context) {
+	count, err := h.trustUsecase.Recompute(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to recompute trust levels", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to recompute trust levels"})
+		return
+	}
+	h.audit.Record(c, entity.AuditTrustRecompute, entity.AuditTargetUser, nil, nil, gin.H{"users": count})
+	c.JSON(http.StatusOK, gin.H{"users": count})
+}
+
+func parseUserID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// abortTrustError отвечает на ошибки уровней доверия, в том числе на отказы
+// в обработчиках постов, комментариев и чата.
+func abortTrustError(c *gin.Context, logger *zap.Logger, err error, message string) {
+	switch {
+	case errors.Is(err, usecase.ErrUserNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidTrustLevel):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrTrustLevelTooLow):
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrPostLimitReached):
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+	default:
+		logger.Error(message, zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
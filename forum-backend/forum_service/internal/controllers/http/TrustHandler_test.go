@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/usecase"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// openTrust возвращает уровни доверия, которые разрешают любые действия.
+func openTrust() *mocks.TrustUsecase {
+	trust := new(mocks.TrustUsecase)
+	trust.On("CheckPost", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	trust.On("CheckContent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	trust.On("CheckChat", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return trust
+}
+
+func TestTrustHandler_GetTrust(t *testing.T) {
+	mockTrustUsecase := new(mocks.TrustUsecase)
+	handler := NewTrustHandler(mockTrustUsecase, nil, nil, zap.NewNop())
+
+	mockTrustUsecase.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{
+		UserID:     3,
+		Reputation: 42,
+		Level:      entity.TrustBasic,
+		Stats:      entity.ReputationStats{Posts: 1, PostUpvotes: 4},
+	}, nil)
+
+	w := serveGuestRoute(handler.GetTrust, http.MethodGet, "/users/:id/trust", "/users/3/trust")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var trust entity.UserTrust
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &trust))
+	assert.Equal(t, 42, trust.Reputation)
+	assert.Equal(t, entity.TrustBasic, trust.Level)
+	assert.Nil(t, trust.Override)
+	assert.Equal(t, 4, trust.Stats.PostUpvotes)
+}
+
+func TestTrustHandler_GetTrust_NotFound(t *testing.T) {
+	mockTrustUsecase := new(mocks.TrustUsecase)
+	handler := NewTrustHandler(mockTrustUsecase, nil, nil, zap.NewNop())
+
+	mockTrustUsecase.On("GetTrust", mock.Anything, 9).Return(entity.UserTrust{}, usecase.ErrUserNotFound)
+
+	w := serveGuestRoute(handler.GetTrust, http.MethodGet, "/users/:id/trust", "/users/9/trust")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTrustHandler_SetTrustLevel(t *testing.T) {
+	mockTrustUsecase := new(mocks.TrustUsecase)
+	mockAuditRepo := new(mocks.AuditRepository)
+	handler := NewTrustHandler(mockTrustUsecase, nil, NewAuditor(mockAuditRepo, zap.NewNop()), zap.NewNop())
+
+	level, adminID := entity.TrustRegular, 1
+	mockTrustUsecase.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Level: entity.TrustNew}, nil)
+	mockAuditRepo.On("Append", mock.Anything, mock.MatchedBy(func(entry entity.AuditEntry) bool {
+		return entry.ActorID == 1 && entry.Action == entity.AuditTrustSet && entry.TargetType == entity.AuditTargetUser && entry.TargetID == "3" &&
+			strings.Contains(string(entry.Before), `"trust_level":"new"`) && strings.Contains(string(entry.After), `"override":"regular"`)
+	})).Return(nil)
+	mockTrustUsecase.On("SetOverride", mock.Anything, 3, entity.TrustRegular, 1).
+		Return(entity.UserTrust{UserID: 3, Level: level, Override: &level, OverrideBy: &adminID}, nil)
+
+	w := servePostRoute(handler.SetTrustLevel, http.MethodPut, "/users/:id/trust", "/users/3/trust",
+		`{"trust_level":"regular"}`, entity.Principal{UserID: 1, Role: "admin"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"override":"regular"`)
+	mockTrustUsecase.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestTrustHandler_SetTrustLevel_Invalid(t *testing.T) {
+	mockTrustUsecase := new(mocks.TrustUsecase)
+	handler := NewTrustHandler(mockTrustUsecase, nil, nil, zap.NewNop())
+
+	mockTrustUsecase.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Level: entity.TrustNew}, nil)
+	mockTrustUsecase.On("SetOverride", mock.Anything, 3, "elder", 1).Return(entity.UserTrust{}, usecase.ErrInvalidTrustLevel)
+
+	w := servePostRoute(handler.SetTrustLevel, http.MethodPut, "/users/:id/trust", "/users/3/trust",
+		`{"trust_level":"elder"}`, entity.Principal{UserID: 1, Role: "admin"})
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTrustHandler_RecomputeTrust(t *testing.T) {
+	mockTrustUsecase := new(mocks.TrustUsecase)
+	handler := NewTrustHandler(mockTrustUsecase, nil, nil, zap.NewNop())
+
+	mockTrustUsecase.On("Recompute", mock.Anything).Return(3, nil)
+
+	w := servePostRoute(handler.RecomputeTrust, http.MethodPost, "/trust/recompute", "/trust/recompute", "",
+		entity.Principal{UserID: 1, Role: "admin"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"users":3}`, w.Body.String())
+}
+
+func TestPostHandler_CreatePost_TrustGate(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{usecase.ErrTrustLevelTooLow, http.StatusForbidden},
+		{usecase.ErrPostLimitReached, http.StatusTooManyRequests},
+	} {
+		mockPostUsecase := new(mocks.PostUsecase)
+		trust := new(mocks.TrustUsecase)
+		handler := NewPostHandler(mockPostUsecase, openCategories(), trust, nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+		principal := entity.Principal{UserID: 2, Role: "user"}
+		trust.On("CheckPost", mock.Anything, principal, "Title\nsee https://example.com").Return(tc.err)
+
+		w := servePostRoute(handler.CreatePost, http.MethodPost, "/posts", "/posts",
+			`{"title":"Title","content":"see https://example.com"}`, principal)
+
+		assert.Equal(t, tc.code, w.Code, tc.err.Error())
+		mockPostUsecase.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything)
+	}
+}
+
+func TestCommentHandler_CreateComment_TrustGate(t *testing.T) {
+	mockCommentUsecase := new(mocks.CommentsUsecases)
+	trust := new(mocks.TrustUsecase)
+	handler := NewCommentHandler(mockCommentUsecase, openCategories(), trust, nil, nil, zap.NewNop(), new(mocks.UserClient))
+
+	principal := entity.Principal{UserID: 2, Role: "user"}
+	trust.On("CheckContent", mock.Anything, principal, "![cat](cat.png)").Return(usecase.ErrTrustLevelTooLow)
+
+	w := servePostRoute(handler.CreateComment, http.MethodPost, "/posts/:id/comments", "/posts/1/comments",
+		`{"content":"![cat](cat.png)"}`, principal)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockCommentUsecase.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+}
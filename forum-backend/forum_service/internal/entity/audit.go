@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Действия форума, которые пишутся в общий журнал audit_log. Сам журнал и
+// выборку из него ведет auth_service.
+const (
+	AuditPostUpdate        = "post.update"
+	AuditPostRollback      = "post.rollback"
+	AuditPostDelete        = "post.delete"
+	AuditPostRestore       = "post.restore"
+	AuditCommentUpdate     = "comment.update"
+	AuditCommentDelete     = "comment.delete"
+	AuditCommentRestore    = "comment.restore"
+	AuditCategoryCreate    = "category.create"
+	AuditCategoryUpdate    = "category.update"
+	AuditCategoryReorder   = "category.reorder"
+	AuditCategoryMerge     = "category.merge"
+	AuditCategoryArchive   = "category.archive"
+	AuditCategoryRestore   = "category.unarchive"
+	AuditTagRename         = "tag.rename"
+	AuditTagMerge          = "tag.merge"
+	AuditTagDelete         = "tag.delete"
+	AuditTagAliasCreate    = "tag.alias.create"
+	AuditTagAliasDelete    = "tag.alias.delete"
+	AuditTrustSet          = "trust.set"
+	AuditTrustClear        = "trust.clear"
+	AuditTrustRecompute    = "trust.recompute"
+	AuditCaseClaim         = "moderation.case.claim"
+	AuditCaseRelease       = "moderation.case.release"
+	AuditCaseResolve       = "moderation.case.resolve"
+	AuditChatMessageDelete = "chat.message.delete"
+	AuditChatRoomCreate    = "chat.room.create"
+	AuditChatMemberRemove  = "chat.room.member.remove"
+	AuditChatRoomRetention = "chat.room.retention"
+)
+
+// Типы объектов в журнале.
+const (
+	AuditTargetPost        = "post"
+	AuditTargetComment     = "comment"
+	AuditTargetCategory    = "category"
+	AuditTargetTag         = "tag"
+	AuditTargetUser        = "user"
+	AuditTargetCase        = "moderation_case"
+	AuditTargetChatMessage = "chat_message"
+	AuditTargetChatRoom    = "chat_room"
+)
+
+// AuditEntry — запись журнала привилегированных действий. Before и After —
+// JSON-снимки объекта до и после действия, nil — снимка нет.
+type AuditEntry struct {
+	ActorID    int
+	ActorRole  string
+	Action     string
+	TargetType string
+	TargetID   string
+	Before     json.RawMessage
+	After      json.RawMessage
+	IP         string
+	UserAgent  string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// DefaultCategoryID — раздел "general", в который попадают посты без
+// category_id. Его нельзя архивировать или слить с другим.
+const DefaultCategoryID = 1
+
+// Действия, доступ к которым настраивается для раздела.
+const (
+	CategoryActionRead    = "read"
+	CategoryActionPost    = "post"
+	CategoryActionComment = "comment"
+)
+
+// CategoryPermissions — роли, которым разрешено действие в разделе. Пустой
+// список разрешает действие всем: читать — в том числе гостям, писать и
+// комментировать — любому вошедшему пользователю.
+type CategoryPermissions struct {
+	Read    []string `json:"read" example:""`
+	Post    []string `json:"post" example:"admin"`
+	Comment []string `json:"comment" example:""`
+}
+
+// Roles возвращает роли, которым разрешено action.
+func (p CategoryPermissions) Roles(action string) []string {
+	switch action {
+	case CategoryActionRead:
+		return p.Read
+	case CategoryActionPost:
+		return p.Post
+	case CategoryActionComment:
+		return p.Comment
+	}
+	return nil
+}
+
+// Category — раздел форума. Разделы образуют дерево через ParentID и
+// упорядочены по Position среди соседей.
+type Category struct {
+	ID          int                 `json:"id" example:"2"`
+	ParentID    *int                `json:"parent_id" example:"1"`
+	Slug        string              `json:"slug" example:"announcements"`
+	Name        string              `json:"name" example:"Объявления"`
+	Description string              `json:"description" example:"Новости форума"`
+	Position    int                 `json:"position" example:"0"`
+	Permissions CategoryPermissions `json:"permissions"`
+	Archived    bool                `json:"archived" example:"false"`
+	ArchivedAt  *time.Time          `json:"archived_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Children    []*Category         `json:"children,omitempty"`
+}
+
+// PostFilter — условия выборки постов. Нулевой CategoryID означает все
+// разделы; посты из HiddenCategoryIDs не попадают в выборку. Tags отбирает
+// посты с метками в режиме TagMode (по умолчанию TagModeAll). Sort задает
+// порядок (по умолчанию PostSortNew), Window — за какое время брать посты
+// (по умолчанию PostWindowAll).
+type PostFilter struct {
+	CategoryID        int
+	HiddenCategoryIDs []int
+	Tags              []string
+	TagMode           string
+	Sort              string
+	Window            string
+	Limit             int
+	Offset            int
+}
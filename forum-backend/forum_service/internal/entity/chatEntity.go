@@ -4,8 +4,123 @@ import "time"
 
 type ChatMessage struct {
 	ID        int       `json:"id" db:"id"`
+	RoomID    int       `json:"room_id" db:"room_id"`
 	UserID    int       `json:"userID" db:"user_id"`
 	Username  string    `json:"username" db:"username"`
 	Content   string    `json:"content" db:"content"`
 	Timestamp time.Time `json:"timestamp" db:"timestamp"`
+	// EditedAt — когда автор последний раз исправил сообщение.
+	EditedAt *time.Time `json:"edited_at,omitempty" db:"edited_at"`
+}
+
+// DefaultChatRoomID — общая комната. В нее попадают сообщения без room_id,
+// и в нее клиент входит сразу после подключения.
+const DefaultChatRoomID = 1
+
+// Виды комнат чата.
+const (
+	ChatRoomPublic  = "public"
+	ChatRoomPrivate = "private"
+	ChatRoomPost    = "post"
+)
+
+// Роли участников закрытой комнаты.
+const (
+	ChatRoleOwner  = "owner"
+	ChatRoleMember = "member"
+)
+
+// ChatRoom — комната чата. Открытая комната доступна всем, закрытая — только
+// участникам, комната поста — всем, кто может читать пост.
+type ChatRoom struct {
+	ID        int       `json:"id" example:"2"`
+	Kind      string    `json:"kind" example:"private"`
+	Name      string    `json:"name" example:"Модераторы"`
+	PostID    *int      `json:"post_id,omitempty" example:"7"`
+	CreatedBy *int      `json:"created_by,omitempty" example:"1"`
+	CreatedAt time.Time `json:"created_at"`
+	// MembersCount — число участников; у открытых комнат и комнат постов
+	// участников нет.
+	MembersCount int `json:"members_count" example:"3"`
+	// RetentionSeconds — срок хранения сообщений комнаты: nil — срок по
+	// умолчанию, 0 — бессрочно.
+	RetentionSeconds *int `json:"retention_seconds,omitempty" example:"86400"`
+}
+
+// ChatCleanupResult — сколько сообщений чата очистка удалила по сроку
+// хранения и в сколько файлов архива выгрузила их перед удалением.
+type ChatCleanupResult struct {
+	Messages int `json:"messages"`
+	Archives int `json:"archives"`
+}
+
+type ChatRoomMember struct {
+	RoomID   int       `json:"room_id" example:"2"`
+	UserID   int       `json:"user_id" example:"5"`
+	Username string    `json:"username,omitempty" example:"user123"`
+	Role     string    `json:"role" example:"member"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Типы сообщений протокола чата. join, leave и message присылает клиент;
+// joined, left, message, message.updated, message.deleted, dm и error —
+// сервер.
+const (
+	ChatEventJoin    = "join"
+	ChatEventLeave   = "leave"
+	ChatEventMessage = "message"
+	ChatEventJoined  = "joined"
+	ChatEventLeft    = "left"
+	ChatEventError   = "error"
+	// ChatEventDirect — новое личное сообщение в беседе пользователя.
+	ChatEventDirect = "dm"
+	// ChatEventMessageUpdated — сообщение комнаты исправлено; событие
+	// несет сообщение целиком.
+	ChatEventMessageUpdated = "message.updated"
+	// ChatEventMessageDeleted — сообщение комнаты удалено автором или
+	// модератором; событие несет только message_id.
+	ChatEventMessageDeleted = "message.deleted"
+)
+
+// ChatEnvelope — сообщение клиента чата. Сообщение без type считается
+// message, без room_id — адресованным общей комнате.
+type ChatEnvelope struct {
+	Type    string `json:"type" example:"message"`
+	RoomID  int    `json:"room_id" example:"2"`
+	Content string `json:"content" example:"Привет"`
+	// Since — для join: ID последнего сообщения комнаты, которое клиент
+	// видел. Вместо истории он получит только пропущенные сообщения.
+	Since int `json:"since,omitempty" example:"120"`
+}
+
+// ChatEvent — сообщение сервера чата. Поля сообщения комнаты лежат на
+// верхнем уровне, как до появления комнат.
+type ChatEvent struct {
+	Type   string `json:"type"`
+	RoomID int    `json:"room_id,omitempty"`
+	*ChatMessage
+	DirectMessage *DirectMessage `json:"dm,omitempty"`
+	Error         string         `json:"error,omitempty"`
+	// MessageID — ID удаленного сообщения в message.deleted.
+	MessageID int `json:"message_id,omitempty"`
+	// Gap — в joined: пропущенных сообщений больше, чем прислано вслед за
+	// событием. Более ранние загружаются через GET /chat/messages с
+	// before — ID первого присланного сообщения.
+	Gap bool `json:"gap,omitempty"`
+}
+
+// NewChatMessageEvent оборачивает сообщение комнаты в событие.
+func NewChatMessageEvent(msg ChatMessage) ChatEvent {
+	return ChatEvent{Type: ChatEventMessage, RoomID: msg.RoomID, ChatMessage: &msg}
+}
+
+// NewChatMessageUpdatedEvent оборачивает исправленное сообщение комнаты в
+// событие.
+func NewChatMessageUpdatedEvent(msg ChatMessage) ChatEvent {
+	return ChatEvent{Type: ChatEventMessageUpdated, RoomID: msg.RoomID, ChatMessage: &msg}
+}
+
+// NewChatMessageDeletedEvent сообщает комнате roomID об удалении сообщения.
+func NewChatMessageDeletedEvent(roomID, messageID int) ChatEvent {
+	return ChatEvent{Type: ChatEventMessageDeleted, RoomID: roomID, MessageID: messageID}
 }
@@ -1,11 +1,61 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/textdiff"
+)
+
+// DeletedCommentContent заменяет текст удаленного комментария, у которого
+// остались ответы.
+const DeletedCommentContent = "[deleted]"
+
+// HiddenCommentContent заменяет текст комментария, скрытого по жалобам до
+// решения модератора.
+const HiddenCommentContent = "[hidden]"
 
 type Comment struct {
-	ID        int       `json:"id" db:"id" exmaple:"1"`
-	AuthorId  int       `json:"author_id" db:"author_id" exmaple:"1"`
-	PostId    int       `json:"post_id" db:"post_id" exmaple:"1"`
-	Content   string    `json:"content" db:"content" exmaple:"текст комментария"`
-	CreatedAt time.Time `json:"created_at" exmaple:"22:00"`
+	ID        int        `json:"id" db:"id" exmaple:"1"`
+	AuthorId  int        `json:"author_id" db:"author_id" exmaple:"1"`
+	PostId    int        `json:"post_id" db:"post_id" exmaple:"1"`
+	ParentId  *int       `json:"parent_id,omitempty" db:"parent_id" exmaple:"1"`
+	Content   string     `json:"content" db:"content" exmaple:"текст комментария"`
+	CreatedAt time.Time  `json:"created_at" exmaple:"22:00"`
+	Deleted   bool       `json:"deleted,omitempty" db:"deleted"`
+	Hidden    bool       `json:"hidden,omitempty" db:"hidden"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	EditedBy  *int       `json:"edited_by,omitempty" db:"edited_by" exmaple:"1"`
+	Votes
+}
+
+// CommentRevision — одна версия текста комментария. Последняя версия в
+// списке (Current) — текущий текст комментария.
+type CommentRevision struct {
+	Version        int       `json:"version" exmaple:"1"`
+	CommentID      int       `json:"comment_id" exmaple:"1"`
+	Content        string    `json:"content" exmaple:"текст комментария"`
+	EditorID       int       `json:"editor_id" exmaple:"1"`
+	EditorUsername string    `json:"editor_username,omitempty" exmaple:"user"`
+	CreatedAt      time.Time `json:"created_at"`
+	Current        bool      `json:"current,omitempty"`
+}
+
+// CommentNode — комментарий в дереве обсуждения. Path содержит id всех
+// предков от корня ветки и самого комментария. Если ответы глубже
+// max_depth не загружены, RepliesCount больше, чем len(Replies).
+type CommentNode struct {
+	Comment
+	Username     string         `json:"username"`
+	Depth        int            `json:"depth"`
+	Path         []int          `json:"path"`
+	RepliesCount int            `json:"replies_count"`
+	Replies      []*CommentNode `json:"replies,omitempty"`
+}
+
+// RevisionDiff — построчная разница между версиями From и To.
+type RevisionDiff struct {
+	From    int             `json:"from" exmaple:"1"`
+	To      int             `json:"to" exmaple:"2"`
+	Changed bool            `json:"changed"`
+	Lines   []textdiff.Line `json:"lines"`
 }
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+// MaxConversationParticipants — предел участников групповой беседы вместе
+// с создателем.
+const MaxConversationParticipants = 10
+
+// Conversation — личная беседа. Беседа на двоих у пары пользователей одна;
+// групповая создается каждый раз заново и может иметь название.
+type Conversation struct {
+	ID           int                       `json:"id" example:"3"`
+	IsGroup      bool                      `json:"is_group" example:"false"`
+	Title        string                    `json:"title,omitempty" example:"Организаторы встречи"`
+	CreatedBy    int                       `json:"created_by" example:"1"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	Participants []ConversationParticipant `json:"participants"`
+	LastMessage  *DirectMessage            `json:"last_message,omitempty"`
+	// UnreadCount — непрочитанные сообщения текущего пользователя.
+	UnreadCount int `json:"unread_count" example:"2"`
+}
+
+type ConversationParticipant struct {
+	UserID            int       `json:"user_id" example:"2"`
+	Username          string    `json:"username,omitempty" example:"user123"`
+	LastReadMessageID int       `json:"last_read_message_id" example:"41"`
+	JoinedAt          time.Time `json:"joined_at"`
+}
+
+type DirectMessage struct {
+	ID             int       `json:"id" example:"42"`
+	ConversationID int       `json:"conversation_id" example:"3"`
+	SenderID       int       `json:"sender_id" example:"1"`
+	SenderUsername string    `json:"sender_username,omitempty" example:"user123"`
+	Content        string    `json:"content" example:"Привет!"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// BlockedUser — запись черного списка пользователя.
+type BlockedUser struct {
+	UserID    int       `json:"user_id" example:"7"`
+	Username  string    `json:"username,omitempty" example:"spammer"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,8 +1,65 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/textdiff"
+)
+
 type Post struct {
 	ID       int    `json:"id" db:"id" example:"1" `
 	AuthorId int    `json:"author_id" db:"author_id" example:"1" `
 	Title    string `json:"title" db:"title" example:"Заголовк"`
 	Content  string `json:"content" db:"content" example:"Текст"`
+	// CategoryID — раздел поста; при создании без него пост попадает в
+	// раздел по умолчанию.
+	CategoryID int `json:"category_id" db:"category_id" example:"1"`
+	// Tags — метки поста, не больше MaxPostTags. Регистр и синонимы
+	// приводятся к основной метке при сохранении.
+	Tags      []string  `json:"tags" db:"-" example:"go,grpc"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// EditedBy и EditReason описывают последнюю правку; у неизменявшегося
+	// поста они пустые.
+	EditedBy   *int   `json:"edited_by,omitempty" db:"edited_by" example:"2"`
+	EditReason string `json:"edit_reason,omitempty" db:"edit_reason" example:"исправлена опечатка"`
+	// Голоса и реакции только читаются: их меняют запросы к реакциям.
+	Votes
+}
+
+// PostDetails — пост со сведениями для страницы поста.
+type PostDetails struct {
+	Post
+	AuthorUsername string `json:"author_username" db:"-" example:"user123"`
+	AuthorRole     string `json:"author_role" db:"-" example:"user"`
+	CommentsCount  int    `json:"comments_count" db:"comments_count" example:"3"`
+	Edited         bool   `json:"edited" db:"-" example:"false"`
+	EditorUsername string `json:"edited_by_username,omitempty" db:"-" example:"moderator"`
+	// Hidden — пост скрыт по жалобам до решения модератора. Такой пост видят
+	// только автор и модераторы.
+	Hidden bool `json:"hidden,omitempty" db:"hidden" example:"false"`
+}
+
+// PostRevision — одна версия поста. EditorID, Reason и CreatedAt описывают
+// правку, которая привела к этой версии; у первой версии это автор и время
+// создания поста. Последняя версия в списке (Current) — текущий пост.
+type PostRevision struct {
+	Version        int       `json:"version" example:"1"`
+	PostID         int       `json:"post_id" example:"1"`
+	Title          string    `json:"title" example:"Заголовок"`
+	Content        string    `json:"content" example:"Текст"`
+	EditorID       int       `json:"editor_id" example:"1"`
+	EditorUsername string    `json:"editor_username,omitempty" example:"user123"`
+	Reason         string    `json:"reason,omitempty" example:"исправлена опечатка"`
+	CreatedAt      time.Time `json:"created_at"`
+	Current        bool      `json:"current,omitempty"`
+}
+
+// PostRevisionDiff — построчная разница заголовков и текстов версий From и To.
+type PostRevisionDiff struct {
+	From    int             `json:"from" example:"1"`
+	To      int             `json:"to" example:"2"`
+	Changed bool            `json:"changed"`
+	Title   []textdiff.Line `json:"title"`
+	Content []textdiff.Line `json:"content"`
 }
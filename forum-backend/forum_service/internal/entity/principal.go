@@ -0,0 +1,51 @@
+package entity
+
+// Права, которые проверяют обработчики форума. Сами права и их привязка
+// к ролям хранятся в таблицах permissions/role_permissions (auth_service).
+const (
+	PermPostUpdateAny        = "post.update.any"
+	PermPostDeleteAny        = "post.delete.any"
+	PermCommentUpdateAny     = "comment.update.any"
+	PermCommentDeleteAny     = "comment.delete.any"
+	PermUserBan              = "user.ban"
+	PermChatMute             = "chat.mute"
+	PermCategoryManage       = "category.manage"
+	PermTagManage            = "tag.manage"
+	PermTrustManage          = "trust.manage"
+	PermTrustExempt          = "trust.exempt"
+	PermReportReview         = "report.review"
+	PermTrashManage          = "trash.manage"
+	PermChatRoomManage       = "chat.room.manage"
+	PermChatMessageDeleteAny = "chat.message.delete.any"
+)
+
+// Principal — пользователь, от имени которого выполняется запрос.
+// Заполняется AuthMiddleware по проверенному access-токену.
+type Principal struct {
+	UserID      int      `json:"user_id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	Token       string   `json:"-"`
+	// Sanctions — действующие санкции пользователя на момент запроса.
+	Sanctions Sanctions `json:"-"`
+}
+
+// HasRole сообщает, совпадает ли роль пользователя с одной из переданных.
+func (p Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Can сообщает, есть ли у роли пользователя право permission.
+func (p Principal) Can(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
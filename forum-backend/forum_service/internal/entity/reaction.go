@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Цели реакций.
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+// Голоса за пост или комментарий. Остальные виды реакций — эмодзи из
+// настроенного набора.
+const (
+	ReactionUp   = "up"
+	ReactionDown = "down"
+)
+
+// Сортировки ленты постов. hot учитывает и счет, и возраст поста:
+// свежие посты с тем же счетом выше старых.
+const (
+	PostSortNew           = "new"
+	PostSortTop           = "top"
+	PostSortHot           = "hot"
+	PostSortControversial = "controversial"
+)
+
+// Окна времени для ленты постов: в выборку попадают посты, созданные за
+// последний час, день и т.д.
+const (
+	PostWindowHour  = "hour"
+	PostWindowDay   = "day"
+	PostWindowWeek  = "week"
+	PostWindowMonth = "month"
+	PostWindowYear  = "year"
+	PostWindowAll   = "all"
+)
+
+// Votes — счетчики реакций поста или комментария. Score — разница голосов
+// up и down; Reactions — количество эмодзи по видам.
+type Votes struct {
+	Upvotes   int            `json:"upvotes" db:"upvotes" example:"5"`
+	Downvotes int            `json:"downvotes" db:"downvotes" example:"1"`
+	Score     int            `json:"score" db:"-" example:"4"`
+	Reactions ReactionCounts `json:"reactions" db:"reactions"`
+}
+
+// ReactionCounts — количество реакций по видам. Из базы читается как
+// JSON-объект, который собирает json_group_object. Цель без реакций хранит
+// nil, а в JSON отдается пустым объектом.
+type ReactionCounts map[string]int
+
+func (c *ReactionCounts) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return c.UnmarshalJSON(v)
+	case string:
+		return c.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("unsupported reaction counts type %T", src)
+	}
+}
+
+func (c *ReactionCounts) UnmarshalJSON(data []byte) error {
+	var counts map[string]int
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	if len(counts) == 0 {
+		counts = nil
+	}
+	*c = counts
+	return nil
+}
+
+func (c ReactionCounts) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]int(c))
+}
+
+// ReactionTarget — пост или комментарий, на который ставят реакцию. PostID —
+// пост, по разделу которого проверяется доступ; AuthorID — автор цели.
+type ReactionTarget struct {
+	Type     string
+	ID       int
+	PostID   int
+	AuthorID int
+	Deleted  bool
+}
+
+// Reaction — реакция пользователя.
+type Reaction struct {
+	TargetType string    `json:"target_type" example:"post"`
+	TargetID   int       `json:"target_id" example:"1"`
+	UserID     int       `json:"user_id" example:"1"`
+	Username   string    `json:"username,omitempty" example:"user123"`
+	Kind       string    `json:"kind" example:"up"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ReactionFilter — условия выборки реакций на цель. Пустой Kind — реакции
+// всех видов.
+type ReactionFilter struct {
+	TargetType string
+	TargetID   int
+	Kind       string
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,121 @@
+package entity
+
+import "time"
+
+// Цели жалоб: посты и комментарии, как у реакций, и сообщения чата.
+const ReportTargetChatMessage = "chat_message"
+
+// Причины жалоб. Для other нужен комментарий жалующегося.
+const (
+	ReportReasonSpam           = "spam"
+	ReportReasonHarassment     = "harassment"
+	ReportReasonHate           = "hate"
+	ReportReasonViolence       = "violence"
+	ReportReasonSexual         = "sexual"
+	ReportReasonMisinformation = "misinformation"
+	ReportReasonOffTopic       = "off_topic"
+	ReportReasonOther          = "other"
+)
+
+// ReportReasons — причины жалоб в порядке, в котором их показывает форма.
+var ReportReasons = []string{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonHate,
+	ReportReasonViolence,
+	ReportReasonSexual,
+	ReportReasonMisinformation,
+	ReportReasonOffTopic,
+	ReportReasonOther,
+}
+
+// Состояния дела модерации. Фильтр очереди принимает еще pending — открытые
+// и взятые в работу дела — и all.
+const (
+	CaseStatusOpen     = "open"
+	CaseStatusClaimed  = "claimed"
+	CaseStatusResolved = "resolved"
+	CaseStatusPending  = "pending"
+	CaseStatusAll      = "all"
+)
+
+// Решения по делу. dismiss — жалобы отклонены, delete — цель удалена,
+// warn и ban — автору вынесено предупреждение или бан.
+const (
+	ResolutionDismiss = "dismiss"
+	ResolutionDelete  = "delete"
+	ResolutionWarn    = "warn"
+	ResolutionBan     = "ban"
+)
+
+// ReportTarget — пост, комментарий или сообщение чата, на которое жалуются.
+// PostID — пост, по разделу которого проверяется доступ; у сообщений чата
+// его нет.
+type ReportTarget struct {
+	Type     string
+	ID       int
+	PostID   int
+	AuthorID int
+	Content  string
+	Deleted  bool
+}
+
+// Report — жалоба пользователя.
+type Report struct {
+	ID               int       `json:"id" example:"1"`
+	CaseID           int       `json:"case_id" example:"1"`
+	ReporterID       int       `json:"reporter_id" example:"3"`
+	ReporterUsername string    `json:"reporter_username,omitempty" example:"user123"`
+	Reason           string    `json:"reason" example:"spam"`
+	Note             string    `json:"note,omitempty" example:"ссылка на казино"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ModerationCase — дело модерации: все жалобы на одну цель до решения
+// модератора. Content — текст цели на момент первой жалобы; Hidden —
+// цель сейчас скрыта по жалобам.
+type ModerationCase struct {
+	ID                   int    `json:"id" example:"1"`
+	TargetType           string `json:"target_type" example:"post"`
+	TargetID             int    `json:"target_id" example:"1"`
+	PostID               *int   `json:"post_id,omitempty" example:"1"`
+	TargetAuthorID       int    `json:"target_author_id" example:"2"`
+	TargetAuthorUsername string `json:"target_author_username,omitempty" example:"spammer"`
+	Content              string `json:"content" example:"Текст"`
+	Hidden               bool   `json:"hidden"`
+	// RoomID — комната сообщения чата, пока сообщение не удалено.
+	RoomID         *int       `json:"room_id,omitempty" example:"1"`
+	Status         string     `json:"status" example:"open"`
+	ReportsCount   int        `json:"reports_count" example:"3"`
+	ClaimedBy      *int       `json:"claimed_by,omitempty" example:"5"`
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+	Resolution     *string    `json:"resolution,omitempty" example:"delete"`
+	ResolutionNote string     `json:"resolution_note,omitempty" example:"реклама"`
+	ResolvedBy     *int       `json:"resolved_by,omitempty" example:"5"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Reports        []Report   `json:"reports,omitempty"`
+}
+
+// Warning — предупреждение, вынесенное автору при решении дела. Reason —
+// комментарий модератора к решению.
+type Warning struct {
+	ID        int       `json:"id" example:"1"`
+	UserID    int       `json:"user_id" example:"2"`
+	CaseID    int       `json:"case_id" example:"1"`
+	Reason    string    `json:"reason" example:"реклама"`
+	IssuedBy  int       `json:"issued_by" example:"5"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CaseFilter — условия выборки дел из очереди модерации. Пустые поля не
+// ограничивают выборку; Status по умолчанию — pending.
+type CaseFilter struct {
+	Status     string
+	TargetType string
+	Reason     string
+	ClaimedBy  int
+	AuthorID   int
+	Limit      int
+	Offset     int
+}
@@ -1,7 +1,119 @@
 package entity
 
 type WSAuthRequest struct {
-	Token  string `form:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	UserID string `form:"userID" binding:"required" example:"123"`
-	Auth   string `form:"auth" binding:"required" example:"true"`
+	Token string `form:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Mode  string `form:"mode" binding:"omitempty,oneof=anonymous" example:"anonymous"`
+	// Since — ID последнего сообщения общей комнаты, которое клиент видел
+	// до переподключения
+	Since int `form:"since" binding:"omitempty,min=0" example:"120"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required" example:"исправленный текст"`
+}
+
+type UpdatePostRequest struct {
+	Title   string `json:"title" example:"Заголовок"`
+	Content string `json:"content" example:"Текст"`
+	Reason  string `json:"reason" example:"исправлена опечатка"`
+	// Tags заменяет метки поста; без поля метки не меняются, [] — снимает все.
+	Tags []string `json:"tags" example:"go,grpc"`
+}
+
+type RollbackPostRequest struct {
+	Reason string `json:"reason" example:"возврат к исходной версии"`
+}
+
+type CategoryRequest struct {
+	ParentID    *int                `json:"parent_id" example:"1"`
+	Slug        string              `json:"slug" binding:"required" example:"announcements"`
+	Name        string              `json:"name" binding:"required" example:"Объявления"`
+	Description string              `json:"description" example:"Новости форума"`
+	Position    int                 `json:"position" example:"0"`
+	Permissions CategoryPermissions `json:"permissions"`
+}
+
+type ReorderCategoriesRequest struct {
+	ParentID *int  `json:"parent_id" example:"1"`
+	IDs      []int `json:"ids" binding:"required" example:"3,2,4"`
+}
+
+type MergeCategoryRequest struct {
+	TargetID int `json:"target_id" binding:"required" example:"1"`
+}
+
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required" example:"golang"`
+}
+
+type MergeTagRequest struct {
+	Target string `json:"target" binding:"required" example:"go"`
+}
+
+type TagAliasRequest struct {
+	Alias string `json:"alias" binding:"required" example:"golang"`
+}
+
+type SetTrustLevelRequest struct {
+	Level string `json:"trust_level" binding:"required" example:"member"`
+}
+
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" binding:"required" example:"post"`
+	TargetID   int    `json:"target_id" binding:"required" example:"1"`
+	Reason     string `json:"reason" binding:"required" example:"spam"`
+	Note       string `json:"note" example:"ссылка на казино"`
+}
+
+type ResolveCaseRequest struct {
+	Action string `json:"action" binding:"required" example:"warn"`
+	Note   string `json:"note" example:"реклама"`
+	// DeleteContent удаляет цель вместе с предупреждением или баном автора
+	DeleteContent bool `json:"delete_content" example:"true"`
+	// BanDurationHours — срок бана; 0 — бессрочно
+	BanDurationHours int `json:"ban_duration_hours" binding:"omitempty,min=0" example:"72"`
+}
+
+// CreateSanctionRequest — запрос к auth_service на санкцию против
+// пользователя; повторяет его формат.
+type CreateSanctionRequest struct {
+	Type          string `json:"type"`
+	Reason        string `json:"reason"`
+	DurationHours int    `json:"duration_hours"`
+}
+
+type UpdateChatMessageRequest struct {
+	Content string `json:"content" binding:"required" example:"исправленный текст"`
+}
+
+type CreateChatRoomRequest struct {
+	Name string `json:"name" binding:"required" example:"Модераторы"`
+	// Kind — public или private; комнаты постов создаются сами
+	Kind string `json:"kind" binding:"required,oneof=public private" example:"private"`
+}
+
+type AddChatRoomMemberRequest struct {
+	UserID int `json:"user_id" binding:"required" example:"5"`
+}
+
+type SetChatRoomRetentionRequest struct {
+	// RetentionSeconds — срок хранения сообщений в секундах: null — срок по
+	// умолчанию, 0 — бессрочно
+	RetentionSeconds *int `json:"retention_seconds" binding:"omitempty,min=0" example:"86400"`
+}
+
+type CreateConversationRequest struct {
+	// UserIDs — собеседники без создателя; один собеседник — беседа на двоих
+	UserIDs []int  `json:"user_ids" binding:"required,min=1,dive,gt=0" example:"2,3"`
+	Title   string `json:"title" example:"Организаторы встречи"`
+	// Content — первое сообщение беседы, необязательно
+	Content string `json:"content" example:"Привет!"`
+}
+
+type SendDirectMessageRequest struct {
+	Content string `json:"content" binding:"required" example:"Привет!"`
+}
+
+type MarkConversationReadRequest struct {
+	MessageID int `json:"message_id" binding:"required" example:"42"`
 }
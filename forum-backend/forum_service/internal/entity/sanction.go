@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Виды санкций. Санкции накладывает auth_service, форум только читает
+// действующие из таблицы user_sanctions.
+const (
+	SanctionBan        = "ban"
+	SanctionSuspension = "suspension"
+	SanctionMute       = "mute"
+)
+
+// Sanction — действующая санкция пользователя. У бессрочной санкции нет
+// ExpiresAt.
+type Sanction struct {
+	ID        int        `json:"id"`
+	Type      string     `json:"type"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Sanctions — действующие санкции одного пользователя.
+type Sanctions []Sanction
+
+// LoginBlock возвращает блокировку, запрещающую входить на форум, или nil.
+func (s Sanctions) LoginBlock() *Sanction {
+	return s.find(SanctionBan)
+}
+
+// WriteBlock возвращает санкцию, запрещающую писать на форуме (блокировку
+// или ограничение), или nil.
+func (s Sanctions) WriteBlock() *Sanction {
+	return s.find(SanctionBan, SanctionSuspension)
+}
+
+// ChatBlock возвращает санкцию, запрещающую писать в чат, или nil.
+func (s Sanctions) ChatBlock() *Sanction {
+	return s.find(SanctionBan, SanctionSuspension, SanctionMute)
+}
+
+func (s Sanctions) find(types ...string) *Sanction {
+	for i := range s {
+		for _, t := range types {
+			if s[i].Type == t {
+				return &s[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	SearchTypePost    = "post"
+	SearchTypeComment = "comment"
+)
+
+// SearchFilter — условия поиска. Пустой Type означает посты и комментарии,
+// нулевой AuthorID — любого автора. To не включается в диапазон. Посты из
+// HiddenCategoryIDs и комментарии к ним не ищутся.
+type SearchFilter struct {
+	Query             string
+	Type              string
+	AuthorID          int
+	From              *time.Time
+	To                *time.Time
+	HiddenCategoryIDs []int
+	Limit             int
+	Offset            int
+}
+
+// SearchResult — найденный пост или комментарий. Title и Snippet — HTML:
+// текст экранирован, совпадения обернуты в <mark>. Для комментария Title —
+// заголовок поста, к которому он оставлен.
+type SearchResult struct {
+	Type      string    `json:"type" example:"post"`
+	ID        int       `json:"id" example:"1"`
+	PostID    int       `json:"post_id" example:"1"`
+	Title     string    `json:"title" example:"Первый <mark>пост</mark>"`
+	Snippet   string    `json:"snippet" example:"…текст <mark>поста</mark>…"`
+	AuthorID  int       `json:"author_id" example:"1"`
+	Username  string    `json:"username" example:"user123"`
+	CreatedAt time.Time `json:"created_at"`
+	// Score — релевантность по BM25, чем больше, тем лучше.
+	Score float64 `json:"score" example:"3.2"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// MaxPostTags — сколько меток можно назначить одному посту.
+const MaxPostTags = 5
+
+// Режимы фильтрации постов по нескольким меткам: all — пост должен иметь
+// все метки, any — хотя бы одну.
+const (
+	TagModeAll = "all"
+	TagModeAny = "any"
+)
+
+// Tag — метка постов. Name хранится в нижнем регистре; Aliases — синонимы,
+// которые при назначении и фильтрации заменяются на Name.
+type Tag struct {
+	ID         int       `json:"id" example:"1"`
+	Name       string    `json:"name" example:"go"`
+	Aliases    []string  `json:"aliases,omitempty" example:"golang"`
+	PostsCount int       `json:"posts_count" example:"12"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TagFilter — условия выборки меток. Prefix ищет по началу имени; посты из
+// HiddenCategoryIDs не учитываются в PostsCount.
+type TagFilter struct {
+	Prefix            string
+	HiddenCategoryIDs []int
+	Limit             int
+	Offset            int
+}
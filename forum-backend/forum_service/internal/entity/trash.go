@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// TrashedPost — пост в корзине. Комментарии поста восстанавливаются вместе
+// с ним; CommentsCount — сколько их вернется.
+type TrashedPost struct {
+	ID             int       `json:"id" example:"1"`
+	AuthorId       int       `json:"author_id" example:"1"`
+	AuthorUsername string    `json:"author_username,omitempty" example:"user123"`
+	Title          string    `json:"title" example:"Заголовок"`
+	Content        string    `json:"content" example:"Текст"`
+	CategoryID     int       `json:"category_id" example:"1"`
+	CreatedAt      time.Time `json:"created_at"`
+	CommentsCount  int       `json:"comments_count" example:"3"`
+	DeletedAt      time.Time `json:"deleted_at"`
+	// DeletedBy пуст у постов, удаленных до появления корзины.
+	DeletedBy         *int   `json:"deleted_by,omitempty" example:"2"`
+	DeletedByUsername string `json:"deleted_by_username,omitempty" example:"moderator"`
+}
+
+// TrashedComment — комментарий в корзине с исходным текстом. Комментарии
+// удаленных постов сюда не попадают: они восстанавливаются вместе с постом.
+type TrashedComment struct {
+	ID                int       `json:"id" example:"1"`
+	AuthorId          int       `json:"author_id" example:"1"`
+	AuthorUsername    string    `json:"author_username,omitempty" example:"user123"`
+	PostId            int       `json:"post_id" example:"1"`
+	PostTitle         string    `json:"post_title" example:"Заголовок"`
+	ParentId          *int      `json:"parent_id,omitempty" example:"1"`
+	Content           string    `json:"content" example:"текст комментария"`
+	CreatedAt         time.Time `json:"created_at"`
+	DeletedAt         time.Time `json:"deleted_at"`
+	DeletedBy         *int      `json:"deleted_by,omitempty" example:"2"`
+	DeletedByUsername string    `json:"deleted_by_username,omitempty" example:"moderator"`
+}
+
+// PurgeResult — сколько постов и комментариев очистка корзины удалила
+// насовсем. Комментарии удаленных постов в Comments не входят.
+type PurgeResult struct {
+	Posts    int `json:"posts"`
+	Comments int `json:"comments"`
+}
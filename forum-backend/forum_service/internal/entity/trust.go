@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// Уровни доверия в порядке возрастания. Уровень вычисляется по репутации,
+// если администратор не назначил его вручную.
+const (
+	TrustNew     = "new"
+	TrustBasic   = "basic"
+	TrustMember  = "member"
+	TrustRegular = "regular"
+)
+
+// TrustLevels — уровни доверия от низшего к высшему.
+var TrustLevels = []string{TrustNew, TrustBasic, TrustMember, TrustRegular}
+
+// TrustRank возвращает место уровня в TrustLevels; для неизвестного уровня
+// возвращает -1.
+func TrustRank(level string) int {
+	for i, l := range TrustLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReputationStats — активность пользователя, из которой складывается
+// репутация: его посты и комментарии и голоса, которые они получили.
+// Удаленные комментарии не считаются.
+type ReputationStats struct {
+	UserID           int `json:"-"`
+	Posts            int `json:"posts" example:"4"`
+	Comments         int `json:"comments" example:"12"`
+	PostUpvotes      int `json:"post_upvotes" example:"7"`
+	PostDownvotes    int `json:"post_downvotes" example:"1"`
+	CommentUpvotes   int `json:"comment_upvotes" example:"9"`
+	CommentDownvotes int `json:"comment_downvotes" example:"2"`
+}
+
+// UserTrust — репутация и уровень доверия пользователя. Override — уровень,
+// назначенный администратором OverrideBy; пока он задан, Level равен ему.
+type UserTrust struct {
+	UserID     int             `json:"user_id" example:"1"`
+	Reputation int             `json:"reputation" example:"139"`
+	Level      string          `json:"trust_level" example:"member"`
+	Override   *string         `json:"override,omitempty" example:"regular"`
+	OverrideBy *int            `json:"override_by,omitempty" example:"1"`
+	Stats      ReputationStats `json:"stats"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
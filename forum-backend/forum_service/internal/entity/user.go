@@ -0,0 +1,8 @@
+package entity
+
+// UserInfo — сведения о пользователе, которые forum_service получает от auth_service.
+type UserInfo struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
@@ -109,6 +109,250 @@ func (x *UserResponse) GetUsername() string {
 	return ""
 }
 
+type UserInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserInfo) Reset() {
+	*x = UserInfo{}
+	mi := &file_internal_proto_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInfo) ProtoMessage() {}
+
+func (x *UserInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInfo.ProtoReflect.Descriptor instead.
+func (*UserInfo) Descriptor() ([]byte, []int) {
+	return file_internal_proto_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserInfo) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserInfo) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []int32                `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersRequest) Reset() {
+	*x = UsersRequest{}
+	mi := &file_internal_proto_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersRequest) ProtoMessage() {}
+
+func (x *UsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersRequest.ProtoReflect.Descriptor instead.
+func (*UsersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UsersRequest) GetUserIds() []int32 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type UsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserInfo            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersResponse) Reset() {
+	*x = UsersResponse{}
+	mi := &file_internal_proto_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersResponse) ProtoMessage() {}
+
+func (x *UsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersResponse.ProtoReflect.Descriptor instead.
+func (*UsersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UsersResponse) GetUsers() []*UserInfo {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type WatchUserChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchUserChangesRequest) Reset() {
+	*x = WatchUserChangesRequest{}
+	mi := &file_internal_proto_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchUserChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchUserChangesRequest) ProtoMessage() {}
+
+func (x *WatchUserChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchUserChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchUserChangesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_user_proto_rawDescGZIP(), []int{5}
+}
+
+type UserChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserChange) Reset() {
+	*x = UserChange{}
+	mi := &file_internal_proto_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserChange) ProtoMessage() {}
+
+func (x *UserChange) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserChange.ProtoReflect.Descriptor instead.
+func (*UserChange) Descriptor() ([]byte, []int) {
+	return file_internal_proto_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserChange) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserChange) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserChange) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 var File_internal_proto_user_proto protoreflect.FileDescriptor
 
 const file_internal_proto_user_proto_rawDesc = "" +
@@ -117,9 +361,26 @@ const file_internal_proto_user_proto_rawDesc = "" +
 	"\vUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\"*\n" +
 	"\fUserResponse\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername2C\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"S\n" +
+	"\bUserInfo\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\")\n" +
+	"\fUsersRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\x05R\auserIds\"5\n" +
+	"\rUsersResponse\x12$\n" +
+	"\x05users\x18\x01 \x03(\v2\x0e.user.UserInfoR\x05users\"\x19\n" +
+	"\x17WatchUserChangesRequest\"U\n" +
+	"\n" +
+	"UserChange\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role2\xed\x01\n" +
 	"\vUserService\x124\n" +
-	"\vGetUsername\x12\x11.user.UserRequest\x1a\x12.user.UserResponseBCZAgithub.com/Engls/forum-project2/forum-service/internal/proto/userb\x06proto3"
+	"\vGetUsername\x12\x11.user.UserRequest\x1a\x12.user.UserResponse\x12,\n" +
+	"\aGetUser\x12\x11.user.UserRequest\x1a\x0e.user.UserInfo\x123\n" +
+	"\bGetUsers\x12\x12.user.UsersRequest\x1a\x13.user.UsersResponse\x12E\n" +
+	"\x10WatchUserChanges\x12\x1d.user.WatchUserChangesRequest\x1a\x10.user.UserChange0\x01BCZAgithub.com/Engls/forum-project2/forum-service/internal/proto/userb\x06proto3"
 
 var (
 	file_internal_proto_user_proto_rawDescOnce sync.Once
@@ -133,19 +394,31 @@ func file_internal_proto_user_proto_rawDescGZIP() []byte {
 	return file_internal_proto_user_proto_rawDescData
 }
 
-var file_internal_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_internal_proto_user_proto_goTypes = []any{
-	(*UserRequest)(nil),  // 0: user.UserRequest
-	(*UserResponse)(nil), // 1: user.UserResponse
+	(*UserRequest)(nil),             // 0: user.UserRequest
+	(*UserResponse)(nil),            // 1: user.UserResponse
+	(*UserInfo)(nil),                // 2: user.UserInfo
+	(*UsersRequest)(nil),            // 3: user.UsersRequest
+	(*UsersResponse)(nil),           // 4: user.UsersResponse
+	(*WatchUserChangesRequest)(nil), // 5: user.WatchUserChangesRequest
+	(*UserChange)(nil),              // 6: user.UserChange
 }
 var file_internal_proto_user_proto_depIdxs = []int32{
-	0, // 0: user.UserService.GetUsername:input_type -> user.UserRequest
-	1, // 1: user.UserService.GetUsername:output_type -> user.UserResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: user.UsersResponse.users:type_name -> user.UserInfo
+	0, // 1: user.UserService.GetUsername:input_type -> user.UserRequest
+	0, // 2: user.UserService.GetUser:input_type -> user.UserRequest
+	3, // 3: user.UserService.GetUsers:input_type -> user.UsersRequest
+	5, // 4: user.UserService.WatchUserChanges:input_type -> user.WatchUserChangesRequest
+	1, // 5: user.UserService.GetUsername:output_type -> user.UserResponse
+	2, // 6: user.UserService.GetUser:output_type -> user.UserInfo
+	4, // 7: user.UserService.GetUsers:output_type -> user.UsersResponse
+	6, // 8: user.UserService.WatchUserChanges:output_type -> user.UserChange
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_user_proto_init() }
@@ -159,7 +432,7 @@ func file_internal_proto_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_user_proto_rawDesc), len(file_internal_proto_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
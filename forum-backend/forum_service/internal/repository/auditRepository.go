@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// AuditRepository пишет в журнал audit_log, который читает auth_service.
+// Журнал только пополняется.
+type AuditRepository interface {
+	Append(ctx context.Context, entry entity.AuditEntry) error
+}
+
+type auditRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewAuditRepository(db DB, logger *zap.Logger) AuditRepository {
+	return &auditRepository{db: db, logger: logger}
+}
+
+func (r *auditRepository) Append(ctx context.Context, entry entity.AuditEntry) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO audit_log (actor_id, actor_role, action, target_type, target_id, before, after, ip, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ActorID, entry.ActorRole, entry.Action, entry.TargetType, entry.TargetID,
+		snapshotValue(entry.Before), snapshotValue(entry.After), entry.IP, entry.UserAgent, entry.CreatedAt.UTC())
+	if err != nil {
+		r.logger.Error("Failed to append audit entry", zap.String("action", entry.Action), zap.Int("actorID", entry.ActorID), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// snapshotValue хранит снимок текстом, как и auth_service, а пустой — NULL.
+func snapshotValue(snapshot json.RawMessage) interface{} {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	return string(snapshot)
+}
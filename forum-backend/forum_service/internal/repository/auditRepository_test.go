@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAuditRepository_Append_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	auditRepo := NewAuditRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectExec(`INSERT INTO audit_log \(actor_id, actor_role, action, target_type, target_id, before, after, ip, user_agent, created_at\)`).
+		WithArgs(7, "moderator", entity.AuditPostDelete, entity.AuditTargetPost, "3", `{"id":3}`, nil, "10.0.0.1", "curl/8.0", createdAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = auditRepo.Append(context.Background(), entity.AuditEntry{
+		ActorID:    7,
+		ActorRole:  "moderator",
+		Action:     entity.AuditPostDelete,
+		TargetType: entity.AuditTargetPost,
+		TargetID:   "3",
+		Before:     json.RawMessage(`{"id":3}`),
+		IP:         "10.0.0.1",
+		UserAgent:  "curl/8.0",
+		CreatedAt:  createdAt,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepository_Append_Failure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	auditRepo := NewAuditRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT INTO audit_log`).WillReturnError(errors.New("audit_log is append-only"))
+
+	err = auditRepo.Append(context.Background(), entity.AuditEntry{ActorID: 7, Action: entity.AuditTagDelete})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type CategoryRepository interface {
+	// GetCategories возвращает все разделы, включая архивные, упорядоченные
+	// по position внутри родителя.
+	GetCategories(ctx context.Context) ([]entity.Category, error)
+	CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error)
+	// UpdateCategory для несуществующего раздела возвращает sql.ErrNoRows.
+	UpdateCategory(ctx context.Context, category entity.Category) (entity.Category, error)
+	// ReorderCategories выставляет разделам position по их порядку в ids.
+	ReorderCategories(ctx context.Context, ids []int) error
+	// MergeCategory переносит посты и подразделы source в target и удаляет source.
+	MergeCategory(ctx context.Context, sourceID, targetID int) error
+	SetCategoryArchived(ctx context.Context, id int, archived bool) (entity.Category, error)
+	// GetPostCategoryID для несуществующего поста возвращает sql.ErrNoRows.
+	GetPostCategoryID(ctx context.Context, postID int) (int, error)
+}
+
+type categoryRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewCategoryRepository(db DB, logger *zap.Logger) CategoryRepository {
+	return &categoryRepository{db: db, logger: logger}
+}
+
+const categoryColumns = `id, parent_id, slug, name, description, position, read_roles, post_roles, comment_roles, archived_at, created_at, updated_at`
+
+func scanCategory(row rowScanner) (entity.Category, error) {
+	var category entity.Category
+	var readRoles, postRoles, commentRoles string
+	err := row.Scan(
+		&category.ID,
+		&category.ParentID,
+		&category.Slug,
+		&category.Name,
+		&category.Description,
+		&category.Position,
+		&readRoles,
+		&postRoles,
+		&commentRoles,
+		&category.ArchivedAt,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	category.Permissions = entity.CategoryPermissions{
+		Read:    splitRoles(readRoles),
+		Post:    splitRoles(postRoles),
+		Comment: splitRoles(commentRoles),
+	}
+	category.Archived = category.ArchivedAt != nil
+	return category, err
+}
+
+// Роли хранятся строкой через запятую: имена ролей запятых не содержат.
+func splitRoles(roles string) []string {
+	if roles == "" {
+		return []string{}
+	}
+	return strings.Split(roles, ",")
+}
+
+func joinRoles(roles []string) string {
+	return strings.Join(roles, ",")
+}
+
+func (r *categoryRepository) GetCategories(ctx context.Context) ([]entity.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY position, id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.Error("Failed to get categories", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []entity.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan category", zap.Error(err))
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (r *categoryRepository) CreateCategory(ctx context.Context, category entity.Category) (entity.Category, error) {
+	query := `
+		INSERT INTO categories (parent_id, slug, name, description, position, read_roles, post_roles, comment_roles)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING ` + categoryColumns
+	created, err := scanCategory(r.db.QueryRowContext(ctx, query,
+		category.ParentID,
+		category.Slug,
+		category.Name,
+		category.Description,
+		category.Position,
+		joinRoles(category.Permissions.Read),
+		joinRoles(category.Permissions.Post),
+		joinRoles(category.Permissions.Comment),
+	))
+	if err != nil {
+		r.logger.Error("Failed to create category", zap.Error(err), zap.String("slug", category.Slug))
+		return entity.Category{}, err
+	}
+	r.logger.Info("Category created", zap.Int("categoryID", created.ID), zap.String("slug", created.Slug))
+	return created, nil
+}
+
+func (r *categoryRepository) UpdateCategory(ctx context.Context, category entity.Category) (entity.Category, error) {
+	query := `
+		UPDATE categories
+		SET parent_id = ?, slug = ?, name = ?, description = ?, position = ?,
+		    read_roles = ?, post_roles = ?, comment_roles = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		RETURNING ` + categoryColumns
+	updated, err := scanCategory(r.db.QueryRowContext(ctx, query,
+		category.ParentID,
+		category.Slug,
+		category.Name,
+		category.Description,
+		category.Position,
+		joinRoles(category.Permissions.Read),
+		joinRoles(category.Permissions.Post),
+		joinRoles(category.Permissions.Comment),
+		category.ID,
+	))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to update category", zap.Error(err), zap.Int("categoryID", category.ID))
+		}
+		return entity.Category{}, err
+	}
+	r.logger.Info("Category updated", zap.Int("categoryID", updated.ID))
+	return updated, nil
+}
+
+func (r *categoryRepository) ReorderCategories(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var cases strings.Builder
+	args := make([]interface{}, 0, len(ids)*3)
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		cases.WriteString(" WHEN ? THEN ?")
+		args = append(args, id, i)
+		placeholders[i] = "?"
+	}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	query := `UPDATE categories SET position = CASE id` + cases.String() + ` END, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		r.logger.Error("Failed to reorder categories", zap.Error(err), zap.Ints("categoryIDs", ids))
+		return err
+	}
+	return nil
+}
+
+// MergeCategory выполняет перенос без транзакции, но каждый шаг можно
+// повторить: если слияние прервалось, повторный вызов его завершит.
+func (r *categoryRepository) MergeCategory(ctx context.Context, sourceID, targetID int) error {
+	steps := []string{
+		`UPDATE posts SET category_id = ? WHERE category_id = ?`,
+		`UPDATE categories SET parent_id = ?, updated_at = CURRENT_TIMESTAMP WHERE parent_id = ?`,
+	}
+	for _, query := range steps {
+		if _, err := r.db.ExecContext(ctx, query, targetID, sourceID); err != nil {
+			r.logger.Error("Failed to merge category", zap.Error(err), zap.Int("sourceID", sourceID), zap.Int("targetID", targetID))
+			return err
+		}
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE id = ?`, sourceID); err != nil {
+		r.logger.Error("Failed to delete merged category", zap.Error(err), zap.Int("sourceID", sourceID))
+		return err
+	}
+	r.logger.Info("Category merged", zap.Int("sourceID", sourceID), zap.Int("targetID", targetID))
+	return nil
+}
+
+func (r *categoryRepository) SetCategoryArchived(ctx context.Context, id int, archived bool) (entity.Category, error) {
+	query := `
+		UPDATE categories
+		SET archived_at = CASE WHEN ? THEN COALESCE(archived_at, CURRENT_TIMESTAMP) END, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		RETURNING ` + categoryColumns
+	category, err := scanCategory(r.db.QueryRowContext(ctx, query, archived, id))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to archive category", zap.Error(err), zap.Int("categoryID", id))
+		}
+		return entity.Category{}, err
+	}
+	return category, nil
+}
+
+func (r *categoryRepository) GetPostCategoryID(ctx context.Context, postID int) (int, error) {
+	var categoryID int
+	err := r.db.QueryRowContext(ctx, `SELECT category_id FROM posts WHERE id = ? AND deleted_at IS NULL`, postID).Scan(&categoryID)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get post category", zap.Error(err), zap.Int("postID", postID))
+	}
+	return categoryID, err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var categoryColumnNames = []string{"id", "parent_id", "slug", "name", "description", "position", "read_roles", "post_roles", "comment_roles", "archived_at", "created_at", "updated_at"}
+
+func TestCategoryRepository_GetCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+	now := time.Now()
+
+	rows := sqlmock.NewRows(categoryColumnNames).
+		AddRow(1, nil, "general", "Общее", "", 0, "", "", "", nil, now, now).
+		AddRow(2, 1, "staff", "Staff", "", 1, "admin,moderator", "", "admin", now, now, now)
+	mock.ExpectQuery(`SELECT id, parent_id, slug, .* FROM categories ORDER BY position, id`).WillReturnRows(rows)
+
+	categories, err := repo.GetCategories(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+	assert.Nil(t, categories[0].ParentID)
+	assert.False(t, categories[0].Archived)
+	assert.Equal(t, []string{}, categories[0].Permissions.Read)
+	assert.Equal(t, 1, *categories[1].ParentID)
+	assert.True(t, categories[1].Archived)
+	assert.Equal(t, entity.CategoryPermissions{
+		Read:    []string{"admin", "moderator"},
+		Post:    []string{},
+		Comment: []string{"admin"},
+	}, categories[1].Permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepository_CreateCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+	now := time.Now()
+	category := entity.Category{
+		Slug:        "staff",
+		Name:        "Staff",
+		Permissions: entity.CategoryPermissions{Read: []string{"admin", "moderator"}},
+	}
+
+	mock.ExpectQuery(`INSERT INTO categories .* RETURNING id, parent_id`).
+		WithArgs(nil, "staff", "Staff", "", 0, "admin,moderator", "", "").
+		WillReturnRows(sqlmock.NewRows(categoryColumnNames).
+			AddRow(2, nil, "staff", "Staff", "", 0, "admin,moderator", "", "", nil, now, now))
+
+	created, err := repo.CreateCategory(context.Background(), category)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, created.ID)
+	assert.Equal(t, []string{"admin", "moderator"}, created.Permissions.Read)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepository_ReorderCategories(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`UPDATE categories SET position = CASE id WHEN \? THEN \? WHEN \? THEN \? END, .* WHERE id IN \(\?, \?\)`).
+		WithArgs(3, 0, 1, 1, 3, 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.ReorderCategories(context.Background(), []int{3, 1})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepository_MergeCategory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewCategoryRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`UPDATE posts SET category_id = \? WHERE category_id = \?`).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(`UPDATE categories SET parent_id = \?, .* WHERE parent_id = \?`).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM categories WHERE id = \?`).
+		WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.MergeCategory(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
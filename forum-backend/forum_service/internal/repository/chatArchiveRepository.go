@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type ChatArchiveRepository interface {
+	// Archive выгружает сообщения в сжатый gzip файл NDJSON — по сообщению
+	// на строку — и возвращает путь к файлу. Файл называется по ID первого
+	// и последнего сообщения, так что повторная выгрузка тех же сообщений
+	// перезаписывает его, а не дублирует.
+	Archive(ctx context.Context, messages []entity.ChatMessage) (string, error)
+}
+
+type chatArchiveRepository struct {
+	dir    string
+	logger *zap.Logger
+}
+
+// NewChatArchiveRepository создает архив сообщений чата в каталоге dir.
+// Каталог создается при первой выгрузке.
+func NewChatArchiveRepository(dir string, logger *zap.Logger) ChatArchiveRepository {
+	return &chatArchiveRepository{dir: dir, logger: logger}
+}
+
+// Archive пишет во временный файл и переименовывает его только после
+// успешной записи: недописанный архив не должен выглядеть готовым, ведь
+// после выгрузки сообщения удаляются из базы.
+func (r *chatArchiveRepository) Archive(ctx context.Context, messages []entity.ChatMessage) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		r.logger.Error("Failed to create chat archive directory", zap.Error(err), zap.String("dir", r.dir))
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(r.dir, ".chat-*.tmp")
+	if err != nil {
+		r.logger.Error("Failed to create chat archive file", zap.Error(err), zap.String("dir", r.dir))
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writeChatArchive(tmp, messages); err != nil {
+		tmp.Close()
+		r.logger.Error("Failed to write chat archive", zap.Error(err), zap.String("file", tmp.Name()))
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("chat-%010d-%010d.ndjson.gz", messages[0].ID, messages[len(messages)-1].ID)
+	path := filepath.Join(r.dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		r.logger.Error("Failed to save chat archive", zap.Error(err), zap.String("file", path))
+		return "", err
+	}
+	r.logger.Info("Chat messages archived", zap.String("file", path), zap.Int("count", len(messages)))
+	return path, nil
+}
+
+func writeChatArchive(f *os.File, messages []entity.ChatMessage) error {
+	gz := gzip.NewWriter(f)
+	enc := json.NewEncoder(gz)
+	for _, msg := range messages {
+		if err := enc.Encode(msg); err != nil {
+			return err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
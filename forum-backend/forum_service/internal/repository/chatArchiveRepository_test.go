@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChatArchiveRepository_Archive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "archive")
+	repo := NewChatArchiveRepository(dir, zap.NewNop())
+	messages := []entity.ChatMessage{
+		{ID: 7, RoomID: 1, UserID: 1, Username: "alice", Content: "привет", Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{ID: 9, RoomID: 2, UserID: 2, Username: "bob", Content: "строка\nс переносом", Timestamp: time.Date(2026, 1, 2, 3, 5, 0, 0, time.UTC)},
+	}
+
+	path, err := repo.Archive(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "chat-0000000007-0000000009.ndjson.gz"), path)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var archived []entity.ChatMessage
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var msg entity.ChatMessage
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		archived = append(archived, msg)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, messages, archived)
+
+	// Временных файлов после выгрузки не остается
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
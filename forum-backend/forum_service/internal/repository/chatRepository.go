@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
@@ -17,11 +18,35 @@ type DB interface {
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
 }
 
 type ChatRepository interface {
-	StoreMessage(ctx context.Context, msg entity.ChatMessage) error
-	GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error)
+	// StoreMessage сохраняет сообщение и возвращает его с присвоенным ID.
+	StoreMessage(ctx context.Context, msg entity.ChatMessage) (entity.ChatMessage, error)
+	// GetRecentMessages возвращает последние limit сообщений комнаты от
+	// старых к новым.
+	GetRecentMessages(ctx context.Context, roomID, limit int) ([]entity.ChatMessage, error)
+	// GetMessages возвращает последние limit сообщений комнаты с ID больше
+	// after и меньше before (0 — без границы) от старых к новым. Скрытые
+	// по жалобам сообщения не возвращаются.
+	GetMessages(ctx context.Context, roomID, before, after, limit int) ([]entity.ChatMessage, error)
+	GetMessageByID(ctx context.Context, id int) (entity.ChatMessage, error)
+	// UpdateMessage заменяет текст сообщения и отмечает время правки.
+	// Скрытое по жалобам или несуществующее сообщение не меняется:
+	// возвращается sql.ErrNoRows.
+	UpdateMessage(ctx context.Context, id int, content string, editedAt time.Time) error
+	// DeleteMessage удаляет сообщение. Нерешенные дела модерации о нем, еще
+	// не взятые в работу, удаляются вместе с ним. Для несуществующего
+	// сообщения возвращает sql.ErrNoRows.
+	DeleteMessage(ctx context.Context, id int) error
+	// GetExpiredMessages возвращает до limit самых старых по ID сообщений,
+	// срок хранения которых к моменту now истек. Срок задается комнатой,
+	// а если не задан — defaultRetention; срок 0 означает бессрочное
+	// хранение.
+	GetExpiredMessages(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]entity.ChatMessage, error)
+	// DeleteMessages удаляет сообщения по ID и возвращает число удаленных.
+	DeleteMessages(ctx context.Context, ids []int) (int, error)
 }
 
 type chatRepo struct {
@@ -33,35 +58,43 @@ func NewChatRepository(db DB, logger *zap.Logger) ChatRepository {
 	return &chatRepo{db: db, logger: logger}
 }
 
-func (r *chatRepo) StoreMessage(ctx context.Context, msg entity.ChatMessage) error {
+func (r *chatRepo) StoreMessage(ctx context.Context, msg entity.ChatMessage) (entity.ChatMessage, error) {
 	r.logger.Info("Saving message",
+		zap.Int("roomID", msg.RoomID),
 		zap.Int("userID", msg.UserID),
 		zap.String("username", msg.Username),
 		zap.String("content", msg.Content),
 		zap.Time("timestamp", msg.Timestamp),
 	)
 
-	query := `INSERT INTO chat_messages (user_id, username, content, timestamp) VALUES (?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339))
+	query := `INSERT INTO chat_messages (room_id, user_id, username, content, timestamp) VALUES (?, ?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, msg.RoomID, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339))
 	if err != nil {
 		r.logger.Error("Failed to store message", zap.Error(err))
-		return err
+		return entity.ChatMessage{}, err
 	}
-	return nil
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get message ID", zap.Error(err))
+		return entity.ChatMessage{}, err
+	}
+	msg.ID = int(id)
+	return msg, nil
 }
 
-func (r *chatRepo) GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error) {
+func (r *chatRepo) GetRecentMessages(ctx context.Context, roomID, limit int) ([]entity.ChatMessage, error) {
 	query := `
-        SELECT id, user_id, username, content,
-               timestamp
+        SELECT id, room_id, user_id, username, content,
+               timestamp, edited_at
         FROM chat_messages
-        ORDER BY timestamp DESC
+        WHERE room_id = ? AND hidden_at IS NULL
+        ORDER BY timestamp DESC, id DESC
         LIMIT ?`
 
 	var messages []entity.ChatMessage
-	err := r.db.SelectContext(ctx, &messages, query, limit)
+	err := r.db.SelectContext(ctx, &messages, query, roomID, limit)
 	if err != nil {
-		r.logger.Error("Failed to get recent messages", zap.Error(err))
+		r.logger.Error("Failed to get recent messages", zap.Error(err), zap.Int("roomID", roomID))
 		return nil, err
 	}
 
@@ -69,6 +102,128 @@ func (r *chatRepo) GetRecentMessages(ctx context.Context, limit int) ([]entity.C
 		messages[i], messages[j] = messages[j], messages[i]
 	}
 
-	r.logger.Info("Recent messages retrieved successfully", zap.Int("count", len(messages)))
+	r.logger.Info("Recent messages retrieved successfully", zap.Int("roomID", roomID), zap.Int("count", len(messages)))
+	return messages, nil
+}
+
+func (r *chatRepo) GetMessages(ctx context.Context, roomID, before, after, limit int) ([]entity.ChatMessage, error) {
+	query := `
+        SELECT id, room_id, user_id, username, content, timestamp, edited_at
+        FROM chat_messages
+        WHERE room_id = ? AND hidden_at IS NULL`
+	args := []any{roomID}
+	if before > 0 {
+		query += ` AND id < ?`
+		args = append(args, before)
+	}
+	if after > 0 {
+		query += ` AND id > ?`
+		args = append(args, after)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	var messages []entity.ChatMessage
+	if err := r.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		r.logger.Error("Failed to get messages", zap.Error(err), zap.Int("roomID", roomID))
+		return nil, err
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
 	return messages, nil
 }
+
+// GetMessageByID возвращает сообщение чата, в том числе скрытое по жалобам.
+// Для несуществующего сообщения возвращает sql.ErrNoRows.
+func (r *chatRepo) GetMessageByID(ctx context.Context, id int) (entity.ChatMessage, error) {
+	query := `SELECT id, room_id, user_id, username, content, timestamp, edited_at FROM chat_messages WHERE id = ?`
+
+	var msg entity.ChatMessage
+	err := r.db.GetContext(ctx, &msg, query, id)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get message", zap.Error(err), zap.Int("messageID", id))
+	}
+	return msg, err
+}
+
+func (r *chatRepo) UpdateMessage(ctx context.Context, id int, content string, editedAt time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE chat_messages SET content = ?, edited_at = ? WHERE id = ? AND hidden_at IS NULL`,
+		content, editedAt.Format(time.RFC3339), id)
+	if err != nil {
+		r.logger.Error("Failed to update message", zap.Error(err), zap.Int("messageID", id))
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	r.logger.Info("Message updated successfully", zap.Int("messageID", id))
+	return nil
+}
+
+func (r *chatRepo) DeleteMessage(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM chat_messages WHERE id = ?`, id)
+	if err != nil {
+		r.logger.Error("Failed to delete message", zap.Error(err), zap.Int("messageID", id))
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	r.logger.Info("Message deleted successfully", zap.Int("messageID", id))
+	return nil
+}
+
+// GetExpiredMessages сравнивает время в секундах Unix: сообщения хранят
+// время в RFC 3339 с часовым поясом, и строки напрямую не сравнимы.
+func (r *chatRepo) GetExpiredMessages(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]entity.ChatMessage, error) {
+	query := `
+		SELECT m.id, m.room_id, m.user_id, m.username, m.content, m.timestamp, m.edited_at
+		FROM chat_messages m
+		LEFT JOIN chat_rooms r ON r.id = m.room_id
+		WHERE COALESCE(r.retention_seconds, ?) > 0
+		  AND CAST(strftime('%s', m.timestamp) AS INTEGER) < ? - COALESCE(r.retention_seconds, ?)
+		ORDER BY m.id
+		LIMIT ?
+	`
+	defaultSeconds := int64(defaultRetention / time.Second)
+
+	var messages []entity.ChatMessage
+	err := r.db.SelectContext(ctx, &messages, query, defaultSeconds, now.Unix(), defaultSeconds, limit)
+	if err != nil {
+		r.logger.Error("Failed to get expired messages", zap.Error(err))
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *chatRepo) DeleteMessages(ctx context.Context, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM chat_messages WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		r.logger.Error("Failed to delete messages", zap.Error(err), zap.Int("count", len(ids)))
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
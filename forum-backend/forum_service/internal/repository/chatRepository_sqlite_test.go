@@ -0,0 +1,173 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChatRepository_ExpiredMessages_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	roomRepo := NewChatRoomRepository(db, zap.NewNop())
+	chatRepo := NewChatRepository(db, zap.NewNop())
+	now := time.Now()
+
+	forever, err := roomRepo.CreateRoom(ctx, entity.ChatRoom{Kind: entity.ChatRoomPublic, Name: "Архив"})
+	require.NoError(t, err)
+	short, err := roomRepo.CreateRoom(ctx, entity.ChatRoom{Kind: entity.ChatRoomPublic, Name: "Флуд"})
+	require.NoError(t, err)
+	keep, hour := 0, 3600
+	require.NoError(t, roomRepo.SetRetention(ctx, forever.ID, &keep))
+	require.NoError(t, roomRepo.SetRetention(ctx, short.ID, &hour))
+	assert.ErrorIs(t, roomRepo.SetRetention(ctx, 99, &hour), sql.ErrNoRows)
+	room, err := roomRepo.GetRoom(ctx, short.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &hour, room.RetentionSeconds)
+
+	// Время пишется с часовым поясом: сравнение не должно от него зависеть
+	moscow := time.FixedZone("MSK", 3*3600)
+	var ids []int
+	for _, msg := range []entity.ChatMessage{
+		{RoomID: entity.DefaultChatRoomID, Timestamp: now.Add(-48 * time.Hour).In(moscow)},
+		{RoomID: entity.DefaultChatRoomID, Timestamp: now.Add(-2 * time.Hour)},
+		{RoomID: forever.ID, Timestamp: now.Add(-365 * 24 * time.Hour)},
+		{RoomID: short.ID, Timestamp: now.Add(-2 * time.Hour).In(moscow)},
+		{RoomID: short.ID, Timestamp: now.Add(-time.Minute)},
+	} {
+		msg.UserID, msg.Username, msg.Content = 1, "alice", "сообщение"
+		stored, err := chatRepo.StoreMessage(ctx, msg)
+		require.NoError(t, err)
+		ids = append(ids, stored.ID)
+	}
+
+	expired, err := chatRepo.GetExpiredMessages(ctx, now, 24*time.Hour, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 2)
+	assert.Equal(t, []int{ids[0], ids[3]}, []int{expired[0].ID, expired[1].ID})
+	assert.Equal(t, "alice", expired[0].Username)
+
+	// Без срока по умолчанию истекают только комнаты со своим сроком
+	expired, err = chatRepo.GetExpiredMessages(ctx, now, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, ids[3], expired[0].ID)
+
+	deleted, err := chatRepo.DeleteMessages(ctx, []int{ids[0], ids[3]})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	expired, err = chatRepo.GetExpiredMessages(ctx, now, 24*time.Hour, 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+
+	// Триггер, удалявший старые сообщения при вставке, снят миграцией
+	recent, err := chatRepo.GetRecentMessages(ctx, forever.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, recent, 1)
+}
+
+func TestChatRepository_GetMessages_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	chatRepo := NewChatRepository(db, zap.NewNop())
+
+	var ids []int
+	for i, roomID := range []int{1, 1, 2, 1, 1} {
+		stored, err := chatRepo.StoreMessage(ctx, entity.ChatMessage{
+			RoomID: roomID, UserID: 1, Username: "alice", Content: "сообщение", Timestamp: time.Now().Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+		ids = append(ids, stored.ID)
+	}
+	_, err := db.Exec(`UPDATE chat_messages SET hidden_at = CURRENT_TIMESTAMP WHERE id = ?`, ids[3])
+	require.NoError(t, err)
+
+	messageIDs := func(messages []entity.ChatMessage) []int {
+		result := []int{}
+		for _, msg := range messages {
+			result = append(result, msg.ID)
+		}
+		return result
+	}
+
+	// Последние сообщения комнаты от старых к новым, без скрытых
+	latest, err := chatRepo.GetMessages(ctx, 1, 0, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1], ids[4]}, messageIDs(latest))
+	older, err := chatRepo.GetMessages(ctx, 1, ids[1], 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[0]}, messageIDs(older))
+
+	// Пропущенные после ids[0]
+	missed, err := chatRepo.GetMessages(ctx, 1, 0, ids[0], 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{ids[1], ids[4]}, messageIDs(missed))
+}
+
+func TestChatRepository_UpdateMessage_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	chatRepo := NewChatRepository(db, zap.NewNop())
+
+	stored, err := chatRepo.StoreMessage(ctx, entity.ChatMessage{RoomID: 1, UserID: 1, Username: "alice", Content: "helo", Timestamp: time.Now()})
+	require.NoError(t, err)
+	assert.Nil(t, stored.EditedAt)
+
+	require.NoError(t, chatRepo.UpdateMessage(ctx, stored.ID, "hello", time.Now()))
+	msg, err := chatRepo.GetMessageByID(ctx, stored.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", msg.Content)
+	assert.NotNil(t, msg.EditedAt)
+
+	// Скрытое модератором сообщение не исправить
+	_, err = db.Exec(`UPDATE chat_messages SET hidden_at = CURRENT_TIMESTAMP WHERE id = ?`, stored.ID)
+	require.NoError(t, err)
+	assert.ErrorIs(t, chatRepo.UpdateMessage(ctx, stored.ID, "again", time.Now()), sql.ErrNoRows)
+	assert.ErrorIs(t, chatRepo.UpdateMessage(ctx, stored.ID+100, "again", time.Now()), sql.ErrNoRows)
+}
+
+func TestChatRepository_DeleteMessage_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	chatRepo := NewChatRepository(db, zap.NewNop())
+	moderationRepo := NewModerationRepository(db, zap.NewNop())
+
+	var ids []int
+	for i := 0; i < 2; i++ {
+		stored, err := chatRepo.StoreMessage(ctx, entity.ChatMessage{RoomID: entity.DefaultChatRoomID, UserID: 2, Username: "bob", Content: "реклама", Timestamp: time.Now()})
+		require.NoError(t, err)
+		ids = append(ids, stored.ID)
+	}
+	open, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReportTargetChatMessage, TargetID: ids[0], TargetAuthorID: 2, Content: "реклама"})
+	require.NoError(t, err)
+	_, err = moderationRepo.AddReport(ctx, entity.Report{CaseID: open, ReporterID: 1, Reason: entity.ReportReasonSpam})
+	require.NoError(t, err)
+	claimed, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReportTargetChatMessage, TargetID: ids[1], TargetAuthorID: 2, Content: "реклама"})
+	require.NoError(t, err)
+	ok, err := moderationRepo.ClaimCase(ctx, claimed, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Открытое дело удаляется вместе с сообщением и жалобами
+	require.NoError(t, chatRepo.DeleteMessage(ctx, ids[0]))
+	assert.ErrorIs(t, chatRepo.DeleteMessage(ctx, ids[0]), sql.ErrNoRows)
+	_, err = moderationRepo.GetCase(ctx, open)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	var reports int
+	require.NoError(t, db.Get(&reports, `SELECT COUNT(*) FROM reports WHERE case_id = ?`, open))
+	assert.Zero(t, reports)
+
+	// Взятое в работу дело остается: модератор закроет его сам
+	require.NoError(t, chatRepo.DeleteMessage(ctx, ids[1]))
+	c, err := moderationRepo.GetCase(ctx, claimed)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CaseStatusClaimed, c.Status)
+}
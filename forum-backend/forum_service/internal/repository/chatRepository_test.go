@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
 	"github.com/stretchr/testify/assert"
@@ -23,17 +25,19 @@ func TestChatRepo_StoreMessage_Success(t *testing.T) {
 	chatRepo := NewChatRepository(mockDB, logger)
 
 	msg := entity.ChatMessage{
+		RoomID:    entity.DefaultChatRoomID,
 		UserID:    1,
 		Username:  "testuser",
 		Content:   "This is a test message",
 		Timestamp: time.Date(2025, time.April, 22, 23, 51, 38, 843016900, time.Local),
 	}
 
-	mockDB.On("ExecContext", mock.Anything, mock.Anything, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339)).Return(sql.Result(nil), nil)
+	mockDB.On("ExecContext", mock.Anything, mock.Anything, msg.RoomID, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339)).Return(sqlmock.NewResult(5, 1), nil)
 
-	err := chatRepo.StoreMessage(context.Background(), msg)
+	stored, err := chatRepo.StoreMessage(context.Background(), msg)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 5, stored.ID)
 
 	mockDB.AssertExpectations(t)
 }
@@ -47,15 +51,16 @@ func TestChatRepo_StoreMessage_Failure(t *testing.T) {
 	chatRepo := NewChatRepository(mockDB, logger)
 
 	msg := entity.ChatMessage{
+		RoomID:    entity.DefaultChatRoomID,
 		UserID:    1,
 		Username:  "testuser",
 		Content:   "This is a test message",
 		Timestamp: time.Now(),
 	}
 
-	mockDB.On("ExecContext", mock.Anything, mock.Anything, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339)).Return(nil, errors.New("failed to store message"))
+	mockDB.On("ExecContext", mock.Anything, mock.Anything, msg.RoomID, msg.UserID, msg.Username, msg.Content, msg.Timestamp.Format(time.RFC3339)).Return(nil, errors.New("failed to store message"))
 
-	err := chatRepo.StoreMessage(context.Background(), msg)
+	_, err := chatRepo.StoreMessage(context.Background(), msg)
 
 	assert.Error(t, err)
 
@@ -76,12 +81,12 @@ func TestChatRepo_GetRecentMessages_Success(t *testing.T) {
 		{ID: 2, UserID: 2, Username: "user2", Content: "Message 2", Timestamp: time.Now()},
 	}
 
-	mockDB.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, limit).Return(nil).Run(func(args mock.Arguments) {
+	mockDB.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, entity.DefaultChatRoomID, limit).Return(nil).Run(func(args mock.Arguments) {
 		dest := args.Get(1).(*[]entity.ChatMessage)
 		*dest = messages
 	})
 
-	result, err := chatRepo.GetRecentMessages(context.Background(), limit)
+	result, err := chatRepo.GetRecentMessages(context.Background(), entity.DefaultChatRoomID, limit)
 
 	assert.NoError(t, err)
 	assert.Equal(t, messages, result)
@@ -99,12 +104,45 @@ func TestChatRepo_GetRecentMessages_Failure(t *testing.T) {
 
 	limit := 10
 
-	mockDB.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, limit).Return(errors.New("failed to get recent messages"))
+	mockDB.On("SelectContext", mock.Anything, mock.Anything, mock.Anything, entity.DefaultChatRoomID, limit).Return(errors.New("failed to get recent messages"))
 
-	result, err := chatRepo.GetRecentMessages(context.Background(), limit)
+	result, err := chatRepo.GetRecentMessages(context.Background(), entity.DefaultChatRoomID, limit)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 
 	mockDB.AssertExpectations(t)
 }
+
+func TestChatRepo_GetMessageByID(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockDB := new(mocks.DB)
+	chatRepo := NewChatRepository(mockDB, logger)
+
+	message := entity.ChatMessage{ID: 7, UserID: 2, Username: "user2", Content: "spam", Timestamp: time.Now()}
+	mockDB.On("GetContext", mock.Anything, mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "FROM chat_messages WHERE id = ?")
+	}), 7).Return(nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*entity.ChatMessage) = message
+	})
+
+	result, err := chatRepo.GetMessageByID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, message, result)
+	mockDB.AssertExpectations(t)
+}
+
+func TestChatRepo_DeleteMessage(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockDB := new(mocks.DB)
+	chatRepo := NewChatRepository(mockDB, logger)
+
+	mockDB.On("ExecContext", mock.Anything, "DELETE FROM chat_messages WHERE id = ?", 7).Return(sqlmock.NewResult(0, 1), nil)
+	mockDB.On("ExecContext", mock.Anything, "DELETE FROM chat_messages WHERE id = ?", 8).Return(sqlmock.NewResult(0, 0), nil)
+
+	assert.NoError(t, chatRepo.DeleteMessage(context.Background(), 7))
+	// Уже удаленное сообщение второй раз не удаляется
+	assert.ErrorIs(t, chatRepo.DeleteMessage(context.Background(), 8), sql.ErrNoRows)
+	mockDB.AssertExpectations(t)
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type ChatRoomRepository interface {
+	// CreateRoom создает комнату. Создатель закрытой комнаты становится ее
+	// владельцем.
+	CreateRoom(ctx context.Context, room entity.ChatRoom) (entity.ChatRoom, error)
+	// GetRoom для несуществующей комнаты возвращает sql.ErrNoRows.
+	GetRoom(ctx context.Context, id int) (entity.ChatRoom, error)
+	// EnsurePostRoom возвращает комнату поста, создавая ее при первом
+	// обращении.
+	EnsurePostRoom(ctx context.Context, postID int, name string) (entity.ChatRoom, error)
+	// GetRooms возвращает открытые комнаты и закрытые, в которых состоит
+	// userID (0 — только открытые). Комнаты постов не перечисляются.
+	GetRooms(ctx context.Context, userID int) ([]entity.ChatRoom, error)
+	GetMembers(ctx context.Context, roomID int) ([]entity.ChatRoomMember, error)
+	// GetMember для пользователя, который не состоит в комнате, возвращает
+	// sql.ErrNoRows.
+	GetMember(ctx context.Context, roomID, userID int) (entity.ChatRoomMember, error)
+	// AddMember возвращает false, если пользователь уже состоит в комнате.
+	AddMember(ctx context.Context, member entity.ChatRoomMember) (bool, error)
+	// RemoveMember возвращает false, если пользователь не состоял в комнате.
+	RemoveMember(ctx context.Context, roomID, userID int) (bool, error)
+	// SetRetention задает срок хранения сообщений комнаты в секундах; nil
+	// возвращает срок по умолчанию. Для несуществующей комнаты возвращает
+	// sql.ErrNoRows.
+	SetRetention(ctx context.Context, roomID int, seconds *int) error
+}
+
+type chatRoomRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewChatRoomRepository(db DB, logger *zap.Logger) ChatRoomRepository {
+	return &chatRoomRepository{db: db, logger: logger}
+}
+
+const chatRoomColumns = `r.id, r.kind, r.name, r.post_id, r.created_by, r.created_at,
+	(SELECT COUNT(*) FROM chat_room_members m WHERE m.room_id = r.id), r.retention_seconds`
+
+func scanChatRoom(row rowScanner) (entity.ChatRoom, error) {
+	var room entity.ChatRoom
+	err := row.Scan(
+		&room.ID,
+		&room.Kind,
+		&room.Name,
+		&room.PostID,
+		&room.CreatedBy,
+		&room.CreatedAt,
+		&room.MembersCount,
+		&room.RetentionSeconds,
+	)
+	return room, err
+}
+
+func (r *chatRoomRepository) CreateRoom(ctx context.Context, room entity.ChatRoom) (entity.ChatRoom, error) {
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO chat_rooms (kind, name, post_id, created_by) VALUES (?, ?, ?, ?)`,
+		room.Kind, room.Name, room.PostID, room.CreatedBy)
+	if err != nil {
+		r.logger.Error("Failed to create chat room", zap.Error(err), zap.String("name", room.Name))
+		return entity.ChatRoom{}, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.ChatRoom{}, err
+	}
+
+	if room.Kind == entity.ChatRoomPrivate && room.CreatedBy != nil {
+		owner := entity.ChatRoomMember{RoomID: int(id), UserID: *room.CreatedBy, Role: entity.ChatRoleOwner}
+		if _, err := r.AddMember(ctx, owner); err != nil {
+			return entity.ChatRoom{}, err
+		}
+	}
+	r.logger.Info("Chat room created", zap.Int64("roomID", id), zap.String("kind", room.Kind))
+	return r.GetRoom(ctx, int(id))
+}
+
+func (r *chatRoomRepository) GetRoom(ctx context.Context, id int) (entity.ChatRoom, error) {
+	query := `SELECT ` + chatRoomColumns + ` FROM chat_rooms r WHERE r.id = ?`
+	room, err := scanChatRoom(r.db.QueryRowContext(ctx, query, id))
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get chat room", zap.Error(err), zap.Int("roomID", id))
+	}
+	return room, err
+}
+
+func (r *chatRoomRepository) EnsurePostRoom(ctx context.Context, postID int, name string) (entity.ChatRoom, error) {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chat_rooms (kind, name, post_id) VALUES (?, ?, ?)
+		ON CONFLICT(post_id) DO NOTHING
+	`, entity.ChatRoomPost, name, postID)
+	if err != nil {
+		r.logger.Error("Failed to create post chat room", zap.Error(err), zap.Int("postID", postID))
+		return entity.ChatRoom{}, err
+	}
+
+	query := `SELECT ` + chatRoomColumns + ` FROM chat_rooms r WHERE r.post_id = ?`
+	room, err := scanChatRoom(r.db.QueryRowContext(ctx, query, postID))
+	if err != nil {
+		r.logger.Error("Failed to get post chat room", zap.Error(err), zap.Int("postID", postID))
+	}
+	return room, err
+}
+
+func (r *chatRoomRepository) GetRooms(ctx context.Context, userID int) ([]entity.ChatRoom, error) {
+	query := `
+		SELECT ` + chatRoomColumns + `
+		FROM chat_rooms r
+		WHERE r.kind = ?
+		   OR (r.kind = ? AND EXISTS (
+				SELECT 1 FROM chat_room_members m WHERE m.room_id = r.id AND m.user_id = ?
+		   ))
+		ORDER BY r.id
+	`
+	rows, err := r.db.QueryContext(ctx, query, entity.ChatRoomPublic, entity.ChatRoomPrivate, userID)
+	if err != nil {
+		r.logger.Error("Failed to get chat rooms", zap.Error(err), zap.Int("userID", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []entity.ChatRoom
+	for rows.Next() {
+		room, err := scanChatRoom(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan chat room", zap.Error(err))
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, rows.Err()
+}
+
+func (r *chatRoomRepository) GetMembers(ctx context.Context, roomID int) ([]entity.ChatRoomMember, error) {
+	query := `
+		SELECT room_id, user_id, role, joined_at FROM chat_room_members
+		WHERE room_id = ?
+		ORDER BY joined_at, user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		r.logger.Error("Failed to get chat room members", zap.Error(err), zap.Int("roomID", roomID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []entity.ChatRoomMember
+	for rows.Next() {
+		var member entity.ChatRoomMember
+		if err := rows.Scan(&member.RoomID, &member.UserID, &member.Role, &member.JoinedAt); err != nil {
+			r.logger.Error("Failed to scan chat room member", zap.Error(err))
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *chatRoomRepository) GetMember(ctx context.Context, roomID, userID int) (entity.ChatRoomMember, error) {
+	query := `SELECT room_id, user_id, role, joined_at FROM chat_room_members WHERE room_id = ? AND user_id = ?`
+	var member entity.ChatRoomMember
+	err := r.db.QueryRowContext(ctx, query, roomID, userID).Scan(&member.RoomID, &member.UserID, &member.Role, &member.JoinedAt)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get chat room member", zap.Error(err), zap.Int("roomID", roomID), zap.Int("userID", userID))
+	}
+	return member, err
+}
+
+func (r *chatRoomRepository) AddMember(ctx context.Context, member entity.ChatRoomMember) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO chat_room_members (room_id, user_id, role) VALUES (?, ?, ?)
+		ON CONFLICT(room_id, user_id) DO NOTHING
+	`, member.RoomID, member.UserID, member.Role)
+	if err != nil {
+		r.logger.Error("Failed to add chat room member", zap.Error(err), zap.Int("roomID", member.RoomID), zap.Int("userID", member.UserID))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *chatRoomRepository) RemoveMember(ctx context.Context, roomID, userID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM chat_room_members WHERE room_id = ? AND user_id = ?`, roomID, userID)
+	if err != nil {
+		r.logger.Error("Failed to remove chat room member", zap.Error(err), zap.Int("roomID", roomID), zap.Int("userID", userID))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *chatRoomRepository) SetRetention(ctx context.Context, roomID int, seconds *int) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE chat_rooms SET retention_seconds = ? WHERE id = ?`, seconds, roomID)
+	if err != nil {
+		r.logger.Error("Failed to set chat room retention", zap.Error(err), zap.Int("roomID", roomID))
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
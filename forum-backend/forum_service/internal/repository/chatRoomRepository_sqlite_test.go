@@ -0,0 +1,93 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChatRoomRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	roomRepo := NewChatRoomRepository(db, zap.NewNop())
+	chatRepo := NewChatRepository(db, zap.NewNop())
+
+	// Общая комната создается миграцией
+	general, err := roomRepo.GetRoom(ctx, entity.DefaultChatRoomID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ChatRoomPublic, general.Kind)
+	_, err = roomRepo.GetRoom(ctx, 99)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	ownerID := 1
+	private, err := roomRepo.CreateRoom(ctx, entity.ChatRoom{Kind: entity.ChatRoomPrivate, Name: "Друзья", CreatedBy: &ownerID})
+	require.NoError(t, err)
+	assert.Equal(t, 1, private.MembersCount)
+	owner, err := roomRepo.GetMember(ctx, private.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ChatRoleOwner, owner.Role)
+
+	added, err := roomRepo.AddMember(ctx, entity.ChatRoomMember{RoomID: private.ID, UserID: 2, Role: entity.ChatRoleMember})
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = roomRepo.AddMember(ctx, entity.ChatRoomMember{RoomID: private.ID, UserID: 2, Role: entity.ChatRoleMember})
+	require.NoError(t, err)
+	assert.False(t, added)
+	members, err := roomRepo.GetMembers(ctx, private.ID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// Комната поста создается один раз и не перечисляется в списке комнат
+	postRoom, err := roomRepo.EnsurePostRoom(ctx, 1, "Елки")
+	require.NoError(t, err)
+	again, err := roomRepo.EnsurePostRoom(ctx, 1, "Елки")
+	require.NoError(t, err)
+	assert.Equal(t, postRoom.ID, again.ID)
+	assert.Equal(t, 1, *postRoom.PostID)
+
+	roomIDs := func(userID int) []int {
+		rooms, err := roomRepo.GetRooms(ctx, userID)
+		require.NoError(t, err)
+		ids := make([]int, 0, len(rooms))
+		for _, room := range rooms {
+			ids = append(ids, room.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int{general.ID, private.ID}, roomIDs(2))
+	assert.Equal(t, []int{general.ID}, roomIDs(3))
+
+	removed, err := roomRepo.RemoveMember(ctx, private.ID, 2)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = roomRepo.RemoveMember(ctx, private.ID, 2)
+	require.NoError(t, err)
+	assert.False(t, removed)
+	assert.Equal(t, []int{general.ID}, roomIDs(2))
+
+	// История у каждой комнаты своя
+	now := time.Now().Truncate(time.Second)
+	for i, roomID := range []int{general.ID, private.ID, private.ID} {
+		stored, err := chatRepo.StoreMessage(ctx, entity.ChatMessage{
+			RoomID: roomID, UserID: 1, Username: "user1", Content: "сообщение", Timestamp: now.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, stored.ID)
+	}
+	history, err := chatRepo.GetRecentMessages(ctx, private.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, private.ID, history[0].RoomID)
+	assert.True(t, history[0].ID < history[1].ID)
+	history, err = chatRepo.GetRecentMessages(ctx, general.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -33,8 +34,8 @@ func TestCommentsRepository_CreateComment_Success(t *testing.T) {
 	createdComment.ID = 1
 	createdComment.CreatedAt = time.Now()
 
-	mock.ExpectQuery(`INSERT INTO comments \(post_id, author_id, content\) VALUES \(\$1, \$2, \$3\) RETURNING id, created_at`).
-		WithArgs(comment.PostId, comment.AuthorId, comment.Content).
+	mock.ExpectQuery(`INSERT INTO comments \(post_id, author_id, parent_id, content\)\s+VALUES \(\$1, \$2, \$3, \$4\)\s+RETURNING id, created_at`).
+		WithArgs(comment.PostId, comment.AuthorId, comment.ParentId, comment.Content).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(createdComment.ID, createdComment.CreatedAt))
 
 	result, err := commentsRepo.CreateComment(context.Background(), comment)
@@ -63,8 +64,8 @@ func TestCommentsRepository_CreateComment_Failure(t *testing.T) {
 		Content:  "This is a test comment",
 	}
 
-	mock.ExpectQuery(`INSERT INTO comments \(post_id, author_id, content\) VALUES \(\$1, \$2, \$3\) RETURNING id, created_at`).
-		WithArgs(comment.PostId, comment.AuthorId, comment.Content).
+	mock.ExpectQuery(`INSERT INTO comments \(post_id, author_id, parent_id, content\)\s+VALUES \(\$1, \$2, \$3, \$4\)\s+RETURNING id, created_at`).
+		WithArgs(comment.PostId, comment.AuthorId, comment.ParentId, comment.Content).
 		WillReturnError(errors.New("failed to create comment"))
 
 	result, err := commentsRepo.CreateComment(context.Background(), comment)
@@ -144,10 +145,10 @@ func TestCommentsRepository_GetCommentByID_Success(t *testing.T) {
 	dbAdapter := adapters.DbAdapter{db}
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
-	comment := entity.Comment{ID: 1, PostId: 1, AuthorId: 1, Content: "Test", CreatedAt: time.Now()}
-	rows := sqlmock.NewRows([]string{"id", "post_id", "author_id", "content", "created_at"}).
-		AddRow(comment.ID, comment.PostId, comment.AuthorId, comment.Content, comment.CreatedAt)
-	mock.ExpectQuery(`SELECT id, post_id, author_id, content, created_at FROM comments WHERE id = \$1`).
+	comment := entity.Comment{ID: 1, PostId: 1, AuthorId: 1, ParentId: intPtr(7), Content: "Test", CreatedAt: time.Now()}
+	rows := sqlmock.NewRows([]string{"id", "content", "author_id", "post_id", "parent_id", "created_at", "deleted", "hidden", "updated_at", "edited_by", "upvotes", "downvotes", "reactions"}).
+		AddRow(comment.ID, comment.Content, comment.AuthorId, comment.PostId, 7, comment.CreatedAt, false, false, nil, nil, 0, 0, "{}")
+	mock.ExpectQuery(`SELECT id, content, author_id, post_id, parent_id, created_at, deleted_at IS NOT NULL, hidden_at IS NOT NULL, updated_at, edited_by, upvotes, downvotes, .+ FROM comments WHERE id = \?`).
 		WithArgs(comment.ID).
 		WillReturnRows(rows)
 
@@ -166,9 +167,9 @@ func TestCommentsRepository_GetCommentByID_NotFound(t *testing.T) {
 	dbAdapter := adapters.DbAdapter{db}
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
-	mock.ExpectQuery(`SELECT id, post_id, author_id, content, created_at FROM comments WHERE id = \$1`).
+	mock.ExpectQuery(`SELECT id, content, author_id, post_id, parent_id, created_at, deleted_at IS NOT NULL, hidden_at IS NOT NULL, updated_at, edited_by, upvotes, downvotes, .+ FROM comments WHERE id = \?`).
 		WithArgs(42).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "post_id", "author_id", "content", "created_at"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content", "author_id", "post_id", "parent_id", "created_at", "deleted", "hidden", "updated_at", "edited_by", "upvotes", "downvotes", "reactions"}))
 
 	result, err := commentsRepo.GetCommentByID(context.Background(), 42)
 	assert.Error(t, err)
@@ -185,9 +186,11 @@ func TestCommentsRepository_DeleteComment_Success(t *testing.T) {
 	dbAdapter := adapters.DbAdapter{db}
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
-	mock.ExpectExec(`DELETE FROM comments WHERE id = \$1`).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE comments SET deleted_at = CURRENT_TIMESTAMP, deleted_by = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(7, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = commentsRepo.DeleteComment(context.Background(), 1)
+	err = commentsRepo.DeleteComment(context.Background(), 1, 7)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -201,9 +204,9 @@ func TestCommentsRepository_DeleteComment_Failure(t *testing.T) {
 	dbAdapter := adapters.DbAdapter{db}
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
-	mock.ExpectExec(`DELETE FROM comments WHERE id = \$1`).WithArgs(2).WillReturnError(errors.New("delete error"))
+	mock.ExpectExec(`UPDATE comments SET deleted_at = CURRENT_TIMESTAMP`).WithArgs(7, 2).WillReturnError(errors.New("delete error"))
 
-	err = commentsRepo.DeleteComment(context.Background(), 2)
+	err = commentsRepo.DeleteComment(context.Background(), 2, 7)
 	assert.Error(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -218,7 +221,7 @@ func TestCommentsRepository_GetTotalCommentsCount_Success(t *testing.T) {
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(5)
-	mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM comments WHERE post_id = \$1`).WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(`WITH RECURSIVE visible\(id\) AS .* SELECT \(SELECT COUNT\(\*\) FROM visible\)`).WithArgs(1).WillReturnRows(rows)
 
 	count, err := commentsRepo.GetTotalCommentsCount(context.Background(), 1)
 	assert.NoError(t, err)
@@ -235,10 +238,121 @@ func TestCommentsRepository_GetTotalCommentsCount_Failure(t *testing.T) {
 	dbAdapter := adapters.DbAdapter{db}
 	commentsRepo := NewCommentsRepository(&dbAdapter, logger)
 
-	mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM comments WHERE post_id = \$1`).WithArgs(1).WillReturnError(errors.New("count error"))
+	mock.ExpectQuery(`WITH RECURSIVE visible\(id\) AS .* SELECT \(SELECT COUNT\(\*\) FROM visible\)`).WithArgs(1).WillReturnError(errors.New("count error"))
 
 	count, err := commentsRepo.GetTotalCommentsCount(context.Background(), 1)
 	assert.Error(t, err)
 	assert.Equal(t, 0, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func intPtr(v int) *int { return &v }
+
+func TestCommentsRepository_GetCommentThreads_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	commentsRepo := NewCommentsRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "content", "author_id", "post_id", "parent_id", "created_at", "deleted", "hidden", "updated_at", "edited_by", "upvotes", "downvotes", "reactions", "depth", "replies_count"}).
+		AddRow(1, entity.DeletedCommentContent, 3, 1, nil, createdAt, true, false, nil, nil, 0, 0, "{}", 0, 1).
+		AddRow(2, "reply", 4, 1, 1, createdAt, false, true, createdAt, 9, 5, 2, `{"🎉":1}`, 1, 0)
+	mock.ExpectQuery(`WITH RECURSIVE visible\(id\) AS .*, roots AS`).WithArgs(1, 10, 0, 5).WillReturnRows(rows)
+
+	result, err := commentsRepo.GetCommentThreads(context.Background(), 1, 10, 0, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.CommentNode{
+		{Comment: entity.Comment{ID: 1, Content: entity.DeletedCommentContent, AuthorId: 3, PostId: 1, CreatedAt: createdAt, Deleted: true}, RepliesCount: 1},
+		{Comment: entity.Comment{ID: 2, Content: entity.HiddenCommentContent, AuthorId: 4, PostId: 1, ParentId: intPtr(1), CreatedAt: createdAt, Hidden: true, UpdatedAt: &createdAt, EditedBy: intPtr(9),
+			Votes: entity.Votes{Upvotes: 5, Downvotes: 2, Score: 3, Reactions: entity.ReactionCounts{"🎉": 1}}}, Depth: 1},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentsRepository_GetTotalThreadsCount(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	commentsRepo := NewCommentsRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`WITH RECURSIVE visible\(id\) AS .* SELECT COUNT\(\*\) FROM comments WHERE parent_id IS NULL AND id IN \(SELECT id FROM visible\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := commentsRepo.GetTotalThreadsCount(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentsRepository_DeleteComment_AlreadyDeleted(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	commentsRepo := NewCommentsRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectExec(`UPDATE comments SET deleted_at = CURRENT_TIMESTAMP`).
+		WithArgs(7, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = commentsRepo.DeleteComment(context.Background(), 5, 7)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentsRepository_UpdateComment(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	commentsRepo := NewCommentsRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	mock.ExpectQuery(`UPDATE comments SET content = \?, updated_at = CURRENT_TIMESTAMP, edited_by = \?\s+WHERE id = \? AND deleted_at IS NULL\s+RETURNING id, content`).
+		WithArgs("edited", 2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "content", "author_id", "post_id", "parent_id", "created_at", "deleted", "hidden", "updated_at", "edited_by", "upvotes", "downvotes", "reactions"}).
+			AddRow(5, "edited", 1, 3, nil, createdAt, false, false, updatedAt, 2, 0, 0, "{}"))
+
+	comment, err := commentsRepo.UpdateComment(context.Background(), 5, "edited", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Comment{ID: 5, Content: "edited", AuthorId: 1, PostId: 3, CreatedAt: createdAt, UpdatedAt: &updatedAt, EditedBy: intPtr(2)}, comment)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentsRepository_GetCommentRevisions(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	commentsRepo := NewCommentsRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(`SELECT version, comment_id, content, editor_id, created_at\s+FROM comment_revisions\s+WHERE comment_id = \?\s+ORDER BY version ASC`).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "comment_id", "content", "editor_id", "created_at"}).
+			AddRow(1, 5, "first", 1, createdAt).
+			AddRow(2, 5, "second", 2, createdAt.Add(time.Minute)))
+
+	revisions, err := commentsRepo.GetCommentRevisions(context.Background(), 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.CommentRevision{
+		{Version: 1, CommentID: 5, Content: "first", EditorID: 1, CreatedAt: createdAt},
+		{Version: 2, CommentID: 5, Content: "second", EditorID: 2, CreatedAt: createdAt.Add(time.Minute)},
+	}, revisions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"go.uber.org/zap"
@@ -12,10 +13,64 @@ type CommentsRepository interface {
 	GetComments(ctx context.Context, postID, limit, offset int) ([]entity.Comment, error)
 	GetTotalCommentsCount(ctx context.Context, postID int) (int, error)
 	GetCommentsByPostID(ctx context.Context, postID int) ([]entity.Comment, error)
-	DeleteComment(ctx context.Context, id int) error
+	DeleteComment(ctx context.Context, id, deletedBy int) error
 	GetCommentByID(ctx context.Context, id int) (entity.Comment, error)
+	GetCommentThreads(ctx context.Context, postID, limit, offset, maxDepth int) ([]entity.CommentNode, error)
+	GetTotalThreadsCount(ctx context.Context, postID int) (int, error)
+	UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error)
+	GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error)
 }
 
+// commentColumns — порядок колонок, который ожидает scanComment. Текст
+// удаленного и скрытого по жалобам комментария scanComment заменяет
+// заглушкой.
+var commentColumns = `id, content, author_id, post_id, parent_id, created_at, deleted_at IS NOT NULL, hidden_at IS NOT NULL, updated_at, edited_by, upvotes, downvotes, ` +
+	reactionCountsColumn(entity.ReactionTargetComment, `comments.id`)
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanComment(row rowScanner, comment *entity.Comment, extra ...any) error {
+	err := row.Scan(append([]any{
+		&comment.ID,
+		&comment.Content,
+		&comment.AuthorId,
+		&comment.PostId,
+		&comment.ParentId,
+		&comment.CreatedAt,
+		&comment.Deleted,
+		&comment.Hidden,
+		&comment.UpdatedAt,
+		&comment.EditedBy,
+		&comment.Upvotes,
+		&comment.Downvotes,
+		&comment.Reactions,
+	}, extra...)...)
+	comment.Score = comment.Upvotes - comment.Downvotes
+	switch {
+	case comment.Deleted:
+		comment.Content = entity.DeletedCommentContent
+	case comment.Hidden:
+		comment.Content = entity.HiddenCommentContent
+	}
+	return err
+}
+
+// visibleComments — комментарии поста, которые показываются в обсуждении:
+// неудаленные и не скрытые по жалобам, а также удаленные и скрытые, под
+// которыми остались такие ответы. Вторые показываются заглушкой, чтобы не
+// разрушать ветку. Первый параметр — id поста.
+const visibleComments = `visible(id) AS (
+	SELECT id FROM comments WHERE post_id = ? AND deleted_at IS NULL AND hidden_at IS NULL
+	UNION
+	SELECT c.parent_id FROM comments c JOIN visible v ON c.id = v.id WHERE c.parent_id IS NOT NULL
+)`
+
+// visibleCommentsCount — число комментариев поста, которое показывается
+// рядом с постом и в пагинации обсуждения. Запросу нужен visibleComments.
+const visibleCommentsCount = `(SELECT COUNT(*) FROM visible)`
+
 type commentsRepository struct {
 	db     DB
 	logger *zap.Logger
@@ -27,11 +82,11 @@ func NewCommentsRepository(db DB, logger *zap.Logger) CommentsRepository {
 
 func (r *commentsRepository) CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
 	query := `
-		INSERT INTO comments (post_id, author_id, content)
-		VALUES ($1, $2, $3)
+		INSERT INTO comments (post_id, author_id, parent_id, content)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id, created_at
 	`
-	err := r.db.QueryRowContext(ctx, query, comment.PostId, comment.AuthorId, comment.Content).Scan(&comment.ID, &comment.CreatedAt)
+	err := r.db.QueryRowContext(ctx, query, comment.PostId, comment.AuthorId, comment.ParentId, comment.Content).Scan(&comment.ID, &comment.CreatedAt)
 	if err != nil {
 		r.logger.Error("Failed to create comment", zap.Error(err), zap.Int("postID", comment.PostId), zap.Int("authorID", comment.AuthorId))
 		return entity.Comment{}, err
@@ -42,11 +97,12 @@ func (r *commentsRepository) CreateComment(ctx context.Context, comment entity.C
 
 func (r *commentsRepository) GetComments(ctx context.Context, postID, limit, offset int) ([]entity.Comment, error) {
 	query := `
-        SELECT id, content, author_id, post_id, created_at 
-        FROM comments 
-        WHERE post_id = $1 
-        ORDER BY created_at DESC 
-        LIMIT $2 OFFSET $3
+        WITH RECURSIVE ` + visibleComments + `
+        SELECT ` + commentColumns + `
+        FROM comments
+        WHERE id IN (SELECT id FROM visible)
+        ORDER BY created_at DESC
+        LIMIT ? OFFSET ?
     `
 	rows, err := r.db.QueryContext(ctx, query, postID, limit, offset)
 	if err != nil {
@@ -57,13 +113,7 @@ func (r *commentsRepository) GetComments(ctx context.Context, postID, limit, off
 	var comments []entity.Comment
 	for rows.Next() {
 		var comment entity.Comment
-		if err := rows.Scan(
-			&comment.ID,
-			&comment.Content,
-			&comment.AuthorId,
-			&comment.PostId,
-			&comment.CreatedAt,
-		); err != nil {
+		if err := scanComment(rows, &comment); err != nil {
 			return nil, err
 		}
 		comments = append(comments, comment)
@@ -73,16 +123,17 @@ func (r *commentsRepository) GetComments(ctx context.Context, postID, limit, off
 
 func (r *commentsRepository) GetTotalCommentsCount(ctx context.Context, postID int) (int, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM comments WHERE post_id = $1`
+	query := `WITH RECURSIVE ` + visibleComments + ` SELECT ` + visibleCommentsCount
 	err := r.db.QueryRowContext(ctx, query, postID).Scan(&count)
 	return count, err
 }
 
 func (r *commentsRepository) GetCommentsByPostID(ctx context.Context, postID int) ([]entity.Comment, error) {
 	query := `
-        SELECT id, content, author_id, post_id, created_at 
-        FROM comments 
-        WHERE post_id = $1 
+        WITH RECURSIVE ` + visibleComments + `
+        SELECT ` + commentColumns + `
+        FROM comments
+        WHERE id IN (SELECT id FROM visible)
         ORDER BY created_at DESC
     `
 	rows, err := r.db.QueryContext(ctx, query, postID)
@@ -94,13 +145,7 @@ func (r *commentsRepository) GetCommentsByPostID(ctx context.Context, postID int
 	var comments []entity.Comment
 	for rows.Next() {
 		var comment entity.Comment
-		if err := rows.Scan(
-			&comment.ID,
-			&comment.Content,
-			&comment.AuthorId,
-			&comment.PostId,
-			&comment.CreatedAt,
-		); err != nil {
+		if err := scanComment(rows, &comment); err != nil {
 			return nil, err
 		}
 		comments = append(comments, comment)
@@ -108,24 +153,125 @@ func (r *commentsRepository) GetCommentsByPostID(ctx context.Context, postID int
 	return comments, nil
 }
 
-func (r *commentsRepository) DeleteComment(ctx context.Context, id int) error {
-	query := `DELETE FROM comments WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
+// DeleteComment переносит комментарий в корзину от имени deletedBy. Текст
+// сохраняется для восстановления, а при чтении заменяется заглушкой. Для
+// несуществующего и уже удаленного комментария возвращает sql.ErrNoRows.
+func (r *commentsRepository) DeleteComment(ctx context.Context, id, deletedBy int) error {
+	query := `UPDATE comments SET deleted_at = CURRENT_TIMESTAMP, deleted_by = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, deletedBy, id)
 	if err != nil {
 		r.logger.Error("Failed to delete comment", zap.Error(err), zap.Int("commentID", id))
 		return err
 	}
-	r.logger.Info("Comment deleted successfully", zap.Int("commentID", id))
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+	r.logger.Info("Comment moved to trash", zap.Int("commentID", id), zap.Int("deletedBy", deletedBy))
 	return nil
 }
 
 func (r *commentsRepository) GetCommentByID(ctx context.Context, id int) (entity.Comment, error) {
-	query := `SELECT id, content, author_id, post_id, created_at FROM comments WHERE id = ?`
+	query := `SELECT ` + commentColumns + ` FROM comments WHERE id = ?`
 	var comment entity.Comment
-	err := r.db.GetContext(ctx, &comment, query, id)
+	err := scanComment(r.db.QueryRowContext(ctx, query, id), &comment)
 	if err != nil {
 		r.logger.Error("Failed to get comment", zap.Error(err), zap.Int("commentID", id))
 		return entity.Comment{}, err
 	}
 	return comment, nil
 }
+
+// GetCommentThreads возвращает страницу веток обсуждения поста: корневые
+// комментарии в порядке создания и их ответы не глубже maxDepth. Удаленные
+// комментарии без неудаленных ответов пропускаются. Строки упорядочены по
+// времени создания; дерево собирает usecase.
+func (r *commentsRepository) GetCommentThreads(ctx context.Context, postID, limit, offset, maxDepth int) ([]entity.CommentNode, error) {
+	query := `
+		WITH RECURSIVE ` + visibleComments + `, roots AS (
+			SELECT id FROM comments
+			WHERE parent_id IS NULL AND id IN (SELECT id FROM visible)
+			ORDER BY created_at ASC, id ASC
+			LIMIT ? OFFSET ?
+		), thread(id, depth) AS (
+			SELECT id, 0 FROM roots
+			UNION ALL
+			SELECT c.id, t.depth + 1 FROM comments c JOIN thread t ON c.parent_id = t.id
+			WHERE t.depth < ? AND c.id IN (SELECT id FROM visible)
+		)
+		SELECT c.id, c.content, c.author_id, c.post_id, c.parent_id, c.created_at, c.deleted_at IS NOT NULL, c.hidden_at IS NOT NULL,
+		       c.updated_at, c.edited_by, c.upvotes, c.downvotes, ` + reactionCountsColumn(entity.ReactionTargetComment, `c.id`) + `,
+		       t.depth,
+		       (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.id IN (SELECT id FROM visible)) AS replies_count
+		FROM thread t JOIN comments c ON c.id = t.id
+		ORDER BY c.created_at ASC, c.id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, postID, limit, offset, maxDepth)
+	if err != nil {
+		r.logger.Error("Failed to get comment threads", zap.Error(err), zap.Int("postID", postID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nodes []entity.CommentNode
+	for rows.Next() {
+		var node entity.CommentNode
+		if err := scanComment(rows, &node.Comment, &node.Depth, &node.RepliesCount); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, rows.Err()
+}
+
+func (r *commentsRepository) GetTotalThreadsCount(ctx context.Context, postID int) (int, error) {
+	var count int
+	query := `WITH RECURSIVE ` + visibleComments + `
+		SELECT COUNT(*) FROM comments WHERE parent_id IS NULL AND id IN (SELECT id FROM visible)`
+	err := r.db.QueryRowContext(ctx, query, postID).Scan(&count)
+	return count, err
+}
+
+// UpdateComment меняет текст комментария. Прежнюю версию сохраняет в
+// comment_revisions триггер save_comment_revision.
+func (r *commentsRepository) UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error) {
+	query := `
+		UPDATE comments SET content = ?, updated_at = CURRENT_TIMESTAMP, edited_by = ?
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING ` + commentColumns
+	var comment entity.Comment
+	err := scanComment(r.db.QueryRowContext(ctx, query, content, editorID, id), &comment)
+	if err != nil {
+		r.logger.Error("Failed to update comment", zap.Error(err), zap.Int("commentID", id), zap.Int("editorID", editorID))
+		return entity.Comment{}, err
+	}
+	r.logger.Info("Comment updated successfully", zap.Int("commentID", id), zap.Int("editorID", editorID))
+	return comment, nil
+}
+
+// GetCommentRevisions возвращает прежние версии комментария, начиная с первой.
+func (r *commentsRepository) GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error) {
+	query := `
+		SELECT version, comment_id, content, editor_id, created_at
+		FROM comment_revisions
+		WHERE comment_id = ?
+		ORDER BY version ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to get comment revisions", zap.Error(err), zap.Int("commentID", id))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []entity.CommentRevision
+	for rows.Next() {
+		var rev entity.CommentRevision
+		if err := rows.Scan(&rev.Version, &rev.CommentID, &rev.Content, &rev.EditorID, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
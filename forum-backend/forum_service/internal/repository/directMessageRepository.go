@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type DirectMessageRepository interface {
+	// GetOrCreateDirect возвращает ID беседы двух пользователей, создавая ее
+	// при первом обращении.
+	GetOrCreateDirect(ctx context.Context, createdBy, userID int) (int, error)
+	// CreateGroup создает групповую беседу; userIDs включают создателя.
+	CreateGroup(ctx context.Context, createdBy int, title string, userIDs []int) (int, error)
+	// GetConversation возвращает беседу глазами участника userID. Для
+	// несуществующей беседы и беседы без userID возвращает sql.ErrNoRows.
+	GetConversation(ctx context.Context, id, userID int) (entity.Conversation, error)
+	// GetConversations возвращает беседы пользователя с сообщениями, начиная
+	// с самой свежей. before — last_message_id последней беседы предыдущей
+	// страницы, 0 — первая страница.
+	GetConversations(ctx context.Context, userID, before, limit int) ([]entity.Conversation, error)
+	GetParticipantIDs(ctx context.Context, conversationID int) ([]int, error)
+	// GetMessages возвращает limit сообщений беседы с ID меньше before (0 —
+	// последние) от старых к новым.
+	GetMessages(ctx context.Context, conversationID, before, limit int) ([]entity.DirectMessage, error)
+	// StoreMessage сохраняет сообщение; для отправителя оно сразу прочитано.
+	StoreMessage(ctx context.Context, msg entity.DirectMessage) (entity.DirectMessage, error)
+	// MarkRead отмечает прочитанными сообщения беседы до messageID
+	// включительно. Отметка не сдвигается назад и дальше последнего сообщения.
+	MarkRead(ctx context.Context, conversationID, userID, messageID int) error
+	// GetUnreadCount возвращает число непрочитанных сообщений во всех беседах
+	// пользователя.
+	GetUnreadCount(ctx context.Context, userID int) (int, error)
+}
+
+type directMessageRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewDirectMessageRepository(db DB, logger *zap.Logger) DirectMessageRepository {
+	return &directMessageRepository{db: db, logger: logger}
+}
+
+// directKey — ключ беседы двух пользователей, не зависящий от порядка.
+func directKey(a, b int) string {
+	if a > b {
+		a, b = b, a
+	}
+	return fmt.Sprintf("%d:%d", a, b)
+}
+
+func (r *directMessageRepository) GetOrCreateDirect(ctx context.Context, createdBy, userID int) (int, error) {
+	key := directKey(createdBy, userID)
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO dm_conversations (direct_key, created_by) VALUES (?, ?)
+		ON CONFLICT(direct_key) DO NOTHING
+	`, key, createdBy)
+	if err != nil {
+		r.logger.Error("Failed to create direct conversation", zap.Error(err), zap.String("key", key))
+		return 0, err
+	}
+
+	var id int
+	if err := r.db.QueryRowContext(ctx, `SELECT id FROM dm_conversations WHERE direct_key = ?`, key).Scan(&id); err != nil {
+		r.logger.Error("Failed to get direct conversation", zap.Error(err), zap.String("key", key))
+		return 0, err
+	}
+	if err := r.addParticipants(ctx, id, []int{createdBy, userID}); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *directMessageRepository) CreateGroup(ctx context.Context, createdBy int, title string, userIDs []int) (int, error) {
+	result, err := r.db.ExecContext(ctx, `INSERT INTO dm_conversations (title, created_by) VALUES (?, ?)`, title, createdBy)
+	if err != nil {
+		r.logger.Error("Failed to create group conversation", zap.Error(err), zap.Int("createdBy", createdBy))
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	if err := r.addParticipants(ctx, int(id), userIDs); err != nil {
+		return 0, err
+	}
+	r.logger.Info("Group conversation created", zap.Int64("conversationID", id), zap.Ints("userIDs", userIDs))
+	return int(id), nil
+}
+
+func (r *directMessageRepository) addParticipants(ctx context.Context, conversationID int, userIDs []int) error {
+	for _, userID := range userIDs {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO dm_participants (conversation_id, user_id) VALUES (?, ?)
+			ON CONFLICT(conversation_id, user_id) DO NOTHING
+		`, conversationID, userID)
+		if err != nil {
+			r.logger.Error("Failed to add conversation participant", zap.Error(err),
+				zap.Int("conversationID", conversationID), zap.Int("userID", userID))
+			return err
+		}
+	}
+	return nil
+}
+
+// conversationQuery выбирает беседы участника (первый параметр) с последним
+// сообщением и числом непрочитанных им сообщений.
+const conversationQuery = `
+	SELECT c.id, c.direct_key IS NULL, c.title, c.created_by, c.created_at,
+	       m.id, m.sender_id, m.content, m.created_at,
+	       (SELECT COUNT(*) FROM dm_messages u
+	        WHERE u.conversation_id = c.id AND u.id > p.last_read_message_id AND u.sender_id != p.user_id)
+	FROM dm_conversations c
+	JOIN dm_participants p ON p.conversation_id = c.id AND p.user_id = ?
+	LEFT JOIN dm_messages m ON m.id = c.last_message_id`
+
+func scanConversation(row rowScanner) (entity.Conversation, error) {
+	var (
+		conv          entity.Conversation
+		lastID        sql.NullInt64
+		lastSender    sql.NullInt64
+		lastContent   sql.NullString
+		lastCreatedAt sql.NullTime
+	)
+	err := row.Scan(
+		&conv.ID,
+		&conv.IsGroup,
+		&conv.Title,
+		&conv.CreatedBy,
+		&conv.CreatedAt,
+		&lastID,
+		&lastSender,
+		&lastContent,
+		&lastCreatedAt,
+		&conv.UnreadCount,
+	)
+	if err != nil {
+		return conv, err
+	}
+	if lastID.Valid {
+		conv.LastMessage = &entity.DirectMessage{
+			ID:             int(lastID.Int64),
+			ConversationID: conv.ID,
+			SenderID:       int(lastSender.Int64),
+			Content:        lastContent.String,
+			CreatedAt:      lastCreatedAt.Time,
+		}
+	}
+	return conv, nil
+}
+
+func (r *directMessageRepository) GetConversation(ctx context.Context, id, userID int) (entity.Conversation, error) {
+	conv, err := scanConversation(r.db.QueryRowContext(ctx, conversationQuery+` WHERE c.id = ?`, userID, id))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to get conversation", zap.Error(err), zap.Int("conversationID", id))
+		}
+		return entity.Conversation{}, err
+	}
+
+	conversations := []entity.Conversation{conv}
+	if err := r.fillParticipants(ctx, conversations); err != nil {
+		return entity.Conversation{}, err
+	}
+	return conversations[0], nil
+}
+
+func (r *directMessageRepository) GetConversations(ctx context.Context, userID, before, limit int) ([]entity.Conversation, error) {
+	query := conversationQuery + ` WHERE c.last_message_id > 0`
+	args := []any{userID}
+	if before > 0 {
+		query += ` AND c.last_message_id < ?`
+		args = append(args, before)
+	}
+	query += ` ORDER BY c.last_message_id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to get conversations", zap.Error(err), zap.Int("userID", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversations []entity.Conversation
+	for rows.Next() {
+		conv, err := scanConversation(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan conversation", zap.Error(err))
+			return nil, err
+		}
+		conversations = append(conversations, conv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.fillParticipants(ctx, conversations); err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// fillParticipants загружает участников бесед одним запросом.
+func (r *directMessageRepository) fillParticipants(ctx context.Context, conversations []entity.Conversation) error {
+	if len(conversations) == 0 {
+		return nil
+	}
+	index := make(map[int]int, len(conversations))
+	placeholders := make([]string, len(conversations))
+	args := make([]any, len(conversations))
+	for i, conv := range conversations {
+		index[conv.ID] = i
+		placeholders[i] = "?"
+		args[i] = conv.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT conversation_id, user_id, last_read_message_id, joined_at
+		FROM dm_participants
+		WHERE conversation_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY conversation_id, joined_at, user_id
+	`, args...)
+	if err != nil {
+		r.logger.Error("Failed to get conversation participants", zap.Error(err))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var conversationID int
+		var participant entity.ConversationParticipant
+		if err := rows.Scan(&conversationID, &participant.UserID, &participant.LastReadMessageID, &participant.JoinedAt); err != nil {
+			r.logger.Error("Failed to scan conversation participant", zap.Error(err))
+			return err
+		}
+		conv := &conversations[index[conversationID]]
+		conv.Participants = append(conv.Participants, participant)
+	}
+	return rows.Err()
+}
+
+func (r *directMessageRepository) GetParticipantIDs(ctx context.Context, conversationID int) ([]int, error) {
+	var userIDs []int
+	err := r.db.SelectContext(ctx, &userIDs,
+		`SELECT user_id FROM dm_participants WHERE conversation_id = ? ORDER BY user_id`, conversationID)
+	if err != nil {
+		r.logger.Error("Failed to get conversation participants", zap.Error(err), zap.Int("conversationID", conversationID))
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+func (r *directMessageRepository) GetMessages(ctx context.Context, conversationID, before, limit int) ([]entity.DirectMessage, error) {
+	query := `SELECT id, conversation_id, sender_id, content, created_at FROM dm_messages WHERE conversation_id = ?`
+	args := []any{conversationID}
+	if before > 0 {
+		query += ` AND id < ?`
+		args = append(args, before)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to get direct messages", zap.Error(err), zap.Int("conversationID", conversationID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []entity.DirectMessage
+	for rows.Next() {
+		var msg entity.DirectMessage
+		if err := rows.Scan(&msg.ID, &msg.ConversationID, &msg.SenderID, &msg.Content, &msg.CreatedAt); err != nil {
+			r.logger.Error("Failed to scan direct message", zap.Error(err))
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, nil
+}
+
+func (r *directMessageRepository) StoreMessage(ctx context.Context, msg entity.DirectMessage) (entity.DirectMessage, error) {
+	msg.CreatedAt = time.Now().UTC().Truncate(time.Second)
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO dm_messages (conversation_id, sender_id, content, created_at) VALUES (?, ?, ?, ?)`,
+		msg.ConversationID, msg.SenderID, msg.Content, msg.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to store direct message", zap.Error(err), zap.Int("conversationID", msg.ConversationID))
+		return entity.DirectMessage{}, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.DirectMessage{}, err
+	}
+	msg.ID = int(id)
+
+	if _, err := r.db.ExecContext(ctx, `UPDATE dm_conversations SET last_message_id = ? WHERE id = ?`, msg.ID, msg.ConversationID); err != nil {
+		r.logger.Error("Failed to update conversation", zap.Error(err), zap.Int("conversationID", msg.ConversationID))
+		return entity.DirectMessage{}, err
+	}
+	if err := r.MarkRead(ctx, msg.ConversationID, msg.SenderID, msg.ID); err != nil {
+		return entity.DirectMessage{}, err
+	}
+	return msg, nil
+}
+
+func (r *directMessageRepository) MarkRead(ctx context.Context, conversationID, userID, messageID int) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE dm_participants
+		SET last_read_message_id = MIN(?, (SELECT last_message_id FROM dm_conversations WHERE id = ?))
+		WHERE conversation_id = ? AND user_id = ? AND last_read_message_id < ?
+	`, messageID, conversationID, conversationID, userID, messageID)
+	if err != nil {
+		r.logger.Error("Failed to mark conversation read", zap.Error(err),
+			zap.Int("conversationID", conversationID), zap.Int("userID", userID))
+	}
+	return err
+}
+
+func (r *directMessageRepository) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `
+		SELECT COUNT(*) FROM dm_messages m
+		JOIN dm_participants p ON p.conversation_id = m.conversation_id AND p.user_id = ?
+		WHERE m.id > p.last_read_message_id AND m.sender_id != p.user_id
+	`, userID)
+	if err != nil {
+		r.logger.Error("Failed to count unread direct messages", zap.Error(err), zap.Int("userID", userID))
+	}
+	return count, err
+}
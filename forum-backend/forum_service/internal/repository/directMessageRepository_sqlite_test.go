@@ -0,0 +1,134 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDirectMessageRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	repo := NewDirectMessageRepository(db, zap.NewNop())
+	_, err := db.Exec(`INSERT INTO users (id, username, password, role) VALUES (3, 'carol', 'x', 'user')`)
+	require.NoError(t, err)
+
+	// Беседа на двоих одна на пару, в каком порядке ее ни создавай
+	direct, err := repo.GetOrCreateDirect(ctx, 1, 2)
+	require.NoError(t, err)
+	again, err := repo.GetOrCreateDirect(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, direct, again)
+	group, err := repo.CreateGroup(ctx, 1, "Встреча", []int{1, 2, 3})
+	require.NoError(t, err)
+
+	participants, err := repo.GetParticipantIDs(ctx, group)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, participants)
+	_, err = repo.GetConversation(ctx, direct, 3)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Беседы без сообщений во входящие не попадают
+	inbox, err := repo.GetConversations(ctx, 2, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, inbox)
+
+	var ids []int
+	for _, msg := range []entity.DirectMessage{
+		{ConversationID: direct, SenderID: 1, Content: "привет"},
+		{ConversationID: direct, SenderID: 1, Content: "как дела?"},
+		{ConversationID: group, SenderID: 3, Content: "всем привет"},
+		{ConversationID: direct, SenderID: 2, Content: "отлично"},
+	} {
+		stored, err := repo.StoreMessage(ctx, msg)
+		require.NoError(t, err)
+		ids = append(ids, stored.ID)
+	}
+
+	// Свежая беседа первой; непрочитанные считаются без своих сообщений
+	inbox, err = repo.GetConversations(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, inbox, 2)
+	assert.Equal(t, direct, inbox[0].ID)
+	assert.False(t, inbox[0].IsGroup)
+	assert.Equal(t, "отлично", inbox[0].LastMessage.Content)
+	assert.Equal(t, 1, inbox[0].UnreadCount)
+	assert.Len(t, inbox[0].Participants, 2)
+	assert.True(t, inbox[1].IsGroup)
+	assert.Equal(t, "Встреча", inbox[1].Title)
+
+	page, err := repo.GetConversations(ctx, 1, inbox[0].LastMessage.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, group, page[0].ID)
+
+	// Ответ отмечает прочитанным все, что было до него; отметка не
+	// уходит дальше последнего сообщения и не сдвигается назад
+	unread, err := repo.GetUnreadCount(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, unread)
+	unread, err = repo.GetUnreadCount(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, unread)
+	require.NoError(t, repo.MarkRead(ctx, direct, 1, ids[3]+1000))
+	require.NoError(t, repo.MarkRead(ctx, direct, 1, ids[0]))
+	conv, err := repo.GetConversation(ctx, direct, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, conv.UnreadCount)
+	for _, participant := range conv.Participants {
+		assert.Equal(t, ids[3], participant.LastReadMessageID)
+	}
+	unread, err = repo.GetUnreadCount(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, unread)
+
+	// История листается от новых страниц к старым
+	history, err := repo.GetMessages(ctx, direct, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, []string{"как дела?", "отлично"}, []string{history[0].Content, history[1].Content})
+	history, err = repo.GetMessages(ctx, direct, history[0].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "привет", history[0].Content)
+}
+
+func TestUserBlockRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	repo := NewUserBlockRepository(db, zap.NewNop())
+
+	added, err := repo.Block(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = repo.Block(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	// Черный список действует в обе стороны
+	blocked, err := repo.IsBlockedBetween(ctx, 2, []int{1, 3})
+	require.NoError(t, err)
+	assert.True(t, blocked)
+	blocked, err = repo.IsBlockedBetween(ctx, 3, []int{1, 2})
+	require.NoError(t, err)
+	assert.False(t, blocked)
+
+	list, err := repo.GetBlocked(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, 2, list[0].UserID)
+
+	removed, err := repo.Unblock(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	blocked, err = repo.IsBlockedBetween(ctx, 2, []int{1})
+	require.NoError(t, err)
+	assert.False(t, blocked)
+}
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type ModerationRepository interface {
+	// OpenCase возвращает id нерешенного дела по цели c.TargetType/c.TargetID
+	// и создает его, если такого дела нет. Автор и текст цели сохраняются
+	// только при создании.
+	OpenCase(ctx context.Context, c entity.ModerationCase) (int, error)
+	// AddReport сохраняет жалобу и возвращает ее id. 0 — пользователь уже
+	// жаловался в этом деле.
+	AddReport(ctx context.Context, report entity.Report) (int, error)
+	CountReports(ctx context.Context, caseID int) (int, error)
+	// SetTargetHidden скрывает цель до решения модератора или снова
+	// показывает ее.
+	SetTargetHidden(ctx context.Context, targetType string, targetID int, hidden bool) error
+	GetCases(ctx context.Context, filter entity.CaseFilter) ([]entity.ModerationCase, error)
+	GetTotalCasesCount(ctx context.Context, filter entity.CaseFilter) (int, error)
+	// GetCase возвращает дело без жалоб. Для несуществующего дела возвращает
+	// sql.ErrNoRows.
+	GetCase(ctx context.Context, id int) (entity.ModerationCase, error)
+	// GetReports возвращает жалобы дела в порядке поступления.
+	GetReports(ctx context.Context, caseID int) ([]entity.Report, error)
+	// ClaimCase берет нерешенное дело в работу. false — дело решено или его
+	// уже взял другой модератор.
+	ClaimCase(ctx context.Context, id, moderatorID int) (bool, error)
+	// ReleaseCase возвращает взятое дело в очередь. false — дело не в работе.
+	ReleaseCase(ctx context.Context, id int) (bool, error)
+	// ResolveCase закрывает дело решением resolution. Дело, взятое другим
+	// модератором, и уже решенное дело не меняются: возвращается false.
+	ResolveCase(ctx context.Context, id int, resolution, note string, moderatorID int) (bool, error)
+	// AddWarning сохраняет предупреждение автору по делу warning.CaseID.
+	// Повторное предупреждение по тому же делу не сохраняется.
+	AddWarning(ctx context.Context, warning entity.Warning) error
+	// MarkBanIssued отмечает, что по делу выносится бан автору. false — бан
+	// по делу уже вынесен.
+	MarkBanIssued(ctx context.Context, id int) (bool, error)
+	// ClearBanIssued снимает отметку о бане, если auth_service его не вынес.
+	ClearBanIssued(ctx context.Context, id int) error
+	// GetWarnings возвращает предупреждения пользователя, начиная с последнего.
+	GetWarnings(ctx context.Context, userID int) ([]entity.Warning, error)
+}
+
+// reportTables — таблицы целей жалоб.
+var reportTables = map[string]string{
+	entity.ReactionTargetPost:      "posts",
+	entity.ReactionTargetComment:   "comments",
+	entity.ReportTargetChatMessage: "chat_messages",
+}
+
+// caseColumns — колонки дела в порядке, который ожидает scanCase. Hidden и
+// комната сообщения чата берутся из самой цели: после удаления цели Hidden
+// ложен, а комнаты нет.
+const caseColumns = `mc.id, mc.target_type, mc.target_id, mc.post_id, mc.target_author_id, mc.content,
+	COALESCE(CASE mc.target_type
+		WHEN 'post' THEN (SELECT hidden_at IS NOT NULL FROM posts WHERE id = mc.target_id)
+		WHEN 'comment' THEN (SELECT hidden_at IS NOT NULL FROM comments WHERE id = mc.target_id)
+		ELSE (SELECT hidden_at IS NOT NULL FROM chat_messages WHERE id = mc.target_id)
+	END, 0),
+	mc.status, (SELECT COUNT(*) FROM reports r WHERE r.case_id = mc.id),
+	mc.claimed_by, mc.claimed_at, mc.resolution, mc.resolution_note, mc.resolved_by, mc.resolved_at, mc.created_at,
+	CASE mc.target_type WHEN 'chat_message' THEN (SELECT room_id FROM chat_messages WHERE id = mc.target_id) END`
+
+func scanCase(row rowScanner, c *entity.ModerationCase) error {
+	return row.Scan(
+		&c.ID,
+		&c.TargetType,
+		&c.TargetID,
+		&c.PostID,
+		&c.TargetAuthorID,
+		&c.Content,
+		&c.Hidden,
+		&c.Status,
+		&c.ReportsCount,
+		&c.ClaimedBy,
+		&c.ClaimedAt,
+		&c.Resolution,
+		&c.ResolutionNote,
+		&c.ResolvedBy,
+		&c.ResolvedAt,
+		&c.CreatedAt,
+		&c.RoomID,
+	)
+}
+
+type moderationRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewModerationRepository(db DB, logger *zap.Logger) ModerationRepository {
+	return &moderationRepository{db: db, logger: logger}
+}
+
+func (r *moderationRepository) OpenCase(ctx context.Context, c entity.ModerationCase) (int, error) {
+	// Дело по цели может открыть параллельная жалоба: частичный уникальный
+	// индекс оставит одно, а его id найдет следующий запрос
+	query := `
+		INSERT INTO moderation_cases (target_type, target_id, post_id, target_author_id, content)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (target_type, target_id) WHERE status != 'resolved' DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, c.TargetType, c.TargetID, c.PostID, c.TargetAuthorID, c.Content)
+	if err != nil {
+		r.logger.Error("Failed to open moderation case", zap.Error(err), zap.String("targetType", c.TargetType), zap.Int("targetID", c.TargetID))
+		return 0, err
+	}
+
+	var id int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT id FROM moderation_cases WHERE target_type = ? AND target_id = ? AND status != 'resolved'`,
+		c.TargetType, c.TargetID).Scan(&id)
+	if err != nil {
+		r.logger.Error("Failed to get moderation case", zap.Error(err), zap.String("targetType", c.TargetType), zap.Int("targetID", c.TargetID))
+	}
+	return id, err
+}
+
+func (r *moderationRepository) AddReport(ctx context.Context, report entity.Report) (int, error) {
+	query := `
+		INSERT INTO reports (case_id, reporter_id, reason, note) VALUES (?, ?, ?, ?)
+		ON CONFLICT (case_id, reporter_id) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query, report.CaseID, report.ReporterID, report.Reason, report.Note)
+	if err != nil {
+		r.logger.Error("Failed to add report", zap.Error(err), zap.Int("caseID", report.CaseID), zap.Int("reporterID", report.ReporterID))
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get last insert ID", zap.Error(err))
+		return 0, err
+	}
+	r.logger.Info("Report added", zap.Int64("reportID", id), zap.Int("caseID", report.CaseID), zap.Int("reporterID", report.ReporterID))
+	return int(id), nil
+}
+
+func (r *moderationRepository) CountReports(ctx context.Context, caseID int) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reports WHERE case_id = ?`, caseID).Scan(&count)
+	return count, err
+}
+
+func (r *moderationRepository) SetTargetHidden(ctx context.Context, targetType string, targetID int, hidden bool) error {
+	table, ok := reportTables[targetType]
+	if !ok {
+		return fmt.Errorf("unknown report target %q", targetType)
+	}
+	query := `UPDATE ` + table + ` SET hidden_at = CURRENT_TIMESTAMP WHERE id = ? AND hidden_at IS NULL`
+	if !hidden {
+		query = `UPDATE ` + table + ` SET hidden_at = NULL WHERE id = ?`
+	}
+	_, err := r.db.ExecContext(ctx, query, targetID)
+	if err != nil {
+		r.logger.Error("Failed to change target visibility", zap.Error(err), zap.String("targetType", targetType), zap.Int("targetID", targetID))
+		return err
+	}
+	r.logger.Info("Target visibility changed", zap.String("targetType", targetType), zap.Int("targetID", targetID), zap.Bool("hidden", hidden))
+	return nil
+}
+
+func caseFilterConditions(filter entity.CaseFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	switch filter.Status {
+	case entity.CaseStatusAll:
+	case entity.CaseStatusOpen, entity.CaseStatusClaimed, entity.CaseStatusResolved:
+		conditions = append(conditions, "mc.status = ?")
+		args = append(args, filter.Status)
+	default:
+		conditions = append(conditions, "mc.status != 'resolved'")
+	}
+	if filter.TargetType != "" {
+		conditions = append(conditions, "mc.target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+	if filter.Reason != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM reports r WHERE r.case_id = mc.id AND r.reason = ?)")
+		args = append(args, filter.Reason)
+	}
+	if filter.ClaimedBy != 0 {
+		conditions = append(conditions, "mc.claimed_by = ?")
+		args = append(args, filter.ClaimedBy)
+	}
+	if filter.AuthorID != 0 {
+		conditions = append(conditions, "mc.target_author_id = ?")
+		args = append(args, filter.AuthorID)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// GetCases возвращает дела очереди: нерешенные — от старых к новым, решенные —
+// начиная с последних решений. В выборке всех дел нерешенные идут первыми.
+func (r *moderationRepository) GetCases(ctx context.Context, filter entity.CaseFilter) ([]entity.ModerationCase, error) {
+	where, args := caseFilterConditions(filter)
+	order := `mc.created_at ASC, mc.id ASC`
+	if filter.Status == entity.CaseStatusResolved || filter.Status == entity.CaseStatusAll {
+		order = `mc.resolved_at IS NOT NULL, mc.resolved_at DESC, mc.created_at ASC, mc.id ASC`
+	}
+	query := `SELECT ` + caseColumns + ` FROM moderation_cases mc` + where + ` ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		r.logger.Error("Failed to get moderation cases", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cases []entity.ModerationCase
+	for rows.Next() {
+		var c entity.ModerationCase
+		if err := scanCase(rows, &c); err != nil {
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+	return cases, rows.Err()
+}
+
+func (r *moderationRepository) GetTotalCasesCount(ctx context.Context, filter entity.CaseFilter) (int, error) {
+	where, args := caseFilterConditions(filter)
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM moderation_cases mc`+where, args...).Scan(&count)
+	return count, err
+}
+
+func (r *moderationRepository) GetCase(ctx context.Context, id int) (entity.ModerationCase, error) {
+	var c entity.ModerationCase
+	err := scanCase(r.db.QueryRowContext(ctx, `SELECT `+caseColumns+` FROM moderation_cases mc WHERE mc.id = ?`, id), &c)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get moderation case", zap.Error(err), zap.Int("caseID", id))
+	}
+	return c, err
+}
+
+func (r *moderationRepository) GetReports(ctx context.Context, caseID int) ([]entity.Report, error) {
+	query := `SELECT id, case_id, reporter_id, reason, note, created_at FROM reports WHERE case_id = ? ORDER BY created_at ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, caseID)
+	if err != nil {
+		r.logger.Error("Failed to get reports", zap.Error(err), zap.Int("caseID", caseID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []entity.Report
+	for rows.Next() {
+		var report entity.Report
+		if err := rows.Scan(&report.ID, &report.CaseID, &report.ReporterID, &report.Reason, &report.Note, &report.CreatedAt); err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+func (r *moderationRepository) ClaimCase(ctx context.Context, id, moderatorID int) (bool, error) {
+	query := `
+		UPDATE moderation_cases SET status = 'claimed', claimed_by = ?, claimed_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status != 'resolved' AND (claimed_by IS NULL OR claimed_by = ?)
+	`
+	return r.update(ctx, "claim", id, query, moderatorID, id, moderatorID)
+}
+
+func (r *moderationRepository) ReleaseCase(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE moderation_cases SET status = 'open', claimed_by = NULL, claimed_at = NULL WHERE id = ? AND status = 'claimed'`
+	return r.update(ctx, "release", id, query, id)
+}
+
+func (r *moderationRepository) ResolveCase(ctx context.Context, id int, resolution, note string, moderatorID int) (bool, error) {
+	query := `
+		UPDATE moderation_cases SET status = 'resolved', resolution = ?, resolution_note = ?,
+			resolved_by = ?, resolved_at = CURRENT_TIMESTAMP,
+			claimed_by = COALESCE(claimed_by, ?), claimed_at = COALESCE(claimed_at, CURRENT_TIMESTAMP)
+		WHERE id = ? AND status != 'resolved' AND (claimed_by IS NULL OR claimed_by = ?)
+	`
+	return r.update(ctx, "resolve", id, query, resolution, note, moderatorID, moderatorID, id, moderatorID)
+}
+
+func (r *moderationRepository) AddWarning(ctx context.Context, warning entity.Warning) error {
+	query := `INSERT OR IGNORE INTO user_warnings (user_id, case_id, reason, issued_by) VALUES (?, ?, ?, ?)`
+	if _, err := r.db.ExecContext(ctx, query, warning.UserID, warning.CaseID, warning.Reason, warning.IssuedBy); err != nil {
+		r.logger.Error("Failed to add warning", zap.Error(err), zap.Int("caseID", warning.CaseID), zap.Int("userID", warning.UserID))
+		return err
+	}
+	return nil
+}
+
+func (r *moderationRepository) MarkBanIssued(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE moderation_cases SET ban_issued_at = CURRENT_TIMESTAMP WHERE id = ? AND ban_issued_at IS NULL`
+	return r.update(ctx, "mark ban", id, query, id)
+}
+
+func (r *moderationRepository) ClearBanIssued(ctx context.Context, id int) error {
+	_, err := r.update(ctx, "clear ban", id, `UPDATE moderation_cases SET ban_issued_at = NULL WHERE id = ?`, id)
+	return err
+}
+
+func (r *moderationRepository) GetWarnings(ctx context.Context, userID int) ([]entity.Warning, error) {
+	query := `SELECT id, user_id, case_id, reason, issued_by, created_at FROM user_warnings WHERE user_id = ? ORDER BY created_at DESC, id DESC`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to get warnings", zap.Error(err), zap.Int("userID", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	warnings := []entity.Warning{}
+	for rows.Next() {
+		var warning entity.Warning
+		if err := rows.Scan(&warning.ID, &warning.UserID, &warning.CaseID, &warning.Reason, &warning.IssuedBy, &warning.CreatedAt); err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings, rows.Err()
+}
+
+// update выполняет изменение дела и сообщает, затронуло ли оно строку.
+func (r *moderationRepository) update(ctx context.Context, action string, id int, query string, args ...any) (bool, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to update moderation case", zap.Error(err), zap.String("action", action), zap.Int("caseID", id))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		r.logger.Info("Moderation case updated", zap.String("action", action), zap.Int("caseID", id))
+	}
+	return affected > 0, nil
+}
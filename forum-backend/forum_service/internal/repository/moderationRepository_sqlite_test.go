@@ -0,0 +1,195 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestModerationRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	moderationRepo := NewModerationRepository(db, zap.NewNop())
+	postRepo := NewPostRepository(db, zap.NewNop())
+	commentRepo := NewCommentsRepository(db, zap.NewNop())
+	searchRepo := NewSearchRepository(db, zap.NewNop())
+
+	_, err := db.Exec(`INSERT INTO users (id, username, password, role) VALUES (3, 'carol', 'x', 'user'), (4, 'dave', 'x', 'moderator')`)
+	require.NoError(t, err)
+
+	// Жалобы на одну цель попадают в одно дело, повторная жалоба не
+	// сохраняется
+	postID := 2
+	open := entity.ModerationCase{TargetType: entity.ReactionTargetPost, TargetID: 2, PostID: &postID, TargetAuthorID: 2, Content: "Running tests"}
+	caseID, err := moderationRepo.OpenCase(ctx, open)
+	require.NoError(t, err)
+	again, err := moderationRepo.OpenCase(ctx, open)
+	require.NoError(t, err)
+	assert.Equal(t, caseID, again)
+
+	reportID, err := moderationRepo.AddReport(ctx, entity.Report{CaseID: caseID, ReporterID: 1, Reason: entity.ReportReasonSpam})
+	require.NoError(t, err)
+	assert.NotZero(t, reportID)
+	reportID, err = moderationRepo.AddReport(ctx, entity.Report{CaseID: caseID, ReporterID: 1, Reason: entity.ReportReasonHate})
+	require.NoError(t, err)
+	assert.Zero(t, reportID)
+	_, err = moderationRepo.AddReport(ctx, entity.Report{CaseID: caseID, ReporterID: 3, Reason: entity.ReportReasonOther, Note: "реклама"})
+	require.NoError(t, err)
+	count, err := moderationRepo.CountReports(ctx, caseID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Скрытый пост пропадает из ленты и поиска, но открывается по id
+	require.NoError(t, moderationRepo.SetTargetHidden(ctx, entity.ReactionTargetPost, 2, true))
+	assert.Equal(t, []int{1}, postIDs(t, postRepo, entity.PostFilter{}))
+	assert.Empty(t, searchFTS(t, searchRepo, "tests", entity.SearchFilter{}))
+	details, err := postRepo.GetPostDetails(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, details.Hidden)
+
+	// Текст скрытого комментария не отдается
+	require.NoError(t, moderationRepo.SetTargetHidden(ctx, entity.ReactionTargetComment, 1, true))
+	comment, err := commentRepo.GetCommentByID(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, comment.Hidden)
+	assert.Equal(t, entity.HiddenCommentContent, comment.Content)
+
+	commentCaseID, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReactionTargetComment, TargetID: 1, TargetAuthorID: 2, Content: "Красивые елки"})
+	require.NoError(t, err)
+	_, err = moderationRepo.AddReport(ctx, entity.Report{CaseID: commentCaseID, ReporterID: 1, Reason: entity.ReportReasonHate})
+	require.NoError(t, err)
+
+	c, err := moderationRepo.GetCase(ctx, caseID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CaseStatusOpen, c.Status)
+	assert.Equal(t, 2, c.ReportsCount)
+	assert.True(t, c.Hidden)
+	assert.Equal(t, &postID, c.PostID)
+	assert.Nil(t, c.Resolution)
+
+	reports, err := moderationRepo.GetReports(ctx, caseID)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "реклама", reports[1].Note)
+
+	caseIDs := func(filter entity.CaseFilter) []int {
+		t.Helper()
+		filter.Limit = 10
+		cases, err := moderationRepo.GetCases(ctx, filter)
+		require.NoError(t, err)
+		total, err := moderationRepo.GetTotalCasesCount(ctx, filter)
+		require.NoError(t, err)
+		ids := []int{}
+		for _, c := range cases {
+			ids = append(ids, c.ID)
+		}
+		assert.Equal(t, len(ids), total)
+		return ids
+	}
+	assert.Equal(t, []int{caseID, commentCaseID}, caseIDs(entity.CaseFilter{}))
+	assert.Equal(t, []int{commentCaseID}, caseIDs(entity.CaseFilter{Reason: entity.ReportReasonHate}))
+	assert.Equal(t, []int{caseID}, caseIDs(entity.CaseFilter{TargetType: entity.ReactionTargetPost}))
+
+	// Взятое дело не может взять или решить другой модератор
+	claimed, err := moderationRepo.ClaimCase(ctx, caseID, 4)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = moderationRepo.ClaimCase(ctx, caseID, 1)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, []int{caseID}, caseIDs(entity.CaseFilter{Status: entity.CaseStatusClaimed, ClaimedBy: 4}))
+	resolved, err := moderationRepo.ResolveCase(ctx, caseID, entity.ResolutionDismiss, "", 1)
+	require.NoError(t, err)
+	assert.False(t, resolved)
+
+	released, err := moderationRepo.ReleaseCase(ctx, caseID)
+	require.NoError(t, err)
+	assert.True(t, released)
+	resolved, err = moderationRepo.ResolveCase(ctx, caseID, entity.ResolutionWarn, "реклама", 4)
+	require.NoError(t, err)
+	assert.True(t, resolved)
+	resolved, err = moderationRepo.ResolveCase(ctx, caseID, entity.ResolutionBan, "", 4)
+	require.NoError(t, err)
+	assert.False(t, resolved)
+
+	c, err = moderationRepo.GetCase(ctx, caseID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CaseStatusResolved, c.Status)
+	assert.Equal(t, entity.ResolutionWarn, *c.Resolution)
+	assert.Equal(t, 4, *c.ResolvedBy)
+	assert.Equal(t, 4, *c.ClaimedBy)
+	assert.NotNil(t, c.ResolvedAt)
+	assert.Equal(t, []int{caseID}, caseIDs(entity.CaseFilter{Status: entity.CaseStatusResolved}))
+	// В общем списке нерешенные дела идут раньше решенных
+	assert.Equal(t, []int{commentCaseID, caseID}, caseIDs(entity.CaseFilter{Status: entity.CaseStatusAll}))
+
+	// После решения новая жалоба открывает новое дело
+	next, err := moderationRepo.OpenCase(ctx, open)
+	require.NoError(t, err)
+	assert.NotEqual(t, caseID, next)
+
+	require.NoError(t, moderationRepo.SetTargetHidden(ctx, entity.ReactionTargetPost, 2, false))
+	assert.ElementsMatch(t, []int{1, 2}, postIDs(t, postRepo, entity.PostFilter{}))
+
+	_, err = moderationRepo.GetCase(ctx, 999)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestModerationRepository_Warnings_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	moderationRepo := NewModerationRepository(db, zap.NewNop())
+
+	postID := 2
+	first, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReactionTargetPost, TargetID: 2, PostID: &postID, TargetAuthorID: 2})
+	require.NoError(t, err)
+	second, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReactionTargetComment, TargetID: 1, PostID: &postID, TargetAuthorID: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, moderationRepo.AddWarning(ctx, entity.Warning{UserID: 2, CaseID: first, Reason: "реклама", IssuedBy: 1}))
+	// Повторное решение того же дела предупреждение не дублирует
+	require.NoError(t, moderationRepo.AddWarning(ctx, entity.Warning{UserID: 2, CaseID: first, Reason: "реклама", IssuedBy: 1}))
+	require.NoError(t, moderationRepo.AddWarning(ctx, entity.Warning{UserID: 2, CaseID: second, Reason: "грубость", IssuedBy: 1}))
+
+	warnings, err := moderationRepo.GetWarnings(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, second, warnings[0].CaseID)
+	assert.Equal(t, "грубость", warnings[0].Reason)
+	assert.Equal(t, first, warnings[1].CaseID)
+
+	warnings, err = moderationRepo.GetWarnings(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestModerationRepository_MarkBanIssued_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	moderationRepo := NewModerationRepository(db, zap.NewNop())
+
+	postID := 2
+	id, err := moderationRepo.OpenCase(ctx, entity.ModerationCase{TargetType: entity.ReactionTargetPost, TargetID: 2, PostID: &postID, TargetAuthorID: 2})
+	require.NoError(t, err)
+
+	marked, err := moderationRepo.MarkBanIssued(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, marked)
+	// Бан по делу выносится один раз
+	marked, err = moderationRepo.MarkBanIssued(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, marked)
+
+	// После отказа auth_service бан можно вынести снова
+	require.NoError(t, moderationRepo.ClearBanIssued(ctx, id))
+	marked, err = moderationRepo.MarkBanIssued(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, marked)
+}
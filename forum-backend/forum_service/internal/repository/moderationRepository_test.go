@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestModerationRepository_AddReport_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewModerationRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT INTO reports \(case_id, reporter_id, reason, note\) VALUES \(\?, \?, \?, \?\)\s+ON CONFLICT \(case_id, reporter_id\) DO NOTHING`).
+		WithArgs(4, 3, "spam", "").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	id, err := repo.AddReport(context.Background(), entity.Report{CaseID: 4, ReporterID: 3, Reason: entity.ReportReasonSpam})
+
+	assert.NoError(t, err)
+	assert.Zero(t, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestModerationRepository_GetTotalCasesCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewModerationRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM moderation_cases mc WHERE mc.status != 'resolved' AND mc.target_type = \? AND EXISTS \(SELECT 1 FROM reports r WHERE r.case_id = mc.id AND r.reason = \?\) AND mc.target_author_id = \?$`).
+		WithArgs("comment", "hate", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(5))
+
+	count, err := repo.GetTotalCasesCount(context.Background(), entity.CaseFilter{
+		Status:     entity.CaseStatusPending,
+		TargetType: entity.ReactionTargetComment,
+		Reason:     entity.ReportReasonHate,
+		AuthorID:   2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// PermissionRepository читает права ролей из role_permissions, которыми
+// управляет auth_service. Роль берется из JWT, права — из базы на каждый
+// запрос, поэтому изменения прав действуют без перевыпуска токенов.
+type PermissionRepository interface {
+	GetRolePermissions(ctx context.Context, role string) ([]string, error)
+}
+
+type permissionRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewPermissionRepository(db DB, logger *zap.Logger) PermissionRepository {
+	return &permissionRepository{db: db, logger: logger}
+}
+
+func (r *permissionRepository) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission`, role)
+	if err != nil {
+		r.logger.Error("Failed to get role permissions", zap.String("role", role), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			r.logger.Error("Failed to scan role permission", zap.String("role", role), zap.Error(err))
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Failed to read role permissions", zap.String("role", role), zap.Error(err))
+		return nil, err
+	}
+	return permissions, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestPermissionRepository_GetRolePermissions_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	permissionRepo := NewPermissionRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`SELECT permission FROM role_permissions WHERE role = \? ORDER BY permission`).
+		WithArgs("moderator").
+		WillReturnRows(sqlmock.NewRows([]string{"permission"}).AddRow("comment.delete.any").AddRow("post.delete.any"))
+
+	permissions, err := permissionRepo.GetRolePermissions(context.Background(), "moderator")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"comment.delete.any", "post.delete.any"}, permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPermissionRepository_GetRolePermissions_Failure(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	permissionRepo := NewPermissionRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`SELECT permission FROM role_permissions WHERE role = \? ORDER BY permission`).
+		WithArgs("moderator").
+		WillReturnError(errors.New("db error"))
+
+	permissions, err := permissionRepo.GetRolePermissions(context.Background(), "moderator")
+
+	assert.Error(t, err)
+	assert.Nil(t, permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
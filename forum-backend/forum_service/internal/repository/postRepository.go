@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"go.uber.org/zap"
@@ -14,12 +16,13 @@ type DBposts interface {
 
 type PostRepository interface {
 	CreatePost(ctx context.Context, post entity.Post) (*entity.Post, error)
-	GetPosts(ctx context.Context, limit, offset int) ([]entity.Post, error)
+	GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error)
 	GetPostByID(ctx context.Context, id int) (*entity.Post, error)
+	GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error)
 	UpdatePost(ctx context.Context, post entity.Post) (*entity.Post, error)
-	DeletePost(ctx context.Context, id int) error
-	GetUserIDByToken(ctx context.Context, token string) (int, error)
-	GetTotalPostsCount(ctx context.Context) (int, error)
+	DeletePost(ctx context.Context, id, deletedBy int) error
+	GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error)
+	GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error)
 }
 
 type postRepository struct {
@@ -32,8 +35,8 @@ func NewPostRepository(db DB, logger *zap.Logger) PostRepository {
 }
 
 func (r *postRepository) CreatePost(ctx context.Context, post entity.Post) (*entity.Post, error) {
-	query := `INSERT INTO posts (author_id, title, content) VALUES (?, ?, ?)`
-	result, err := r.db.ExecContext(ctx, query, post.AuthorId, post.Title, post.Content)
+	query := `INSERT INTO posts (author_id, title, content, category_id) VALUES (?, ?, ?, ?)`
+	result, err := r.db.ExecContext(ctx, query, post.AuthorId, post.Title, post.Content, post.CategoryID)
 	if err != nil {
 		r.logger.Error("Failed to create post", zap.Error(err), zap.Int("authorID", post.AuthorId))
 		return nil, err
@@ -50,9 +53,12 @@ func (r *postRepository) CreatePost(ctx context.Context, post entity.Post) (*ent
 	return &post, nil
 }
 
-func (r *postRepository) GetPosts(ctx context.Context, limit, offset int) ([]entity.Post, error) {
-	query := `SELECT id, title, content, author_id FROM posts ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+func (r *postRepository) GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error) {
+	where, args := postFilterConditions(filter)
+	query := `SELECT id, title, content, author_id, category_id, created_at, updated_at, upvotes, downvotes, ` +
+		reactionCountsColumn(entity.ReactionTargetPost, `posts.id`) + ` FROM posts` + where +
+		` ORDER BY ` + postOrder(filter.Sort) + ` LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,24 +67,95 @@ func (r *postRepository) GetPosts(ctx context.Context, limit, offset int) ([]ent
 	var posts []entity.Post
 	for rows.Next() {
 		var post entity.Post
-		if err := rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorId); err != nil {
+		if err := rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorId, &post.CategoryID, &post.CreatedAt, &post.UpdatedAt,
+			&post.Upvotes, &post.Downvotes, &post.Reactions); err != nil {
 			return nil, err
 		}
+		post.Score = post.Upvotes - post.Downvotes
 		posts = append(posts, post)
 	}
 	return posts, nil
 }
 
-func (r *postRepository) GetTotalPostsCount(ctx context.Context) (int, error) {
+func (r *postRepository) GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error) {
+	where, args := postFilterConditions(filter)
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts`).Scan(&count)
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts`+where, args...).Scan(&count)
 	return count, err
 }
 
+// postAgeHours — возраст поста в часах.
+const postAgeHours = `((julianday('now') - julianday(created_at)) * 24)`
+
+// postOrders — порядок постов для каждой сортировки. При равенстве новые
+// посты идут первыми.
+var postOrders = map[string]string{
+	entity.PostSortNew: `created_at DESC`,
+	entity.PostSortTop: `upvotes - downvotes DESC, created_at DESC`,
+	// Счет делится на квадрат возраста: чем старше пост, тем больше голосов
+	// нужно, чтобы остаться наверху. Два часа форы не дают первому голосу
+	// за только что созданный пост перевесить все остальные.
+	entity.PostSortHot: `(upvotes - downvotes) / ((` + postAgeHours + ` + 2) * (` + postAgeHours + ` + 2)) DESC, created_at DESC`,
+	// Спорные — посты, у которых много голосов и поровну за и против
+	entity.PostSortControversial: `CASE WHEN upvotes = 0 OR downvotes = 0 THEN 0
+		ELSE (upvotes + downvotes) * MIN(upvotes, downvotes) * 1.0 / MAX(upvotes, downvotes) END DESC, created_at DESC`,
+}
+
+// postWindows — модификаторы datetime для окон времени ленты.
+var postWindows = map[string]string{
+	entity.PostWindowHour:  "-1 hour",
+	entity.PostWindowDay:   "-1 day",
+	entity.PostWindowWeek:  "-7 days",
+	entity.PostWindowMonth: "-1 month",
+	entity.PostWindowYear:  "-1 year",
+}
+
+func postOrder(sort string) string {
+	if order, ok := postOrders[sort]; ok {
+		return order
+	}
+	return postOrders[entity.PostSortNew]
+}
+
+func postFilterConditions(filter entity.PostFilter) (string, []interface{}) {
+	// Удаленные и скрытые по жалобам посты не попадают в ленты
+	conditions := []string{"deleted_at IS NULL", "hidden_at IS NULL"}
+	var args []interface{}
+	if filter.CategoryID != 0 {
+		conditions = append(conditions, "category_id = ?")
+		args = append(args, filter.CategoryID)
+	}
+	if len(filter.HiddenCategoryIDs) > 0 {
+		placeholders := make([]string, len(filter.HiddenCategoryIDs))
+		for i, id := range filter.HiddenCategoryIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		conditions = append(conditions, "category_id NOT IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if len(filter.Tags) > 0 {
+		// В режиме all пост должен иметь каждую из меток
+		tagged := `id IN (SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name IN (` + inPlaceholders(len(filter.Tags)) + `)`
+		args = append(args, stringArgs(filter.Tags)...)
+		if filter.TagMode == entity.TagModeAny {
+			tagged += `)`
+		} else {
+			tagged += ` GROUP BY pt.post_id HAVING COUNT(*) = ?)`
+			args = append(args, len(filter.Tags))
+		}
+		conditions = append(conditions, tagged)
+	}
+	if modifier, ok := postWindows[filter.Window]; ok {
+		conditions = append(conditions, "created_at >= datetime('now', ?)")
+		args = append(args, modifier)
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
 func (r *postRepository) GetPostByID(ctx context.Context, id int) (*entity.Post, error) {
-	query := `SELECT id, author_id, title, content FROM posts WHERE id = ?`
+	query := `SELECT id, author_id, title, content, category_id, created_at, updated_at, edited_by, COALESCE(edit_reason, '') FROM posts WHERE id = ? AND deleted_at IS NULL`
 	var post entity.Post
-	err := r.db.GetContext(ctx, &post, query, id)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&post.ID, &post.AuthorId, &post.Title, &post.Content, &post.CategoryID, &post.CreatedAt, &post.UpdatedAt, &post.EditedBy, &post.EditReason)
 	if err != nil {
 		r.logger.Error("Failed to get post by ID", zap.Error(err), zap.Int("postID", id))
 		return nil, err
@@ -87,36 +164,132 @@ func (r *postRepository) GetPostByID(ctx context.Context, id int) (*entity.Post,
 	return &post, nil
 }
 
+// GetPostDetails возвращает пост вместе с количеством комментариев, в том
+// числе скрытый по жалобам. Комментарии считаются так же, как в пагинации
+// обсуждения. Для несуществующего и удаленного поста
+// возвращает sql.ErrNoRows.
+func (r *postRepository) GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error) {
+	query := `
+		WITH RECURSIVE ` + visibleComments + `
+		SELECT p.id, p.author_id, p.title, p.content, p.category_id, p.created_at, p.updated_at, p.edited_by, COALESCE(p.edit_reason, ''),
+		       p.upvotes, p.downvotes, ` + reactionCountsColumn(entity.ReactionTargetPost, `p.id`) + `,
+		       ` + visibleCommentsCount + ` AS comments_count,
+		       p.hidden_at IS NOT NULL
+		FROM posts p
+		WHERE p.id = ? AND p.deleted_at IS NULL
+	`
+	var details entity.PostDetails
+	err := r.db.QueryRowContext(ctx, query, id, id).Scan(
+		&details.ID,
+		&details.AuthorId,
+		&details.Title,
+		&details.Content,
+		&details.CategoryID,
+		&details.CreatedAt,
+		&details.UpdatedAt,
+		&details.EditedBy,
+		&details.EditReason,
+		&details.Upvotes,
+		&details.Downvotes,
+		&details.Reactions,
+		&details.CommentsCount,
+		&details.Hidden,
+	)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to get post details", zap.Error(err), zap.Int("postID", id))
+		}
+		return nil, err
+	}
+	details.Score = details.Upvotes - details.Downvotes
+	return &details, nil
+}
+
+// UpdatePost меняет заголовок и текст поста от имени post.EditedBy с
+// причиной post.EditReason. Прежнюю версию сохраняет в post_revisions триггер
+// save_post_revision. Для несуществующего и удаленного поста возвращает
+// sql.ErrNoRows.
 func (r *postRepository) UpdatePost(ctx context.Context, post entity.Post) (*entity.Post, error) {
-	query := `UPDATE posts SET title = ?, content = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, post.Title, post.Content, post.ID) //TODO посмотреть что происходит при обновлении не сущ. записи
+	query := `
+		UPDATE posts SET title = ?, content = ?, updated_at = CURRENT_TIMESTAMP, edited_by = ?, edit_reason = ?
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING id, author_id, title, content, category_id, created_at, updated_at, edited_by, COALESCE(edit_reason, '')
+	`
+	var updated entity.Post
+	err := r.db.QueryRowContext(ctx, query, post.Title, post.Content, post.EditedBy, post.EditReason, post.ID).Scan(
+		&updated.ID,
+		&updated.AuthorId,
+		&updated.Title,
+		&updated.Content,
+		&updated.CategoryID,
+		&updated.CreatedAt,
+		&updated.UpdatedAt,
+		&updated.EditedBy,
+		&updated.EditReason,
+	)
 	if err != nil {
 		r.logger.Error("Failed to update post", zap.Error(err), zap.Int("postID", post.ID))
 		return nil, err
 	}
 	r.logger.Info("Post updated successfully", zap.Int("postID", post.ID))
-	return &post, nil
+	return &updated, nil
 }
 
-func (r *postRepository) DeletePost(ctx context.Context, id int) error {
-	query := `DELETE FROM posts WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, id)
+// DeletePost переносит пост в корзину от имени deletedBy. Комментарии
+// поста не меняются: они пропадают из выдачи вместе с постом и
+// возвращаются при его восстановлении. Для несуществующего и уже
+// удаленного поста возвращает sql.ErrNoRows.
+func (r *postRepository) DeletePost(ctx context.Context, id, deletedBy int) error {
+	query := `UPDATE posts SET deleted_at = CURRENT_TIMESTAMP, deleted_by = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, deletedBy, id)
 	if err != nil {
 		r.logger.Error("Failed to delete post", zap.Error(err), zap.Int("postID", id))
 		return err
 	}
-	r.logger.Info("Post deleted successfully", zap.Int("postID", id))
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+	r.logger.Info("Post moved to trash", zap.Int("postID", id), zap.Int("deletedBy", deletedBy))
 	return nil
 }
 
-func (r *postRepository) GetUserIDByToken(ctx context.Context, token string) (int, error) {
-	query := `SELECT user_id FROM tokens WHERE token = ?`
-	var userID int
-	err := r.db.GetContext(ctx, &userID, query, token)
+// GetPostRevisions возвращает прежние версии поста, начиная с первой. Строка
+// post_revisions хранит версию до правки и сведения о самой правке, поэтому
+// редактор, причина и время версии берутся из предыдущей строки. У первой
+// версии они остаются пустыми: их заполняет usecase по данным поста.
+func (r *postRepository) GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error) {
+	query := `
+		SELECT version, post_id, COALESCE(title, ''), COALESCE(content, ''), editor_id, COALESCE(reason, ''), created_at
+		FROM post_revisions
+		WHERE post_id = ?
+		ORDER BY version ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
 	if err != nil {
-		r.logger.Error("Failed to get user ID by token", zap.Error(err), zap.String("token", token))
-		return 0, err
+		r.logger.Error("Failed to get post revisions", zap.Error(err), zap.Int("postID", id))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []entity.PostRevision
+	var prev entity.PostRevision
+	for rows.Next() {
+		var row entity.PostRevision
+		if err := rows.Scan(&row.Version, &row.PostID, &row.Title, &row.Content, &row.EditorID, &row.Reason, &row.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, entity.PostRevision{
+			Version:   row.Version,
+			PostID:    row.PostID,
+			Title:     row.Title,
+			Content:   row.Content,
+			EditorID:  prev.EditorID,
+			Reason:    prev.Reason,
+			CreatedAt: prev.CreatedAt,
+		})
+		prev = row
 	}
-	r.logger.Info("User ID retrieved successfully", zap.String("token", token), zap.Int("userID", userID))
-	return userID, nil
+	return revisions, rows.Err()
 }
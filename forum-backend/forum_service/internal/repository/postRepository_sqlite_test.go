@@ -0,0 +1,64 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPostRepository_UpdatePost_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	postRepo := NewPostRepository(db, zap.NewNop())
+
+	// Пост создан час назад: правка должна сдвинуть updated_at
+	_, err := db.Exec(`UPDATE posts SET created_at = datetime('now', '-1 hour'), updated_at = datetime('now', '-1 hour') WHERE id = 1`)
+	require.NoError(t, err)
+	details, err := postRepo.GetPostDetails(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, details.CreatedAt, details.UpdatedAt)
+	assert.Nil(t, details.EditedBy)
+
+	editorID := 1
+	_, err = postRepo.UpdatePost(ctx, entity.Post{ID: 1, Title: "Ёлки", Content: "Зимой", EditedBy: &editorID, EditReason: "typo"})
+	require.NoError(t, err)
+
+	details, err = postRepo.GetPostDetails(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, details.UpdatedAt.After(details.CreatedAt))
+	assert.Equal(t, &editorID, details.EditedBy)
+}
+
+func TestPostRepository_CommentsCount_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	postRepo := NewPostRepository(db, zap.NewNop())
+	commentsRepo := NewCommentsRepository(db, zap.NewNop())
+
+	// Удаленный и скрытый ответы без ответов не показываются, удаленный
+	// комментарий с живым ответом остается заглушкой
+	_, err := db.Exec(`
+		INSERT INTO comments (id, post_id, author_id, parent_id, content, deleted_at) VALUES (3, 1, 1, 1, 'удален', CURRENT_TIMESTAMP);
+		INSERT INTO comments (id, post_id, author_id, parent_id, content, hidden_at) VALUES (4, 1, 1, 1, 'скрыт', CURRENT_TIMESTAMP);
+		INSERT INTO comments (id, post_id, author_id, content, deleted_at) VALUES (5, 1, 1, 'удален с ответом', CURRENT_TIMESTAMP);
+		INSERT INTO comments (id, post_id, author_id, parent_id, content) VALUES (6, 1, 2, 5, 'ответ');
+	`)
+	require.NoError(t, err)
+
+	details, err := postRepo.GetPostDetails(ctx, 1)
+	require.NoError(t, err)
+	total, err := commentsRepo.GetTotalCommentsCount(ctx, 1)
+	require.NoError(t, err)
+	comments, err := commentsRepo.GetComments(ctx, 1, 10, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, details.CommentsCount)
+	assert.Equal(t, details.CommentsCount, total)
+	assert.Len(t, comments, total)
+}
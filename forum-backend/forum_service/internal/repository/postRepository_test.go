@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
@@ -25,15 +27,16 @@ func TestPostRepository_CreatePost_Success(t *testing.T) {
 	postRepo := NewPostRepository(&dbAdapter, logger)
 
 	post := entity.Post{
-		AuthorId: 1,
-		Title:    "Test Post",
-		Content:  "This is a test post",
+		AuthorId:   1,
+		Title:      "Test Post",
+		Content:    "This is a test post",
+		CategoryID: 2,
 	}
 	createdPost := post
 	createdPost.ID = 1
 
-	mock.ExpectExec(`INSERT INTO posts \(author_id, title, content\) VALUES \(\?, \?, \?\)`).
-		WithArgs(post.AuthorId, post.Title, post.Content).
+	mock.ExpectExec(`INSERT INTO posts \(author_id, title, content, category_id\) VALUES \(\?, \?, \?, \?\)`).
+		WithArgs(post.AuthorId, post.Title, post.Content, post.CategoryID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	result, err := postRepo.CreatePost(context.Background(), post)
@@ -62,8 +65,8 @@ func TestPostRepository_CreatePost_Failure(t *testing.T) {
 		Content:  "This is a test post",
 	}
 
-	mock.ExpectExec(`INSERT INTO posts \(author_id, title, content\) VALUES \(\?, \?, \?\)`).
-		WithArgs(post.AuthorId, post.Title, post.Content).
+	mock.ExpectExec(`INSERT INTO posts \(author_id, title, content, category_id\) VALUES \(\?, \?, \?, \?\)`).
+		WithArgs(post.AuthorId, post.Title, post.Content, post.CategoryID).
 		WillReturnError(errors.New("failed to create post"))
 
 	result, err := postRepo.CreatePost(context.Background(), post)
@@ -86,19 +89,21 @@ func TestPostRepository_GetPosts_Success(t *testing.T) {
 
 	postRepo := NewPostRepository(dbAdapter, logger)
 
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
 	posts := []entity.Post{
-		{ID: 1, AuthorId: 1, Title: "Post 1", Content: "Content 1"},
-		{ID: 2, AuthorId: 2, Title: "Post 2", Content: "Content 2"},
+		{ID: 1, AuthorId: 1, Title: "Post 1", Content: "Content 1", CategoryID: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, AuthorId: 2, Title: "Post 2", Content: "Content 2", CategoryID: 2, CreatedAt: createdAt, UpdatedAt: createdAt},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "author_id", "title", "content"})
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "category_id", "created_at", "updated_at", "upvotes", "downvotes", "reactions"})
 	for _, post := range posts {
-		rows.AddRow(post.ID, post.AuthorId, post.Title, post.Content)
+		rows.AddRow(post.ID, post.Title, post.Content, post.AuthorId, post.CategoryID, post.CreatedAt, post.UpdatedAt, 0, 0, "{}")
 	}
-	mock.ExpectQuery(`SELECT id, author_id, title, content FROM posts`).
+	mock.ExpectQuery(`SELECT id, title, content, author_id, category_id, created_at, updated_at, upvotes, downvotes, .+ FROM posts WHERE deleted_at IS NULL AND hidden_at IS NULL ORDER BY created_at DESC LIMIT \? OFFSET \?`).
+		WithArgs(10, 0).
 		WillReturnRows(rows)
 
-	result, err := postRepo.GetPosts(context.Background(), 10, 0)
+	result, err := postRepo.GetPosts(context.Background(), entity.PostFilter{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.Equal(t, posts, result)
@@ -118,10 +123,10 @@ func TestPostRepository_GetPosts_Failure(t *testing.T) {
 
 	postRepo := NewPostRepository(dbAdapter, logger)
 
-	mock.ExpectQuery(`SELECT id, author_id, title, content FROM posts`).
+	mock.ExpectQuery(`SELECT id, title, content, author_id, category_id, created_at, updated_at, upvotes, downvotes, .+ FROM posts`).
 		WillReturnError(errors.New("failed to get posts"))
 
-	result, err := postRepo.GetPosts(context.Background(), 10, 0)
+	result, err := postRepo.GetPosts(context.Background(), entity.PostFilter{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -138,9 +143,11 @@ func TestPostRepository_GetPostByID_Success(t *testing.T) {
 	dbAdapter := &adapters.DbAdapter{DB: db}
 	postRepo := NewPostRepository(dbAdapter, logger)
 
-	post := entity.Post{ID: 1, AuthorId: 1, Title: "Test", Content: "Test content"}
-	rows := sqlmock.NewRows([]string{"id", "author_id", "title", "content"}).AddRow(post.ID, post.AuthorId, post.Title, post.Content)
-	mock.ExpectQuery(`SELECT id, author_id, title, content FROM posts WHERE id = \?`).WithArgs(post.ID).WillReturnRows(rows)
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	post := entity.Post{ID: 1, AuthorId: 1, Title: "Test", Content: "Test content", CategoryID: 1, CreatedAt: createdAt, UpdatedAt: createdAt}
+	rows := sqlmock.NewRows([]string{"id", "author_id", "title", "content", "category_id", "created_at", "updated_at", "edited_by", "edit_reason"}).
+		AddRow(post.ID, post.AuthorId, post.Title, post.Content, post.CategoryID, post.CreatedAt, post.UpdatedAt, nil, "")
+	mock.ExpectQuery(`SELECT id, author_id, title, content, category_id, created_at, updated_at, edited_by, COALESCE\(edit_reason, ''\) FROM posts WHERE id = \?`).WithArgs(post.ID).WillReturnRows(rows)
 
 	result, err := postRepo.GetPostByID(context.Background(), post.ID)
 	assert.NoError(t, err)
@@ -162,7 +169,7 @@ func TestPostRepository_GetPostByID_Failure(t *testing.T) {
 
 	postID := 1
 
-	mock.ExpectQuery(`SELECT id, author_id, title, content FROM posts WHERE id = \?`).
+	mock.ExpectQuery(`SELECT id, author_id, title, content, category_id, created_at, updated_at, edited_by, COALESCE\(edit_reason, ''\) FROM posts WHERE id = \?`).
 		WithArgs(postID).
 		WillReturnError(errors.New("failed to get post"))
 
@@ -174,6 +181,50 @@ func TestPostRepository_GetPostByID_Failure(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestPostRepository_GetPostDetails_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	rows := sqlmock.NewRows([]string{"id", "author_id", "title", "content", "category_id", "created_at", "updated_at", "edited_by", "edit_reason", "upvotes", "downvotes", "reactions", "comments_count", "hidden"}).
+		AddRow(1, 2, "Title", "Content", 3, createdAt, updatedAt, 5, "spam link removed", 7, 2, `{"👍":3}`, 4, true)
+	mock.ExpectQuery(`WITH RECURSIVE visible\(id\) AS .* SELECT p.id, p.author_id, p.title, p.content, p.category_id, p.created_at, p.updated_at`).WithArgs(1, 1).WillReturnRows(rows)
+
+	result, err := postRepo.GetPostDetails(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.AuthorId)
+	assert.Equal(t, 3, result.CategoryID)
+	assert.Equal(t, 4, result.CommentsCount)
+	assert.Equal(t, updatedAt, result.UpdatedAt)
+	assert.Equal(t, 5, *result.EditedBy)
+	assert.Equal(t, "spam link removed", result.EditReason)
+	assert.True(t, result.Hidden)
+	assert.Equal(t, entity.Votes{Upvotes: 7, Downvotes: 2, Score: 5, Reactions: entity.ReactionCounts{"👍": 3}}, result.Votes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostRepository_GetPostDetails_NotFound(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`SELECT p.id, p.author_id`).WithArgs(42, 42).WillReturnError(sql.ErrNoRows)
+
+	result, err := postRepo.GetPostDetails(context.Background(), 42)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, result)
+}
+
 func TestPostRepository_UpdatePost_Success(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
@@ -186,21 +237,36 @@ func TestPostRepository_UpdatePost_Success(t *testing.T) {
 
 	postRepo := NewPostRepository(dbAdapter, logger)
 
+	editorID := 2
 	post := entity.Post{
-		ID:       1,
-		AuthorId: 1,
-		Title:    "Updated Post",
-		Content:  "This is an updated post",
+		ID:         1,
+		Title:      "Updated Post",
+		Content:    "This is an updated post",
+		EditedBy:   &editorID,
+		EditReason: "typo",
 	}
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
 
-	mock.ExpectExec(`UPDATE posts SET title = \?, content = \? WHERE id = \?`).
-		WithArgs(post.Title, post.Content, post.ID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`UPDATE posts SET title = \?, content = \?, updated_at = CURRENT_TIMESTAMP, edited_by = \?, edit_reason = \?\s+WHERE id = \? AND deleted_at IS NULL\s+RETURNING id, author_id`).
+		WithArgs(post.Title, post.Content, &editorID, post.EditReason, post.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "title", "content", "category_id", "created_at", "updated_at", "edited_by", "edit_reason"}).
+			AddRow(1, 1, post.Title, post.Content, 1, createdAt, updatedAt, 2, "typo"))
 
 	result, err := postRepo.UpdatePost(context.Background(), post)
 
 	assert.NoError(t, err)
-	assert.Equal(t, &post, result)
+	assert.Equal(t, &entity.Post{
+		ID:         1,
+		AuthorId:   1,
+		Title:      post.Title,
+		Content:    post.Content,
+		CategoryID: 1,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+		EditedBy:   &editorID,
+		EditReason: "typo",
+	}, result)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -224,8 +290,8 @@ func TestPostRepository_UpdatePost_Failure(t *testing.T) {
 		Content:  "This is an updated post",
 	}
 
-	mock.ExpectExec(`UPDATE posts SET title = \?, content = \? WHERE id = \?`).
-		WithArgs(post.Title, post.Content, post.ID).
+	mock.ExpectQuery(`UPDATE posts SET title = \?, content = \?`).
+		WithArgs(post.Title, post.Content, post.EditedBy, post.EditReason, post.ID).
 		WillReturnError(errors.New("failed to update post"))
 
 	result, err := postRepo.UpdatePost(context.Background(), post)
@@ -236,6 +302,33 @@ func TestPostRepository_UpdatePost_Failure(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestPostRepository_GetPostRevisions(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	firstEdit := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	secondEdit := firstEdit.Add(time.Hour)
+	mock.ExpectQuery(`SELECT version, post_id, .* FROM post_revisions\s+WHERE post_id = \?\s+ORDER BY version ASC`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "post_id", "title", "content", "editor_id", "reason", "created_at"}).
+			AddRow(1, 1, "v1", "first", 3, "typo", firstEdit).
+			AddRow(2, 1, "v2", "second", 7, "rules", secondEdit))
+
+	revisions, err := postRepo.GetPostRevisions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	// Сведения о правке переходят к версии, которую она создала
+	assert.Equal(t, []entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "v1", Content: "first"},
+		{Version: 2, PostID: 1, Title: "v2", Content: "second", EditorID: 3, Reason: "typo", CreatedAt: firstEdit},
+	}, revisions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestPostRepository_DeletePost_Success(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
@@ -250,11 +343,11 @@ func TestPostRepository_DeletePost_Success(t *testing.T) {
 
 	postID := 1
 
-	mock.ExpectExec(`DELETE FROM posts WHERE id = \?`).
-		WithArgs(postID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE posts SET deleted_at = CURRENT_TIMESTAMP, deleted_by = \? WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(2, postID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = postRepo.DeletePost(context.Background(), postID)
+	err = postRepo.DeletePost(context.Background(), postID, 2)
 
 	assert.NoError(t, err)
 
@@ -275,86 +368,91 @@ func TestPostRepository_DeletePost_Failure(t *testing.T) {
 
 	postID := 1
 
-	mock.ExpectExec(`DELETE FROM posts WHERE id = \?`).
-		WithArgs(postID).
+	mock.ExpectExec(`UPDATE posts SET deleted_at = CURRENT_TIMESTAMP`).
+		WithArgs(2, postID).
 		WillReturnError(errors.New("failed to delete post"))
 
-	err = postRepo.DeletePost(context.Background(), postID)
+	err = postRepo.DeletePost(context.Background(), postID, 2)
 
 	assert.Error(t, err)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPostRepository_GetUserIDByToken_Success(t *testing.T) {
-
+func TestPostRepository_GetTotalPostsCount_Success(t *testing.T) {
 	logger, _ := zap.NewProduction()
-
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
 	dbAdapter := &adapters.DbAdapter{DB: db}
-
 	postRepo := NewPostRepository(dbAdapter, logger)
 
-	token := "test-token"
-	userID := 1
-
-	rows := sqlmock.NewRows([]string{"user_id"}).AddRow(userID)
-	mock.ExpectQuery(`SELECT user_id FROM tokens WHERE token = \?`).
-		WithArgs(token).
-		WillReturnRows(rows)
-
-	result, err := postRepo.GetUserIDByToken(context.Background(), token)
+	rows := sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(5)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts`).WillReturnRows(rows)
 
+	count, err := postRepo.GetTotalPostsCount(context.Background(), entity.PostFilter{})
 	assert.NoError(t, err)
-	assert.Equal(t, userID, result)
-
+	assert.Equal(t, 5, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPostRepository_GetUserIDByToken_Failure(t *testing.T) {
-
-	logger, _ := zap.NewProduction()
-
+func TestPostRepository_GetTotalPostsCount_Filter(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	dbAdapter := &adapters.DbAdapter{DB: db}
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
 
-	postRepo := NewPostRepository(dbAdapter, logger)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts WHERE deleted_at IS NULL AND hidden_at IS NULL AND category_id = \? AND category_id NOT IN \(\?, \?\)`).
+		WithArgs(2, 3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
 
-	token := "test-token"
+	count, err := postRepo.GetTotalPostsCount(context.Background(), entity.PostFilter{CategoryID: 2, HiddenCategoryIDs: []int{3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
 
-	mock.ExpectQuery(`SELECT user_id FROM tokens WHERE token = \?`).
-		WithArgs(token).
-		WillReturnError(errors.New("failed to get user ID by token"))
+func TestPostRepository_GetTotalPostsCount_TagFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
 
-	result, err := postRepo.GetUserIDByToken(context.Background(), token)
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
 
-	assert.Error(t, err)
-	assert.Equal(t, 0, result)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts WHERE deleted_at IS NULL AND hidden_at IS NULL AND id IN \(SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name IN \(\?, \?\) GROUP BY pt.post_id HAVING COUNT\(\*\) = \?\)`).
+		WithArgs("go", "grpc", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts WHERE deleted_at IS NULL AND hidden_at IS NULL AND id IN \(SELECT pt.post_id FROM post_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.name IN \(\?, \?\)\)$`).
+		WithArgs("go", "grpc").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3))
 
+	count, err := postRepo.GetTotalPostsCount(context.Background(), entity.PostFilter{Tags: []string{"go", "grpc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	count, err = postRepo.GetTotalPostsCount(context.Background(), entity.PostFilter{Tags: []string{"go", "grpc"}, TagMode: entity.TagModeAny})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestPostRepository_GetTotalPostsCount_Success(t *testing.T) {
-	logger, _ := zap.NewProduction()
+func TestPostRepository_GetPosts_SortAndWindow(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	dbAdapter := &adapters.DbAdapter{DB: db}
-	postRepo := NewPostRepository(dbAdapter, logger)
+	postRepo := NewPostRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
 
-	rows := sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(5)
-	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts`).WillReturnRows(rows)
+	mock.ExpectQuery(`FROM posts WHERE deleted_at IS NULL AND hidden_at IS NULL AND created_at >= datetime\('now', \?\) ORDER BY upvotes - downvotes DESC, created_at DESC LIMIT \? OFFSET \?`).
+		WithArgs("-7 days", 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content", "author_id", "category_id", "created_at", "updated_at", "upvotes", "downvotes", "reactions"}).
+			AddRow(1, "Title", "Content", 1, 1, time.Now(), time.Now(), 5, 1, `{"🎉":2}`))
 
-	count, err := postRepo.GetTotalPostsCount(context.Background())
+	posts, err := postRepo.GetPosts(context.Background(), entity.PostFilter{Sort: entity.PostSortTop, Window: entity.PostWindowWeek, Limit: 10})
 	assert.NoError(t, err)
-	assert.Equal(t, 5, count)
+	assert.Equal(t, entity.Votes{Upvotes: 5, Downvotes: 1, Score: 4, Reactions: entity.ReactionCounts{"🎉": 2}}, posts[0].Votes)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -369,7 +467,7 @@ func TestPostRepository_GetTotalPostsCount_Failure(t *testing.T) {
 
 	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts`).WillReturnError(errors.New("count error"))
 
-	count, err := postRepo.GetTotalPostsCount(context.Background())
+	count, err := postRepo.GetTotalPostsCount(context.Background(), entity.PostFilter{})
 	assert.Error(t, err)
 	assert.Equal(t, 0, count)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type ReactionRepository interface {
+	// AddReaction ставит реакцию; повторная реакция того же вида ничего не
+	// меняет. Голос снимает противоположный голос пользователя, счетчики
+	// обновляют триггеры.
+	AddReaction(ctx context.Context, reaction entity.Reaction) error
+	DeleteReaction(ctx context.Context, reaction entity.Reaction) error
+	// GetReactions возвращает реакции на цель, новые первыми.
+	GetReactions(ctx context.Context, filter entity.ReactionFilter) ([]entity.Reaction, error)
+	GetTotalReactionsCount(ctx context.Context, filter entity.ReactionFilter) (int, error)
+	// GetVotes возвращает счетчики цели. Для несуществующей цели возвращает
+	// sql.ErrNoRows.
+	GetVotes(ctx context.Context, targetType string, targetID int) (entity.Votes, error)
+}
+
+// reactionTables — таблицы целей реакций.
+var reactionTables = map[string]string{
+	entity.ReactionTargetPost:    "posts",
+	entity.ReactionTargetComment: "comments",
+}
+
+// reactionCountsColumn возвращает подзапрос со счетчиками эмодзи цели в виде
+// JSON-объекта; idColumn — колонка с id цели во внешнем запросе.
+func reactionCountsColumn(targetType, idColumn string) string {
+	return `(SELECT json_group_object(rc.kind, rc.count) FROM reaction_counts rc WHERE rc.target_type = '` +
+		targetType + `' AND rc.target_id = ` + idColumn + `)`
+}
+
+type reactionRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewReactionRepository(db DB, logger *zap.Logger) ReactionRepository {
+	return &reactionRepository{db: db, logger: logger}
+}
+
+func (r *reactionRepository) AddReaction(ctx context.Context, reaction entity.Reaction) error {
+	query := `
+		INSERT INTO reactions (target_type, target_id, user_id, kind) VALUES (?, ?, ?, ?)
+		ON CONFLICT (target_type, target_id, user_id, kind) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Kind)
+	if err != nil {
+		r.logger.Error("Failed to add reaction", zap.Error(err), zap.Any("reaction", reaction))
+	}
+	return err
+}
+
+func (r *reactionRepository) DeleteReaction(ctx context.Context, reaction entity.Reaction) error {
+	query := `DELETE FROM reactions WHERE target_type = ? AND target_id = ? AND user_id = ? AND kind = ?`
+	_, err := r.db.ExecContext(ctx, query, reaction.TargetType, reaction.TargetID, reaction.UserID, reaction.Kind)
+	if err != nil {
+		r.logger.Error("Failed to delete reaction", zap.Error(err), zap.Any("reaction", reaction))
+	}
+	return err
+}
+
+func reactionFilterConditions(filter entity.ReactionFilter) (string, []interface{}) {
+	where := ` WHERE target_type = ? AND target_id = ?`
+	args := []interface{}{filter.TargetType, filter.TargetID}
+	if filter.Kind != "" {
+		where += ` AND kind = ?`
+		args = append(args, filter.Kind)
+	}
+	return where, args
+}
+
+func (r *reactionRepository) GetReactions(ctx context.Context, filter entity.ReactionFilter) ([]entity.Reaction, error) {
+	where, args := reactionFilterConditions(filter)
+	query := `SELECT target_type, target_id, user_id, kind, created_at FROM reactions` + where +
+		` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		r.logger.Error("Failed to get reactions", zap.Error(err), zap.Any("filter", filter))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reactions []entity.Reaction
+	for rows.Next() {
+		var reaction entity.Reaction
+		if err := rows.Scan(&reaction.TargetType, &reaction.TargetID, &reaction.UserID, &reaction.Kind, &reaction.CreatedAt); err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, reaction)
+	}
+	return reactions, rows.Err()
+}
+
+func (r *reactionRepository) GetTotalReactionsCount(ctx context.Context, filter entity.ReactionFilter) (int, error) {
+	where, args := reactionFilterConditions(filter)
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reactions`+where, args...).Scan(&count)
+	return count, err
+}
+
+func (r *reactionRepository) GetVotes(ctx context.Context, targetType string, targetID int) (entity.Votes, error) {
+	table, ok := reactionTables[targetType]
+	if !ok {
+		return entity.Votes{}, fmt.Errorf("unknown reaction target %q", targetType)
+	}
+	query := `SELECT upvotes, downvotes, ` + reactionCountsColumn(targetType, table+`.id`) + ` FROM ` + table + ` WHERE id = ?`
+	var votes entity.Votes
+	err := r.db.QueryRowContext(ctx, query, targetID).Scan(&votes.Upvotes, &votes.Downvotes, &votes.Reactions)
+	if err != nil {
+		return entity.Votes{}, err
+	}
+	votes.Score = votes.Upvotes - votes.Downvotes
+	return votes, nil
+}
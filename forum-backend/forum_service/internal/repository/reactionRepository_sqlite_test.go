@@ -0,0 +1,89 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReactionRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	reactionRepo := NewReactionRepository(db, zap.NewNop())
+	postRepo := NewPostRepository(db, zap.NewNop())
+	commentRepo := NewCommentsRepository(db, zap.NewNop())
+
+	_, err := db.Exec(`INSERT INTO users (id, username, password, role) VALUES (3, 'carol', 'x', 'user')`)
+	require.NoError(t, err)
+	react := func(targetType string, targetID, userID int, kind string) {
+		t.Helper()
+		require.NoError(t, reactionRepo.AddReaction(ctx, entity.Reaction{TargetType: targetType, TargetID: targetID, UserID: userID, Kind: kind}))
+	}
+
+	// Повторный голос ничего не меняет, противоположный заменяет прежний
+	react(entity.ReactionTargetPost, 1, 2, entity.ReactionUp)
+	react(entity.ReactionTargetPost, 1, 2, entity.ReactionUp)
+	react(entity.ReactionTargetPost, 1, 3, entity.ReactionDown)
+	react(entity.ReactionTargetPost, 1, 3, entity.ReactionUp)
+	react(entity.ReactionTargetPost, 1, 3, "🎉")
+	react(entity.ReactionTargetPost, 1, 2, "🎉")
+
+	votes, err := reactionRepo.GetVotes(ctx, entity.ReactionTargetPost, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Votes{Upvotes: 2, Score: 2, Reactions: entity.ReactionCounts{"🎉": 2}}, votes)
+	total, err := reactionRepo.GetTotalReactionsCount(ctx, entity.ReactionFilter{TargetType: entity.ReactionTargetPost, TargetID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+
+	require.NoError(t, reactionRepo.DeleteReaction(ctx, entity.Reaction{TargetType: entity.ReactionTargetPost, TargetID: 1, UserID: 2, Kind: "🎉"}))
+	require.NoError(t, reactionRepo.DeleteReaction(ctx, entity.Reaction{TargetType: entity.ReactionTargetPost, TargetID: 1, UserID: 2, Kind: "🎉"}))
+	details, err := postRepo.GetPostDetails(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Votes{Upvotes: 2, Score: 2, Reactions: entity.ReactionCounts{"🎉": 1}}, details.Votes)
+
+	// Счетчики комментария читаются вместе с ним, в том числе после правки
+	react(entity.ReactionTargetComment, 1, 1, entity.ReactionDown)
+	react(entity.ReactionTargetComment, 1, 3, "😢")
+	comment, err := commentRepo.UpdateComment(ctx, 1, "Очень красивые елки", 2)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Votes{Downvotes: 1, Score: -1, Reactions: entity.ReactionCounts{"😢": 1}}, comment.Votes)
+
+	// В hot свежие посты обгоняют старые с большим счетом; спорный пост —
+	// тот, где голосов поровну
+	_, err = db.Exec(`
+		INSERT INTO posts (id, author_id, title, content, created_at, upvotes, downvotes) VALUES
+			(3, 1, 'Old', 'Old post', datetime('now', '-3 days'), 10, 0),
+			(4, 1, 'Fresh', 'Fresh post', datetime('now', '-1 hour'), 4, 0),
+			(5, 1, 'Debate', 'Debate post', datetime('now', '-2 hours'), 6, 6);
+		UPDATE posts SET created_at = datetime('now', '-2 years') WHERE id = 2;
+	`)
+	require.NoError(t, err)
+
+	sorted := func(filter entity.PostFilter) []int {
+		t.Helper()
+		return postIDs(t, postRepo, filter)
+	}
+	assert.Equal(t, []int{3, 4, 1, 5, 2}, sorted(entity.PostFilter{Sort: entity.PostSortTop}))
+	assert.Equal(t, []int{1, 4, 3}, sorted(entity.PostFilter{Sort: entity.PostSortHot})[:3])
+	assert.Equal(t, 5, sorted(entity.PostFilter{Sort: entity.PostSortControversial})[0])
+	assert.ElementsMatch(t, []int{1, 4, 5}, sorted(entity.PostFilter{Window: entity.PostWindowDay}))
+	assert.ElementsMatch(t, []int{1, 3, 4, 5}, sorted(entity.PostFilter{Sort: entity.PostSortTop, Window: entity.PostWindowMonth}))
+
+	// Удаление поста насовсем удаляет его реакции
+	require.NoError(t, postRepo.DeletePost(ctx, 1, 1))
+	_, err = NewTrashRepository(db, zap.NewNop()).PurgePosts(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	total, err = reactionRepo.GetTotalReactionsCount(ctx, entity.ReactionFilter{TargetType: entity.ReactionTargetPost, TargetID: 1})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	var counts int
+	require.NoError(t, db.Get(&counts, `SELECT COUNT(*) FROM reaction_counts WHERE target_type = 'post'`))
+	assert.Zero(t, counts)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestReactionRepository_AddReaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReactionRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT INTO reactions \(target_type, target_id, user_id, kind\) VALUES \(\?, \?, \?, \?\)\s+ON CONFLICT \(target_type, target_id, user_id, kind\) DO NOTHING`).
+		WithArgs("post", 7, 2, "up").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.AddReaction(context.Background(), entity.Reaction{TargetType: "post", TargetID: 7, UserID: 2, Kind: "up"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReactionRepository_GetReactions_ByKind(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReactionRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(`SELECT target_type, target_id, user_id, kind, created_at FROM reactions WHERE target_type = \? AND target_id = \? AND kind = \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`).
+		WithArgs("comment", 3, "🎉", 50, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"target_type", "target_id", "user_id", "kind", "created_at"}).
+			AddRow("comment", 3, 4, "🎉", createdAt))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reactions WHERE target_type = \? AND target_id = \? AND kind = \?`).
+		WithArgs("comment", 3, "🎉").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(1))
+
+	filter := entity.ReactionFilter{TargetType: "comment", TargetID: 3, Kind: "🎉", Limit: 50}
+	reactions, err := repo.GetReactions(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Reaction{{TargetType: "comment", TargetID: 3, UserID: 4, Kind: "🎉", CreatedAt: createdAt}}, reactions)
+
+	total, err := repo.GetTotalReactionsCount(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReactionRepository_GetVotes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewReactionRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectQuery(`SELECT upvotes, downvotes, .+rc.target_type = 'comment' AND rc.target_id = comments.id\) FROM comments WHERE id = \?`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"upvotes", "downvotes", "reactions"}).AddRow(2, 5, `{"😢":1}`))
+	mock.ExpectQuery(`FROM posts WHERE id = \?`).WithArgs(9).WillReturnError(sql.ErrNoRows)
+
+	votes, err := repo.GetVotes(context.Background(), entity.ReactionTargetComment, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.Votes{Upvotes: 2, Downvotes: 5, Score: -3, Reactions: entity.ReactionCounts{"😢": 1}}, votes)
+
+	_, err = repo.GetVotes(context.Background(), entity.ReactionTargetPost, 9)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = repo.GetVotes(context.Background(), "user", 1)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// SanctionRepository читает действующие санкции пользователей из
+// user_sanctions, которыми управляет auth_service. Санкции проверяются на
+// каждый запрос, поэтому блокировка и ее снятие действуют сразу.
+type SanctionRepository interface {
+	GetActiveSanctions(ctx context.Context, userID int) (entity.Sanctions, error)
+}
+
+type sanctionRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewSanctionRepository(db DB, logger *zap.Logger) SanctionRepository {
+	return &sanctionRepository{db: db, logger: logger}
+}
+
+func (r *sanctionRepository) GetActiveSanctions(ctx context.Context, userID int) (entity.Sanctions, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, type, reason, expires_at FROM user_sanctions
+		WHERE user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY id DESC`, userID, time.Now().UTC())
+	if err != nil {
+		r.logger.Error("Failed to get active sanctions", zap.Int("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sanctions entity.Sanctions
+	for rows.Next() {
+		var sanction entity.Sanction
+		if err := rows.Scan(&sanction.ID, &sanction.Type, &sanction.Reason, &sanction.ExpiresAt); err != nil {
+			r.logger.Error("Failed to scan sanction", zap.Int("userID", userID), zap.Error(err))
+			return nil, err
+		}
+		sanctions = append(sanctions, sanction)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Failed to read sanctions", zap.Int("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return sanctions, nil
+}
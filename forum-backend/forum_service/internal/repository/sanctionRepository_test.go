@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSanctionRepository_GetActiveSanctions_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sanctionRepo := NewSanctionRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+	expiresAt := time.Now().Add(time.Hour).UTC()
+
+	mock.ExpectQuery(`SELECT id, type, reason, expires_at FROM user_sanctions\s+WHERE user_id = \? AND revoked_at IS NULL AND \(expires_at IS NULL OR expires_at > \?\)`).
+		WithArgs(3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "reason", "expires_at"}).
+			AddRow(2, entity.SanctionMute, "флуд", expiresAt).
+			AddRow(1, entity.SanctionSuspension, "оскорбления", nil))
+
+	sanctions, err := sanctionRepo.GetActiveSanctions(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, sanctions, 2)
+	if assert.NotNil(t, sanctions[0].ExpiresAt) {
+		assert.True(t, expiresAt.Equal(*sanctions[0].ExpiresAt))
+	}
+	assert.Nil(t, sanctions[1].ExpiresAt)
+	assert.Equal(t, 1, sanctions.WriteBlock().ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSanctionRepository_GetActiveSanctions_Failure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sanctionRepo := NewSanctionRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectQuery(`SELECT id, type, reason, expires_at FROM user_sanctions`).
+		WithArgs(3, sqlmock.AnyArg()).
+		WillReturnError(errors.New("db error"))
+
+	sanctions, err := sanctionRepo.GetActiveSanctions(context.Background(), 3)
+
+	assert.Error(t, err)
+	assert.Nil(t, sanctions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// Границы совпадений в snippet и highlight. Управляющие символы не
+// встречаются в тексте постов, и их легко заменить после экранирования.
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// Вес заголовка поста в BM25 относительно текста.
+const postTitleWeight = 10.0
+
+// snippetTokens — длина фрагмента текста с совпадением, в словах.
+const snippetTokens = 24
+
+type SearchRepository interface {
+	// Search ищет по выражению FTS5 match и возвращает страницу результатов,
+	// отсортированную по релевантности.
+	Search(ctx context.Context, match string, filter entity.SearchFilter) ([]entity.SearchResult, error)
+	CountSearchResults(ctx context.Context, match string, filter entity.SearchFilter) (int, error)
+	// RebuildSearchIndex заново заполняет поисковый индекс из posts и comments.
+	RebuildSearchIndex(ctx context.Context) error
+}
+
+type searchRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewSearchRepository(db DB, logger *zap.Logger) SearchRepository {
+	return &searchRepository{db: db, logger: logger}
+}
+
+func (r *searchRepository) Search(ctx context.Context, match string, filter entity.SearchFilter) ([]entity.SearchResult, error) {
+	arms, args := searchArms(match, filter, true)
+	query := strings.Join(arms, " UNION ALL ") + ` ORDER BY rank ASC, created_at DESC LIMIT ? OFFSET ?`
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to search", zap.Error(err), zap.String("match", match))
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]entity.SearchResult, 0)
+	for rows.Next() {
+		var res entity.SearchResult
+		var rank float64
+		if err := rows.Scan(&res.Type, &res.ID, &res.PostID, &res.Title, &res.Snippet, &res.AuthorID, &res.CreatedAt, &rank); err != nil {
+			return nil, err
+		}
+		// bm25() в SQLite отрицательна: чем меньше, тем релевантнее
+		res.Score = -rank
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+func (r *searchRepository) CountSearchResults(ctx context.Context, match string, filter entity.SearchFilter) (int, error) {
+	arms, args := searchArms(match, filter, false)
+	query := `SELECT COUNT(*) FROM (` + strings.Join(arms, " UNION ALL ") + `)`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count search results", zap.Error(err), zap.String("match", match))
+	}
+	return count, err
+}
+
+// searchArms собирает по запросу на каждый тип результатов. Без columns
+// запросы возвращают только id — для подсчета. Скрытые по жалобам посты и
+// комментарии, а также комментарии к скрытым постам не ищутся.
+func searchArms(match string, filter entity.SearchFilter, columns bool) ([]string, []any) {
+	var arms []string
+	var args []any
+
+	if filter.Type == "" || filter.Type == entity.SearchTypePost {
+		sel := `SELECT p.id`
+		if columns {
+			sel = `SELECT 'post' AS type, p.id, p.id AS post_id,
+			       highlight(posts_fts, 0, char(2), char(3)) AS title,
+			       snippet(posts_fts, 1, char(2), char(3), '…', ` + strconv.Itoa(snippetTokens) + `) AS snippet,
+			       p.author_id, p.created_at AS created_at,
+			       bm25(posts_fts, ` + strconv.FormatFloat(postTitleWeight, 'f', 1, 64) + `, 1.0) AS rank`
+		}
+		where, whereArgs := searchConditions("p", filter)
+		arms = append(arms, sel+`
+			FROM posts_fts JOIN posts p ON p.id = posts_fts.rowid
+			WHERE posts_fts MATCH ? AND p.deleted_at IS NULL AND p.hidden_at IS NULL`+where)
+		args = append(append(args, match), whereArgs...)
+	}
+
+	if filter.Type == "" || filter.Type == entity.SearchTypeComment {
+		sel := `SELECT c.id`
+		if columns {
+			sel = `SELECT 'comment' AS type, c.id, c.post_id,
+			       COALESCE(p.title, '') AS title,
+			       snippet(comments_fts, 0, char(2), char(3), '…', ` + strconv.Itoa(snippetTokens) + `) AS snippet,
+			       c.author_id, c.created_at AS created_at,
+			       bm25(comments_fts) AS rank`
+		}
+		where, whereArgs := searchConditions("c", filter)
+		arms = append(arms, sel+`
+			FROM comments_fts JOIN comments c ON c.id = comments_fts.rowid
+			LEFT JOIN posts p ON p.id = c.post_id
+			WHERE comments_fts MATCH ? AND c.deleted_at IS NULL AND c.hidden_at IS NULL AND p.deleted_at IS NULL AND p.hidden_at IS NULL`+where)
+		args = append(append(args, match), whereArgs...)
+	}
+	return arms, args
+}
+
+func searchConditions(alias string, filter entity.SearchFilter) (string, []any) {
+	var where strings.Builder
+	var args []any
+	if filter.AuthorID != 0 {
+		where.WriteString(` AND ` + alias + `.author_id = ?`)
+		args = append(args, filter.AuthorID)
+	}
+	// created_at хранится строкой CURRENT_TIMESTAMP в UTC
+	if filter.From != nil {
+		where.WriteString(` AND ` + alias + `.created_at >= ?`)
+		args = append(args, filter.From.UTC().Format(time.DateTime))
+	}
+	if filter.To != nil {
+		where.WriteString(` AND ` + alias + `.created_at < ?`)
+		args = append(args, filter.To.UTC().Format(time.DateTime))
+	}
+	// Раздел берется у поста: для комментариев это пост, к которому они оставлены
+	if len(filter.HiddenCategoryIDs) > 0 {
+		placeholders := make([]string, len(filter.HiddenCategoryIDs))
+		for i, id := range filter.HiddenCategoryIDs {
+			placeholders[i] = "?"
+			args = append(args, id)
+		}
+		where.WriteString(` AND COALESCE(p.category_id, 0) NOT IN (` + strings.Join(placeholders, ", ") + `)`)
+	}
+	return where.String(), args
+}
+
+// rebuildSearchIndexQuery повторяет нормализацию текста из триггеров
+// миграции 009_create_search_index.
+const rebuildSearchIndexQuery = `
+	DELETE FROM posts_fts;
+	INSERT INTO posts_fts (rowid, title, content)
+	SELECT id, replace(replace(title, 'ё', 'е'), 'Ё', 'Е'), replace(replace(content, 'ё', 'е'), 'Ё', 'Е')
+	FROM posts;
+	INSERT INTO posts_fts (posts_fts) VALUES ('optimize');
+
+	DELETE FROM comments_fts;
+	INSERT INTO comments_fts (rowid, content)
+	SELECT id, replace(replace(content, 'ё', 'е'), 'Ё', 'Е')
+	FROM comments
+	WHERE deleted_at IS NULL;
+	INSERT INTO comments_fts (comments_fts) VALUES ('optimize');
+`
+
+// RebuildSearchIndex пересобирает индекс в одной транзакции: при сбое
+// остается прежний индекс, а поиск не видит его пустым.
+func (r *searchRepository) RebuildSearchIndex(ctx context.Context) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Failed to begin search index rebuild", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, rebuildSearchIndexQuery); err != nil {
+		r.logger.Error("Failed to rebuild search index", zap.Error(err))
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit search index rebuild", zap.Error(err))
+		return err
+	}
+	r.logger.Info("Search index rebuilt")
+	return nil
+}
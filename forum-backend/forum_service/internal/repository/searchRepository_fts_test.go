@@ -0,0 +1,113 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Запуск: go test -tags sqlite_fts5 ./internal/repository/
+func newSearchTestDB(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Glob("../../../auth_service/migrations/*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for _, migration := range migrations {
+		schema, err := os.ReadFile(migration)
+		require.NoError(t, err)
+		_, err = db.Exec(string(schema))
+		require.NoError(t, err, migration)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO users (id, username, password, role) VALUES (1, 'alice', 'x', 'user'), (2, 'bob', 'x', 'user');
+		INSERT INTO posts (id, author_id, title, content) VALUES
+			(1, 1, 'Ёлки в лесу', 'Зимой ёлки стоят в снегу'),
+			(2, 2, 'Running tests', 'How to run Go tests quickly');
+		INSERT INTO comments (id, post_id, author_id, content) VALUES
+			(1, 1, 2, 'Красивые елки'),
+			(2, 2, 1, 'I ran the tests <b>twice</b>');
+	`)
+	require.NoError(t, err)
+	return db
+}
+
+func searchFTS(t *testing.T, repo SearchRepository, query string, filter entity.SearchFilter) []entity.SearchResult {
+	match, err := search.ParseQuery(query)
+	require.NoError(t, err)
+	filter.Limit = 10
+	results, err := repo.Search(context.Background(), match, filter)
+	require.NoError(t, err)
+	count, err := repo.CountSearchResults(context.Background(), match, filter)
+	require.NoError(t, err)
+	assert.Equal(t, len(results), count)
+	return results
+}
+
+func TestSearchRepository_FTS(t *testing.T) {
+	db := newSearchTestDB(t)
+	repo := NewSearchRepository(db, zap.NewNop())
+
+	// ё и е совпадают, окончания не учитываются, заголовок весит больше
+	results := searchFTS(t, repo, "ёлками", entity.SearchFilter{})
+	require.Len(t, results, 2)
+	assert.Equal(t, entity.SearchTypePost, results[0].Type)
+	assert.Equal(t, "\x02Елки\x03 в лесу", results[0].Title)
+	assert.Equal(t, entity.SearchTypeComment, results[1].Type)
+	assert.Equal(t, 1, results[1].PostID)
+	assert.Equal(t, "Красивые \x02елки\x03", results[1].Snippet)
+	assert.Greater(t, results[0].Score, results[1].Score)
+
+	// Английские слова приводятся к основе: test находит tests
+	results = searchFTS(t, repo, "test", entity.SearchFilter{Type: entity.SearchTypeComment, AuthorID: 1})
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].ID)
+
+	assert.Len(t, searchFTS(t, repo, `"run go"`, entity.SearchFilter{}), 1)
+	assert.Len(t, searchFTS(t, repo, `"go run"`, entity.SearchFilter{}), 0)
+	assert.Len(t, searchFTS(t, repo, "quick*", entity.SearchFilter{}), 1)
+	assert.Len(t, searchFTS(t, repo, "tests OR елки", entity.SearchFilter{}), 0)
+
+	// Триггеры: правка, удаление комментария и поста
+	_, err := db.Exec(`UPDATE posts SET title = 'Сосны', content = 'Сосны в лесу' WHERE id = 1`)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE comments SET deleted_at = CURRENT_TIMESTAMP WHERE id = 1`)
+	require.NoError(t, err)
+	assert.Empty(t, searchFTS(t, repo, "елки", entity.SearchFilter{}))
+	assert.Len(t, searchFTS(t, repo, "сосна", entity.SearchFilter{}), 1)
+
+	_, err = db.Exec(`DELETE FROM posts WHERE id = 2`)
+	require.NoError(t, err)
+	assert.Empty(t, searchFTS(t, repo, "quickly", entity.SearchFilter{}))
+}
+
+func TestSearchRepository_RebuildSearchIndex_FTS(t *testing.T) {
+	db := newSearchTestDB(t)
+	repo := NewSearchRepository(db, zap.NewNop())
+
+	_, err := db.Exec(`DELETE FROM posts_fts; DELETE FROM comments_fts`)
+	require.NoError(t, err)
+	assert.Empty(t, searchFTS(t, repo, "елки", entity.SearchFilter{}))
+
+	require.NoError(t, repo.RebuildSearchIndex(context.Background()))
+
+	results := searchFTS(t, repo, "елки", entity.SearchFilter{})
+	require.Len(t, results, 2)
+	assert.True(t, strings.Contains(results[0].Title, "Елки"))
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSearchRepository_Search_PostsByAuthor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSearchRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+	from := time.Date(2024, 1, 1, 3, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	filter := entity.SearchFilter{Type: entity.SearchTypePost, AuthorID: 2, From: &from, Limit: 10, Offset: 20}
+	createdAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"type", "id", "post_id", "title", "snippet", "author_id", "created_at", "rank"}).
+		AddRow("post", 1, 1, "\x02Go\x03", "про \x02go\x03", 2, createdAt, -3.5)
+	mock.ExpectQuery(`FROM posts_fts JOIN posts p .* WHERE posts_fts MATCH \? AND p.deleted_at IS NULL AND p.hidden_at IS NULL AND p.author_id = \? AND p.created_at >= \? ORDER BY rank ASC, created_at DESC LIMIT \? OFFSET \?`).
+		WithArgs(`"go"`, 2, "2024-01-01 00:00:00", 10, 20).
+		WillReturnRows(rows)
+
+	results, err := repo.Search(context.Background(), `"go"`, filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.SearchResult{{
+		Type: "post", ID: 1, PostID: 1, Title: "\x02Go\x03", Snippet: "про \x02go\x03",
+		AuthorID: 2, CreatedAt: createdAt, Score: 3.5,
+	}}, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_CountSearchResults_AllTypes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSearchRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM \(SELECT p.id .* UNION ALL SELECT c.id .* c.deleted_at IS NULL AND c.hidden_at IS NULL AND p.deleted_at IS NULL AND p.hidden_at IS NULL\)`).
+		WithArgs(`"go"`, `"go"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.CountSearchResults(context.Background(), `"go"`, entity.SearchFilter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_RebuildSearchIndex_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSearchRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM posts_fts`).WillReturnError(errors.New("no such table: posts_fts"))
+	mock.ExpectRollback()
+
+	err = repo.RebuildSearchIndex(context.Background())
+
+	assert.EqualError(t, err, "no such table: posts_fts")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type TagRepository interface {
+	// GetTags возвращает метки с количеством постов, популярные первыми.
+	GetTags(ctx context.Context, filter entity.TagFilter) ([]entity.Tag, error)
+	GetTotalTagsCount(ctx context.Context, filter entity.TagFilter) (int, error)
+	// GetTagByName возвращает метку вместе с синонимами. Для несуществующей
+	// метки возвращает sql.ErrNoRows.
+	GetTagByName(ctx context.Context, name string, hiddenCategoryIDs []int) (entity.Tag, error)
+	// ResolveAliases возвращает основную метку для каждого из names, который
+	// является синонимом.
+	ResolveAliases(ctx context.Context, names []string) (map[string]string, error)
+	// SetPostTags заменяет метки поста на names, создавая недостающие метки.
+	SetPostTags(ctx context.Context, postID int, names []string) error
+	// GetPostTags возвращает метки постов по их id.
+	GetPostTags(ctx context.Context, postIDs []int) (map[int][]string, error)
+	RenameTag(ctx context.Context, id int, name string) error
+	// MergeTags переносит посты и синонимы source в target, делает имя
+	// source синонимом target и удаляет source.
+	MergeTags(ctx context.Context, sourceID, targetID int) error
+	DeleteTag(ctx context.Context, id int) error
+	AddTagAlias(ctx context.Context, tagID int, alias string) error
+	// DeleteTagAlias для несуществующего синонима возвращает sql.ErrNoRows.
+	DeleteTagAlias(ctx context.Context, tagID int, alias string) error
+}
+
+type tagRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewTagRepository(db DB, logger *zap.Logger) TagRepository {
+	return &tagRepository{db: db, logger: logger}
+}
+
+// inPlaceholders возвращает "?, ?, ..." для n параметров.
+func inPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func stringArgs(values []string) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// tagPostsJoin присоединяет к меткам посты, которые учитываются в
+// posts_count: удаленные посты и посты из скрытых разделов не считаются.
+func tagPostsJoin(hiddenCategoryIDs []int) (string, []interface{}) {
+	join := ` LEFT JOIN post_tags pt ON pt.tag_id = t.id LEFT JOIN posts p ON p.id = pt.post_id AND p.deleted_at IS NULL`
+	if len(hiddenCategoryIDs) == 0 {
+		return join, nil
+	}
+	args := make([]interface{}, len(hiddenCategoryIDs))
+	for i, id := range hiddenCategoryIDs {
+		args[i] = id
+	}
+	return join + ` AND p.category_id NOT IN (` + inPlaceholders(len(hiddenCategoryIDs)) + `)`, args
+}
+
+func tagPrefixCondition(prefix string) (string, []interface{}) {
+	if prefix == "" {
+		return "", nil
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	return ` WHERE t.name LIKE ? ESCAPE '\'`, []interface{}{escaped + "%"}
+}
+
+func (r *tagRepository) GetTags(ctx context.Context, filter entity.TagFilter) ([]entity.Tag, error) {
+	join, args := tagPostsJoin(filter.HiddenCategoryIDs)
+	where, whereArgs := tagPrefixCondition(filter.Prefix)
+	query := `SELECT t.id, t.name, t.created_at, COUNT(p.id) AS posts_count FROM tags t` + join + where +
+		` GROUP BY t.id ORDER BY posts_count DESC, t.name LIMIT ? OFFSET ?`
+	args = append(append(args, whereArgs...), filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to get tags", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]entity.Tag, 0)
+	for rows.Next() {
+		var tag entity.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.PostsCount); err != nil {
+			r.logger.Error("Failed to scan tag", zap.Error(err))
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *tagRepository) GetTotalTagsCount(ctx context.Context, filter entity.TagFilter) (int, error) {
+	where, args := tagPrefixCondition(filter.Prefix)
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM tags t`+where, args...).Scan(&count)
+	return count, err
+}
+
+func (r *tagRepository) GetTagByName(ctx context.Context, name string, hiddenCategoryIDs []int) (entity.Tag, error) {
+	join, args := tagPostsJoin(hiddenCategoryIDs)
+	query := `SELECT t.id, t.name, t.created_at, COUNT(p.id) FROM tags t` + join + ` WHERE t.name = ? GROUP BY t.id`
+
+	var tag entity.Tag
+	err := r.db.QueryRowContext(ctx, query, append(args, name)...).Scan(&tag.ID, &tag.Name, &tag.CreatedAt, &tag.PostsCount)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to get tag", zap.Error(err), zap.String("tag", name))
+		}
+		return entity.Tag{}, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT alias FROM tag_aliases WHERE tag_id = ? ORDER BY alias`, tag.ID)
+	if err != nil {
+		r.logger.Error("Failed to get tag aliases", zap.Error(err), zap.Int("tagID", tag.ID))
+		return entity.Tag{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return entity.Tag{}, err
+		}
+		tag.Aliases = append(tag.Aliases, alias)
+	}
+	return tag, rows.Err()
+}
+
+func (r *tagRepository) ResolveAliases(ctx context.Context, names []string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	if len(names) == 0 {
+		return resolved, nil
+	}
+	query := `SELECT a.alias, t.name FROM tag_aliases a JOIN tags t ON t.id = a.tag_id WHERE a.alias IN (` + inPlaceholders(len(names)) + `)`
+	rows, err := r.db.QueryContext(ctx, query, stringArgs(names)...)
+	if err != nil {
+		r.logger.Error("Failed to resolve tag aliases", zap.Error(err), zap.Strings("tags", names))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias, name string
+		if err := rows.Scan(&alias, &name); err != nil {
+			return nil, err
+		}
+		resolved[alias] = name
+	}
+	return resolved, rows.Err()
+}
+
+// SetPostTags выполняется без транзакции, но каждый шаг можно повторить:
+// при сбое повторный вызов с теми же names приведет метки поста к нужным.
+func (r *tagRepository) SetPostTags(ctx context.Context, postID int, names []string) error {
+	if len(names) == 0 {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM post_tags WHERE post_id = ?`, postID); err != nil {
+			r.logger.Error("Failed to clear post tags", zap.Error(err), zap.Int("postID", postID))
+			return err
+		}
+		return nil
+	}
+
+	in := inPlaceholders(len(names))
+	values := strings.TrimSuffix(strings.Repeat("(?), ", len(names)), ", ")
+	nameArgs := stringArgs(names)
+	steps := []struct {
+		query string
+		args  []interface{}
+	}{
+		{`INSERT OR IGNORE INTO tags (name) VALUES ` + values, nameArgs},
+		{`DELETE FROM post_tags WHERE post_id = ? AND tag_id NOT IN (SELECT id FROM tags WHERE name IN (` + in + `))`, append([]interface{}{postID}, nameArgs...)},
+		{`INSERT OR IGNORE INTO post_tags (post_id, tag_id) SELECT ?, id FROM tags WHERE name IN (` + in + `)`, append([]interface{}{postID}, nameArgs...)},
+	}
+	for _, step := range steps {
+		if _, err := r.db.ExecContext(ctx, step.query, step.args...); err != nil {
+			r.logger.Error("Failed to set post tags", zap.Error(err), zap.Int("postID", postID), zap.Strings("tags", names))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *tagRepository) GetPostTags(ctx context.Context, postIDs []int) (map[int][]string, error) {
+	tags := make(map[int][]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return tags, nil
+	}
+	args := make([]interface{}, len(postIDs))
+	for i, id := range postIDs {
+		args[i] = id
+	}
+	query := `SELECT pt.post_id, t.name FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.post_id IN (` + inPlaceholders(len(postIDs)) + `) ORDER BY pt.post_id, t.name`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		r.logger.Error("Failed to get post tags", zap.Error(err), zap.Ints("postIDs", postIDs))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var postID int
+		var name string
+		if err := rows.Scan(&postID, &name); err != nil {
+			return nil, err
+		}
+		tags[postID] = append(tags[postID], name)
+	}
+	return tags, rows.Err()
+}
+
+func (r *tagRepository) RenameTag(ctx context.Context, id int, name string) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE tags SET name = ? WHERE id = ?`, name, id); err != nil {
+		r.logger.Error("Failed to rename tag", zap.Error(err), zap.Int("tagID", id), zap.String("name", name))
+		return err
+	}
+	r.logger.Info("Tag renamed", zap.Int("tagID", id), zap.String("name", name))
+	return nil
+}
+
+// MergeTags, как и MergeCategory, можно повторить после сбоя.
+func (r *tagRepository) MergeTags(ctx context.Context, sourceID, targetID int) error {
+	steps := []string{
+		`INSERT OR IGNORE INTO post_tags (post_id, tag_id) SELECT post_id, ? FROM post_tags WHERE tag_id = ?`,
+		`UPDATE tag_aliases SET tag_id = ? WHERE tag_id = ?`,
+		`INSERT OR IGNORE INTO tag_aliases (alias, tag_id) SELECT name, ? FROM tags WHERE id = ?`,
+	}
+	for _, query := range steps {
+		if _, err := r.db.ExecContext(ctx, query, targetID, sourceID); err != nil {
+			r.logger.Error("Failed to merge tags", zap.Error(err), zap.Int("sourceID", sourceID), zap.Int("targetID", targetID))
+			return err
+		}
+	}
+	if err := r.DeleteTag(ctx, sourceID); err != nil {
+		return err
+	}
+	r.logger.Info("Tags merged", zap.Int("sourceID", sourceID), zap.Int("targetID", targetID))
+	return nil
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, id int) error {
+	steps := []string{
+		`DELETE FROM post_tags WHERE tag_id = ?`,
+		`DELETE FROM tag_aliases WHERE tag_id = ?`,
+		`DELETE FROM tags WHERE id = ?`,
+	}
+	for _, query := range steps {
+		if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+			r.logger.Error("Failed to delete tag", zap.Error(err), zap.Int("tagID", id))
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *tagRepository) AddTagAlias(ctx context.Context, tagID int, alias string) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO tag_aliases (alias, tag_id) VALUES (?, ?)`, alias, tagID)
+	if err != nil {
+		r.logger.Error("Failed to add tag alias", zap.Error(err), zap.Int("tagID", tagID), zap.String("alias", alias))
+		return err
+	}
+	return nil
+}
+
+func (r *tagRepository) DeleteTagAlias(ctx context.Context, tagID int, alias string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tag_aliases WHERE alias = ? AND tag_id = ?`, alias, tagID)
+	if err != nil {
+		r.logger.Error("Failed to delete tag alias", zap.Error(err), zap.Int("tagID", tagID), zap.String("alias", alias))
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
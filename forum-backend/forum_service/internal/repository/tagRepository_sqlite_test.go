@@ -0,0 +1,89 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func postIDs(t *testing.T, repo PostRepository, filter entity.PostFilter) []int {
+	filter.Limit = 10
+	posts, err := repo.GetPosts(context.Background(), filter)
+	require.NoError(t, err)
+	count, err := repo.GetTotalPostsCount(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, len(posts), count)
+
+	ids := make([]int, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+func TestTagRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	tagRepo := NewTagRepository(db, zap.NewNop())
+	postRepo := NewPostRepository(db, zap.NewNop())
+
+	require.NoError(t, tagRepo.SetPostTags(ctx, 1, []string{"go", "grpc"}))
+	require.NoError(t, tagRepo.SetPostTags(ctx, 2, []string{"golang"}))
+	// Повторный вызов ничего не меняет, лишние метки снимаются
+	require.NoError(t, tagRepo.SetPostTags(ctx, 1, []string{"go", "grpc", "sql"}))
+	require.NoError(t, tagRepo.SetPostTags(ctx, 1, []string{"go", "grpc"}))
+
+	tags, err := tagRepo.GetPostTags(ctx, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int][]string{1: {"go", "grpc"}, 2: {"golang"}}, tags)
+
+	assert.ElementsMatch(t, []int{1}, postIDs(t, postRepo, entity.PostFilter{Tags: []string{"go", "grpc"}}))
+	assert.ElementsMatch(t, []int{1, 2}, postIDs(t, postRepo, entity.PostFilter{Tags: []string{"go", "golang"}, TagMode: entity.TagModeAny}))
+	assert.Empty(t, postIDs(t, postRepo, entity.PostFilter{Tags: []string{"go", "golang"}}))
+
+	// Слияние переносит посты и оставляет имя синонимом
+	golang, err := tagRepo.GetTagByName(ctx, "golang", nil)
+	require.NoError(t, err)
+	goTag, err := tagRepo.GetTagByName(ctx, "go", nil)
+	require.NoError(t, err)
+	require.NoError(t, tagRepo.MergeTags(ctx, golang.ID, goTag.ID))
+	require.NoError(t, tagRepo.MergeTags(ctx, golang.ID, goTag.ID))
+
+	goTag, err = tagRepo.GetTagByName(ctx, "go", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, goTag.PostsCount)
+	assert.Equal(t, []string{"golang"}, goTag.Aliases)
+	resolved, err := tagRepo.ResolveAliases(ctx, []string{"golang", "grpc"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"golang": "go"}, resolved)
+
+	// Посты скрытого раздела не учитываются в количестве
+	_, err = db.Exec(`UPDATE posts SET category_id = 2 WHERE id = 2`)
+	require.NoError(t, err)
+	list, err := tagRepo.GetTags(ctx, entity.TagFilter{HiddenCategoryIDs: []int{2}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, "go", list[0].Name)
+	assert.Equal(t, 1, list[0].PostsCount)
+	assert.Equal(t, "sql", list[2].Name)
+	assert.Equal(t, 0, list[2].PostsCount)
+
+	list, err = tagRepo.GetTags(ctx, entity.TagFilter{Prefix: "gr", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "grpc", list[0].Name)
+
+	require.NoError(t, tagRepo.DeleteTag(ctx, goTag.ID))
+	tags, err = tagRepo.GetPostTags(ctx, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[int][]string{1: {"grpc"}}, tags)
+	resolved, err = tagRepo.ResolveAliases(ctx, []string{"golang"})
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+}
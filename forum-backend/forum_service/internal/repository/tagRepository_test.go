@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTagRepository_SetPostTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTagRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT OR IGNORE INTO tags \(name\) VALUES \(\?\), \(\?\)`).
+		WithArgs("go", "grpc").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM post_tags WHERE post_id = \? AND tag_id NOT IN \(SELECT id FROM tags WHERE name IN \(\?, \?\)\)`).
+		WithArgs(7, "go", "grpc").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT OR IGNORE INTO post_tags \(post_id, tag_id\) SELECT \?, id FROM tags WHERE name IN \(\?, \?\)`).
+		WithArgs(7, "go", "grpc").WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.SetPostTags(context.Background(), 7, []string{"go", "grpc"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepository_SetPostTags_Clear(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTagRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`DELETE FROM post_tags WHERE post_id = \?`).
+		WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.SetPostTags(context.Background(), 7, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepository_MergeTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTagRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT OR IGNORE INTO post_tags \(post_id, tag_id\) SELECT post_id, \? FROM post_tags WHERE tag_id = \?`).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`UPDATE tag_aliases SET tag_id = \? WHERE tag_id = \?`).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT OR IGNORE INTO tag_aliases \(alias, tag_id\) SELECT name, \? FROM tags WHERE id = \?`).
+		WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM post_tags WHERE tag_id = \?`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`DELETE FROM tag_aliases WHERE tag_id = \?`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM tags WHERE id = \?`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.MergeTags(context.Background(), 2, 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTagRepository_DeleteTagAlias_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTagRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`DELETE FROM tag_aliases WHERE alias = \? AND tag_id = \?`).
+		WithArgs("golang", 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteTagAlias(context.Background(), 1, "golang")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
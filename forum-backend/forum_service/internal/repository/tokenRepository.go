@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"go.uber.org/zap"
+)
+
+// TokenRepository читает таблицу tokens, которую ведет auth_service,
+// чтобы отклонять токены, отозванные через logout.
+type TokenRepository interface {
+	// IsTokenRevoked считает отозванным и токен, которого нет в tokens:
+	// auth_service сохраняет каждый выданный токен.
+	IsTokenRevoked(ctx context.Context, token string) (bool, error)
+}
+
+type tokenRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewTokenRepository(db DB, logger *zap.Logger) TokenRepository {
+	return &tokenRepository{db: db, logger: logger}
+}
+
+func (r *tokenRepository) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `SELECT revoked_at IS NOT NULL FROM tokens WHERE token = ?`, token).Scan(&revoked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return true, nil
+		}
+		r.logger.Error("Failed to check token revocation", zap.Error(err))
+		return false, err
+	}
+	return revoked, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTokenRepository_IsTokenRevoked_Revoked(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tokenRepo := NewTokenRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`SELECT revoked_at IS NOT NULL FROM tokens WHERE token = \?`).
+		WithArgs("test-token").
+		WillReturnRows(sqlmock.NewRows([]string{"revoked"}).AddRow(true))
+
+	revoked, err := tokenRepo.IsTokenRevoked(context.Background(), "test-token")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRepository_IsTokenRevoked_UnknownToken(t *testing.T) {
+	logger, _ := zap.NewProduction()
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tokenRepo := NewTokenRepository(&adapters.DbAdapter{DB: db}, logger)
+
+	mock.ExpectQuery(`SELECT revoked_at IS NOT NULL FROM tokens WHERE token = \?`).
+		WithArgs("test-token").
+		WillReturnRows(sqlmock.NewRows([]string{"revoked"}))
+
+	revoked, err := tokenRepo.IsTokenRevoked(context.Background(), "test-token")
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// TrashRepository — корзина удаленных постов и комментариев. Сами посты и
+// комментарии переносит в корзину DeletePost и DeleteComment.
+type TrashRepository interface {
+	// GetDeletedPosts возвращает посты корзины, начиная с удаленных последними.
+	GetDeletedPosts(ctx context.Context, limit, offset int) ([]entity.TrashedPost, error)
+	GetTotalDeletedPosts(ctx context.Context) (int, error)
+	// GetDeletedPost возвращает пост корзины. Для поста, которого нет в
+	// корзине, возвращает sql.ErrNoRows.
+	GetDeletedPost(ctx context.Context, id int) (entity.TrashedPost, error)
+	// GetDeletedComments возвращает комментарии корзины к неудаленным постам,
+	// начиная с удаленных последними.
+	GetDeletedComments(ctx context.Context, limit, offset int) ([]entity.TrashedComment, error)
+	GetTotalDeletedComments(ctx context.Context) (int, error)
+	// GetDeletedComment возвращает комментарий корзины. Для комментария,
+	// которого нет в корзине или чей пост удален, возвращает sql.ErrNoRows.
+	GetDeletedComment(ctx context.Context, id int) (entity.TrashedComment, error)
+	// RestorePost и RestoreComment возвращают пост или комментарий из
+	// корзины. Для того, чего там нет, возвращают sql.ErrNoRows.
+	RestorePost(ctx context.Context, id int) error
+	RestoreComment(ctx context.Context, id int) error
+	// PurgePosts удаляет насовсем посты, удаленные раньше before, вместе с
+	// их комментариями и остальными зависимыми строками и возвращает число
+	// удаленных постов.
+	PurgePosts(ctx context.Context, before time.Time) (int, error)
+	// PurgeComments удаляет насовсем комментарии, удаленные раньше before.
+	// Комментарий, под которым остались ответы, ждет, пока не будут удалены
+	// они.
+	PurgeComments(ctx context.Context, before time.Time) (int, error)
+}
+
+type trashRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewTrashRepository(db DB, logger *zap.Logger) TrashRepository {
+	return &trashRepository{db: db, logger: logger}
+}
+
+// trashedPostColumns — колонки поста в порядке, который ожидает scanTrashedPost.
+const trashedPostColumns = `p.id, p.author_id, COALESCE(p.title, ''), COALESCE(p.content, ''), p.category_id, p.created_at,
+	(SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.deleted_at IS NULL),
+	p.deleted_at, p.deleted_by`
+
+func scanTrashedPost(row rowScanner, post *entity.TrashedPost) error {
+	return row.Scan(
+		&post.ID,
+		&post.AuthorId,
+		&post.Title,
+		&post.Content,
+		&post.CategoryID,
+		&post.CreatedAt,
+		&post.CommentsCount,
+		&post.DeletedAt,
+		&post.DeletedBy,
+	)
+}
+
+// trashedCommentColumns — колонки комментария в порядке, который ожидает
+// scanTrashedComment. Запрос должен присоединять пост как p.
+const trashedCommentColumns = `c.id, c.author_id, c.post_id, COALESCE(p.title, ''), c.parent_id, COALESCE(c.content, ''), c.created_at,
+	c.deleted_at, c.deleted_by`
+
+func scanTrashedComment(row rowScanner, comment *entity.TrashedComment) error {
+	return row.Scan(
+		&comment.ID,
+		&comment.AuthorId,
+		&comment.PostId,
+		&comment.PostTitle,
+		&comment.ParentId,
+		&comment.Content,
+		&comment.CreatedAt,
+		&comment.DeletedAt,
+		&comment.DeletedBy,
+	)
+}
+
+// trashedComments — условие комментариев корзины: пост комментария не удален.
+const trashedComments = `FROM comments c JOIN posts p ON p.id = c.post_id
+	WHERE c.deleted_at IS NOT NULL AND p.deleted_at IS NULL`
+
+func (r *trashRepository) GetDeletedPosts(ctx context.Context, limit, offset int) ([]entity.TrashedPost, error) {
+	query := `SELECT ` + trashedPostColumns + ` FROM posts p WHERE p.deleted_at IS NOT NULL
+		ORDER BY p.deleted_at DESC, p.id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		r.logger.Error("Failed to get deleted posts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []entity.TrashedPost
+	for rows.Next() {
+		var post entity.TrashedPost
+		if err := scanTrashedPost(rows, &post); err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+func (r *trashRepository) GetTotalDeletedPosts(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM posts WHERE deleted_at IS NOT NULL`).Scan(&count)
+	return count, err
+}
+
+func (r *trashRepository) GetDeletedPost(ctx context.Context, id int) (entity.TrashedPost, error) {
+	query := `SELECT ` + trashedPostColumns + ` FROM posts p WHERE p.id = ? AND p.deleted_at IS NOT NULL`
+	var post entity.TrashedPost
+	if err := scanTrashedPost(r.db.QueryRowContext(ctx, query, id), &post); err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to get deleted post", zap.Error(err), zap.Int("postID", id))
+		}
+		return entity.TrashedPost{}, err
+	}
+	return post, nil
+}
+
+func (r *trashRepository) GetDeletedComments(ctx context.Context, limit, offset int) ([]entity.TrashedComment, error) {
+	query := `SELECT ` + trashedCommentColumns + ` ` + trashedComments + `
+		ORDER BY c.deleted_at DESC, c.id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		r.logger.Error("Failed to get deleted comments", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []entity.TrashedComment
+	for rows.Next() {
+		var comment entity.TrashedComment
+		if err := scanTrashedComment(rows, &comment); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *trashRepository) GetTotalDeletedComments(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+trashedComments).Scan(&count)
+	return count, err
+}
+
+func (r *trashRepository) GetDeletedComment(ctx context.Context, id int) (entity.TrashedComment, error) {
+	query := `SELECT ` + trashedCommentColumns + ` ` + trashedComments + ` AND c.id = ?`
+	var comment entity.TrashedComment
+	if err := scanTrashedComment(r.db.QueryRowContext(ctx, query, id), &comment); err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to get deleted comment", zap.Error(err), zap.Int("commentID", id))
+		}
+		return entity.TrashedComment{}, err
+	}
+	return comment, nil
+}
+
+// restoredHidden снимает с восстановленной цели скрытие по жалобам, если
+// по ней нет нерешенного дела: дело, решенное удалением, скрытие не
+// снимало.
+func restoredHidden(targetType, table string) string {
+	return `hidden_at = CASE WHEN EXISTS (
+		SELECT 1 FROM moderation_cases mc
+		WHERE mc.target_type = '` + targetType + `' AND mc.target_id = ` + table + `.id AND mc.status != 'resolved'
+	) THEN hidden_at END`
+}
+
+func (r *trashRepository) RestorePost(ctx context.Context, id int) error {
+	query := `UPDATE posts SET deleted_at = NULL, deleted_by = NULL, ` + restoredHidden(entity.ReactionTargetPost, "posts") + `
+		WHERE id = ? AND deleted_at IS NOT NULL`
+	if err := r.execAffecting(ctx, query, id); err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to restore post", zap.Error(err), zap.Int("postID", id))
+		}
+		return err
+	}
+	r.logger.Info("Post restored from trash", zap.Int("postID", id))
+	return nil
+}
+
+// RestoreComment не трогает удаленных предков комментария: пока под ними
+// есть неудаленные ответы, они показываются заглушкой.
+func (r *trashRepository) RestoreComment(ctx context.Context, id int) error {
+	query := `
+		UPDATE comments SET deleted_at = NULL, deleted_by = NULL, ` + restoredHidden(entity.ReactionTargetComment, "comments") + `
+		WHERE id = ? AND deleted_at IS NOT NULL
+		  AND post_id IN (SELECT id FROM posts WHERE deleted_at IS NULL)
+	`
+	if err := r.execAffecting(ctx, query, id); err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to restore comment", zap.Error(err), zap.Int("commentID", id))
+		}
+		return err
+	}
+	r.logger.Info("Comment restored from trash", zap.Int("commentID", id))
+	return nil
+}
+
+// execAffecting выполняет запрос и возвращает sql.ErrNoRows, если он не
+// изменил ни одной строки.
+func (r *trashRepository) execAffecting(ctx context.Context, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// deletedBefore — граница очистки в формате, в котором CURRENT_TIMESTAMP
+// записывает deleted_at.
+func deletedBefore(before time.Time) string {
+	return before.UTC().Format(time.DateTime)
+}
+
+// PurgePosts удаляет посты одним запросом. Комментарии, правки, теги,
+// комната обсуждения и нерешенные дела модерации поста удаляются
+// триггерами миграции 023 в той же транзакции: внешние ключи в SQLite по
+// умолчанию не проверяются, и ON DELETE CASCADE не срабатывает.
+func (r *trashRepository) PurgePosts(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM posts WHERE deleted_at < ?`, deletedBefore(before))
+	if err != nil {
+		r.logger.Error("Failed to purge deleted posts", zap.Error(err))
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+// PurgeComments удаляет комментарии одним запросом: комментарий удаляется,
+// только если удалены раньше before и все его ответы, поэтому удаленный
+// предок живого или свежего ответа остается. Зависимые строки удаляют
+// триггеры миграции 023.
+func (r *trashRepository) PurgeComments(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		WITH RECURSIVE kept(id) AS (
+			SELECT id FROM comments WHERE deleted_at IS NULL OR deleted_at >= ?
+			UNION
+			SELECT c.parent_id FROM comments c JOIN kept k ON c.id = k.id WHERE c.parent_id IS NOT NULL
+		)
+		DELETE FROM comments
+		WHERE deleted_at < ? AND id NOT IN (SELECT id FROM kept)
+	`
+	cutoff := deletedBefore(before)
+	result, err := r.db.ExecContext(ctx, query, cutoff, cutoff)
+	if err != nil {
+		r.logger.Error("Failed to purge deleted comments", zap.Error(err))
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
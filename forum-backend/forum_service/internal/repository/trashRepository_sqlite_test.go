@@ -0,0 +1,181 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func commentIDs(t *testing.T, repo CommentsRepository, postID int) map[int]string {
+	t.Helper()
+	comments, err := repo.GetCommentsByPostID(context.Background(), postID)
+	require.NoError(t, err)
+	contents := make(map[int]string, len(comments))
+	for _, comment := range comments {
+		contents[comment.ID] = comment.Content
+	}
+	return contents
+}
+
+func TestTrashRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	postRepo := NewPostRepository(db, zap.NewNop())
+	commentRepo := NewCommentsRepository(db, zap.NewNop())
+	trashRepo := NewTrashRepository(db, zap.NewNop())
+
+	// Ветка 10 → 11 → 12 и отдельный комментарий 13 к посту 1
+	_, err := db.Exec(`
+		INSERT INTO comments (id, post_id, author_id, parent_id, content) VALUES
+			(10, 1, 1, NULL, 'корень'),
+			(11, 1, 2, 10, 'ответ'),
+			(12, 1, 1, 11, 'ответ на ответ'),
+			(13, 1, 2, NULL, 'еще корень');
+	`)
+	require.NoError(t, err)
+
+	// Удаленные предки живого ответа остаются заглушками
+	require.NoError(t, commentRepo.DeleteComment(ctx, 10, 2))
+	require.NoError(t, commentRepo.DeleteComment(ctx, 11, 2))
+	assert.ErrorIs(t, commentRepo.DeleteComment(ctx, 11, 2), sql.ErrNoRows)
+	assert.Equal(t, map[int]string{1: "Красивые елки", 10: entity.DeletedCommentContent, 11: entity.DeletedCommentContent, 12: "ответ на ответ", 13: "еще корень"},
+		commentIDs(t, commentRepo, 1))
+
+	// Без живых ответов удаленная ветка пропадает целиком
+	require.NoError(t, commentRepo.DeleteComment(ctx, 12, 1))
+	assert.Equal(t, map[int]string{1: "Красивые елки", 13: "еще корень"}, commentIDs(t, commentRepo, 1))
+	count, err := commentRepo.GetTotalCommentsCount(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	threads, err := commentRepo.GetTotalThreadsCount(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, threads)
+
+	trashed, err := trashRepo.GetDeletedComments(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, trashed, 3)
+	assert.Equal(t, "ответ на ответ", trashed[0].Content)
+	assert.Equal(t, 1, *trashed[0].DeletedBy)
+
+	// Восстановленный ответ возвращает заглушки предков
+	require.NoError(t, trashRepo.RestoreComment(ctx, 12))
+	assert.Len(t, commentIDs(t, commentRepo, 1), 5)
+	nodes, err := commentRepo.GetCommentThreads(ctx, 1, 10, 0, 5)
+	require.NoError(t, err)
+	require.Len(t, nodes, 5)
+	assert.Equal(t, 10, nodes[1].ID)
+	assert.Equal(t, 1, nodes[1].RepliesCount)
+
+	// Удаленный пост пропадает из лент, поиска и проверок доступа
+	require.NoError(t, postRepo.DeletePost(ctx, 1, 2))
+	assert.ErrorIs(t, postRepo.DeletePost(ctx, 1, 2), sql.ErrNoRows)
+	_, err = postRepo.GetPostByID(ctx, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = NewCategoryRepository(db, zap.NewNop()).GetPostCategoryID(ctx, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, []int{2}, postIDs(t, postRepo, entity.PostFilter{}))
+	assert.Empty(t, searchFTS(t, NewSearchRepository(db, zap.NewNop()), "елки", entity.SearchFilter{}))
+
+	posts, err := trashRepo.GetDeletedPosts(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, 3, posts[0].CommentsCount)
+	// Комментарии удаленного поста отдельно не восстанавливаются
+	total, err := trashRepo.GetTotalDeletedComments(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.ErrorIs(t, trashRepo.RestoreComment(ctx, 10), sql.ErrNoRows)
+
+	// Пост возвращается вместе с комментариями
+	require.NoError(t, trashRepo.RestorePost(ctx, 1))
+	assert.ErrorIs(t, trashRepo.RestorePost(ctx, 1), sql.ErrNoRows)
+	_, err = postRepo.GetPostByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, commentIDs(t, commentRepo, 1), 5)
+
+	// Очистка не трогает заглушки с живыми ответами и свежее удаленное
+	_, err = db.Exec(`UPDATE comments SET deleted_at = datetime('now', '-40 days') WHERE id IN (10, 11)`)
+	require.NoError(t, err)
+	before := time.Now().Add(-30 * 24 * time.Hour)
+	purged, err := trashRepo.PurgeComments(ctx, before)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	require.NoError(t, commentRepo.DeleteComment(ctx, 12, 1))
+	purged, err = trashRepo.PurgeComments(ctx, before)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	_, err = db.Exec(`UPDATE comments SET deleted_at = datetime('now', '-40 days') WHERE id = 12`)
+	require.NoError(t, err)
+	purged, err = trashRepo.PurgeComments(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+
+	require.NoError(t, postRepo.DeletePost(ctx, 2, 2))
+	_, err = db.Exec(`UPDATE posts SET deleted_at = datetime('now', '-40 days') WHERE id = 2`)
+	require.NoError(t, err)
+	purged, err = trashRepo.PurgePosts(ctx, before)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	var left int
+	require.NoError(t, db.Get(&left, `SELECT COUNT(*) FROM comments WHERE post_id = 2`))
+	assert.Zero(t, left)
+}
+
+func TestTrashRepository_PurgeDependents_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	trashRepo := NewTrashRepository(db, zap.NewNop())
+
+	// У поста 2 и комментария 2 к нему есть правки, метка, комната
+	// обсуждения, дела модерации и жалобы
+	_, err := db.Exec(`
+		INSERT INTO post_revisions (post_id, version, title, content, editor_id, created_at) VALUES (2, 1, 'было', 'было', 2, CURRENT_TIMESTAMP);
+		INSERT INTO comment_revisions (comment_id, version, content, editor_id, created_at) VALUES (2, 1, 'было', 2, CURRENT_TIMESTAMP);
+		INSERT INTO tags (id, name) VALUES (1, 'новости');
+		INSERT INTO post_tags (post_id, tag_id) VALUES (2, 1);
+		INSERT INTO chat_rooms (id, kind, name, post_id) VALUES (5, 'post', 'обсуждение', 2);
+		INSERT INTO chat_room_members (room_id, user_id) VALUES (5, 1);
+		INSERT INTO chat_messages (id, user_id, username, content, room_id) VALUES (50, 1, 'alice', 'привет', 5), (51, 1, 'alice', 'общий', 1);
+		INSERT INTO moderation_cases (id, target_type, target_id, post_id, target_author_id, status) VALUES
+			(1, 'post', 2, 2, 2, 'open'),
+			(2, 'comment', 2, 2, 2, 'claimed'),
+			(3, 'chat_message', 50, NULL, 1, 'open'),
+			(4, 'post', 2, 2, 2, 'resolved');
+		INSERT INTO reports (case_id, reporter_id, reason) VALUES (1, 1, 'spam'), (2, 1, 'spam'), (3, 2, 'spam'), (4, 1, 'spam');
+		UPDATE posts SET deleted_at = datetime('now', '-40 days') WHERE id = 2;
+	`)
+	require.NoError(t, err)
+
+	purged, err := trashRepo.PurgePosts(ctx, time.Now().Add(-30*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	for query, want := range map[string]int{
+		`SELECT COUNT(*) FROM comments WHERE post_id = 2`:             0,
+		`SELECT COUNT(*) FROM post_revisions WHERE post_id = 2`:       0,
+		`SELECT COUNT(*) FROM comment_revisions WHERE comment_id = 2`: 0,
+		`SELECT COUNT(*) FROM post_tags WHERE post_id = 2`:            0,
+		`SELECT COUNT(*) FROM chat_rooms WHERE id = 5`:                0,
+		`SELECT COUNT(*) FROM chat_room_members WHERE room_id = 5`:    0,
+		`SELECT COUNT(*) FROM chat_messages WHERE room_id = 5`:        0,
+		`SELECT COUNT(*) FROM chat_messages WHERE room_id = 1`:        1,
+		// Решенное дело остается в истории автора вместе с жалобами
+		`SELECT COUNT(*) FROM moderation_cases`:          1,
+		`SELECT COUNT(*) FROM reports`:                   1,
+		`SELECT COUNT(*) FROM reports WHERE case_id = 4`: 1,
+		`SELECT COUNT(*) FROM posts WHERE id = 1`:        1,
+	} {
+		var count int
+		require.NoError(t, db.Get(&count, query))
+		assert.Equal(t, want, count, query)
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+type TrustRepository interface {
+	// GetStats считает активность пользователя. Для несуществующего
+	// пользователя возвращает sql.ErrNoRows.
+	GetStats(ctx context.Context, userID int) (entity.ReputationStats, error)
+	// GetAllStats считает активность всех пользователей, по возрастанию id.
+	GetAllStats(ctx context.Context) ([]entity.ReputationStats, error)
+	// GetTrust возвращает сохраненные репутацию и уровень. Если их еще не
+	// считали и уровень не назначали, возвращает sql.ErrNoRows.
+	GetTrust(ctx context.Context, userID int) (entity.UserTrust, error)
+	// SaveTrust сохраняет репутацию и уровень, назначенный уровень не меняет.
+	SaveTrust(ctx context.Context, trust entity.UserTrust) error
+	// SetOverride назначает пользователю уровень; nil снимает назначение.
+	SetOverride(ctx context.Context, userID int, level *string, adminID *int) error
+	// CountRecentPosts считает посты пользователя за последние period, в том
+	// числе удаленные: удаление поста не освобождает место в суточном лимите.
+	CountRecentPosts(ctx context.Context, userID int, period time.Duration) (int, error)
+}
+
+// reputationStatsColumns — подзапросы с активностью пользователя u.
+const reputationStatsColumns = `u.id,
+	(SELECT COUNT(*) FROM posts WHERE author_id = u.id AND deleted_at IS NULL),
+	(SELECT COUNT(*) FROM comments WHERE author_id = u.id AND deleted_at IS NULL),
+	(SELECT COALESCE(SUM(upvotes), 0) FROM posts WHERE author_id = u.id AND deleted_at IS NULL),
+	(SELECT COALESCE(SUM(downvotes), 0) FROM posts WHERE author_id = u.id AND deleted_at IS NULL),
+	(SELECT COALESCE(SUM(upvotes), 0) FROM comments WHERE author_id = u.id AND deleted_at IS NULL),
+	(SELECT COALESCE(SUM(downvotes), 0) FROM comments WHERE author_id = u.id AND deleted_at IS NULL)`
+
+func scanReputationStats(row rowScanner) (entity.ReputationStats, error) {
+	var stats entity.ReputationStats
+	err := row.Scan(
+		&stats.UserID,
+		&stats.Posts,
+		&stats.Comments,
+		&stats.PostUpvotes,
+		&stats.PostDownvotes,
+		&stats.CommentUpvotes,
+		&stats.CommentDownvotes,
+	)
+	return stats, err
+}
+
+type trustRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewTrustRepository(db DB, logger *zap.Logger) TrustRepository {
+	return &trustRepository{db: db, logger: logger}
+}
+
+func (r *trustRepository) GetStats(ctx context.Context, userID int) (entity.ReputationStats, error) {
+	query := `SELECT ` + reputationStatsColumns + ` FROM users u WHERE u.id = ?`
+	stats, err := scanReputationStats(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get reputation stats", zap.Error(err), zap.Int("userID", userID))
+	}
+	return stats, err
+}
+
+func (r *trustRepository) GetAllStats(ctx context.Context) ([]entity.ReputationStats, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+reputationStatsColumns+` FROM users u ORDER BY u.id`)
+	if err != nil {
+		r.logger.Error("Failed to get reputation stats", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var all []entity.ReputationStats
+	for rows.Next() {
+		stats, err := scanReputationStats(rows)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, stats)
+	}
+	return all, rows.Err()
+}
+
+func (r *trustRepository) GetTrust(ctx context.Context, userID int) (entity.UserTrust, error) {
+	query := `SELECT user_id, reputation, trust_level, override, override_by, updated_at FROM user_trust WHERE user_id = ?`
+	var trust entity.UserTrust
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&trust.UserID,
+		&trust.Reputation,
+		&trust.Level,
+		&trust.Override,
+		&trust.OverrideBy,
+		&trust.UpdatedAt,
+	)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to get user trust", zap.Error(err), zap.Int("userID", userID))
+	}
+	return trust, err
+}
+
+func (r *trustRepository) SaveTrust(ctx context.Context, trust entity.UserTrust) error {
+	query := `
+		INSERT INTO user_trust (user_id, reputation, trust_level, updated_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE SET
+			reputation = excluded.reputation,
+			trust_level = excluded.trust_level,
+			updated_at = excluded.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query, trust.UserID, trust.Reputation, trust.Level)
+	if err != nil {
+		r.logger.Error("Failed to save user trust", zap.Error(err), zap.Int("userID", trust.UserID))
+	}
+	return err
+}
+
+func (r *trustRepository) SetOverride(ctx context.Context, userID int, level *string, adminID *int) error {
+	query := `
+		INSERT INTO user_trust (user_id, override, override_by) VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET override = excluded.override, override_by = excluded.override_by
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, level, adminID)
+	if err != nil {
+		r.logger.Error("Failed to set trust level override", zap.Error(err), zap.Int("userID", userID))
+	}
+	return err
+}
+
+func (r *trustRepository) CountRecentPosts(ctx context.Context, userID int, period time.Duration) (int, error) {
+	query := `SELECT COUNT(*) FROM posts WHERE author_id = ? AND created_at >= datetime('now', ?)`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID, fmt.Sprintf("-%d seconds", int(period.Seconds()))).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count recent posts", zap.Error(err), zap.Int("userID", userID))
+	}
+	return count, err
+}
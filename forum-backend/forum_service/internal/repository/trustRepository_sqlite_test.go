@@ -0,0 +1,77 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTrustRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSearchTestDB(t)
+	trustRepo := NewTrustRepository(db, zap.NewNop())
+	reactionRepo := NewReactionRepository(db, zap.NewNop())
+	commentRepo := NewCommentsRepository(db, zap.NewNop())
+
+	_, err := db.Exec(`
+		INSERT INTO users (id, username, password, role) VALUES (3, 'carol', 'x', 'user');
+		INSERT INTO posts (id, author_id, title, content, created_at) VALUES (3, 1, 'Old', 'Old post', datetime('now', '-2 days'));
+		INSERT INTO comments (id, post_id, author_id, content) VALUES (3, 2, 1, 'Еще комментарий');
+	`)
+	require.NoError(t, err)
+	for _, reaction := range []entity.Reaction{
+		{TargetType: entity.ReactionTargetPost, TargetID: 1, UserID: 2, Kind: entity.ReactionUp},
+		{TargetType: entity.ReactionTargetPost, TargetID: 3, UserID: 3, Kind: entity.ReactionDown},
+		{TargetType: entity.ReactionTargetComment, TargetID: 2, UserID: 3, Kind: entity.ReactionUp},
+		{TargetType: entity.ReactionTargetComment, TargetID: 3, UserID: 2, Kind: entity.ReactionUp},
+	} {
+		require.NoError(t, reactionRepo.AddReaction(ctx, reaction))
+	}
+	// Удаленный комментарий и его голоса не считаются
+	require.NoError(t, commentRepo.DeleteComment(ctx, 3, 1))
+
+	stats, err := trustRepo.GetStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReputationStats{UserID: 1, Posts: 2, Comments: 1, PostUpvotes: 1, PostDownvotes: 1, CommentUpvotes: 1}, stats)
+
+	_, err = trustRepo.GetStats(ctx, 99)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	all, err := trustRepo.GetAllStats(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, stats, all[0])
+	assert.Equal(t, entity.ReputationStats{UserID: 3}, all[2])
+
+	recent, err := trustRepo.CountRecentPosts(ctx, 1, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recent)
+
+	// Сохранение репутации не трогает назначенный уровень, и наоборот
+	_, err = trustRepo.GetTrust(ctx, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	level, adminID := entity.TrustRegular, 2
+	require.NoError(t, trustRepo.SetOverride(ctx, 1, &level, &adminID))
+	require.NoError(t, trustRepo.SaveTrust(ctx, entity.UserTrust{UserID: 1, Reputation: 14, Level: entity.TrustRegular}))
+
+	trust, err := trustRepo.GetTrust(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 14, trust.Reputation)
+	assert.Equal(t, entity.TrustRegular, trust.Level)
+	assert.Equal(t, &level, trust.Override)
+	assert.Equal(t, &adminID, trust.OverrideBy)
+
+	require.NoError(t, trustRepo.SetOverride(ctx, 1, nil, nil))
+	trust, err = trustRepo.GetTrust(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, trust.Override)
+	assert.Equal(t, 14, trust.Reputation)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository/adapters"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTrustRepository_SaveTrust(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTrustRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectExec(`INSERT INTO user_trust \(user_id, reputation, trust_level, updated_at\) VALUES \(\?, \?, \?, CURRENT_TIMESTAMP\)\s+ON CONFLICT \(user_id\) DO UPDATE SET`).
+		WithArgs(3, 120, "member").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.SaveTrust(context.Background(), entity.UserTrust{UserID: 3, Reputation: 120, Level: entity.TrustMember})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrustRepository_CountRecentPosts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewTrustRepository(&adapters.DbAdapter{DB: db}, zap.NewNop())
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM posts WHERE author_id = \? AND created_at >= datetime\('now', \?\)`).
+		WithArgs(3, "-86400 seconds").
+		WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2))
+
+	count, err := repo.CountRecentPosts(context.Background(), 3, 24*time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"go.uber.org/zap"
+)
+
+// UserBlockRepository хранит черные списки пользователей.
+type UserBlockRepository interface {
+	// Block возвращает false, если пользователь уже в черном списке.
+	Block(ctx context.Context, blockerID, blockedID int) (bool, error)
+	// Unblock возвращает false, если пользователя не было в черном списке.
+	Unblock(ctx context.Context, blockerID, blockedID int) (bool, error)
+	GetBlocked(ctx context.Context, blockerID int) ([]entity.BlockedUser, error)
+	// IsBlockedBetween сообщает, занес ли userID в черный список кого-то из
+	// otherIDs или кто-то из них — его.
+	IsBlockedBetween(ctx context.Context, userID int, otherIDs []int) (bool, error)
+}
+
+type userBlockRepository struct {
+	db     DB
+	logger *zap.Logger
+}
+
+func NewUserBlockRepository(db DB, logger *zap.Logger) UserBlockRepository {
+	return &userBlockRepository{db: db, logger: logger}
+}
+
+func (r *userBlockRepository) Block(ctx context.Context, blockerID, blockedID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_blocks (blocker_id, blocked_id) VALUES (?, ?)
+		ON CONFLICT(blocker_id, blocked_id) DO NOTHING
+	`, blockerID, blockedID)
+	if err != nil {
+		r.logger.Error("Failed to block user", zap.Error(err), zap.Int("blockerID", blockerID), zap.Int("blockedID", blockedID))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *userBlockRepository) Unblock(ctx context.Context, blockerID, blockedID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_blocks WHERE blocker_id = ? AND blocked_id = ?`, blockerID, blockedID)
+	if err != nil {
+		r.logger.Error("Failed to unblock user", zap.Error(err), zap.Int("blockerID", blockerID), zap.Int("blockedID", blockedID))
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (r *userBlockRepository) GetBlocked(ctx context.Context, blockerID int) ([]entity.BlockedUser, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT blocked_id, created_at FROM user_blocks
+		WHERE blocker_id = ?
+		ORDER BY created_at DESC, blocked_id
+	`, blockerID)
+	if err != nil {
+		r.logger.Error("Failed to get blocked users", zap.Error(err), zap.Int("blockerID", blockerID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocked []entity.BlockedUser
+	for rows.Next() {
+		var user entity.BlockedUser
+		if err := rows.Scan(&user.UserID, &user.CreatedAt); err != nil {
+			r.logger.Error("Failed to scan blocked user", zap.Error(err))
+			return nil, err
+		}
+		blocked = append(blocked, user)
+	}
+	return blocked, rows.Err()
+}
+
+func (r *userBlockRepository) IsBlockedBetween(ctx context.Context, userID int, otherIDs []int) (bool, error) {
+	if len(otherIDs) == 0 {
+		return false, nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(otherIDs)), ", ")
+	args := make([]any, 0, 2*len(otherIDs)+2)
+	args = append(args, userID)
+	for _, id := range otherIDs {
+		args = append(args, id)
+	}
+	args = append(args, userID)
+	for _, id := range otherIDs {
+		args = append(args, id)
+	}
+
+	var blocked bool
+	err := r.db.GetContext(ctx, &blocked, `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE (blocker_id = ? AND blocked_id IN (`+placeholders+`))
+			   OR (blocked_id = ? AND blocker_id IN (`+placeholders+`))
+		)
+	`, args...)
+	if err != nil {
+		r.logger.Error("Failed to check user blocks", zap.Error(err), zap.Int("userID", userID))
+	}
+	return blocked, err
+}
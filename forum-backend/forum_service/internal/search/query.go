@@ -0,0 +1,118 @@
+// Package search переводит пользовательский поисковый запрос в выражение
+// FTS5 MATCH.
+//
+// Поддерживается:
+//   - слова: ищутся все сразу (И);
+//   - фразы в кавычках: "текст поста";
+//   - поиск по префиксу: пост*.
+//
+// Операторы FTS5 (AND, OR, NOT, NEAR, скобки, имена колонок) в запросе не
+// действуют: каждое слово и фраза экранируются. У русских слов без
+// звездочки отбрасывается окончание, и они ищутся по основе как по
+// префиксу, так что "постами" находит и "пост", и "посты".
+package search
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// MaxTerms ограничивает число слов и фраз в запросе.
+const MaxTerms = 16
+
+var ErrEmptyQuery = errors.New("search query is empty")
+
+type term struct {
+	text   string
+	phrase bool
+	prefix bool
+}
+
+// ParseQuery возвращает выражение для FTS5 MATCH. Для запроса без слов
+// возвращает ErrEmptyQuery; слова сверх MaxTerms отбрасываются.
+func ParseQuery(query string) (string, error) {
+	terms := split(query)
+	if len(terms) == 0 {
+		return "", ErrEmptyQuery
+	}
+	if len(terms) > MaxTerms {
+		terms = terms[:MaxTerms]
+	}
+
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		text := Normalize(t.text)
+		prefix := t.prefix
+		if !t.phrase && !prefix && isCyrillic(text) {
+			text = stemRussian(text)
+			prefix = true
+		}
+
+		part := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+		if prefix {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Normalize приводит текст к виду, в котором он хранится в индексе:
+// нижний регистр и е вместо ё.
+func Normalize(text string) string {
+	return strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+}
+
+func split(query string) []term {
+	var terms []term
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case runes[i] == '"':
+			// Незакрытая кавычка продолжает фразу до конца запроса
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			terms = appendTerm(terms, term{text: string(runes[i+1 : end]), phrase: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			prefix := strings.HasSuffix(word, "*")
+			word = strings.TrimFunc(word, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+			terms = appendTerm(terms, term{text: word, prefix: prefix})
+			i = end
+		}
+	}
+	return terms
+}
+
+// appendTerm пропускает слова без букв и цифр: токенизатор FTS5 их
+// отбрасывает, и пустая фраза в запросе ничего бы не нашла.
+func appendTerm(terms []term, t term) []term {
+	t.text = strings.Join(strings.Fields(t.text), " ")
+	for _, r := range t.text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return append(terms, t)
+		}
+	}
+	return terms
+}
+
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Cyrillic, r) {
+			return false
+		}
+	}
+	return word != ""
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "english words", query: "Running tests", want: `"running" "tests"`},
+		{name: "phrase", query: `"exact phrase"  word`, want: `"exact phrase" "word"`},
+		{name: "prefix", query: "integr*", want: `"integr"*`},
+		{name: "russian words are stemmed", query: "Постами делились", want: `"пост"* "делил"*`},
+		{name: "short russian word", query: "мир", want: `"мир"*`},
+		{name: "yo is folded", query: "Ёлка", want: `"елк"*`},
+		{name: "russian phrase is kept", query: `"новые посты"`, want: `"новые посты"`},
+		{name: "operators are escaped", query: `title:x OR NOT (y)`, want: `"title:x" "or" "not" "y"`},
+		{name: "quotes inside words", query: `it's`, want: `"it's"`},
+		{name: "punctuation is trimmed", query: "мир, привет!", want: `"мир"* "привет"*`},
+		{name: "unterminated phrase", query: `"open phrase`, want: `"open phrase"`},
+		{name: "punctuation only terms are dropped", query: `word -- "..."`, want: `"word"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseQuery(tc.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseQuery_Empty(t *testing.T) {
+	for _, query := range []string{"", "   ", `""`, "*", "!!!"} {
+		_, err := ParseQuery(query)
+		assert.ErrorIs(t, err, ErrEmptyQuery, query)
+	}
+}
+
+func TestParseQuery_TooManyTerms(t *testing.T) {
+	got, err := ParseQuery(strings.Repeat("word ", MaxTerms+5))
+
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(got), MaxTerms)
+}
+
+func TestStemRussian(t *testing.T) {
+	for word, want := range map[string]string{
+		"постами": "пост",
+		"посты":   "пост",
+		"пост":    "пост",
+		"форумы":  "форум",
+		"новыми":  "нов",
+		"писать":  "пис",
+		"кот":     "кот",
+	} {
+		assert.Equal(t, want, stemRussian(word), word)
+	}
+}
@@ -0,0 +1,40 @@
+package search
+
+import "strings"
+
+// minStem — сколько букв должно остаться от русского слова после
+// отбрасывания окончания.
+const minStem = 3
+
+// russianEndings — окончания существительных, прилагательных и глаголов,
+// от длинных к коротким.
+var russianEndings = []string{
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ией",
+	"ать", "ять", "ить", "еть", "ешь", "ете", "ишь", "ите", "ала", "ила",
+	"ия", "ие", "ий", "ой", "ый", "ая", "яя", "ое", "ее", "ую", "юю",
+	"ом", "ем", "ам", "ям", "ах", "ях", "ов", "ев", "ей", "ых", "их",
+	"ал", "ил", "ут", "ют", "ат", "ят",
+	"ы", "и", "а", "я", "о", "е", "у", "ю", "ь", "й",
+}
+
+// stemRussian отбрасывает у слова самое длинное подходящее окончание. Это
+// не полноценный стеммер: основа дальше ищется как префикс, поэтому
+// достаточно, чтобы разные формы слова давали общее начало.
+func stemRussian(word string) string {
+	// Возвратные глаголы: "делились" -> "делили"
+	for _, reflexive := range []string{"ся", "сь"} {
+		if len([]rune(word))-2 > minStem && strings.HasSuffix(word, reflexive) {
+			word = strings.TrimSuffix(word, reflexive)
+			break
+		}
+	}
+
+	runes := []rune(word)
+	for _, ending := range russianEndings {
+		n := len([]rune(ending))
+		if len(runes)-n >= minStem && strings.HasSuffix(word, ending) {
+			return string(runes[:len(runes)-n])
+		}
+	}
+	return word
+}
@@ -0,0 +1,114 @@
+// Package textdiff строит построчную разницу между двумя версиями текста.
+package textdiff
+
+import "strings"
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// maxCells ограничивает размер таблицы LCS. Для текстов большего размера
+// разница строится грубо: все строки старой версии удалены, новой — добавлены.
+const maxCells = 4_000_000
+
+// Line — строка результата: без изменений, добавлена или удалена.
+type Line struct {
+	Op   string `json:"op" example:"insert"`
+	Text string `json:"text" example:"новая строка"`
+}
+
+// Lines возвращает разницу между from и to по строкам, используя
+// наибольшую общую подпоследовательность.
+func Lines(from, to string) []Line {
+	a, b := split(from), split(to)
+
+	// Общие начало и конец не участвуют в поиске LCS
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]Line, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		result = append(result, Line{Op: OpEqual, Text: text})
+	}
+	result = append(result, middle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		result = append(result, Line{Op: OpEqual, Text: text})
+	}
+	return result
+}
+
+// Changed сообщает, есть ли в разнице добавленные или удаленные строки.
+func Changed(lines []Line) bool {
+	for _, line := range lines {
+		if line.Op != OpEqual {
+			return true
+		}
+	}
+	return false
+}
+
+func middle(a, b []string) []Line {
+	var result []Line
+	if len(a)*len(b) > maxCells {
+		for _, text := range a {
+			result = append(result, Line{Op: OpDelete, Text: text})
+		}
+		for _, text := range b {
+			result = append(result, Line{Op: OpInsert, Text: text})
+		}
+		return result
+	}
+
+	// lcs[i][j] — длина LCS для a[i:] и b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, Line{Op: OpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, Line{Op: OpDelete, Text: a[i]})
+			i++
+		default:
+			result = append(result, Line{Op: OpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, Line{Op: OpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, Line{Op: OpInsert, Text: b[j]})
+	}
+	return result
+}
+
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
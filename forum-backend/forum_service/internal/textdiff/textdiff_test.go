@@ -0,0 +1,44 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	diff := Lines("first\nsecond\nthird", "first\nchanged\nthird\nfourth")
+
+	assert.Equal(t, []Line{
+		{Op: OpEqual, Text: "first"},
+		{Op: OpDelete, Text: "second"},
+		{Op: OpInsert, Text: "changed"},
+		{Op: OpEqual, Text: "third"},
+		{Op: OpInsert, Text: "fourth"},
+	}, diff)
+	assert.True(t, Changed(diff))
+}
+
+func TestLines_Equal(t *testing.T) {
+	diff := Lines("same\ntext", "same\r\ntext")
+
+	assert.Equal(t, []Line{{Op: OpEqual, Text: "same"}, {Op: OpEqual, Text: "text"}}, diff)
+	assert.False(t, Changed(diff))
+}
+
+func TestLines_Empty(t *testing.T) {
+	assert.Equal(t, []Line{{Op: OpInsert, Text: "new"}}, Lines("", "new"))
+	assert.Equal(t, []Line{{Op: OpDelete, Text: "old"}}, Lines("old", ""))
+	assert.Empty(t, Lines("", ""))
+}
+
+func TestLines_LargeInputFallsBack(t *testing.T) {
+	from := strings.Repeat("a\n", 3000) + "x"
+	to := strings.Repeat("b\n", 3000) + "x"
+
+	diff := Lines(from, to)
+
+	assert.Len(t, diff, 6001)
+	assert.Equal(t, Line{Op: OpEqual, Text: "x"}, diff[len(diff)-1])
+}
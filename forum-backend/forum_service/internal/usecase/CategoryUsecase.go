@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrCategoryNotFound     = errors.New("category not found")
+	ErrCategoryForbidden    = errors.New("action is not allowed in this category")
+	ErrCategoryArchived     = errors.New("category is archived")
+	ErrCategorySlugTaken    = errors.New("category slug is already taken")
+	ErrInvalidCategorySlug  = errors.New("slug must contain only lowercase latin letters, digits and dashes")
+	ErrInvalidCategoryRole  = errors.New("invalid role name in category permissions")
+	ErrCategoryCycle        = errors.New("category cannot be nested into itself")
+	ErrDefaultCategory      = errors.New("default category cannot be archived or merged")
+	ErrInvalidCategoryOrder = errors.New("ids must list every subcategory of the parent exactly once")
+)
+
+var (
+	categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	// Совпадает с проверкой имен ролей в auth_service.
+	categoryRolePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+)
+
+const maxCategorySlugLength = 100
+
+type CategoryUsecase interface {
+	// GetCategories возвращает дерево разделов, которые может читать
+	// principal (nil — гость). Архивные разделы — только с includeArchived.
+	GetCategories(ctx context.Context, principal *entity.Principal, includeArchived bool) ([]*entity.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string, principal *entity.Principal) (entity.Category, error)
+	// CheckAccess проверяет, может ли principal выполнить action в разделе.
+	// Раздел, который нельзя читать, для него не существует: возвращается
+	// ErrCategoryNotFound. Нельзя писать — ErrCategoryForbidden или
+	// ErrCategoryArchived.
+	CheckAccess(ctx context.Context, categoryID int, principal *entity.Principal, action string) error
+	// CheckPostAccess — то же для раздела, в котором находится пост.
+	CheckPostAccess(ctx context.Context, postID int, principal *entity.Principal, action string) error
+	// HiddenCategoryIDs возвращает разделы, которые principal не может читать.
+	HiddenCategoryIDs(ctx context.Context, principal *entity.Principal) ([]int, error)
+	CreateCategory(ctx context.Context, req entity.CategoryRequest) (entity.Category, error)
+	UpdateCategory(ctx context.Context, id int, req entity.CategoryRequest) (entity.Category, error)
+	// ReorderCategories задает порядок подразделов parentID (nil — корня).
+	ReorderCategories(ctx context.Context, parentID *int, ids []int) error
+	MergeCategory(ctx context.Context, sourceID, targetID int) error
+	SetArchived(ctx context.Context, id int, archived bool) (entity.Category, error)
+}
+
+type categoryUsecase struct {
+	categoryRepo repository.CategoryRepository
+	logger       *zap.Logger
+}
+
+func NewCategoryUsecase(categoryRepo repository.CategoryRepository, logger *zap.Logger) CategoryUsecase {
+	return &categoryUsecase{categoryRepo: categoryRepo, logger: logger}
+}
+
+// categoryIndex — все разделы по id. Разделов немного, поэтому проверки
+// доступа каждый раз загружают их целиком.
+type categoryIndex map[int]*entity.Category
+
+func (u *categoryUsecase) loadCategories(ctx context.Context) ([]entity.Category, categoryIndex, error) {
+	categories, err := u.categoryRepo.GetCategories(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := make(categoryIndex, len(categories))
+	for i := range categories {
+		index[categories[i].ID] = &categories[i]
+	}
+	return categories, index, nil
+}
+
+// canRead проверяет доступ на чтение к разделу и всем его родителям.
+func (idx categoryIndex) canRead(category *entity.Category, principal *entity.Principal) bool {
+	for c := category; c != nil; c = idx.parent(c) {
+		if !rolesAllow(c.Permissions.Read, principal, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// archived сообщает, архивирован ли раздел или один из его родителей.
+func (idx categoryIndex) archived(category *entity.Category) bool {
+	for c := category; c != nil; c = idx.parent(c) {
+		if c.Archived {
+			return true
+		}
+	}
+	return false
+}
+
+func (idx categoryIndex) parent(category *entity.Category) *entity.Category {
+	if category.ParentID == nil {
+		return nil
+	}
+	return idx[*category.ParentID]
+}
+
+// isDescendant сообщает, лежит ли раздел id в поддереве ancestorID.
+func (idx categoryIndex) isDescendant(id, ancestorID int) bool {
+	for c := idx[id]; c != nil; c = idx.parent(c) {
+		if c.ID == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+func rolesAllow(roles []string, principal *entity.Principal, guests bool) bool {
+	if principal == nil {
+		return guests && len(roles) == 0
+	}
+	if principal.Can(entity.PermCategoryManage) || len(roles) == 0 {
+		return true
+	}
+	return principal.HasRole(roles...)
+}
+
+func (idx categoryIndex) checkAccess(category *entity.Category, principal *entity.Principal, action string) error {
+	if !idx.canRead(category, principal) {
+		return ErrCategoryNotFound
+	}
+	if action == entity.CategoryActionRead {
+		return nil
+	}
+	if idx.archived(category) {
+		return ErrCategoryArchived
+	}
+	if !rolesAllow(category.Permissions.Roles(action), principal, false) {
+		return ErrCategoryForbidden
+	}
+	return nil
+}
+
+func (u *categoryUsecase) GetCategories(ctx context.Context, principal *entity.Principal, includeArchived bool) ([]*entity.Category, error) {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]*entity.Category, 0)
+	for i := range categories {
+		category := &categories[i]
+		if !index.canRead(category, principal) || (!includeArchived && index.archived(category)) {
+			continue
+		}
+		if parent := index.parent(category); parent != nil {
+			parent.Children = append(parent.Children, category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+	return roots, nil
+}
+
+func (u *categoryUsecase) GetCategoryBySlug(ctx context.Context, slug string, principal *entity.Principal) (entity.Category, error) {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return entity.Category{}, err
+	}
+	for i := range categories {
+		if categories[i].Slug == slug {
+			if !index.canRead(&categories[i], principal) {
+				return entity.Category{}, ErrCategoryNotFound
+			}
+			return categories[i], nil
+		}
+	}
+	return entity.Category{}, ErrCategoryNotFound
+}
+
+func (u *categoryUsecase) CheckAccess(ctx context.Context, categoryID int, principal *entity.Principal, action string) error {
+	_, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return err
+	}
+	category, ok := index[categoryID]
+	if !ok {
+		return ErrCategoryNotFound
+	}
+	return index.checkAccess(category, principal, action)
+}
+
+func (u *categoryUsecase) CheckPostAccess(ctx context.Context, postID int, principal *entity.Principal, action string) error {
+	categoryID, err := u.categoryRepo.GetPostCategoryID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	err = u.CheckAccess(ctx, categoryID, principal, action)
+	if errors.Is(err, ErrCategoryNotFound) {
+		// Пост из скрытого раздела для пользователя не существует
+		return ErrPostNotFound
+	}
+	return err
+}
+
+func (u *categoryUsecase) HiddenCategoryIDs(ctx context.Context, principal *entity.Principal) ([]int, error) {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hidden []int
+	for i := range categories {
+		if !index.canRead(&categories[i], principal) {
+			hidden = append(hidden, categories[i].ID)
+		}
+	}
+	return hidden, nil
+}
+
+func (u *categoryUsecase) CreateCategory(ctx context.Context, req entity.CategoryRequest) (entity.Category, error) {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return entity.Category{}, err
+	}
+	category := categoryFromRequest(req)
+	if err := validateCategory(category, categories, index); err != nil {
+		return entity.Category{}, err
+	}
+	return u.categoryRepo.CreateCategory(ctx, category)
+}
+
+func (u *categoryUsecase) UpdateCategory(ctx context.Context, id int, req entity.CategoryRequest) (entity.Category, error) {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return entity.Category{}, err
+	}
+	if _, ok := index[id]; !ok {
+		return entity.Category{}, ErrCategoryNotFound
+	}
+
+	category := categoryFromRequest(req)
+	category.ID = id
+	if err := validateCategory(category, categories, index); err != nil {
+		return entity.Category{}, err
+	}
+	if category.ParentID != nil && index.isDescendant(*category.ParentID, id) {
+		return entity.Category{}, ErrCategoryCycle
+	}
+
+	updated, err := u.categoryRepo.UpdateCategory(ctx, category)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Category{}, ErrCategoryNotFound
+	}
+	return updated, err
+}
+
+func categoryFromRequest(req entity.CategoryRequest) entity.Category {
+	return entity.Category{
+		ParentID:    req.ParentID,
+		Slug:        strings.TrimSpace(req.Slug),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Position:    req.Position,
+		Permissions: entity.CategoryPermissions{
+			Read:    normalizeRoles(req.Permissions.Read),
+			Post:    normalizeRoles(req.Permissions.Post),
+			Comment: normalizeRoles(req.Permissions.Comment),
+		},
+	}
+}
+
+// normalizeRoles убирает повторы и сортирует роли.
+func normalizeRoles(roles []string) []string {
+	seen := make(map[string]bool, len(roles))
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if !seen[role] {
+			seen[role] = true
+			normalized = append(normalized, role)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func validateCategory(category entity.Category, categories []entity.Category, index categoryIndex) error {
+	if len(category.Slug) > maxCategorySlugLength || !categorySlugPattern.MatchString(category.Slug) {
+		return ErrInvalidCategorySlug
+	}
+	for _, other := range categories {
+		if other.Slug == category.Slug && other.ID != category.ID {
+			return ErrCategorySlugTaken
+		}
+	}
+	if category.ParentID != nil {
+		if *category.ParentID == category.ID {
+			return ErrCategoryCycle
+		}
+		if _, ok := index[*category.ParentID]; !ok {
+			return ErrCategoryNotFound
+		}
+	}
+	for _, action := range []string{entity.CategoryActionRead, entity.CategoryActionPost, entity.CategoryActionComment} {
+		for _, role := range category.Permissions.Roles(action) {
+			if !categoryRolePattern.MatchString(role) {
+				return ErrInvalidCategoryRole
+			}
+		}
+	}
+	return nil
+}
+
+func (u *categoryUsecase) ReorderCategories(ctx context.Context, parentID *int, ids []int) error {
+	categories, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return err
+	}
+	if parentID != nil {
+		if _, ok := index[*parentID]; !ok {
+			return ErrCategoryNotFound
+		}
+	}
+
+	// Порядок задается для всех подразделов родителя сразу, иначе
+	// пропущенные разделы оказались бы среди переставленных
+	siblings := make(map[int]bool)
+	for _, category := range categories {
+		if sameParent(category.ParentID, parentID) {
+			siblings[category.ID] = true
+		}
+	}
+	if len(ids) != len(siblings) {
+		return ErrInvalidCategoryOrder
+	}
+	for _, id := range ids {
+		if !siblings[id] {
+			return ErrInvalidCategoryOrder
+		}
+		delete(siblings, id)
+	}
+	return u.categoryRepo.ReorderCategories(ctx, ids)
+}
+
+func sameParent(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (u *categoryUsecase) MergeCategory(ctx context.Context, sourceID, targetID int) error {
+	if sourceID == entity.DefaultCategoryID {
+		return ErrDefaultCategory
+	}
+	_, index, err := u.loadCategories(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := index[sourceID]; !ok {
+		return ErrCategoryNotFound
+	}
+	if _, ok := index[targetID]; !ok {
+		return ErrCategoryNotFound
+	}
+	// Подразделы source переезжают в target, поэтому target не может быть
+	// ни самим source, ни его потомком
+	if index.isDescendant(targetID, sourceID) {
+		return ErrCategoryCycle
+	}
+	return u.categoryRepo.MergeCategory(ctx, sourceID, targetID)
+}
+
+func (u *categoryUsecase) SetArchived(ctx context.Context, id int, archived bool) (entity.Category, error) {
+	if archived && id == entity.DefaultCategoryID {
+		return entity.Category{}, ErrDefaultCategory
+	}
+	category, err := u.categoryRepo.SetCategoryArchived(ctx, id, archived)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Category{}, ErrCategoryNotFound
+	}
+	return category, err
+}
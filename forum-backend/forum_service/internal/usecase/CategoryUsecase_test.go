@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// testCategories: general (всем), staff (только moderator) с подразделом
+// staff-talk, archive (архивный) и news (писать может только admin).
+func testCategories() []entity.Category {
+	return []entity.Category{
+		{ID: 1, Slug: "general"},
+		{ID: 2, Slug: "staff", Permissions: entity.CategoryPermissions{Read: []string{"moderator"}}},
+		{ID: 3, Slug: "staff-talk", ParentID: intPtr(2)},
+		{ID: 4, Slug: "archive", Archived: true},
+		{ID: 5, Slug: "news", Permissions: entity.CategoryPermissions{Post: []string{"admin"}}},
+	}
+}
+
+func newTestCategoryUsecase() (*mocks.CategoryRepository, CategoryUsecase) {
+	mockCategoryRepo := new(mocks.CategoryRepository)
+	mockCategoryRepo.On("GetCategories", mock.Anything).Return(testCategories(), nil).Maybe()
+	return mockCategoryRepo, NewCategoryUsecase(mockCategoryRepo, zap.NewNop())
+}
+
+func TestCategoryUsecase_GetCategories_Guest(t *testing.T) {
+	_, categoryUsecase := newTestCategoryUsecase()
+
+	categories, err := categoryUsecase.GetCategories(context.Background(), nil, false)
+
+	assert.NoError(t, err)
+	slugs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		slugs = append(slugs, category.Slug)
+	}
+	assert.Equal(t, []string{"general", "news"}, slugs)
+}
+
+func TestCategoryUsecase_GetCategories_Tree(t *testing.T) {
+	_, categoryUsecase := newTestCategoryUsecase()
+	moderator := &entity.Principal{UserID: 1, Role: "moderator"}
+
+	categories, err := categoryUsecase.GetCategories(context.Background(), moderator, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, categories, 4)
+	assert.Equal(t, "staff", categories[1].Slug)
+	assert.Len(t, categories[1].Children, 1)
+	assert.Equal(t, "staff-talk", categories[1].Children[0].Slug)
+}
+
+func TestCategoryUsecase_CheckAccess(t *testing.T) {
+	user := &entity.Principal{UserID: 1, Role: "user"}
+	moderator := &entity.Principal{UserID: 2, Role: "moderator"}
+	manager := &entity.Principal{UserID: 3, Role: "admin", Permissions: []string{entity.PermCategoryManage}}
+
+	tests := []struct {
+		name       string
+		categoryID int
+		principal  *entity.Principal
+		action     string
+		err        error
+	}{
+		{"guest reads open category", 1, nil, entity.CategoryActionRead, nil},
+		{"guest cannot post", 1, nil, entity.CategoryActionPost, ErrCategoryForbidden},
+		{"user posts in open category", 1, user, entity.CategoryActionPost, nil},
+		{"restricted category is hidden", 2, user, entity.CategoryActionRead, ErrCategoryNotFound},
+		{"child of hidden category is hidden", 3, user, entity.CategoryActionRead, ErrCategoryNotFound},
+		{"role grants read to child", 3, moderator, entity.CategoryActionComment, nil},
+		{"archived category is read only", 4, user, entity.CategoryActionComment, ErrCategoryArchived},
+		{"archived category is readable", 4, nil, entity.CategoryActionRead, nil},
+		{"post restricted to role", 5, user, entity.CategoryActionPost, ErrCategoryForbidden},
+		{"comment not restricted", 5, user, entity.CategoryActionComment, nil},
+		{"manage permission bypasses roles", 2, manager, entity.CategoryActionPost, nil},
+		{"unknown category", 42, user, entity.CategoryActionRead, ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, categoryUsecase := newTestCategoryUsecase()
+
+			err := categoryUsecase.CheckAccess(context.Background(), tt.categoryID, tt.principal, tt.action)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCategoryUsecase_CheckPostAccess_HiddenPost(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+	mockCategoryRepo.On("GetPostCategoryID", mock.Anything, 7).Return(2, nil)
+	mockCategoryRepo.On("GetPostCategoryID", mock.Anything, 8).Return(0, sql.ErrNoRows)
+
+	assert.ErrorIs(t, categoryUsecase.CheckPostAccess(context.Background(), 7, nil, entity.CategoryActionRead), ErrPostNotFound)
+	assert.ErrorIs(t, categoryUsecase.CheckPostAccess(context.Background(), 8, nil, entity.CategoryActionRead), ErrPostNotFound)
+}
+
+func TestCategoryUsecase_HiddenCategoryIDs(t *testing.T) {
+	_, categoryUsecase := newTestCategoryUsecase()
+
+	hidden, err := categoryUsecase.HiddenCategoryIDs(context.Background(), &entity.Principal{UserID: 1, Role: "user"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, hidden)
+}
+
+func TestCategoryUsecase_CreateCategory_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  entity.CategoryRequest
+		err  error
+	}{
+		{"invalid slug", entity.CategoryRequest{Slug: "Новости", Name: "Новости"}, ErrInvalidCategorySlug},
+		{"slug taken", entity.CategoryRequest{Slug: "news", Name: "Новости"}, ErrCategorySlugTaken},
+		{"unknown parent", entity.CategoryRequest{Slug: "faq", Name: "FAQ", ParentID: intPtr(42)}, ErrCategoryNotFound},
+		{"invalid role", entity.CategoryRequest{Slug: "faq", Name: "FAQ", Permissions: entity.CategoryPermissions{Read: []string{"Admin,user"}}}, ErrInvalidCategoryRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+
+			_, err := categoryUsecase.CreateCategory(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, tt.err)
+			mockCategoryRepo.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCategoryUsecase_CreateCategory_Success(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+	expected := entity.Category{
+		ParentID: intPtr(2),
+		Slug:     "faq",
+		Name:     "FAQ",
+		Permissions: entity.CategoryPermissions{
+			Read:    []string{"moderator", "user"},
+			Post:    []string{},
+			Comment: []string{},
+		},
+	}
+	mockCategoryRepo.On("CreateCategory", mock.Anything, expected).Return(entity.Category{ID: 6, Slug: "faq"}, nil)
+
+	category, err := categoryUsecase.CreateCategory(context.Background(), entity.CategoryRequest{
+		ParentID:    intPtr(2),
+		Slug:        " faq ",
+		Name:        "FAQ",
+		Permissions: entity.CategoryPermissions{Read: []string{"user", "moderator", "user"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, category.ID)
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestCategoryUsecase_UpdateCategory_Cycle(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+
+	_, err := categoryUsecase.UpdateCategory(context.Background(), 2, entity.CategoryRequest{Slug: "staff", Name: "Staff", ParentID: intPtr(3)})
+
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+	mockCategoryRepo.AssertNotCalled(t, "UpdateCategory", mock.Anything, mock.Anything)
+}
+
+func TestCategoryUsecase_ReorderCategories(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+	mockCategoryRepo.On("ReorderCategories", mock.Anything, []int{5, 1, 4, 2}).Return(nil)
+
+	assert.ErrorIs(t, categoryUsecase.ReorderCategories(context.Background(), nil, []int{5, 1}), ErrInvalidCategoryOrder)
+	assert.ErrorIs(t, categoryUsecase.ReorderCategories(context.Background(), nil, []int{5, 1, 4, 3}), ErrInvalidCategoryOrder)
+	assert.NoError(t, categoryUsecase.ReorderCategories(context.Background(), nil, []int{5, 1, 4, 2}))
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestCategoryUsecase_MergeCategory(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+	mockCategoryRepo.On("MergeCategory", mock.Anything, 2, 1).Return(nil)
+
+	assert.ErrorIs(t, categoryUsecase.MergeCategory(context.Background(), entity.DefaultCategoryID, 5), ErrDefaultCategory)
+	assert.ErrorIs(t, categoryUsecase.MergeCategory(context.Background(), 2, 3), ErrCategoryCycle)
+	assert.ErrorIs(t, categoryUsecase.MergeCategory(context.Background(), 2, 42), ErrCategoryNotFound)
+	assert.NoError(t, categoryUsecase.MergeCategory(context.Background(), 2, 1))
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestCategoryUsecase_SetArchived(t *testing.T) {
+	mockCategoryRepo, categoryUsecase := newTestCategoryUsecase()
+	mockCategoryRepo.On("SetCategoryArchived", mock.Anything, 42, true).Return(entity.Category{}, sql.ErrNoRows)
+
+	_, err := categoryUsecase.SetArchived(context.Background(), entity.DefaultCategoryID, true)
+	assert.ErrorIs(t, err, ErrDefaultCategory)
+
+	_, err = categoryUsecase.SetArchived(context.Background(), 42, true)
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// chatCleanupBatchSize — сколько сообщений очистка выгружает и удаляет за
+// раз; каждая порция попадает в отдельный файл архива.
+const chatCleanupBatchSize = 1000
+
+type ChatRetentionUsecase interface {
+	// Cleanup удаляет сообщения чата, срок хранения которых истек. Если
+	// архив включен, сообщения сначала выгружаются в него; порция, которую
+	// не удалось выгрузить, остается в базе.
+	Cleanup(ctx context.Context) (entity.ChatCleanupResult, error)
+	// RunJanitor запускает очистку каждые interval, пока не отменен ctx.
+	RunJanitor(ctx context.Context, interval time.Duration)
+}
+
+type chatRetentionUsecase struct {
+	chatRepo    repository.ChatRepository
+	archiveRepo repository.ChatArchiveRepository
+	retention   time.Duration
+	logger      *zap.Logger
+}
+
+// NewChatRetentionUsecase создает очистку чата. Сообщения комнат, для
+// которых срок не задан, хранятся retention; 0 — бессрочно. archiveRepo
+// nil отключает архив.
+func NewChatRetentionUsecase(
+	chatRepo repository.ChatRepository,
+	archiveRepo repository.ChatArchiveRepository,
+	retention time.Duration,
+	logger *zap.Logger,
+) ChatRetentionUsecase {
+	return &chatRetentionUsecase{chatRepo: chatRepo, archiveRepo: archiveRepo, retention: retention, logger: logger}
+}
+
+func (u *chatRetentionUsecase) Cleanup(ctx context.Context) (entity.ChatCleanupResult, error) {
+	now := time.Now()
+
+	var result entity.ChatCleanupResult
+	for {
+		messages, err := u.chatRepo.GetExpiredMessages(ctx, now, u.retention, chatCleanupBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+
+		if u.archiveRepo != nil {
+			if _, err := u.archiveRepo.Archive(ctx, messages); err != nil {
+				return result, err
+			}
+			result.Archives++
+		}
+
+		ids := make([]int, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+		deleted, err := u.chatRepo.DeleteMessages(ctx, ids)
+		if err != nil {
+			return result, err
+		}
+		result.Messages += deleted
+
+		// Если порция не удалилась, следующая выборка вернет ее же
+		if len(messages) < chatCleanupBatchSize || deleted == 0 {
+			break
+		}
+	}
+
+	if result.Messages > 0 {
+		u.logger.Info("Expired chat messages removed", zap.Int("messages", result.Messages), zap.Int("archives", result.Archives))
+	}
+	return result, nil
+}
+
+// RunJanitor работает и при бессрочном хранении по умолчанию: у отдельных
+// комнат срок может быть задан.
+func (u *chatRetentionUsecase) RunJanitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := u.Cleanup(ctx); err != nil {
+			u.logger.Error("Failed to clean up chat messages", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func expiredBatch(firstID, count int) []entity.ChatMessage {
+	messages := make([]entity.ChatMessage, count)
+	for i := range messages {
+		messages[i] = entity.ChatMessage{ID: firstID + i, RoomID: entity.DefaultChatRoomID}
+	}
+	return messages
+}
+
+func TestChatRetentionUsecase_Cleanup_ArchivesBeforeDelete(t *testing.T) {
+	chatRepo := new(mocks.ChatRepository)
+	archiveRepo := new(mocks.ChatArchiveRepository)
+	u := NewChatRetentionUsecase(chatRepo, archiveRepo, 24*time.Hour, zap.NewNop())
+
+	full := expiredBatch(1, chatCleanupBatchSize)
+	rest := expiredBatch(chatCleanupBatchSize+1, 2)
+	chatRepo.On("GetExpiredMessages", mock.Anything, mock.Anything, 24*time.Hour, chatCleanupBatchSize).Return(full, nil).Once()
+	chatRepo.On("GetExpiredMessages", mock.Anything, mock.Anything, 24*time.Hour, chatCleanupBatchSize).Return(rest, nil).Once()
+	archiveRepo.On("Archive", mock.Anything, full).Return("chat-1.ndjson.gz", nil).Once()
+	archiveRepo.On("Archive", mock.Anything, rest).Return("chat-2.ndjson.gz", nil).Once()
+	chatRepo.On("DeleteMessages", mock.Anything, mock.Anything).Return(func(_ context.Context, ids []int) int { return len(ids) }, nil)
+
+	result, err := u.Cleanup(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ChatCleanupResult{Messages: chatCleanupBatchSize + 2, Archives: 2}, result)
+	chatRepo.AssertNumberOfCalls(t, "DeleteMessages", 2)
+	chatRepo.AssertCalled(t, "DeleteMessages", mock.Anything, []int{chatCleanupBatchSize + 1, chatCleanupBatchSize + 2})
+}
+
+func TestChatRetentionUsecase_Cleanup_ArchiveFailureKeepsMessages(t *testing.T) {
+	chatRepo := new(mocks.ChatRepository)
+	archiveRepo := new(mocks.ChatArchiveRepository)
+	u := NewChatRetentionUsecase(chatRepo, archiveRepo, time.Hour, zap.NewNop())
+
+	chatRepo.On("GetExpiredMessages", mock.Anything, mock.Anything, time.Hour, chatCleanupBatchSize).Return(expiredBatch(1, 3), nil)
+	archiveRepo.On("Archive", mock.Anything, mock.Anything).Return("", errors.New("no space left on device"))
+
+	result, err := u.Cleanup(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, entity.ChatCleanupResult{}, result)
+	chatRepo.AssertNotCalled(t, "DeleteMessages", mock.Anything, mock.Anything)
+}
+
+func TestChatRetentionUsecase_Cleanup_WithoutArchive(t *testing.T) {
+	chatRepo := new(mocks.ChatRepository)
+	u := NewChatRetentionUsecase(chatRepo, nil, 0, zap.NewNop())
+
+	chatRepo.On("GetExpiredMessages", mock.Anything, mock.Anything, time.Duration(0), chatCleanupBatchSize).Return(expiredBatch(5, 2), nil)
+	chatRepo.On("DeleteMessages", mock.Anything, []int{5, 6}).Return(2, nil)
+
+	result, err := u.Cleanup(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ChatCleanupResult{Messages: 2}, result)
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChatRoomNotFound      = errors.New("chat room not found")
+	ErrChatRoomForbidden     = errors.New("not allowed to manage this chat room")
+	ErrChatRoomNotPrivate    = errors.New("members are managed only in private chat rooms")
+	ErrInvalidChatRoomName   = errors.New("chat room name must be from 1 to 100 characters")
+	ErrAlreadyChatRoomMember = errors.New("user is already a member of the chat room")
+	ErrNotChatRoomMember     = errors.New("user is not a member of the chat room")
+	ErrInvalidChatRetention  = errors.New("chat room retention must not be negative")
+)
+
+const maxChatRoomNameLength = 100
+
+type ChatRoomUsecase interface {
+	// GetRooms возвращает открытые комнаты и закрытые комнаты principal
+	// (nil — гость).
+	GetRooms(ctx context.Context, principal *entity.Principal) ([]entity.ChatRoom, error)
+	// GetRoom возвращает комнату, если principal может в нее войти. Закрытая
+	// комната, в которой он не состоит, и комната недоступного поста для
+	// него не существуют: возвращается ErrChatRoomNotFound.
+	GetRoom(ctx context.Context, id int, principal *entity.Principal) (entity.ChatRoom, error)
+	// GetPostRoom возвращает комнату поста, создавая ее при первом обращении.
+	GetPostRoom(ctx context.Context, postID int, principal *entity.Principal) (entity.ChatRoom, error)
+	// CreateRoom создает открытую или закрытую комнату. Открытые комнаты
+	// создаются с правом chat.room.manage.
+	CreateRoom(ctx context.Context, req entity.CreateChatRoomRequest, principal entity.Principal) (entity.ChatRoom, error)
+	GetMembers(ctx context.Context, roomID int, principal entity.Principal) ([]entity.ChatRoomMember, error)
+	// AddMember приглашает пользователя в закрытую комнату. Приглашают
+	// владелец комнаты и пользователи с правом chat.room.manage.
+	AddMember(ctx context.Context, roomID, userID int, principal entity.Principal) (entity.ChatRoomMember, error)
+	// RemoveMember исключает пользователя из закрытой комнаты. Выйти из
+	// комнаты может любой участник, исключить другого — тот, кто может
+	// приглашать; владельца — только пользователь с правом chat.room.manage.
+	RemoveMember(ctx context.Context, roomID, userID int, principal entity.Principal) error
+	// SetRetention задает срок хранения сообщений комнаты в секундах: nil —
+	// срок по умолчанию, 0 — бессрочно. Требует права chat.room.manage.
+	SetRetention(ctx context.Context, roomID int, seconds *int, principal entity.Principal) (entity.ChatRoom, error)
+}
+
+type chatRoomUsecase struct {
+	roomRepo        repository.ChatRoomRepository
+	postRepo        repository.PostRepository
+	categoryUsecase CategoryUsecase
+	logger          *zap.Logger
+}
+
+func NewChatRoomUsecase(
+	roomRepo repository.ChatRoomRepository,
+	postRepo repository.PostRepository,
+	categoryUsecase CategoryUsecase,
+	logger *zap.Logger,
+) ChatRoomUsecase {
+	return &chatRoomUsecase{roomRepo: roomRepo, postRepo: postRepo, categoryUsecase: categoryUsecase, logger: logger}
+}
+
+func (u *chatRoomUsecase) GetRooms(ctx context.Context, principal *entity.Principal) ([]entity.ChatRoom, error) {
+	userID := 0
+	if principal != nil {
+		userID = principal.UserID
+	}
+	return u.roomRepo.GetRooms(ctx, userID)
+}
+
+func (u *chatRoomUsecase) GetRoom(ctx context.Context, id int, principal *entity.Principal) (entity.ChatRoom, error) {
+	room, err := u.roomRepo.GetRoom(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ChatRoom{}, ErrChatRoomNotFound
+		}
+		return entity.ChatRoom{}, err
+	}
+	if err := u.checkRead(ctx, room, principal); err != nil {
+		return entity.ChatRoom{}, err
+	}
+	return room, nil
+}
+
+func (u *chatRoomUsecase) checkRead(ctx context.Context, room entity.ChatRoom, principal *entity.Principal) error {
+	switch room.Kind {
+	case entity.ChatRoomPrivate:
+		if principal == nil {
+			return ErrChatRoomNotFound
+		}
+		if principal.Can(entity.PermChatRoomManage) {
+			return nil
+		}
+		if _, err := u.roomRepo.GetMember(ctx, room.ID, principal.UserID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrChatRoomNotFound
+			}
+			return err
+		}
+	case entity.ChatRoomPost:
+		if room.PostID == nil {
+			return ErrChatRoomNotFound
+		}
+		err := u.categoryUsecase.CheckPostAccess(ctx, *room.PostID, principal, entity.CategoryActionRead)
+		if errors.Is(err, ErrPostNotFound) {
+			return ErrChatRoomNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *chatRoomUsecase) GetPostRoom(ctx context.Context, postID int, principal *entity.Principal) (entity.ChatRoom, error) {
+	if err := u.categoryUsecase.CheckPostAccess(ctx, postID, principal, entity.CategoryActionRead); err != nil {
+		return entity.ChatRoom{}, err
+	}
+	post, err := u.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ChatRoom{}, ErrPostNotFound
+		}
+		return entity.ChatRoom{}, err
+	}
+	return u.roomRepo.EnsurePostRoom(ctx, postID, truncateRunes(post.Title, maxChatRoomNameLength))
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+func (u *chatRoomUsecase) CreateRoom(ctx context.Context, req entity.CreateChatRoomRequest, principal entity.Principal) (entity.ChatRoom, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxChatRoomNameLength {
+		return entity.ChatRoom{}, ErrInvalidChatRoomName
+	}
+	if req.Kind == entity.ChatRoomPublic && !principal.Can(entity.PermChatRoomManage) {
+		return entity.ChatRoom{}, ErrChatRoomForbidden
+	}
+	if principal.Sanctions.ChatBlock() != nil {
+		return entity.ChatRoom{}, ErrChatMuted
+	}
+
+	createdBy := principal.UserID
+	room, err := u.roomRepo.CreateRoom(ctx, entity.ChatRoom{Kind: req.Kind, Name: name, CreatedBy: &createdBy})
+	if err != nil {
+		return entity.ChatRoom{}, err
+	}
+	u.logger.Info("Chat room created", zap.Int("roomID", room.ID), zap.String("kind", room.Kind), zap.Int("userID", principal.UserID))
+	return room, nil
+}
+
+// privateRoom возвращает закрытую комнату, в которую может войти principal.
+func (u *chatRoomUsecase) privateRoom(ctx context.Context, roomID int, principal entity.Principal) (entity.ChatRoom, error) {
+	room, err := u.GetRoom(ctx, roomID, &principal)
+	if err != nil {
+		return entity.ChatRoom{}, err
+	}
+	if room.Kind != entity.ChatRoomPrivate {
+		return entity.ChatRoom{}, ErrChatRoomNotPrivate
+	}
+	return room, nil
+}
+
+// canManage сообщает, может ли principal приглашать и исключать участников.
+func (u *chatRoomUsecase) canManage(ctx context.Context, roomID int, principal entity.Principal) (bool, error) {
+	if principal.Can(entity.PermChatRoomManage) {
+		return true, nil
+	}
+	member, err := u.roomRepo.GetMember(ctx, roomID, principal.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return member.Role == entity.ChatRoleOwner, nil
+}
+
+func (u *chatRoomUsecase) GetMembers(ctx context.Context, roomID int, principal entity.Principal) ([]entity.ChatRoomMember, error) {
+	if _, err := u.privateRoom(ctx, roomID, principal); err != nil {
+		return nil, err
+	}
+	return u.roomRepo.GetMembers(ctx, roomID)
+}
+
+func (u *chatRoomUsecase) AddMember(ctx context.Context, roomID, userID int, principal entity.Principal) (entity.ChatRoomMember, error) {
+	if _, err := u.privateRoom(ctx, roomID, principal); err != nil {
+		return entity.ChatRoomMember{}, err
+	}
+	allowed, err := u.canManage(ctx, roomID, principal)
+	if err != nil {
+		return entity.ChatRoomMember{}, err
+	}
+	if !allowed {
+		return entity.ChatRoomMember{}, ErrChatRoomForbidden
+	}
+
+	added, err := u.roomRepo.AddMember(ctx, entity.ChatRoomMember{RoomID: roomID, UserID: userID, Role: entity.ChatRoleMember})
+	if err != nil {
+		return entity.ChatRoomMember{}, err
+	}
+	if !added {
+		return entity.ChatRoomMember{}, ErrAlreadyChatRoomMember
+	}
+	u.logger.Info("Chat room member added", zap.Int("roomID", roomID), zap.Int("userID", userID), zap.Int("addedBy", principal.UserID))
+	return u.roomRepo.GetMember(ctx, roomID, userID)
+}
+
+func (u *chatRoomUsecase) RemoveMember(ctx context.Context, roomID, userID int, principal entity.Principal) error {
+	if _, err := u.privateRoom(ctx, roomID, principal); err != nil {
+		return err
+	}
+	member, err := u.roomRepo.GetMember(ctx, roomID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotChatRoomMember
+		}
+		return err
+	}
+
+	if userID != principal.UserID {
+		allowed, err := u.canManage(ctx, roomID, principal)
+		if err != nil {
+			return err
+		}
+		if !allowed || (member.Role == entity.ChatRoleOwner && !principal.Can(entity.PermChatRoomManage)) {
+			return ErrChatRoomForbidden
+		}
+	}
+
+	removed, err := u.roomRepo.RemoveMember(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotChatRoomMember
+	}
+	u.logger.Info("Chat room member removed", zap.Int("roomID", roomID), zap.Int("userID", userID), zap.Int("removedBy", principal.UserID))
+	return nil
+}
+
+func (u *chatRoomUsecase) SetRetention(ctx context.Context, roomID int, seconds *int, principal entity.Principal) (entity.ChatRoom, error) {
+	if !principal.Can(entity.PermChatRoomManage) {
+		return entity.ChatRoom{}, ErrChatRoomForbidden
+	}
+	if seconds != nil && *seconds < 0 {
+		return entity.ChatRoom{}, ErrInvalidChatRetention
+	}
+
+	if err := u.roomRepo.SetRetention(ctx, roomID, seconds); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ChatRoom{}, ErrChatRoomNotFound
+		}
+		return entity.ChatRoom{}, err
+	}
+	fields := []zap.Field{zap.Int("roomID", roomID), zap.Int("userID", principal.UserID)}
+	if seconds != nil {
+		fields = append(fields, zap.Int("retentionSeconds", *seconds))
+	}
+	u.logger.Info("Chat room retention changed", fields...)
+	return u.roomRepo.GetRoom(ctx, roomID)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestChatRoomUsecase() (ChatRoomUsecase, *mocks.ChatRoomRepository, *mocks.PostRepository, *mocks.CategoryUsecase) {
+	roomRepo := new(mocks.ChatRoomRepository)
+	postRepo := new(mocks.PostRepository)
+	categoryUsecase := new(mocks.CategoryUsecase)
+	return NewChatRoomUsecase(roomRepo, postRepo, categoryUsecase, zap.NewNop()), roomRepo, postRepo, categoryUsecase
+}
+
+func TestChatRoomUsecase_GetRoom_PrivateHiddenFromOutsiders(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+	room := entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate, Name: "Модераторы"}
+
+	roomRepo.On("GetRoom", mock.Anything, 2).Return(room, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 5).Return(entity.ChatRoomMember{}, sql.ErrNoRows)
+	roomRepo.On("GetMember", mock.Anything, 2, 6).Return(entity.ChatRoomMember{RoomID: 2, UserID: 6, Role: entity.ChatRoleMember}, nil)
+
+	_, err := u.GetRoom(context.Background(), 2, nil)
+	assert.ErrorIs(t, err, ErrChatRoomNotFound)
+	_, err = u.GetRoom(context.Background(), 2, &entity.Principal{UserID: 5, Role: "user"})
+	assert.ErrorIs(t, err, ErrChatRoomNotFound)
+
+	got, err := u.GetRoom(context.Background(), 2, &entity.Principal{UserID: 6, Role: "user"})
+	assert.NoError(t, err)
+	assert.Equal(t, room, got)
+	got, err = u.GetRoom(context.Background(), 2, &entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}})
+	assert.NoError(t, err)
+	assert.Equal(t, room, got)
+}
+
+func TestChatRoomUsecase_GetRoom_PostRoomFollowsPostAccess(t *testing.T) {
+	u, roomRepo, _, categoryUsecase := newTestChatRoomUsecase()
+	postID := 7
+
+	roomRepo.On("GetRoom", mock.Anything, 3).Return(entity.ChatRoom{ID: 3, Kind: entity.ChatRoomPost, PostID: &postID}, nil)
+	roomRepo.On("GetRoom", mock.Anything, 9).Return(entity.ChatRoom{}, sql.ErrNoRows)
+	categoryUsecase.On("CheckPostAccess", mock.Anything, 7, (*entity.Principal)(nil), entity.CategoryActionRead).Return(ErrPostNotFound)
+
+	_, err := u.GetRoom(context.Background(), 3, nil)
+	assert.ErrorIs(t, err, ErrChatRoomNotFound)
+	_, err = u.GetRoom(context.Background(), 9, nil)
+	assert.ErrorIs(t, err, ErrChatRoomNotFound)
+}
+
+func TestChatRoomUsecase_GetPostRoom(t *testing.T) {
+	u, roomRepo, postRepo, categoryUsecase := newTestChatRoomUsecase()
+	title := strings.Repeat("ё", 120)
+	room := entity.ChatRoom{ID: 4, Kind: entity.ChatRoomPost, Name: strings.Repeat("ё", 100)}
+
+	categoryUsecase.On("CheckPostAccess", mock.Anything, 7, (*entity.Principal)(nil), entity.CategoryActionRead).Return(nil)
+	postRepo.On("GetPostByID", mock.Anything, 7).Return(&entity.Post{ID: 7, Title: title}, nil)
+	roomRepo.On("EnsurePostRoom", mock.Anything, 7, strings.Repeat("ё", 100)).Return(room, nil)
+
+	got, err := u.GetPostRoom(context.Background(), 7, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, room, got)
+	roomRepo.AssertExpectations(t)
+}
+
+func TestChatRoomUsecase_CreateRoom(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+	user := entity.Principal{UserID: 5, Role: "user"}
+	ownerID := 5
+
+	roomRepo.On("CreateRoom", mock.Anything, entity.ChatRoom{Kind: entity.ChatRoomPrivate, Name: "Друзья", CreatedBy: &ownerID}).
+		Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate, Name: "Друзья", CreatedBy: &ownerID, MembersCount: 1}, nil)
+
+	room, err := u.CreateRoom(context.Background(), entity.CreateChatRoomRequest{Name: "  Друзья ", Kind: entity.ChatRoomPrivate}, user)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, room.ID)
+
+	_, err = u.CreateRoom(context.Background(), entity.CreateChatRoomRequest{Name: "Новости", Kind: entity.ChatRoomPublic}, user)
+	assert.ErrorIs(t, err, ErrChatRoomForbidden)
+	_, err = u.CreateRoom(context.Background(), entity.CreateChatRoomRequest{Name: "   ", Kind: entity.ChatRoomPrivate}, user)
+	assert.ErrorIs(t, err, ErrInvalidChatRoomName)
+
+	muted := user
+	muted.Sanctions = entity.Sanctions{{Type: entity.SanctionMute}}
+	_, err = u.CreateRoom(context.Background(), entity.CreateChatRoomRequest{Name: "Флуд", Kind: entity.ChatRoomPrivate}, muted)
+	assert.ErrorIs(t, err, ErrChatMuted)
+	roomRepo.AssertNumberOfCalls(t, "CreateRoom", 1)
+}
+
+func TestChatRoomUsecase_AddMember(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+	owner := entity.Principal{UserID: 5, Role: "user"}
+	member := entity.Principal{UserID: 6, Role: "user"}
+
+	roomRepo.On("GetRoom", mock.Anything, 2).Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate}, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 5).Return(entity.ChatRoomMember{RoomID: 2, UserID: 5, Role: entity.ChatRoleOwner}, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 6).Return(entity.ChatRoomMember{RoomID: 2, UserID: 6, Role: entity.ChatRoleMember}, nil)
+	roomRepo.On("AddMember", mock.Anything, entity.ChatRoomMember{RoomID: 2, UserID: 8, Role: entity.ChatRoleMember}).Return(true, nil).Once()
+	roomRepo.On("AddMember", mock.Anything, entity.ChatRoomMember{RoomID: 2, UserID: 8, Role: entity.ChatRoleMember}).Return(false, nil).Once()
+	roomRepo.On("GetMember", mock.Anything, 2, 8).Return(entity.ChatRoomMember{RoomID: 2, UserID: 8, Role: entity.ChatRoleMember}, nil)
+
+	added, err := u.AddMember(context.Background(), 2, 8, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, added.UserID)
+
+	_, err = u.AddMember(context.Background(), 2, 8, owner)
+	assert.ErrorIs(t, err, ErrAlreadyChatRoomMember)
+	_, err = u.AddMember(context.Background(), 2, 9, member)
+	assert.ErrorIs(t, err, ErrChatRoomForbidden)
+}
+
+func TestChatRoomUsecase_AddMember_NotPrivate(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+
+	roomRepo.On("GetRoom", mock.Anything, 1).Return(entity.ChatRoom{ID: 1, Kind: entity.ChatRoomPublic}, nil)
+
+	_, err := u.AddMember(context.Background(), 1, 8, entity.Principal{UserID: 5, Permissions: []string{entity.PermChatRoomManage}})
+
+	assert.ErrorIs(t, err, ErrChatRoomNotPrivate)
+	roomRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestChatRoomUsecase_RemoveMember(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+	owner := entity.Principal{UserID: 5, Role: "user"}
+	member := entity.Principal{UserID: 6, Role: "user"}
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}}
+
+	roomRepo.On("GetRoom", mock.Anything, 2).Return(entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPrivate}, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 5).Return(entity.ChatRoomMember{RoomID: 2, UserID: 5, Role: entity.ChatRoleOwner}, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 6).Return(entity.ChatRoomMember{RoomID: 2, UserID: 6, Role: entity.ChatRoleMember}, nil)
+	roomRepo.On("GetMember", mock.Anything, 2, 8).Return(entity.ChatRoomMember{}, sql.ErrNoRows)
+	roomRepo.On("RemoveMember", mock.Anything, 2, mock.Anything).Return(true, nil)
+
+	// Участник не может исключить владельца, но может выйти сам
+	assert.ErrorIs(t, u.RemoveMember(context.Background(), 2, 5, member), ErrChatRoomForbidden)
+	assert.NoError(t, u.RemoveMember(context.Background(), 2, 6, member))
+	// Владелец исключает участников, модератор — и владельца
+	assert.NoError(t, u.RemoveMember(context.Background(), 2, 6, owner))
+	assert.NoError(t, u.RemoveMember(context.Background(), 2, 5, moderator))
+	assert.ErrorIs(t, u.RemoveMember(context.Background(), 2, 8, owner), ErrNotChatRoomMember)
+	roomRepo.AssertNumberOfCalls(t, "RemoveMember", 3)
+}
+
+func TestChatRoomUsecase_SetRetention(t *testing.T) {
+	u, roomRepo, _, _ := newTestChatRoomUsecase()
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatRoomManage}}
+	day := 86400
+	room := entity.ChatRoom{ID: 2, Kind: entity.ChatRoomPublic, RetentionSeconds: &day}
+
+	roomRepo.On("SetRetention", mock.Anything, 2, &day).Return(nil)
+	roomRepo.On("SetRetention", mock.Anything, 99, (*int)(nil)).Return(sql.ErrNoRows)
+	roomRepo.On("GetRoom", mock.Anything, 2).Return(room, nil)
+
+	got, err := u.SetRetention(context.Background(), 2, &day, moderator)
+	assert.NoError(t, err)
+	assert.Equal(t, room, got)
+
+	_, err = u.SetRetention(context.Background(), 99, nil, moderator)
+	assert.ErrorIs(t, err, ErrChatRoomNotFound)
+	negative := -1
+	_, err = u.SetRetention(context.Background(), 2, &negative, moderator)
+	assert.ErrorIs(t, err, ErrInvalidChatRetention)
+	_, err = u.SetRetention(context.Background(), 2, &day, entity.Principal{UserID: 5, Role: "user"})
+	assert.ErrorIs(t, err, ErrChatRoomForbidden)
+	roomRepo.AssertNumberOfCalls(t, "SetRetention", 2)
+}
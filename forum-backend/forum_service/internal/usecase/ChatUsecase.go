@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
@@ -9,22 +12,71 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrChatMuted — у автора действующая санкция, запрещающая писать в чат.
+	ErrChatMuted              = errors.New("user is not allowed to write to the chat")
+	ErrChatMessageForbidden   = errors.New("not allowed to change this chat message")
+	ErrChatMessageEditExpired = errors.New("chat message can no longer be changed")
+	ErrInvalidChatMessage     = errors.New("chat message must be from 1 to 512 bytes")
+)
+
+const (
+	defaultChatMessagePageSize = 50
+	// maxChatMessageLength — правка через HTTP не должна обходить
+	// ограничение на размер сообщения сокета.
+	maxChatMessageLength = 512
+)
+
 type ChatUsecase interface {
-	HandleMessage(ctx context.Context, userID int, username, content string) error
-	GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error)
+	// HandleMessage сохраняет сообщение в комнате roomID и возвращает его.
+	// Санкции автора проверяются на каждое сообщение, чтобы мут действовал и
+	// на уже открытые соединения. Доступ к комнате проверяется при входе в нее.
+	HandleMessage(ctx context.Context, roomID, userID int, username, content string) (entity.ChatMessage, error)
+	GetRecentMessages(ctx context.Context, roomID, limit int) ([]entity.ChatMessage, error)
+	// GetMessages возвращает страницу истории комнаты от старых к новым: без
+	// before — последние сообщения, с before — более ранние. Второе значение
+	// — курсор следующей (более ранней) страницы; 0 — это начало истории.
+	// Доступ к комнате проверяет вызывающий.
+	GetMessages(ctx context.Context, roomID, before, limit int) ([]entity.ChatMessage, int, error)
+	// GetMissedMessages возвращает сообщения комнаты, пришедшие после
+	// сообщения since, — не больше limit последних. Второе значение
+	// сообщает, что пропущенных было больше и самые ранние не вернулись.
+	GetMissedMessages(ctx context.Context, roomID, since, limit int) ([]entity.ChatMessage, bool, error)
+	// EditMessage заменяет текст сообщения. Исправить сообщение может только
+	// автор и только в течение окна правки после отправки.
+	EditMessage(ctx context.Context, id int, content string, principal entity.Principal) (entity.ChatMessage, error)
+	// DeleteMessage удаляет сообщение и возвращает его в том виде, в котором
+	// оно было. Автор удаляет свое сообщение в течение окна правки,
+	// пользователь с правом chat.message.delete.any — любое в любое время.
+	DeleteMessage(ctx context.Context, id int, principal entity.Principal) (entity.ChatMessage, error)
 }
 
 type chatUsecase struct {
-	repo   repository.ChatRepository
-	logger *zap.Logger
+	repo         repository.ChatRepository
+	sanctionRepo repository.SanctionRepository
+	editWindow   time.Duration
+	logger       *zap.Logger
 }
 
-func NewChatUsecase(repo repository.ChatRepository, logger *zap.Logger) ChatUsecase {
-	return &chatUsecase{repo: repo, logger: logger}
+// NewChatUsecase создает usecase чата. Автор может исправить или удалить
+// сообщение в течение editWindow после отправки; 0 снимает ограничение.
+func NewChatUsecase(repo repository.ChatRepository, sanctionRepo repository.SanctionRepository, editWindow time.Duration, logger *zap.Logger) ChatUsecase {
+	return &chatUsecase{repo: repo, sanctionRepo: sanctionRepo, editWindow: editWindow, logger: logger}
 }
 
-func (uc *chatUsecase) HandleMessage(ctx context.Context, userID int, username, content string) error {
+func (uc *chatUsecase) HandleMessage(ctx context.Context, roomID, userID int, username, content string) (entity.ChatMessage, error) {
+	sanctions, err := uc.sanctionRepo.GetActiveSanctions(ctx, userID)
+	if err != nil {
+		uc.logger.Error("Failed to check chat sanctions", zap.Int("userID", userID), zap.Error(err))
+		return entity.ChatMessage{}, err
+	}
+	if sanction := sanctions.ChatBlock(); sanction != nil {
+		uc.logger.Warn("Message from muted user dropped", zap.Int("userID", userID), zap.String("sanction", sanction.Type))
+		return entity.ChatMessage{}, ErrChatMuted
+	}
+
 	message := entity.ChatMessage{
+		RoomID:    roomID,
 		UserID:    userID,
 		Username:  username,
 		Content:   content,
@@ -32,25 +84,26 @@ func (uc *chatUsecase) HandleMessage(ctx context.Context, userID int, username,
 	}
 
 	uc.logger.Info("Handling message",
+		zap.Int("roomID", roomID),
 		zap.Int("userID", userID),
 		zap.String("username", username),
 		zap.String("content", content),
 	)
 
-	err := uc.repo.StoreMessage(ctx, message)
+	stored, err := uc.repo.StoreMessage(ctx, message)
 	if err != nil {
 		uc.logger.Error("Failed to store message", zap.Error(err))
-		return err
+		return entity.ChatMessage{}, err
 	}
 
-	uc.logger.Info("Message stored successfully", zap.Int("userID", userID), zap.String("username", username))
-	return nil
+	uc.logger.Info("Message stored successfully", zap.Int("messageID", stored.ID), zap.Int("userID", userID), zap.String("username", username))
+	return stored, nil
 }
 
-func (uc *chatUsecase) GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error) {
-	uc.logger.Info("Fetching recent messages", zap.Int("limit", limit))
+func (uc *chatUsecase) GetRecentMessages(ctx context.Context, roomID, limit int) ([]entity.ChatMessage, error) {
+	uc.logger.Info("Fetching recent messages", zap.Int("roomID", roomID), zap.Int("limit", limit))
 
-	messages, err := uc.repo.GetRecentMessages(ctx, limit)
+	messages, err := uc.repo.GetRecentMessages(ctx, roomID, limit)
 	if err != nil {
 		uc.logger.Error("Failed to get recent messages", zap.Error(err))
 		return nil, err
@@ -59,3 +112,100 @@ func (uc *chatUsecase) GetRecentMessages(ctx context.Context, limit int) ([]enti
 	uc.logger.Info("Recent messages fetched successfully", zap.Int("count", len(messages)))
 	return messages, nil
 }
+
+func (uc *chatUsecase) GetMessages(ctx context.Context, roomID, before, limit int) ([]entity.ChatMessage, int, error) {
+	if limit <= 0 {
+		limit = defaultChatMessagePageSize
+	}
+	messages, err := uc.repo.GetMessages(ctx, roomID, before, 0, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	next := 0
+	if len(messages) == limit {
+		next = messages[0].ID
+	}
+	return messages, next, nil
+}
+
+// GetMissedMessages запрашивает на одно сообщение больше: так видно, что
+// пропущенные не уместились в limit.
+func (uc *chatUsecase) GetMissedMessages(ctx context.Context, roomID, since, limit int) ([]entity.ChatMessage, bool, error) {
+	messages, err := uc.repo.GetMessages(ctx, roomID, 0, since, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(messages) > limit {
+		return messages[len(messages)-limit:], true, nil
+	}
+	return messages, false, nil
+}
+
+func (uc *chatUsecase) EditMessage(ctx context.Context, id int, content string, principal entity.Principal) (entity.ChatMessage, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > maxChatMessageLength {
+		return entity.ChatMessage{}, ErrInvalidChatMessage
+	}
+	msg, err := uc.getMessage(ctx, id)
+	if err != nil {
+		return entity.ChatMessage{}, err
+	}
+	if msg.UserID != principal.UserID {
+		return entity.ChatMessage{}, ErrChatMessageForbidden
+	}
+	if !uc.withinEditWindow(msg) {
+		return entity.ChatMessage{}, ErrChatMessageEditExpired
+	}
+	if principal.Sanctions.ChatBlock() != nil {
+		return entity.ChatMessage{}, ErrChatMuted
+	}
+
+	editedAt := time.Now()
+	if err := uc.repo.UpdateMessage(ctx, id, content, editedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ChatMessage{}, ErrChatMessageNotFound
+		}
+		return entity.ChatMessage{}, err
+	}
+	uc.logger.Info("Chat message edited", zap.Int("messageID", id), zap.Int("roomID", msg.RoomID), zap.Int("userID", principal.UserID))
+
+	msg.Content = content
+	msg.EditedAt = &editedAt
+	return msg, nil
+}
+
+func (uc *chatUsecase) DeleteMessage(ctx context.Context, id int, principal entity.Principal) (entity.ChatMessage, error) {
+	msg, err := uc.getMessage(ctx, id)
+	if err != nil {
+		return entity.ChatMessage{}, err
+	}
+	if !principal.Can(entity.PermChatMessageDeleteAny) {
+		if msg.UserID != principal.UserID {
+			return entity.ChatMessage{}, ErrChatMessageForbidden
+		}
+		if !uc.withinEditWindow(msg) {
+			return entity.ChatMessage{}, ErrChatMessageEditExpired
+		}
+	}
+
+	if err := uc.repo.DeleteMessage(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ChatMessage{}, ErrChatMessageNotFound
+		}
+		return entity.ChatMessage{}, err
+	}
+	uc.logger.Info("Chat message deleted", zap.Int("messageID", id), zap.Int("roomID", msg.RoomID), zap.Int("deletedBy", principal.UserID))
+	return msg, nil
+}
+
+func (uc *chatUsecase) getMessage(ctx context.Context, id int) (entity.ChatMessage, error) {
+	msg, err := uc.repo.GetMessageByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ChatMessage{}, ErrChatMessageNotFound
+	}
+	return msg, err
+}
+
+func (uc *chatUsecase) withinEditWindow(msg entity.ChatMessage) bool {
+	return uc.editWindow <= 0 || time.Since(msg.Timestamp) <= uc.editWindow
+}
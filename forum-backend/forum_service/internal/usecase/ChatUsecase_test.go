@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -19,21 +20,22 @@ func TestChatUsecase_HandleMessage_Success(t *testing.T) {
 
 	mockChatRepo := new(mocks.ChatRepository)
 
-	chatUsecase := NewChatUsecase(mockChatRepo, logger)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, logger)
 
 	userID := 1
 	username := "testuser"
 	content := "This is a test message"
 	message := entity.ChatMessage{
+		RoomID:    entity.DefaultChatRoomID,
 		UserID:    userID,
 		Username:  username,
 		Content:   content,
 		Timestamp: time.Now(),
 	}
 
-	mockChatRepo.On("StoreMessage", mock.Anything, message).Return(nil)
+	mockChatRepo.On("StoreMessage", mock.Anything, message).Return(message, nil)
 
-	err := chatUsecase.HandleMessage(context.Background(), userID, username, content)
+	_, err := chatUsecase.HandleMessage(context.Background(), entity.DefaultChatRoomID, userID, username, content)
 
 	assert.NoError(t, err)
 
@@ -46,21 +48,22 @@ func TestChatUsecase_HandleMessage_Failure(t *testing.T) {
 
 	mockChatRepo := new(mocks.ChatRepository)
 
-	chatUsecase := NewChatUsecase(mockChatRepo, logger)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, logger)
 
 	userID := 1
 	username := "testuser"
 	content := "This is a test message"
 	message := entity.ChatMessage{
+		RoomID:    entity.DefaultChatRoomID,
 		UserID:    userID,
 		Username:  username,
 		Content:   content,
 		Timestamp: time.Now(),
 	}
 
-	mockChatRepo.On("StoreMessage", mock.Anything, message).Return(errors.New("failed to store message"))
+	mockChatRepo.On("StoreMessage", mock.Anything, message).Return(entity.ChatMessage{}, errors.New("failed to store message"))
 
-	err := chatUsecase.HandleMessage(context.Background(), userID, username, content)
+	_, err := chatUsecase.HandleMessage(context.Background(), entity.DefaultChatRoomID, userID, username, content)
 
 	assert.Error(t, err)
 
@@ -73,7 +76,7 @@ func TestChatUsecase_GetRecentMessages_Success(t *testing.T) {
 
 	mockChatRepo := new(mocks.ChatRepository)
 
-	chatUsecase := NewChatUsecase(mockChatRepo, logger)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, logger)
 
 	limit := 10
 	messages := []entity.ChatMessage{
@@ -81,9 +84,9 @@ func TestChatUsecase_GetRecentMessages_Success(t *testing.T) {
 		{UserID: 2, Username: "user2", Content: "Message 2", Timestamp: time.Now()},
 	}
 
-	mockChatRepo.On("GetRecentMessages", mock.Anything, limit).Return(messages, nil)
+	mockChatRepo.On("GetRecentMessages", mock.Anything, entity.DefaultChatRoomID, limit).Return(messages, nil)
 
-	result, err := chatUsecase.GetRecentMessages(context.Background(), limit)
+	result, err := chatUsecase.GetRecentMessages(context.Background(), entity.DefaultChatRoomID, limit)
 
 	assert.NoError(t, err)
 	assert.Equal(t, messages, result)
@@ -97,16 +100,155 @@ func TestChatUsecase_GetRecentMessages_Failure(t *testing.T) {
 
 	mockChatRepo := new(mocks.ChatRepository)
 
-	chatUsecase := NewChatUsecase(mockChatRepo, logger)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, logger)
 
 	limit := 10
 
-	mockChatRepo.On("GetRecentMessages", mock.Anything, limit).Return(nil, errors.New("failed to get recent messages"))
+	mockChatRepo.On("GetRecentMessages", mock.Anything, entity.DefaultChatRoomID, limit).Return(nil, errors.New("failed to get recent messages"))
 
-	result, err := chatUsecase.GetRecentMessages(context.Background(), limit)
+	result, err := chatUsecase.GetRecentMessages(context.Background(), entity.DefaultChatRoomID, limit)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 
 	mockChatRepo.AssertExpectations(t)
 }
+
+// noChatSanctions возвращает репозиторий санкций без действующих санкций.
+func noChatSanctions() *mocks.SanctionRepository {
+	repo := new(mocks.SanctionRepository)
+	repo.On("GetActiveSanctions", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return repo
+}
+
+func TestChatUsecase_HandleMessage_Muted(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	mockSanctionRepo := new(mocks.SanctionRepository)
+
+	mockSanctionRepo.On("GetActiveSanctions", mock.Anything, 1).
+		Return(entity.Sanctions{{ID: 4, Type: entity.SanctionMute, Reason: "флуд"}}, nil)
+
+	chatUsecase := NewChatUsecase(mockChatRepo, mockSanctionRepo, 0, zap.NewNop())
+
+	_, err := chatUsecase.HandleMessage(context.Background(), entity.DefaultChatRoomID, 1, "testuser", "hello")
+
+	assert.ErrorIs(t, err, ErrChatMuted)
+	mockChatRepo.AssertNotCalled(t, "StoreMessage", mock.Anything, mock.Anything)
+}
+
+func TestChatUsecase_GetMessages_Cursor(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, zap.NewNop())
+
+	mockChatRepo.On("GetMessages", mock.Anything, 2, 40, 0, 2).Return([]entity.ChatMessage{{ID: 30}, {ID: 35}}, nil).Once()
+	mockChatRepo.On("GetMessages", mock.Anything, 2, 30, 0, 2).Return([]entity.ChatMessage{{ID: 12}}, nil).Once()
+
+	messages, next, err := chatUsecase.GetMessages(context.Background(), 2, 40, 2)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, 30, next)
+
+	_, next, err = chatUsecase.GetMessages(context.Background(), 2, next, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, next)
+}
+
+func TestChatUsecase_GetMissedMessages(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 0, zap.NewNop())
+
+	mockChatRepo.On("GetMessages", mock.Anything, 2, 0, 10, 3).Return([]entity.ChatMessage{{ID: 11}, {ID: 14}}, nil).Once()
+	mockChatRepo.On("GetMessages", mock.Anything, 2, 0, 10, 3).Return([]entity.ChatMessage{{ID: 12}, {ID: 14}, {ID: 15}}, nil).Once()
+
+	missed, gap, err := chatUsecase.GetMissedMessages(context.Background(), 2, 10, 2)
+	assert.NoError(t, err)
+	assert.False(t, gap)
+	assert.Equal(t, []entity.ChatMessage{{ID: 11}, {ID: 14}}, missed)
+
+	// Пропущенных больше лимита: возвращаются последние
+	missed, gap, err = chatUsecase.GetMissedMessages(context.Background(), 2, 10, 2)
+	assert.NoError(t, err)
+	assert.True(t, gap)
+	assert.Equal(t, []entity.ChatMessage{{ID: 14}, {ID: 15}}, missed)
+}
+
+func TestChatUsecase_EditMessage(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 15*time.Minute, zap.NewNop())
+	author := entity.Principal{UserID: 1, Role: "user"}
+
+	fresh := entity.ChatMessage{ID: 10, RoomID: 2, UserID: 1, Content: "helo", Timestamp: time.Now().Add(-time.Minute)}
+	stale := entity.ChatMessage{ID: 11, RoomID: 2, UserID: 1, Content: "old", Timestamp: time.Now().Add(-time.Hour)}
+	mockChatRepo.On("GetMessageByID", mock.Anything, 10).Return(fresh, nil)
+	mockChatRepo.On("GetMessageByID", mock.Anything, 11).Return(stale, nil)
+	mockChatRepo.On("GetMessageByID", mock.Anything, 12).Return(entity.ChatMessage{}, sql.ErrNoRows)
+	mockChatRepo.On("UpdateMessage", mock.Anything, 10, "hello", mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	msg, err := chatUsecase.EditMessage(context.Background(), 10, "  hello ", author)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", msg.Content)
+	assert.Equal(t, 2, msg.RoomID)
+	assert.NotNil(t, msg.EditedAt)
+
+	_, err = chatUsecase.EditMessage(context.Background(), 10, "hello", entity.Principal{UserID: 2, Role: "user"})
+	assert.ErrorIs(t, err, ErrChatMessageForbidden)
+
+	_, err = chatUsecase.EditMessage(context.Background(), 11, "new", author)
+	assert.ErrorIs(t, err, ErrChatMessageEditExpired)
+
+	_, err = chatUsecase.EditMessage(context.Background(), 12, "new", author)
+	assert.ErrorIs(t, err, ErrChatMessageNotFound)
+
+	_, err = chatUsecase.EditMessage(context.Background(), 10, "   ", author)
+	assert.ErrorIs(t, err, ErrInvalidChatMessage)
+
+	muted := author
+	muted.Sanctions = entity.Sanctions{{ID: 4, Type: entity.SanctionMute}}
+	_, err = chatUsecase.EditMessage(context.Background(), 10, "hello", muted)
+	assert.ErrorIs(t, err, ErrChatMuted)
+
+	mockChatRepo.AssertNumberOfCalls(t, "UpdateMessage", 1)
+}
+
+func TestChatUsecase_DeleteMessage(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 15*time.Minute, zap.NewNop())
+	author := entity.Principal{UserID: 1, Role: "user"}
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatMessageDeleteAny}}
+
+	fresh := entity.ChatMessage{ID: 10, RoomID: 2, UserID: 1, Timestamp: time.Now().Add(-time.Minute)}
+	stale := entity.ChatMessage{ID: 11, RoomID: 2, UserID: 1, Timestamp: time.Now().Add(-time.Hour)}
+	mockChatRepo.On("GetMessageByID", mock.Anything, 10).Return(fresh, nil)
+	mockChatRepo.On("GetMessageByID", mock.Anything, 11).Return(stale, nil)
+	mockChatRepo.On("DeleteMessage", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := chatUsecase.DeleteMessage(context.Background(), 10, entity.Principal{UserID: 2, Role: "user"})
+	assert.ErrorIs(t, err, ErrChatMessageForbidden)
+
+	_, err = chatUsecase.DeleteMessage(context.Background(), 11, author)
+	assert.ErrorIs(t, err, ErrChatMessageEditExpired)
+
+	msg, err := chatUsecase.DeleteMessage(context.Background(), 10, author)
+	assert.NoError(t, err)
+	assert.Equal(t, fresh, msg)
+
+	// Модератор удаляет чужое сообщение и после окна правки
+	_, err = chatUsecase.DeleteMessage(context.Background(), 11, moderator)
+	assert.NoError(t, err)
+
+	mockChatRepo.AssertNumberOfCalls(t, "DeleteMessage", 2)
+}
+
+func TestChatUsecase_DeleteMessage_AlreadyDeleted(t *testing.T) {
+	mockChatRepo := new(mocks.ChatRepository)
+	chatUsecase := NewChatUsecase(mockChatRepo, noChatSanctions(), 15*time.Minute, zap.NewNop())
+	moderator := entity.Principal{UserID: 7, Role: "moderator", Permissions: []string{entity.PermChatMessageDeleteAny}}
+
+	// Сообщение удалили между чтением и удалением
+	mockChatRepo.On("GetMessageByID", mock.Anything, 10).Return(entity.ChatMessage{ID: 10, RoomID: 2, UserID: 1}, nil)
+	mockChatRepo.On("DeleteMessage", mock.Anything, 10).Return(sql.ErrNoRows)
+
+	_, err := chatUsecase.DeleteMessage(context.Background(), 10, moderator)
+
+	assert.ErrorIs(t, err, ErrChatMessageNotFound)
+}
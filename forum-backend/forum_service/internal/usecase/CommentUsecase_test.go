@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
@@ -102,3 +104,238 @@ func TestCommentsUsecases_GetCommentByPostID_Failure(t *testing.T) {
 
 	mockCommentRepo.AssertExpectations(t)
 }
+
+func intPtr(v int) *int { return &v }
+
+func TestCommentsUsecases_CreateComment_Reply(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, logger)
+
+	reply := entity.Comment{PostId: 1, AuthorId: 2, ParentId: intPtr(10), Content: "reply"}
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 10).Return(entity.Comment{ID: 10, PostId: 1}, nil)
+	mockCommentRepo.On("CreateComment", mock.Anything, reply).Return(entity.Comment{ID: 11, PostId: 1, ParentId: intPtr(10)}, nil)
+
+	result, err := commentsUsecases.CreateComment(context.Background(), reply)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10, *result.ParentId)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestCommentsUsecases_CreateComment_InvalidParent(t *testing.T) {
+	cases := map[string]struct {
+		parent entity.Comment
+		err    error
+		want   error
+	}{
+		"missing":      {err: sql.ErrNoRows, want: ErrInvalidParentComment},
+		"another post": {parent: entity.Comment{ID: 10, PostId: 2}, want: ErrInvalidParentComment},
+		"deleted":      {parent: entity.Comment{ID: 10, PostId: 1, Deleted: true}, want: ErrParentCommentDeleted},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			logger, _ := zap.NewProduction()
+			mockCommentRepo := new(mocks.CommentsRepository)
+			commentsUsecases := NewCommentsUsecases(mockCommentRepo, logger)
+
+			mockCommentRepo.On("GetCommentByID", mock.Anything, 10).Return(tc.parent, tc.err)
+
+			_, err := commentsUsecases.CreateComment(context.Background(), entity.Comment{PostId: 1, ParentId: intPtr(10), Content: "reply"})
+
+			assert.ErrorIs(t, err, tc.want)
+			mockCommentRepo.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCommentsUsecases_DeleteComment_Success(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, logger)
+
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, PostId: 1}, nil)
+	mockCommentRepo.On("DeleteComment", mock.Anything, 1, 5).Return(nil)
+
+	err := commentsUsecases.DeleteComment(context.Background(), 1, 5)
+
+	assert.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestCommentsUsecases_DeleteComment_AlreadyDeleted(t *testing.T) {
+	cases := map[string]struct {
+		comment   entity.Comment
+		deleteErr error
+	}{
+		"deleted before":    {comment: entity.Comment{ID: 1, Deleted: true}},
+		"deleted meanwhile": {comment: entity.Comment{ID: 1}, deleteErr: sql.ErrNoRows},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			logger, _ := zap.NewProduction()
+			mockCommentRepo := new(mocks.CommentsRepository)
+			commentsUsecases := NewCommentsUsecases(mockCommentRepo, logger)
+
+			mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(tc.comment, nil)
+			mockCommentRepo.On("DeleteComment", mock.Anything, 1, 5).Return(tc.deleteErr).Maybe()
+
+			err := commentsUsecases.DeleteComment(context.Background(), 1, 5)
+
+			assert.ErrorIs(t, err, ErrCommentDeleted)
+		})
+	}
+}
+
+func TestCommentsUsecases_GetCommentThreads_ClampsDepth(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, logger)
+
+	mockCommentRepo.On("GetCommentThreads", mock.Anything, 1, 10, 0, MaxCommentDepth).Return([]entity.CommentNode{}, nil).Once()
+	mockCommentRepo.On("GetCommentThreads", mock.Anything, 1, 10, 0, DefaultCommentDepth).Return([]entity.CommentNode{}, nil).Once()
+
+	_, err := commentsUsecases.GetCommentThreads(context.Background(), 1, 10, 0, 100)
+	assert.NoError(t, err)
+	_, err = commentsUsecases.GetCommentThreads(context.Background(), 1, 10, 0, -1)
+	assert.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestBuildCommentTree(t *testing.T) {
+	nodes := []entity.CommentNode{
+		{Comment: entity.Comment{ID: 1}},
+		{Comment: entity.Comment{ID: 2}},
+		{Comment: entity.Comment{ID: 3, ParentId: intPtr(1)}, Depth: 1},
+		{Comment: entity.Comment{ID: 4, ParentId: intPtr(3)}, Depth: 2},
+		{Comment: entity.Comment{ID: 5, ParentId: intPtr(1)}, Depth: 1},
+	}
+
+	roots := BuildCommentTree(nodes)
+
+	assert.Len(t, roots, 2)
+	assert.Equal(t, []int{1}, roots[0].Path)
+	assert.Len(t, roots[0].Replies, 2)
+	assert.Equal(t, []int{1, 3, 4}, roots[0].Replies[0].Replies[0].Path)
+	assert.Equal(t, []int{1, 5}, roots[0].Replies[1].Path)
+
+	flat := FlattenCommentTree(roots)
+	ids := make([]int, len(flat))
+	for i, node := range flat {
+		ids[i] = node.ID
+		assert.Nil(t, node.Replies)
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 2}, ids)
+	// Плоский список не разрушает деревья
+	assert.Len(t, roots[0].Replies, 2)
+}
+
+func TestCommentsUsecases_UpdateComment(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+
+	updated := entity.Comment{ID: 1, PostId: 1, AuthorId: 3, Content: "edited", EditedBy: intPtr(2)}
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, PostId: 1, AuthorId: 3, Content: "old"}, nil)
+	mockCommentRepo.On("UpdateComment", mock.Anything, 1, "edited", 2).Return(updated, nil)
+
+	result, err := commentsUsecases.UpdateComment(context.Background(), 1, "edited", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, updated, result)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestCommentsUsecases_UpdateComment_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment entity.Comment
+		err     error
+		want    error
+	}{
+		{name: "not found", err: sql.ErrNoRows, want: ErrCommentNotFound},
+		{name: "deleted", comment: entity.Comment{ID: 1, Deleted: true}, want: ErrCommentDeleted},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCommentRepo := new(mocks.CommentsRepository)
+			commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+			mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(tc.comment, tc.err)
+
+			_, err := commentsUsecases.UpdateComment(context.Background(), 1, "edited", 2)
+
+			assert.ErrorIs(t, err, tc.want)
+			mockCommentRepo.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCommentsUsecases_UpdateComment_DeletedConcurrently(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1}, nil)
+	mockCommentRepo.On("UpdateComment", mock.Anything, 1, "edited", 2).Return(entity.Comment{}, sql.ErrNoRows)
+
+	_, err := commentsUsecases.UpdateComment(context.Background(), 1, "edited", 2)
+
+	assert.ErrorIs(t, err, ErrCommentDeleted)
+}
+
+func TestCommentsUsecases_GetCommentRevisions(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{
+		ID: 1, AuthorId: 3, Content: "second", CreatedAt: createdAt, UpdatedAt: &updatedAt, EditedBy: intPtr(2),
+	}, nil)
+	mockCommentRepo.On("GetCommentRevisions", mock.Anything, 1).Return([]entity.CommentRevision{
+		{Version: 1, CommentID: 1, Content: "first", EditorID: 3, CreatedAt: createdAt},
+	}, nil)
+
+	revisions, err := commentsUsecases.GetCommentRevisions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.CommentRevision{
+		{Version: 1, CommentID: 1, Content: "first", EditorID: 3, CreatedAt: createdAt},
+		{Version: 2, CommentID: 1, Content: "second", EditorID: 2, CreatedAt: updatedAt, Current: true},
+	}, revisions)
+}
+
+func TestCommentsUsecases_GetCommentRevisions_NeverEdited(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, AuthorId: 3, Content: "text", CreatedAt: createdAt}, nil)
+	mockCommentRepo.On("GetCommentRevisions", mock.Anything, 1).Return(nil, nil)
+
+	revisions, err := commentsUsecases.GetCommentRevisions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.CommentRevision{
+		{Version: 1, CommentID: 1, Content: "text", EditorID: 3, CreatedAt: createdAt, Current: true},
+	}, revisions)
+}
+
+func TestCommentsUsecases_DiffCommentRevisions(t *testing.T) {
+	mockCommentRepo := new(mocks.CommentsRepository)
+	commentsUsecases := NewCommentsUsecases(mockCommentRepo, zap.NewNop())
+
+	mockCommentRepo.On("GetCommentByID", mock.Anything, 1).Return(entity.Comment{ID: 1, Content: "a\nc"}, nil)
+	mockCommentRepo.On("GetCommentRevisions", mock.Anything, 1).Return([]entity.CommentRevision{
+		{Version: 1, CommentID: 1, Content: "a\nb"},
+	}, nil)
+
+	diff, err := commentsUsecases.DiffCommentRevisions(context.Background(), 1, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 2, diff.To)
+	assert.True(t, diff.Changed)
+	assert.Len(t, diff.Lines, 3)
+
+	_, err = commentsUsecases.DiffCommentRevisions(context.Background(), 1, 1, 3)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+}
@@ -2,19 +2,42 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/textdiff"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrInvalidParentComment = errors.New("parent comment not found in this post")
+	ErrParentCommentDeleted = errors.New("cannot reply to a deleted comment")
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrCommentDeleted       = errors.New("comment is deleted")
+	ErrRevisionNotFound     = errors.New("revision not found")
+)
+
+const (
+	// DefaultCommentDepth — глубина ответов в дереве, если она не задана.
+	DefaultCommentDepth = 5
+	// MaxCommentDepth ограничивает глубину, которую можно запросить.
+	MaxCommentDepth = 20
+)
+
 type CommentsUsecases interface {
 	CreateComment(ctx context.Context, comment entity.Comment) (entity.Comment, error)
 	GetCommentByPostID(ctx context.Context, postId int) ([]entity.Comment, error)
 	GetComments(ctx context.Context, postID, limit, offset int) ([]entity.Comment, error)
 	GetTotalCommentsCount(ctx context.Context, postID int) (int, error)
-	DeleteComment(ctx context.Context, id int) error
+	DeleteComment(ctx context.Context, id, deletedBy int) error
 	GetCommentByID(ctx context.Context, id int) (entity.Comment, error)
+	GetCommentThreads(ctx context.Context, postID, limit, offset, maxDepth int) ([]*entity.CommentNode, error)
+	GetTotalThreadsCount(ctx context.Context, postID int) (int, error)
+	UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error)
+	GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error)
+	DiffCommentRevisions(ctx context.Context, id, from, to int) (entity.RevisionDiff, error)
 }
 
 type commentsUsecases struct {
@@ -33,6 +56,12 @@ func (u *commentsUsecases) CreateComment(ctx context.Context, comment entity.Com
 		zap.String("content", comment.Content),
 	)
 
+	if comment.ParentId != nil {
+		if err := u.checkParent(ctx, comment); err != nil {
+			return entity.Comment{}, err
+		}
+	}
+
 	createdComment, err := u.commentRepo.CreateComment(ctx, comment)
 	if err != nil {
 		u.logger.Error("Failed to create comment", zap.Error(err))
@@ -64,15 +93,28 @@ func (u *commentsUsecases) GetCommentByPostID(ctx context.Context, postId int) (
 	return comments, nil
 }
 
-func (u *commentsUsecases) DeleteComment(ctx context.Context, id int) error {
-	u.logger.Info("Deleting comment", zap.Int("commentID", id))
+// DeleteComment переносит комментарий в корзину от имени deletedBy. Права
+// проверяет вызывающий. Ответы остаются на месте: пока они есть, вместо
+// комментария показывается заглушка.
+func (u *commentsUsecases) DeleteComment(ctx context.Context, id, deletedBy int) error {
+	u.logger.Info("Deleting comment", zap.Int("commentID", id), zap.Int("deletedBy", deletedBy))
 
-	err := u.commentRepo.DeleteComment(ctx, id)
+	comment, err := u.GetCommentByID(ctx, id)
 	if err != nil {
-		u.logger.Error("Failed to delete comment", zap.Error(err), zap.Int("commentID", id))
 		return err
 	}
+	if comment.Deleted {
+		return ErrCommentDeleted
+	}
 
+	if err := u.commentRepo.DeleteComment(ctx, id, deletedBy); err != nil {
+		// Комментарий удалили между проверкой и удалением
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCommentDeleted
+		}
+		u.logger.Error("Failed to delete comment", zap.Error(err), zap.Int("commentID", id))
+		return err
+	}
 	u.logger.Info("Comment deleted successfully", zap.Int("commentID", id))
 	return nil
 }
@@ -82,6 +124,9 @@ func (u *commentsUsecases) GetCommentByID(ctx context.Context, id int) (entity.C
 
 	comment, err := u.commentRepo.GetCommentByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Comment{}, ErrCommentNotFound
+		}
 		u.logger.Error("Failed to get comment by ID", zap.Error(err), zap.Int("commentID", id))
 		return entity.Comment{}, err
 	}
@@ -89,3 +134,177 @@ func (u *commentsUsecases) GetCommentByID(ctx context.Context, id int) (entity.C
 	u.logger.Info("Comment fetched successfully", zap.Int("commentID", id))
 	return comment, nil
 }
+
+// UpdateComment заменяет текст комментария от имени editorID. Права
+// проверяет вызывающий. Удаленные комментарии не редактируются.
+func (u *commentsUsecases) UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error) {
+	u.logger.Info("Updating comment", zap.Int("commentID", id), zap.Int("editorID", editorID))
+
+	comment, err := u.GetCommentByID(ctx, id)
+	if err != nil {
+		return entity.Comment{}, err
+	}
+	if comment.Deleted {
+		return entity.Comment{}, ErrCommentDeleted
+	}
+
+	updated, err := u.commentRepo.UpdateComment(ctx, id, content, editorID)
+	if err != nil {
+		// Комментарий удалили между проверкой и обновлением
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Comment{}, ErrCommentDeleted
+		}
+		u.logger.Error("Failed to update comment", zap.Error(err), zap.Int("commentID", id))
+		return entity.Comment{}, err
+	}
+
+	u.logger.Info("Comment updated successfully", zap.Int("commentID", id))
+	return updated, nil
+}
+
+// GetCommentRevisions возвращает все версии комментария от первой до
+// текущей. Текущая версия идет последней и помечена Current. История
+// удаленных и скрытых комментариев не отдается.
+func (u *commentsUsecases) GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error) {
+	comment, err := u.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, ErrCommentDeleted
+	}
+	// Скрытый по жалобам комментарий не должен читаться через историю
+	if comment.Hidden {
+		return nil, ErrCommentNotFound
+	}
+
+	revisions, err := u.commentRepo.GetCommentRevisions(ctx, id)
+	if err != nil {
+		u.logger.Error("Failed to get comment revisions", zap.Error(err), zap.Int("commentID", id))
+		return nil, err
+	}
+
+	current := entity.CommentRevision{
+		Version:   len(revisions) + 1,
+		CommentID: comment.ID,
+		Content:   comment.Content,
+		EditorID:  comment.AuthorId,
+		CreatedAt: comment.CreatedAt,
+		Current:   true,
+	}
+	if comment.EditedBy != nil {
+		current.EditorID = *comment.EditedBy
+	}
+	if comment.UpdatedAt != nil {
+		current.CreatedAt = *comment.UpdatedAt
+	}
+	return append(revisions, current), nil
+}
+
+// DiffCommentRevisions сравнивает две версии комментария. Версии
+// нумеруются с 1, последняя — текущий текст. to, равный 0, означает текущую
+// версию, from, равный 0, — версию перед to.
+func (u *commentsUsecases) DiffCommentRevisions(ctx context.Context, id, from, to int) (entity.RevisionDiff, error) {
+	revisions, err := u.GetCommentRevisions(ctx, id)
+	if err != nil {
+		return entity.RevisionDiff{}, err
+	}
+	if to == 0 {
+		to = len(revisions)
+	}
+	if from == 0 {
+		from = max(to-1, 1)
+	}
+	if from < 1 || from > len(revisions) || to < 1 || to > len(revisions) {
+		return entity.RevisionDiff{}, ErrRevisionNotFound
+	}
+
+	lines := textdiff.Lines(revisions[from-1].Content, revisions[to-1].Content)
+	return entity.RevisionDiff{From: from, To: to, Changed: textdiff.Changed(lines), Lines: lines}, nil
+}
+
+func (u *commentsUsecases) checkParent(ctx context.Context, comment entity.Comment) error {
+	parent, err := u.commentRepo.GetCommentByID(ctx, *comment.ParentId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidParentComment
+		}
+		return err
+	}
+	if parent.PostId != comment.PostId {
+		return ErrInvalidParentComment
+	}
+	if parent.Deleted {
+		return ErrParentCommentDeleted
+	}
+	return nil
+}
+
+// GetCommentThreads возвращает страницу веток обсуждения в виде деревьев.
+// maxDepth вне диапазона [0, MaxCommentDepth] заменяется значением по умолчанию
+// или ограничивается сверху.
+func (u *commentsUsecases) GetCommentThreads(ctx context.Context, postID, limit, offset, maxDepth int) ([]*entity.CommentNode, error) {
+	if maxDepth < 0 {
+		maxDepth = DefaultCommentDepth
+	}
+	if maxDepth > MaxCommentDepth {
+		maxDepth = MaxCommentDepth
+	}
+
+	nodes, err := u.commentRepo.GetCommentThreads(ctx, postID, limit, offset, maxDepth)
+	if err != nil {
+		u.logger.Error("Failed to get comment threads", zap.Error(err), zap.Int("postID", postID))
+		return nil, err
+	}
+	return BuildCommentTree(nodes), nil
+}
+
+func (u *commentsUsecases) GetTotalThreadsCount(ctx context.Context, postID int) (int, error) {
+	return u.commentRepo.GetTotalThreadsCount(ctx, postID)
+}
+
+// BuildCommentTree собирает деревья из комментариев, упорядоченных так, что
+// родитель идет раньше ответов. Комментарии, чей родитель не попал в выборку,
+// становятся корнями.
+func BuildCommentTree(nodes []entity.CommentNode) []*entity.CommentNode {
+	byID := make(map[int]*entity.CommentNode, len(nodes))
+	roots := make([]*entity.CommentNode, 0)
+	for i := range nodes {
+		node := &nodes[i]
+		byID[node.ID] = node
+
+		var parent *entity.CommentNode
+		if node.ParentId != nil {
+			parent = byID[*node.ParentId]
+		}
+		if parent == nil {
+			node.Path = []int{node.ID}
+			roots = append(roots, node)
+			continue
+		}
+		node.Path = append(append(make([]int, 0, len(parent.Path)+1), parent.Path...), node.ID)
+		parent.Replies = append(parent.Replies, node)
+	}
+	return roots
+}
+
+// WalkCommentTree вызывает fn для каждого комментария в порядке обхода в глубину.
+func WalkCommentTree(roots []*entity.CommentNode, fn func(node *entity.CommentNode)) {
+	for _, node := range roots {
+		fn(node)
+		WalkCommentTree(node.Replies, fn)
+	}
+}
+
+// FlattenCommentTree раскладывает деревья в список в порядке обхода в
+// глубину; вложенность передается через Depth и Path. Исходные деревья
+// не изменяются.
+func FlattenCommentTree(roots []*entity.CommentNode) []*entity.CommentNode {
+	flat := make([]*entity.CommentNode, 0)
+	WalkCommentTree(roots, func(node *entity.CommentNode) {
+		item := *node
+		item.Replies = nil
+		flat = append(flat, &item)
+	})
+	return flat
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrConversationNotFound     = errors.New("conversation not found")
+	ErrInvalidConversation      = errors.New("conversation needs at least one other participant")
+	ErrTooManyParticipants      = errors.New("too many conversation participants")
+	ErrInvalidConversationTitle = errors.New("conversation title must be at most 100 characters")
+	ErrInvalidDirectMessage     = errors.New("message must be from 1 to 2000 characters")
+	ErrDirectMessageBlocked     = errors.New("user has blocked you or is blocked by you")
+	ErrRecipientUnavailable     = errors.New("user is banned and cannot receive messages")
+	ErrCannotBlockSelf          = errors.New("cannot block yourself")
+	ErrUserAlreadyBlocked       = errors.New("user is already blocked")
+	ErrUserNotBlocked           = errors.New("user is not blocked")
+)
+
+const (
+	maxDirectMessageLength       = 2000
+	maxConversationTitleLength   = 100
+	defaultConversationPageLimit = 20
+	defaultDirectMessagePageSize = 50
+)
+
+type DirectMessageUsecase interface {
+	// GetInbox возвращает беседы пользователя с сообщениями, начиная с самой
+	// свежей, и курсор следующей страницы (0 — страниц больше нет).
+	GetInbox(ctx context.Context, userID, before, limit int) ([]entity.Conversation, int, error)
+	GetUnreadCount(ctx context.Context, userID int) (int, error)
+	// StartConversation создает беседу с userIDs. Беседа на двоих у пары
+	// пользователей одна: повторный вызов возвращает существующую. Если
+	// задан Content, он отправляется в беседу и возвращается вторым значением.
+	// Если кого-то из userIDs нет в auth_service, возвращается ErrUserNotFound.
+	StartConversation(ctx context.Context, req entity.CreateConversationRequest, principal entity.Principal) (entity.Conversation, *entity.DirectMessage, error)
+	// GetConversation возвращает беседу участника; для остальных ее нет.
+	GetConversation(ctx context.Context, id, userID int) (entity.Conversation, error)
+	// GetMessages возвращает страницу истории беседы от старых к новым и
+	// курсор предыдущей страницы (0 — это начало беседы).
+	GetMessages(ctx context.Context, conversationID, userID, before, limit int) ([]entity.DirectMessage, int, error)
+	// SendMessage пишет в беседу и возвращает сообщение вместе с ID
+	// участников беседы, которым его нужно доставить. Писать нельзя с
+	// мутом, ограничением или блокировкой, в беседу с забаненным и если
+	// кто-то из участников беседы занес отправителя в черный список или
+	// отправитель — его.
+	SendMessage(ctx context.Context, conversationID int, content string, principal entity.Principal) (entity.DirectMessage, []int, error)
+	MarkRead(ctx context.Context, conversationID, userID, messageID int) error
+
+	GetBlockedUsers(ctx context.Context, userID int) ([]entity.BlockedUser, error)
+	BlockUser(ctx context.Context, userID, blockedID int) error
+	UnblockUser(ctx context.Context, userID, blockedID int) error
+}
+
+type directMessageUsecase struct {
+	dmRepo       repository.DirectMessageRepository
+	blockRepo    repository.UserBlockRepository
+	sanctionRepo repository.SanctionRepository
+	userClient   grpc.UserClientInterface
+	logger       *zap.Logger
+}
+
+func NewDirectMessageUsecase(
+	dmRepo repository.DirectMessageRepository,
+	blockRepo repository.UserBlockRepository,
+	sanctionRepo repository.SanctionRepository,
+	userClient grpc.UserClientInterface,
+	logger *zap.Logger,
+) DirectMessageUsecase {
+	return &directMessageUsecase{dmRepo: dmRepo, blockRepo: blockRepo, sanctionRepo: sanctionRepo, userClient: userClient, logger: logger}
+}
+
+func (u *directMessageUsecase) GetInbox(ctx context.Context, userID, before, limit int) ([]entity.Conversation, int, error) {
+	if limit <= 0 {
+		limit = defaultConversationPageLimit
+	}
+	conversations, err := u.dmRepo.GetConversations(ctx, userID, before, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	next := 0
+	if len(conversations) == limit {
+		next = conversations[len(conversations)-1].LastMessage.ID
+	}
+	return conversations, next, nil
+}
+
+func (u *directMessageUsecase) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	return u.dmRepo.GetUnreadCount(ctx, userID)
+}
+
+func (u *directMessageUsecase) StartConversation(ctx context.Context, req entity.CreateConversationRequest, principal entity.Principal) (entity.Conversation, *entity.DirectMessage, error) {
+	if principal.Sanctions.ChatBlock() != nil {
+		return entity.Conversation{}, nil, ErrChatMuted
+	}
+
+	recipients := uniqueOtherIDs(req.UserIDs, principal.UserID)
+	if len(recipients) == 0 {
+		return entity.Conversation{}, nil, ErrInvalidConversation
+	}
+	if len(recipients)+1 > entity.MaxConversationParticipants {
+		return entity.Conversation{}, nil, ErrTooManyParticipants
+	}
+	title := strings.TrimSpace(req.Title)
+	if utf8.RuneCountInString(title) > maxConversationTitleLength {
+		return entity.Conversation{}, nil, ErrInvalidConversationTitle
+	}
+	users, err := u.userClient.GetUsers(ctx, recipients)
+	if err != nil {
+		return entity.Conversation{}, nil, err
+	}
+	for _, userID := range recipients {
+		if _, found := users[userID]; !found {
+			return entity.Conversation{}, nil, ErrUserNotFound
+		}
+	}
+	if err := u.checkRecipients(ctx, principal.UserID, recipients); err != nil {
+		return entity.Conversation{}, nil, err
+	}
+
+	var id int
+	if len(recipients) == 1 {
+		id, err = u.dmRepo.GetOrCreateDirect(ctx, principal.UserID, recipients[0])
+	} else {
+		id, err = u.dmRepo.CreateGroup(ctx, principal.UserID, title, append([]int{principal.UserID}, recipients...))
+	}
+	if err != nil {
+		return entity.Conversation{}, nil, err
+	}
+
+	var first *entity.DirectMessage
+	if req.Content != "" {
+		msg, _, err := u.SendMessage(ctx, id, req.Content, principal)
+		if err != nil {
+			return entity.Conversation{}, nil, err
+		}
+		first = &msg
+	}
+
+	conv, err := u.GetConversation(ctx, id, principal.UserID)
+	if err != nil {
+		return entity.Conversation{}, nil, err
+	}
+	return conv, first, nil
+}
+
+// uniqueOtherIDs возвращает ID без повторов и без self по возрастанию.
+func uniqueOtherIDs(userIDs []int, self int) []int {
+	seen := make(map[int]bool, len(userIDs))
+	var ids []int
+	for _, id := range userIDs {
+		if id == self || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// checkRecipients проверяет, что отправитель может писать recipients:
+// никто из них не забанен и не состоит с ним в черном списке.
+func (u *directMessageUsecase) checkRecipients(ctx context.Context, senderID int, recipients []int) error {
+	blocked, err := u.blockRepo.IsBlockedBetween(ctx, senderID, recipients)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrDirectMessageBlocked
+	}
+	for _, userID := range recipients {
+		sanctions, err := u.sanctionRepo.GetActiveSanctions(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if sanctions.LoginBlock() != nil {
+			return ErrRecipientUnavailable
+		}
+	}
+	return nil
+}
+
+func (u *directMessageUsecase) GetConversation(ctx context.Context, id, userID int) (entity.Conversation, error) {
+	conv, err := u.dmRepo.GetConversation(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Conversation{}, ErrConversationNotFound
+		}
+		return entity.Conversation{}, err
+	}
+	return conv, nil
+}
+
+// participants возвращает участников беседы, если userID — один из них.
+func (u *directMessageUsecase) participants(ctx context.Context, conversationID, userID int) ([]int, error) {
+	userIDs, err := u.dmRepo.GetParticipantIDs(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range userIDs {
+		if id == userID {
+			return userIDs, nil
+		}
+	}
+	return nil, ErrConversationNotFound
+}
+
+func (u *directMessageUsecase) GetMessages(ctx context.Context, conversationID, userID, before, limit int) ([]entity.DirectMessage, int, error) {
+	if _, err := u.participants(ctx, conversationID, userID); err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		limit = defaultDirectMessagePageSize
+	}
+	messages, err := u.dmRepo.GetMessages(ctx, conversationID, before, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	next := 0
+	if len(messages) == limit {
+		next = messages[0].ID
+	}
+	return messages, next, nil
+}
+
+func (u *directMessageUsecase) SendMessage(ctx context.Context, conversationID int, content string, principal entity.Principal) (entity.DirectMessage, []int, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > maxDirectMessageLength {
+		return entity.DirectMessage{}, nil, ErrInvalidDirectMessage
+	}
+	if principal.Sanctions.ChatBlock() != nil {
+		return entity.DirectMessage{}, nil, ErrChatMuted
+	}
+
+	userIDs, err := u.participants(ctx, conversationID, principal.UserID)
+	if err != nil {
+		return entity.DirectMessage{}, nil, err
+	}
+	if err := u.checkRecipients(ctx, principal.UserID, uniqueOtherIDs(userIDs, principal.UserID)); err != nil {
+		return entity.DirectMessage{}, nil, err
+	}
+
+	msg, err := u.dmRepo.StoreMessage(ctx, entity.DirectMessage{
+		ConversationID: conversationID,
+		SenderID:       principal.UserID,
+		Content:        content,
+	})
+	if err != nil {
+		return entity.DirectMessage{}, nil, err
+	}
+	u.logger.Info("Direct message sent", zap.Int("conversationID", conversationID), zap.Int("messageID", msg.ID), zap.Int("senderID", principal.UserID))
+	return msg, userIDs, nil
+}
+
+func (u *directMessageUsecase) MarkRead(ctx context.Context, conversationID, userID, messageID int) error {
+	if _, err := u.participants(ctx, conversationID, userID); err != nil {
+		return err
+	}
+	return u.dmRepo.MarkRead(ctx, conversationID, userID, messageID)
+}
+
+func (u *directMessageUsecase) GetBlockedUsers(ctx context.Context, userID int) ([]entity.BlockedUser, error) {
+	return u.blockRepo.GetBlocked(ctx, userID)
+}
+
+func (u *directMessageUsecase) BlockUser(ctx context.Context, userID, blockedID int) error {
+	if userID == blockedID {
+		return ErrCannotBlockSelf
+	}
+	added, err := u.blockRepo.Block(ctx, userID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrUserAlreadyBlocked
+	}
+	u.logger.Info("User blocked", zap.Int("userID", userID), zap.Int("blockedID", blockedID))
+	return nil
+}
+
+func (u *directMessageUsecase) UnblockUser(ctx context.Context, userID, blockedID int) error {
+	removed, err := u.blockRepo.Unblock(ctx, userID, blockedID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrUserNotBlocked
+	}
+	u.logger.Info("User unblocked", zap.Int("userID", userID), zap.Int("blockedID", blockedID))
+	return nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestDirectMessageUsecase() (DirectMessageUsecase, *mocks.DirectMessageRepository, *mocks.UserBlockRepository, *mocks.SanctionRepository, *mocks.UserClient) {
+	dmRepo := new(mocks.DirectMessageRepository)
+	blockRepo := new(mocks.UserBlockRepository)
+	sanctionRepo := new(mocks.SanctionRepository)
+	userClient := new(mocks.UserClient)
+	return NewDirectMessageUsecase(dmRepo, blockRepo, sanctionRepo, userClient, zap.NewNop()), dmRepo, blockRepo, sanctionRepo, userClient
+}
+
+// knownUsers — ответ GetUsers, в котором есть все userIDs.
+func knownUsers(userIDs ...int) map[int]entity.UserInfo {
+	users := make(map[int]entity.UserInfo, len(userIDs))
+	for _, userID := range userIDs {
+		users[userID] = entity.UserInfo{ID: userID}
+	}
+	return users
+}
+
+func TestDirectMessageUsecase_StartConversation_Direct(t *testing.T) {
+	u, dmRepo, blockRepo, sanctionRepo, userClient := newTestDirectMessageUsecase()
+	sender := entity.Principal{UserID: 1, Role: "user"}
+	stored := entity.DirectMessage{ID: 10, ConversationID: 3, SenderID: 1, Content: "привет"}
+
+	userClient.On("GetUsers", mock.Anything, []int{2}).Return(knownUsers(2), nil)
+	blockRepo.On("IsBlockedBetween", mock.Anything, 1, []int{2}).Return(false, nil)
+	sanctionRepo.On("GetActiveSanctions", mock.Anything, 2).Return(nil, nil)
+	dmRepo.On("GetOrCreateDirect", mock.Anything, 1, 2).Return(3, nil)
+	dmRepo.On("GetParticipantIDs", mock.Anything, 3).Return([]int{1, 2}, nil)
+	dmRepo.On("StoreMessage", mock.Anything, entity.DirectMessage{ConversationID: 3, SenderID: 1, Content: "привет"}).Return(stored, nil)
+	dmRepo.On("GetConversation", mock.Anything, 3, 1).Return(entity.Conversation{ID: 3, LastMessage: &stored}, nil)
+
+	conv, first, err := u.StartConversation(context.Background(),
+		entity.CreateConversationRequest{UserIDs: []int{2, 1, 2}, Content: " привет "}, sender)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, conv.ID)
+	assert.Equal(t, &stored, first)
+	dmRepo.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDirectMessageUsecase_StartConversation_Group(t *testing.T) {
+	u, dmRepo, blockRepo, sanctionRepo, userClient := newTestDirectMessageUsecase()
+	sender := entity.Principal{UserID: 1, Role: "user"}
+
+	userClient.On("GetUsers", mock.Anything, []int{2, 3}).Return(knownUsers(2, 3), nil)
+	blockRepo.On("IsBlockedBetween", mock.Anything, 1, []int{2, 3}).Return(false, nil)
+	sanctionRepo.On("GetActiveSanctions", mock.Anything, mock.Anything).Return(nil, nil)
+	dmRepo.On("CreateGroup", mock.Anything, 1, "Встреча", []int{1, 2, 3}).Return(4, nil)
+	dmRepo.On("GetConversation", mock.Anything, 4, 1).Return(entity.Conversation{ID: 4, IsGroup: true, Title: "Встреча"}, nil)
+
+	conv, first, err := u.StartConversation(context.Background(),
+		entity.CreateConversationRequest{UserIDs: []int{3, 2}, Title: " Встреча "}, sender)
+
+	assert.NoError(t, err)
+	assert.True(t, conv.IsGroup)
+	assert.Nil(t, first)
+	dmRepo.AssertNotCalled(t, "StoreMessage", mock.Anything, mock.Anything)
+}
+
+func TestDirectMessageUsecase_StartConversation_Rejected(t *testing.T) {
+	tooMany := make([]int, entity.MaxConversationParticipants)
+	for i := range tooMany {
+		tooMany[i] = i + 2
+	}
+
+	for name, tc := range map[string]struct {
+		userIDs   []int
+		sanctions entity.Sanctions
+		unknown   int
+		blocked   bool
+		banned    bool
+		want      error
+	}{
+		"only self":     {userIDs: []int{1}, want: ErrInvalidConversation},
+		"too many":      {userIDs: tooMany, want: ErrTooManyParticipants},
+		"sender muted":  {userIDs: []int{2}, sanctions: entity.Sanctions{{Type: entity.SanctionMute}}, want: ErrChatMuted},
+		"blocked":       {userIDs: []int{2}, blocked: true, want: ErrDirectMessageBlocked},
+		"recipient ban": {userIDs: []int{2}, banned: true, want: ErrRecipientUnavailable},
+		"unknown user":  {userIDs: []int{2, 42}, unknown: 42, want: ErrUserNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			u, dmRepo, blockRepo, sanctionRepo, userClient := newTestDirectMessageUsecase()
+			users := knownUsers(tc.userIDs...)
+			delete(users, tc.unknown)
+			userClient.On("GetUsers", mock.Anything, mock.Anything).Return(users, nil)
+			blockRepo.On("IsBlockedBetween", mock.Anything, 1, mock.Anything).Return(tc.blocked, nil)
+			var recipientSanctions entity.Sanctions
+			if tc.banned {
+				recipientSanctions = entity.Sanctions{{Type: entity.SanctionBan}}
+			}
+			sanctionRepo.On("GetActiveSanctions", mock.Anything, mock.Anything).Return(recipientSanctions, nil)
+
+			_, _, err := u.StartConversation(context.Background(),
+				entity.CreateConversationRequest{UserIDs: tc.userIDs}, entity.Principal{UserID: 1, Sanctions: tc.sanctions})
+
+			assert.ErrorIs(t, err, tc.want)
+			dmRepo.AssertNotCalled(t, "GetOrCreateDirect", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDirectMessageUsecase_SendMessage(t *testing.T) {
+	u, dmRepo, blockRepo, sanctionRepo, _ := newTestDirectMessageUsecase()
+	sender := entity.Principal{UserID: 1, Role: "user"}
+	stored := entity.DirectMessage{ID: 11, ConversationID: 3, SenderID: 1, Content: "как дела?"}
+
+	dmRepo.On("GetParticipantIDs", mock.Anything, 3).Return([]int{1, 2}, nil)
+	blockRepo.On("IsBlockedBetween", mock.Anything, 1, []int{2}).Return(false, nil)
+	sanctionRepo.On("GetActiveSanctions", mock.Anything, 2).Return(nil, nil)
+	dmRepo.On("StoreMessage", mock.Anything, entity.DirectMessage{ConversationID: 3, SenderID: 1, Content: "как дела?"}).Return(stored, nil)
+
+	msg, userIDs, err := u.SendMessage(context.Background(), 3, "как дела?", sender)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, msg)
+	assert.Equal(t, []int{1, 2}, userIDs)
+}
+
+func TestDirectMessageUsecase_SendMessage_Rejected(t *testing.T) {
+	u, dmRepo, blockRepo, _, _ := newTestDirectMessageUsecase()
+
+	dmRepo.On("GetParticipantIDs", mock.Anything, 3).Return([]int{1, 2}, nil)
+	blockRepo.On("IsBlockedBetween", mock.Anything, 2, []int{1}).Return(true, nil)
+
+	_, _, err := u.SendMessage(context.Background(), 3, "привет", entity.Principal{UserID: 5})
+	assert.ErrorIs(t, err, ErrConversationNotFound)
+	_, _, err = u.SendMessage(context.Background(), 3, "привет", entity.Principal{UserID: 2})
+	assert.ErrorIs(t, err, ErrDirectMessageBlocked)
+	_, _, err = u.SendMessage(context.Background(), 3, strings.Repeat("я", maxDirectMessageLength+1), entity.Principal{UserID: 2})
+	assert.ErrorIs(t, err, ErrInvalidDirectMessage)
+	dmRepo.AssertNotCalled(t, "StoreMessage", mock.Anything, mock.Anything)
+}
+
+func TestDirectMessageUsecase_GetMessages_Cursor(t *testing.T) {
+	u, dmRepo, _, _, _ := newTestDirectMessageUsecase()
+
+	dmRepo.On("GetParticipantIDs", mock.Anything, 3).Return([]int{1, 2}, nil)
+	dmRepo.On("GetMessages", mock.Anything, 3, 40, 2).Return([]entity.DirectMessage{{ID: 30}, {ID: 35}}, nil).Once()
+	dmRepo.On("GetMessages", mock.Anything, 3, 30, 2).Return([]entity.DirectMessage{{ID: 12}}, nil).Once()
+
+	messages, next, err := u.GetMessages(context.Background(), 3, 1, 40, 2)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, 30, next)
+
+	_, next, err = u.GetMessages(context.Background(), 3, 1, next, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, next)
+}
+
+func TestDirectMessageUsecase_GetConversation_NotParticipant(t *testing.T) {
+	u, dmRepo, _, _, _ := newTestDirectMessageUsecase()
+
+	dmRepo.On("GetConversation", mock.Anything, 3, 5).Return(entity.Conversation{}, sql.ErrNoRows)
+
+	_, err := u.GetConversation(context.Background(), 3, 5)
+
+	assert.ErrorIs(t, err, ErrConversationNotFound)
+}
+
+func TestDirectMessageUsecase_BlockUser(t *testing.T) {
+	u, _, blockRepo, _, _ := newTestDirectMessageUsecase()
+
+	blockRepo.On("Block", mock.Anything, 1, 2).Return(true, nil).Once()
+	blockRepo.On("Block", mock.Anything, 1, 2).Return(false, nil).Once()
+	blockRepo.On("Unblock", mock.Anything, 1, 3).Return(false, nil)
+
+	assert.NoError(t, u.BlockUser(context.Background(), 1, 2))
+	assert.ErrorIs(t, u.BlockUser(context.Background(), 1, 2), ErrUserAlreadyBlocked)
+	assert.ErrorIs(t, u.BlockUser(context.Background(), 1, 1), ErrCannotBlockSelf)
+	assert.ErrorIs(t, u.UnblockUser(context.Background(), 1, 3), ErrUserNotBlocked)
+}
@@ -0,0 +1,449 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/adapters"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+// maxReportNoteLength — предельная длина комментария к жалобе и к решению.
+const maxReportNoteLength = 1000
+
+var (
+	ErrInvalidReportTarget = errors.New("unknown report target")
+	ErrInvalidReportReason = errors.New("unknown report reason")
+	ErrReportNoteRequired  = errors.New("note is required for reason other")
+	ErrReportNoteTooLong   = errors.New("note is too long")
+	ErrSelfReport          = errors.New("cannot report own content")
+	ErrAlreadyReported     = errors.New("already reported")
+	ErrChatMessageNotFound = errors.New("chat message not found")
+	ErrCaseNotFound        = errors.New("moderation case not found")
+	ErrInvalidCaseStatus   = errors.New("unknown moderation case status")
+	ErrCaseClaimed         = errors.New("moderation case is claimed by another moderator")
+	ErrCaseResolved        = errors.New("moderation case is already resolved")
+	ErrInvalidResolution   = errors.New("unknown resolution")
+	ErrBanForbidden        = errors.New("not allowed to ban users")
+	ErrBanRejected         = errors.New("ban rejected")
+	ErrResolutionNote      = errors.New("note is required to warn or ban the author")
+)
+
+type ModerationUsecase interface {
+	// Reasons возвращает причины жалоб.
+	Reasons() []string
+	// GetTarget возвращает пост, комментарий или сообщение чата, на которое
+	// жалуются.
+	GetTarget(ctx context.Context, targetType string, id int) (entity.ReportTarget, error)
+	// Report сохраняет жалобу reporterID на цель. Жалобы на одну цель
+	// собираются в нерешенное дело; в нем пользователь жалуется один раз.
+	// Набравшая порог жалоб цель скрывается до решения модератора.
+	Report(ctx context.Context, target entity.ReportTarget, reporterID int, reason, note string) (entity.Report, error)
+	GetCases(ctx context.Context, filter entity.CaseFilter) ([]entity.ModerationCase, int, error)
+	// GetCase возвращает дело вместе с жалобами.
+	GetCase(ctx context.Context, id int) (entity.ModerationCase, error)
+	// Claim берет дело в работу. Дело, взятое другим модератором, взять нельзя.
+	Claim(ctx context.Context, id int, principal entity.Principal) (entity.ModerationCase, error)
+	// Release возвращает дело в очередь. Чужое дело может вернуть только
+	// администратор.
+	Release(ctx context.Context, id int, principal entity.Principal) (entity.ModerationCase, error)
+	// Resolve закрывает дело. dismiss снова показывает скрытую цель, delete
+	// удаляет ее; warn и ban удаляют цель, если попросили, а иначе тоже
+	// показывают ее. warn сохраняет автору предупреждение, ban блокирует
+	// автора через auth_service на req.BanDurationHours; комментарий
+	// модератора для них обязателен — это причина, которую увидит автор.
+	// Для ban нужно право user.ban. Решаемое дело сначала берется в работу,
+	// а предупреждение и бан по делу выносятся один раз.
+	Resolve(ctx context.Context, id int, principal entity.Principal, req entity.ResolveCaseRequest) (entity.ModerationCase, error)
+	// GetWarnings возвращает предупреждения пользователя, начиная с последнего.
+	GetWarnings(ctx context.Context, userID int) ([]entity.Warning, error)
+}
+
+type moderationUsecase struct {
+	moderationRepo repository.ModerationRepository
+	postRepo       repository.PostRepository
+	commentUsecase CommentsUsecases
+	chatRepo       repository.ChatRepository
+	authSanctions  adapters.AuthSanctionClient
+	hideThreshold  int
+	logger         *zap.Logger
+}
+
+// NewModerationUsecase создает usecase жалоб. Цель, набравшая hideThreshold
+// жалоб, скрывается; 0 отключает скрытие.
+func NewModerationUsecase(
+	moderationRepo repository.ModerationRepository,
+	postRepo repository.PostRepository,
+	commentUsecase CommentsUsecases,
+	chatRepo repository.ChatRepository,
+	authSanctions adapters.AuthSanctionClient,
+	hideThreshold int,
+	logger *zap.Logger,
+) ModerationUsecase {
+	return &moderationUsecase{
+		moderationRepo: moderationRepo,
+		postRepo:       postRepo,
+		commentUsecase: commentUsecase,
+		chatRepo:       chatRepo,
+		authSanctions:  authSanctions,
+		hideThreshold:  hideThreshold,
+		logger:         logger,
+	}
+}
+
+func (u *moderationUsecase) Reasons() []string {
+	return entity.ReportReasons
+}
+
+func (u *moderationUsecase) GetTarget(ctx context.Context, targetType string, id int) (entity.ReportTarget, error) {
+	switch targetType {
+	case entity.ReactionTargetPost:
+		post, err := u.postRepo.GetPostByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ReportTarget{}, ErrPostNotFound
+		}
+		if err != nil {
+			return entity.ReportTarget{}, err
+		}
+		return entity.ReportTarget{
+			Type:     targetType,
+			ID:       post.ID,
+			PostID:   post.ID,
+			AuthorID: post.AuthorId,
+			Content:  post.Title + "\n\n" + post.Content,
+		}, nil
+	case entity.ReactionTargetComment:
+		comment, err := u.commentUsecase.GetCommentByID(ctx, id)
+		if err != nil {
+			return entity.ReportTarget{}, err
+		}
+		return entity.ReportTarget{
+			Type:     targetType,
+			ID:       comment.ID,
+			PostID:   comment.PostId,
+			AuthorID: comment.AuthorId,
+			Content:  comment.Content,
+			Deleted:  comment.Deleted,
+		}, nil
+	case entity.ReportTargetChatMessage:
+		msg, err := u.chatRepo.GetMessageByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ReportTarget{}, ErrChatMessageNotFound
+		}
+		if err != nil {
+			return entity.ReportTarget{}, err
+		}
+		return entity.ReportTarget{Type: targetType, ID: msg.ID, AuthorID: msg.UserID, Content: msg.Content}, nil
+	default:
+		return entity.ReportTarget{}, ErrInvalidReportTarget
+	}
+}
+
+func validReason(reason string) bool {
+	for _, r := range entity.ReportReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func checkNote(note string) error {
+	if utf8.RuneCountInString(note) > maxReportNoteLength {
+		return ErrReportNoteTooLong
+	}
+	return nil
+}
+
+func (u *moderationUsecase) Report(ctx context.Context, target entity.ReportTarget, reporterID int, reason, note string) (entity.Report, error) {
+	note = strings.TrimSpace(note)
+	if !validReason(reason) {
+		return entity.Report{}, ErrInvalidReportReason
+	}
+	if reason == entity.ReportReasonOther && note == "" {
+		return entity.Report{}, ErrReportNoteRequired
+	}
+	if err := checkNote(note); err != nil {
+		return entity.Report{}, err
+	}
+	if target.AuthorID == reporterID {
+		return entity.Report{}, ErrSelfReport
+	}
+	if target.Deleted {
+		return entity.Report{}, ErrCommentDeleted
+	}
+
+	c := entity.ModerationCase{
+		TargetType:     target.Type,
+		TargetID:       target.ID,
+		TargetAuthorID: target.AuthorID,
+		Content:        target.Content,
+	}
+	if target.PostID != 0 {
+		c.PostID = &target.PostID
+	}
+	caseID, err := u.moderationRepo.OpenCase(ctx, c)
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	report := entity.Report{CaseID: caseID, ReporterID: reporterID, Reason: reason, Note: note}
+	report.ID, err = u.moderationRepo.AddReport(ctx, report)
+	if err != nil {
+		return entity.Report{}, err
+	}
+	if report.ID == 0 {
+		return entity.Report{}, ErrAlreadyReported
+	}
+
+	if u.hideThreshold > 0 {
+		count, err := u.moderationRepo.CountReports(ctx, caseID)
+		if err != nil {
+			return entity.Report{}, err
+		}
+		if count >= u.hideThreshold {
+			if err := u.moderationRepo.SetTargetHidden(ctx, target.Type, target.ID, true); err != nil {
+				return entity.Report{}, err
+			}
+		}
+	}
+	return report, nil
+}
+
+func (u *moderationUsecase) GetCases(ctx context.Context, filter entity.CaseFilter) ([]entity.ModerationCase, int, error) {
+	switch filter.Status {
+	case "":
+		filter.Status = entity.CaseStatusPending
+	case entity.CaseStatusPending, entity.CaseStatusOpen, entity.CaseStatusClaimed, entity.CaseStatusResolved, entity.CaseStatusAll:
+	default:
+		return nil, 0, ErrInvalidCaseStatus
+	}
+	switch filter.TargetType {
+	case "", entity.ReactionTargetPost, entity.ReactionTargetComment, entity.ReportTargetChatMessage:
+	default:
+		return nil, 0, ErrInvalidReportTarget
+	}
+	if filter.Reason != "" && !validReason(filter.Reason) {
+		return nil, 0, ErrInvalidReportReason
+	}
+
+	cases, err := u.moderationRepo.GetCases(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.moderationRepo.GetTotalCasesCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cases, total, nil
+}
+
+func (u *moderationUsecase) getCase(ctx context.Context, id int) (entity.ModerationCase, error) {
+	c, err := u.moderationRepo.GetCase(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ModerationCase{}, ErrCaseNotFound
+	}
+	return c, err
+}
+
+func (u *moderationUsecase) GetCase(ctx context.Context, id int) (entity.ModerationCase, error) {
+	c, err := u.getCase(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	c.Reports, err = u.moderationRepo.GetReports(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	return c, nil
+}
+
+// caseConflict объясняет, почему дело нельзя взять или закрыть.
+func caseConflict(c entity.ModerationCase) error {
+	if c.Status == entity.CaseStatusResolved {
+		return ErrCaseResolved
+	}
+	return ErrCaseClaimed
+}
+
+func (u *moderationUsecase) Claim(ctx context.Context, id int, principal entity.Principal) (entity.ModerationCase, error) {
+	claimed, err := u.moderationRepo.ClaimCase(ctx, id, principal.UserID)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	c, err := u.GetCase(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	if !claimed {
+		return entity.ModerationCase{}, caseConflict(c)
+	}
+	return c, nil
+}
+
+func (u *moderationUsecase) Release(ctx context.Context, id int, principal entity.Principal) (entity.ModerationCase, error) {
+	c, err := u.getCase(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	if c.Status == entity.CaseStatusResolved {
+		return entity.ModerationCase{}, ErrCaseResolved
+	}
+	if c.ClaimedBy != nil {
+		if *c.ClaimedBy != principal.UserID && !principal.HasRole("admin") {
+			return entity.ModerationCase{}, ErrCaseClaimed
+		}
+		if _, err := u.moderationRepo.ReleaseCase(ctx, id); err != nil {
+			return entity.ModerationCase{}, err
+		}
+	}
+	return u.GetCase(ctx, id)
+}
+
+func (u *moderationUsecase) Resolve(ctx context.Context, id int, principal entity.Principal, req entity.ResolveCaseRequest) (entity.ModerationCase, error) {
+	note := strings.TrimSpace(req.Note)
+	if err := checkNote(note); err != nil {
+		return entity.ModerationCase{}, err
+	}
+	if (req.Action == entity.ResolutionWarn || req.Action == entity.ResolutionBan) && note == "" {
+		return entity.ModerationCase{}, ErrResolutionNote
+	}
+	deleteContent := false
+	switch req.Action {
+	case entity.ResolutionDismiss:
+	case entity.ResolutionDelete:
+		deleteContent = true
+	case entity.ResolutionWarn:
+		deleteContent = req.DeleteContent
+	case entity.ResolutionBan:
+		if !principal.Can(entity.PermUserBan) {
+			return entity.ModerationCase{}, ErrBanForbidden
+		}
+		deleteContent = req.DeleteContent
+	default:
+		return entity.ModerationCase{}, ErrInvalidResolution
+	}
+
+	// Дело берется в работу до наказания автора: из модераторов, решающих
+	// одно дело одновременно, автора накажет только один
+	claimed, err := u.moderationRepo.ClaimCase(ctx, id, principal.UserID)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	c, err := u.getCase(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	if !claimed {
+		return entity.ModerationCase{}, caseConflict(c)
+	}
+
+	// Автор наказывается до закрытия дела: если санкцию вынести не
+	// удалось, дело остается открытым. Предупреждение и бан по делу
+	// выносятся один раз, и повторное решение после сбоя их не дублирует
+	switch req.Action {
+	case entity.ResolutionWarn:
+		err = u.moderationRepo.AddWarning(ctx, entity.Warning{UserID: c.TargetAuthorID, CaseID: id, Reason: note, IssuedBy: principal.UserID})
+	case entity.ResolutionBan:
+		err = u.banOnce(ctx, id, principal, c.TargetAuthorID, note, req.BanDurationHours)
+	}
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+
+	// Цель меняется до закрытия дела: удаление повторяется без вреда, и
+	// после сбоя дело можно закрыть еще раз
+	if deleteContent {
+		err = u.deleteTarget(ctx, c, principal.UserID)
+	} else if c.Hidden {
+		err = u.moderationRepo.SetTargetHidden(ctx, c.TargetType, c.TargetID, false)
+	}
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+
+	resolved, err := u.moderationRepo.ResolveCase(ctx, id, req.Action, note, principal.UserID)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	c, err = u.GetCase(ctx, id)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+	if !resolved {
+		return entity.ModerationCase{}, caseConflict(c)
+	}
+	u.logger.Info("Moderation case resolved",
+		zap.Int("caseID", id),
+		zap.String("resolution", req.Action),
+		zap.Int("moderatorID", principal.UserID),
+		zap.Int("authorID", c.TargetAuthorID),
+		zap.Bool("contentDeleted", deleteContent),
+	)
+	return c, nil
+}
+
+func (u *moderationUsecase) GetWarnings(ctx context.Context, userID int) ([]entity.Warning, error) {
+	return u.moderationRepo.GetWarnings(ctx, userID)
+}
+
+// banOnce блокирует автора по делу id, если бан по нему еще не вынесен.
+// Если auth_service бан не вынес, отметка о нем снимается.
+func (u *moderationUsecase) banOnce(ctx context.Context, id int, principal entity.Principal, userID int, reason string, durationHours int) error {
+	marked, err := u.moderationRepo.MarkBanIssued(ctx, id)
+	if err != nil || !marked {
+		return err
+	}
+	err = u.ban(ctx, principal, userID, reason, durationHours)
+	if err == nil {
+		return nil
+	}
+	if clearErr := u.moderationRepo.ClearBanIssued(ctx, id); clearErr != nil {
+		u.logger.Error("Failed to clear ban mark", zap.Error(clearErr), zap.Int("caseID", id))
+	}
+	return err
+}
+
+// ban блокирует автора через auth_service от имени модератора. Отказ
+// auth_service в правах — ErrBanForbidden, остальные отказы — ErrBanRejected
+// с его объяснением.
+func (u *moderationUsecase) ban(ctx context.Context, principal entity.Principal, userID int, reason string, durationHours int) error {
+	err := u.authSanctions.IssueSanction(ctx, principal.Token, userID, entity.CreateSanctionRequest{
+		Type:          entity.SanctionBan,
+		Reason:        reason,
+		DurationHours: durationHours,
+	})
+	var rejected *adapters.SanctionRejectedError
+	if errors.As(err, &rejected) {
+		if rejected.Status == http.StatusForbidden {
+			return ErrBanForbidden
+		}
+		return fmt.Errorf("%w: %s", ErrBanRejected, rejected.Message)
+	}
+	return err
+}
+
+// deleteTarget удаляет цель дела от имени модератора moderatorID: посты и
+// комментарии попадают в корзину. Уже удаленная цель ошибкой не считается.
+func (u *moderationUsecase) deleteTarget(ctx context.Context, c entity.ModerationCase, moderatorID int) error {
+	var err error
+	switch c.TargetType {
+	case entity.ReactionTargetPost:
+		err = u.postRepo.DeletePost(ctx, c.TargetID, moderatorID)
+	case entity.ReactionTargetComment:
+		err = u.commentUsecase.DeleteComment(ctx, c.TargetID, moderatorID)
+	case entity.ReportTargetChatMessage:
+		err = u.chatRepo.DeleteMessage(ctx, c.TargetID)
+	}
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrCommentNotFound) || errors.Is(err, ErrCommentDeleted) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/adapters"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type moderationMocks struct {
+	moderationRepo *mocks.ModerationRepository
+	postRepo       *mocks.PostRepository
+	commentUsecase *mocks.CommentsUsecases
+	chatRepo       *mocks.ChatRepository
+	authSanctions  *mocks.AuthSanctionClient
+}
+
+func newTestModerationUsecase(hideThreshold int) (ModerationUsecase, moderationMocks) {
+	m := moderationMocks{
+		moderationRepo: new(mocks.ModerationRepository),
+		postRepo:       new(mocks.PostRepository),
+		commentUsecase: new(mocks.CommentsUsecases),
+		chatRepo:       new(mocks.ChatRepository),
+		authSanctions:  new(mocks.AuthSanctionClient),
+	}
+	return NewModerationUsecase(m.moderationRepo, m.postRepo, m.commentUsecase, m.chatRepo, m.authSanctions, hideThreshold, zap.NewNop()), m
+}
+
+var spamPost = entity.ReportTarget{Type: entity.ReactionTargetPost, ID: 5, PostID: 5, AuthorID: 2, Content: "Казино\n\nЗаходите"}
+
+func TestModerationUsecase_GetTarget(t *testing.T) {
+	u, m := newTestModerationUsecase(3)
+
+	m.chatRepo.On("GetMessageByID", mock.Anything, 7).Return(entity.ChatMessage{ID: 7, UserID: 2, Content: "spam"}, nil)
+	m.chatRepo.On("GetMessageByID", mock.Anything, 8).Return(entity.ChatMessage{}, sql.ErrNoRows)
+	m.commentUsecase.On("GetCommentByID", mock.Anything, 9).Return(entity.Comment{ID: 9, PostId: 1, AuthorId: 3, Content: entity.DeletedCommentContent, Deleted: true}, nil)
+
+	target, err := u.GetTarget(context.Background(), entity.ReportTargetChatMessage, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReportTarget{Type: entity.ReportTargetChatMessage, ID: 7, AuthorID: 2, Content: "spam"}, target)
+
+	_, err = u.GetTarget(context.Background(), entity.ReportTargetChatMessage, 8)
+	assert.ErrorIs(t, err, ErrChatMessageNotFound)
+
+	target, err = u.GetTarget(context.Background(), entity.ReactionTargetComment, 9)
+	assert.NoError(t, err)
+	assert.True(t, target.Deleted)
+	assert.Equal(t, 1, target.PostID)
+
+	_, err = u.GetTarget(context.Background(), "user", 1)
+	assert.ErrorIs(t, err, ErrInvalidReportTarget)
+}
+
+func TestModerationUsecase_Report_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		target     entity.ReportTarget
+		reporterID int
+		reason     string
+		note       string
+		want       error
+	}{
+		{"unknown reason", spamPost, 3, "boring", "", ErrInvalidReportReason},
+		{"other without note", spamPost, 3, entity.ReportReasonOther, "  ", ErrReportNoteRequired},
+		{"long note", spamPost, 3, entity.ReportReasonSpam, strings.Repeat("я", maxReportNoteLength+1), ErrReportNoteTooLong},
+		{"own content", spamPost, 2, entity.ReportReasonSpam, "", ErrSelfReport},
+		{"deleted comment", entity.ReportTarget{Type: entity.ReactionTargetComment, ID: 9, AuthorID: 2, Deleted: true}, 3, entity.ReportReasonSpam, "", ErrCommentDeleted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u, m := newTestModerationUsecase(3)
+
+			_, err := u.Report(context.Background(), tc.target, tc.reporterID, tc.reason, tc.note)
+
+			assert.ErrorIs(t, err, tc.want)
+			m.moderationRepo.AssertNotCalled(t, "OpenCase", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestModerationUsecase_Report_HidesAtThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		reports int
+		hidden  bool
+	}{
+		{2, false},
+		{3, true},
+	} {
+		u, m := newTestModerationUsecase(3)
+		m.moderationRepo.On("OpenCase", mock.Anything, mock.MatchedBy(func(c entity.ModerationCase) bool {
+			return c.TargetType == entity.ReactionTargetPost && c.TargetID == 5 && *c.PostID == 5 && c.TargetAuthorID == 2 && c.Content == spamPost.Content
+		})).Return(4, nil)
+		m.moderationRepo.On("AddReport", mock.Anything, entity.Report{CaseID: 4, ReporterID: 3, Reason: entity.ReportReasonOther, Note: "реклама"}).Return(11, nil)
+		m.moderationRepo.On("CountReports", mock.Anything, 4).Return(tc.reports, nil)
+		m.moderationRepo.On("SetTargetHidden", mock.Anything, entity.ReactionTargetPost, 5, true).Return(nil).Maybe()
+
+		report, err := u.Report(context.Background(), spamPost, 3, entity.ReportReasonOther, " реклама ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 11, report.ID)
+		assert.Equal(t, 4, report.CaseID)
+		if tc.hidden {
+			m.moderationRepo.AssertCalled(t, "SetTargetHidden", mock.Anything, entity.ReactionTargetPost, 5, true)
+		} else {
+			m.moderationRepo.AssertNotCalled(t, "SetTargetHidden", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	}
+}
+
+func TestModerationUsecase_Report_Duplicate(t *testing.T) {
+	u, m := newTestModerationUsecase(0)
+
+	m.moderationRepo.On("OpenCase", mock.Anything, mock.Anything).Return(4, nil)
+	m.moderationRepo.On("AddReport", mock.Anything, mock.Anything).Return(0, nil)
+
+	_, err := u.Report(context.Background(), spamPost, 3, entity.ReportReasonSpam, "")
+
+	assert.ErrorIs(t, err, ErrAlreadyReported)
+	m.moderationRepo.AssertNotCalled(t, "CountReports", mock.Anything, mock.Anything)
+}
+
+func TestModerationUsecase_GetCases_DefaultsToPending(t *testing.T) {
+	u, m := newTestModerationUsecase(3)
+
+	filter := entity.CaseFilter{Status: entity.CaseStatusPending, Limit: 20}
+	m.moderationRepo.On("GetCases", mock.Anything, filter).Return([]entity.ModerationCase{{ID: 1}}, nil)
+	m.moderationRepo.On("GetTotalCasesCount", mock.Anything, filter).Return(1, nil)
+
+	cases, total, err := u.GetCases(context.Background(), entity.CaseFilter{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Len(t, cases, 1)
+	assert.Equal(t, 1, total)
+
+	_, _, err = u.GetCases(context.Background(), entity.CaseFilter{Status: "closed"})
+	assert.ErrorIs(t, err, ErrInvalidCaseStatus)
+	_, _, err = u.GetCases(context.Background(), entity.CaseFilter{Reason: "boring"})
+	assert.ErrorIs(t, err, ErrInvalidReportReason)
+}
+
+func TestModerationUsecase_Claim_Conflict(t *testing.T) {
+	u, m := newTestModerationUsecase(3)
+	other := 7
+
+	m.moderationRepo.On("ClaimCase", mock.Anything, 4, 5).Return(false, nil)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(entity.ModerationCase{ID: 4, Status: entity.CaseStatusClaimed, ClaimedBy: &other}, nil)
+	m.moderationRepo.On("GetReports", mock.Anything, 4).Return(nil, nil)
+
+	_, err := u.Claim(context.Background(), 4, entity.Principal{UserID: 5, Role: "moderator"})
+
+	assert.ErrorIs(t, err, ErrCaseClaimed)
+}
+
+func TestModerationUsecase_Release(t *testing.T) {
+	other := 7
+	claimed := entity.ModerationCase{ID: 4, Status: entity.CaseStatusClaimed, ClaimedBy: &other}
+
+	u, m := newTestModerationUsecase(3)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(claimed, nil)
+
+	_, err := u.Release(context.Background(), 4, entity.Principal{UserID: 5, Role: "moderator"})
+	assert.ErrorIs(t, err, ErrCaseClaimed)
+
+	// Администратор может вернуть в очередь чужое дело
+	m.moderationRepo.On("ReleaseCase", mock.Anything, 4).Return(true, nil)
+	m.moderationRepo.On("GetReports", mock.Anything, 4).Return(nil, nil)
+
+	_, err = u.Release(context.Background(), 4, entity.Principal{UserID: 1, Role: "admin"})
+	assert.NoError(t, err)
+	m.moderationRepo.AssertCalled(t, "ReleaseCase", mock.Anything, 4)
+}
+
+func TestModerationUsecase_Resolve(t *testing.T) {
+	moderator := entity.Principal{UserID: 5, Role: "moderator", Permissions: []string{entity.PermReportReview}}
+	admin := entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermReportReview, entity.PermUserBan}, Token: "admin-token"}
+
+	for _, tc := range []struct {
+		name      string
+		target    string
+		principal entity.Principal
+		req       entity.ResolveCaseRequest
+		expect    func(m moderationMocks)
+	}{
+		{
+			name:      "dismiss unhides",
+			target:    entity.ReactionTargetPost,
+			principal: moderator,
+			req:       entity.ResolveCaseRequest{Action: entity.ResolutionDismiss},
+			expect: func(m moderationMocks) {
+				m.moderationRepo.On("SetTargetHidden", mock.Anything, entity.ReactionTargetPost, 9, false).Return(nil).Once()
+			},
+		},
+		{
+			name:      "delete comment",
+			target:    entity.ReactionTargetComment,
+			principal: moderator,
+			req:       entity.ResolveCaseRequest{Action: entity.ResolutionDelete, Note: "травля"},
+			expect: func(m moderationMocks) {
+				m.commentUsecase.On("DeleteComment", mock.Anything, 9, 5).Return(nil).Once()
+			},
+		},
+		{
+			name:      "warn and delete chat message",
+			target:    entity.ReportTargetChatMessage,
+			principal: moderator,
+			req:       entity.ResolveCaseRequest{Action: entity.ResolutionWarn, Note: "флуд", DeleteContent: true},
+			expect: func(m moderationMocks) {
+				m.moderationRepo.On("AddWarning", mock.Anything, entity.Warning{UserID: 2, CaseID: 4, Reason: "флуд", IssuedBy: 5}).Return(nil).Once()
+				m.chatRepo.On("DeleteMessage", mock.Anything, 9).Return(nil).Once()
+			},
+		},
+		{
+			name:      "ban keeps already deleted post",
+			target:    entity.ReactionTargetPost,
+			principal: admin,
+			req:       entity.ResolveCaseRequest{Action: entity.ResolutionBan, Note: "спам", DeleteContent: true, BanDurationHours: 72},
+			expect: func(m moderationMocks) {
+				m.moderationRepo.On("MarkBanIssued", mock.Anything, 4).Return(true, nil).Once()
+				m.authSanctions.On("IssueSanction", mock.Anything, "admin-token", 2,
+					entity.CreateSanctionRequest{Type: entity.SanctionBan, Reason: "спам", DurationHours: 72}).Return(nil).Once()
+				m.postRepo.On("DeletePost", mock.Anything, 9, 1).Return(sql.ErrNoRows).Once()
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u, m := newTestModerationUsecase(3)
+			open := entity.ModerationCase{ID: 4, TargetType: tc.target, TargetID: 9, TargetAuthorID: 2, Hidden: true, Status: entity.CaseStatusOpen}
+			m.moderationRepo.On("ClaimCase", mock.Anything, 4, tc.principal.UserID).Return(true, nil).Once()
+			m.moderationRepo.On("GetCase", mock.Anything, 4).Return(open, nil)
+			m.moderationRepo.On("GetReports", mock.Anything, 4).Return([]entity.Report{{ID: 1, CaseID: 4}}, nil)
+			m.moderationRepo.On("ResolveCase", mock.Anything, 4, tc.req.Action, tc.req.Note, tc.principal.UserID).Return(true, nil).Once()
+			tc.expect(m)
+
+			c, err := u.Resolve(context.Background(), 4, tc.principal, tc.req)
+
+			assert.NoError(t, err)
+			assert.Len(t, c.Reports, 1)
+			m.moderationRepo.AssertExpectations(t)
+			m.postRepo.AssertExpectations(t)
+			m.commentUsecase.AssertExpectations(t)
+			m.chatRepo.AssertExpectations(t)
+			m.authSanctions.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModerationUsecase_Resolve_Rejected(t *testing.T) {
+	other := 7
+	moderator := entity.Principal{UserID: 5, Role: "moderator"}
+
+	u, m := newTestModerationUsecase(3)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(entity.ModerationCase{ID: 4, Status: entity.CaseStatusClaimed, ClaimedBy: &other}, nil)
+	m.moderationRepo.On("GetCase", mock.Anything, 6).Return(entity.ModerationCase{ID: 6, Status: entity.CaseStatusResolved}, nil)
+	m.moderationRepo.On("ClaimCase", mock.Anything, mock.Anything, 5).Return(false, nil)
+
+	_, err := u.Resolve(context.Background(), 4, moderator, entity.ResolveCaseRequest{Action: "mute"})
+	assert.ErrorIs(t, err, ErrInvalidResolution)
+	_, err = u.Resolve(context.Background(), 4, moderator, entity.ResolveCaseRequest{Action: entity.ResolutionBan, Note: "спам"})
+	assert.ErrorIs(t, err, ErrBanForbidden)
+	_, err = u.Resolve(context.Background(), 4, moderator, entity.ResolveCaseRequest{Action: entity.ResolutionWarn, Note: "  "})
+	assert.ErrorIs(t, err, ErrResolutionNote)
+	_, err = u.Resolve(context.Background(), 4, moderator, entity.ResolveCaseRequest{Action: entity.ResolutionDismiss})
+	assert.ErrorIs(t, err, ErrCaseClaimed)
+	_, err = u.Resolve(context.Background(), 6, moderator, entity.ResolveCaseRequest{Action: entity.ResolutionDismiss})
+	assert.ErrorIs(t, err, ErrCaseResolved)
+
+	m.moderationRepo.AssertNotCalled(t, "ResolveCase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.moderationRepo.AssertNotCalled(t, "AddWarning", mock.Anything, mock.Anything)
+}
+
+func TestModerationUsecase_Resolve_BanRejected(t *testing.T) {
+	admin := entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermReportReview, entity.PermUserBan}, Token: "admin-token"}
+	req := entity.ResolveCaseRequest{Action: entity.ResolutionBan, Note: "спам"}
+
+	u, m := newTestModerationUsecase(3)
+	m.moderationRepo.On("ClaimCase", mock.Anything, 4, 1).Return(true, nil)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(entity.ModerationCase{ID: 4, TargetType: entity.ReactionTargetPost, TargetID: 9, TargetAuthorID: 2, Status: entity.CaseStatusOpen}, nil)
+	m.moderationRepo.On("MarkBanIssued", mock.Anything, 4).Return(true, nil)
+	m.moderationRepo.On("ClearBanIssued", mock.Anything, 4).Return(nil)
+	m.authSanctions.On("IssueSanction", mock.Anything, "admin-token", 2, mock.Anything).
+		Return(&adapters.SanctionRejectedError{Status: http.StatusForbidden, Message: "недостаточно прав для этой санкции"}).Once()
+	m.authSanctions.On("IssueSanction", mock.Anything, "admin-token", 2, mock.Anything).
+		Return(&adapters.SanctionRejectedError{Status: http.StatusBadRequest, Message: "нельзя наложить санкцию на себя"}).Once()
+
+	_, err := u.Resolve(context.Background(), 4, admin, req)
+	assert.ErrorIs(t, err, ErrBanForbidden)
+	_, err = u.Resolve(context.Background(), 4, admin, req)
+	assert.ErrorIs(t, err, ErrBanRejected)
+	assert.Contains(t, err.Error(), "нельзя наложить санкцию на себя")
+
+	// Бан не вынесен — отметка снимается, дело остается открытым, цель не
+	// трогается
+	m.moderationRepo.AssertNumberOfCalls(t, "ClearBanIssued", 2)
+	m.moderationRepo.AssertNotCalled(t, "ResolveCase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	m.moderationRepo.AssertNotCalled(t, "SetTargetHidden", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationUsecase_Resolve_RetryKeepsBan(t *testing.T) {
+	admin := entity.Principal{UserID: 1, Role: "admin", Permissions: []string{entity.PermReportReview, entity.PermUserBan}, Token: "admin-token"}
+	req := entity.ResolveCaseRequest{Action: entity.ResolutionBan, Note: "спам"}
+	claimed := entity.ModerationCase{ID: 4, TargetType: entity.ReactionTargetPost, TargetID: 9, TargetAuthorID: 2, Status: entity.CaseStatusClaimed, ClaimedBy: &admin.UserID}
+
+	u, m := newTestModerationUsecase(3)
+	m.moderationRepo.On("ClaimCase", mock.Anything, 4, 1).Return(true, nil)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(claimed, nil)
+	m.moderationRepo.On("GetReports", mock.Anything, 4).Return(nil, nil)
+	// Бан вынесен при прошлой попытке, закрыть дело тогда не удалось
+	m.moderationRepo.On("MarkBanIssued", mock.Anything, 4).Return(false, nil)
+	m.moderationRepo.On("ResolveCase", mock.Anything, 4, entity.ResolutionBan, "спам", 1).Return(true, nil)
+
+	_, err := u.Resolve(context.Background(), 4, admin, req)
+
+	assert.NoError(t, err)
+	m.authSanctions.AssertNotCalled(t, "IssueSanction", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestModerationUsecase_Resolve_ClaimedConcurrently(t *testing.T) {
+	other := 7
+	moderator := entity.Principal{UserID: 5, Role: "moderator", Permissions: []string{entity.PermReportReview}}
+
+	u, m := newTestModerationUsecase(3)
+	// Дело открыто, но другой модератор взял его раньше
+	m.moderationRepo.On("ClaimCase", mock.Anything, 4, 5).Return(false, nil)
+	m.moderationRepo.On("GetCase", mock.Anything, 4).Return(entity.ModerationCase{ID: 4, TargetAuthorID: 2, Status: entity.CaseStatusClaimed, ClaimedBy: &other}, nil)
+
+	_, err := u.Resolve(context.Background(), 4, moderator, entity.ResolveCaseRequest{Action: entity.ResolutionWarn, Note: "флуд"})
+
+	assert.ErrorIs(t, err, ErrCaseClaimed)
+	m.moderationRepo.AssertNotCalled(t, "AddWarning", mock.Anything, mock.Anything)
+}
@@ -2,28 +2,44 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/textdiff"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrPostNotFound      = errors.New("post not found")
+	ErrInvalidPostSort   = errors.New("sort must be new, top, hot or controversial")
+	ErrInvalidPostWindow = errors.New("t must be hour, day, week, month, year or all")
+)
+
 type PostUsecase interface {
 	CreatePost(ctx context.Context, post entity.Post) (*entity.Post, error)
-	GetPosts(ctx context.Context, limit, offset int) ([]entity.Post, error)
+	GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error)
 	GetPostByID(ctx context.Context, id int) (*entity.Post, error)
+	GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error)
 	UpdatePost(ctx context.Context, post entity.Post) (*entity.Post, error)
-	DeletePost(ctx context.Context, id int) error
-	GetTotalPostsCount(ctx context.Context) (int, error)
+	DeletePost(ctx context.Context, id, deletedBy int) error
+	GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error)
+	GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error)
+	GetPostRevision(ctx context.Context, id, version int) (entity.PostRevision, error)
+	DiffPostRevisions(ctx context.Context, id, from, to int) (entity.PostRevisionDiff, error)
+	RollbackPost(ctx context.Context, id, version, editorID int, reason string) (*entity.Post, error)
 }
 
 type postUsecase struct {
 	postRepo repository.PostRepository
+	tagRepo  repository.TagRepository
 	logger   *zap.Logger
 }
 
-func NewPostUsecase(postRepo repository.PostRepository, logger *zap.Logger) PostUsecase {
-	return &postUsecase{postRepo: postRepo, logger: logger}
+func NewPostUsecase(postRepo repository.PostRepository, tagRepo repository.TagRepository, logger *zap.Logger) PostUsecase {
+	return &postUsecase{postRepo: postRepo, tagRepo: tagRepo, logger: logger}
 }
 
 func (u *postUsecase) CreatePost(ctx context.Context, post entity.Post) (*entity.Post, error) {
@@ -33,22 +49,113 @@ func (u *postUsecase) CreatePost(ctx context.Context, post entity.Post) (*entity
 		zap.String("content", post.Content),
 	)
 
+	tags, err := u.postTags(ctx, post.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	createdPost, err := u.postRepo.CreatePost(ctx, post)
 	if err != nil {
 		u.logger.Error("Failed to create post", zap.Error(err))
 		return nil, err
 	}
+	if err := u.tagRepo.SetPostTags(ctx, createdPost.ID, tags); err != nil {
+		return nil, err
+	}
+	createdPost.Tags = tags
 
 	u.logger.Info("Post created successfully", zap.Int("postID", createdPost.ID))
 	return createdPost, nil
 }
 
-func (u *postUsecase) GetPosts(ctx context.Context, limit, offset int) ([]entity.Post, error) {
-	return u.postRepo.GetPosts(ctx, limit, offset)
+func (u *postUsecase) GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error) {
+	filter, err := u.resolveFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := u.postRepo.GetPosts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	postIDs := make([]int, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+	tags, err := u.tagRepo.GetPostTags(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range posts {
+		posts[i].Tags = tagsOrEmpty(tags[posts[i].ID])
+	}
+	return posts, nil
 }
 
-func (u *postUsecase) GetTotalPostsCount(ctx context.Context) (int, error) {
-	return u.postRepo.GetTotalPostsCount(ctx)
+func (u *postUsecase) GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error) {
+	filter, err := u.resolveFilter(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return u.postRepo.GetTotalPostsCount(ctx, filter)
+}
+
+// resolveFilter проверяет фильтр и приводит его метки к основным, чтобы
+// фильтр по синониму находил посты с основной меткой.
+func (u *postUsecase) resolveFilter(ctx context.Context, filter entity.PostFilter) (entity.PostFilter, error) {
+	switch filter.TagMode {
+	case "", entity.TagModeAll, entity.TagModeAny:
+	default:
+		return filter, ErrInvalidTagMode
+	}
+	switch filter.Sort {
+	case "", entity.PostSortNew, entity.PostSortTop, entity.PostSortHot, entity.PostSortControversial:
+	default:
+		return filter, ErrInvalidPostSort
+	}
+	switch filter.Window {
+	case "", entity.PostWindowHour, entity.PostWindowDay, entity.PostWindowWeek,
+		entity.PostWindowMonth, entity.PostWindowYear, entity.PostWindowAll:
+	default:
+		return filter, ErrInvalidPostWindow
+	}
+	if len(filter.Tags) == 0 {
+		return filter, nil
+	}
+	tags, err := resolveTags(ctx, u.tagRepo, filter.Tags)
+	if err != nil {
+		return filter, err
+	}
+	filter.Tags = tags
+	return filter, nil
+}
+
+// postTags проверяет метки, назначаемые посту, и приводит их к основным.
+func (u *postUsecase) postTags(ctx context.Context, names []string) ([]string, error) {
+	tags, err := resolveTags(ctx, u.tagRepo, names)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > entity.MaxPostTags {
+		return nil, ErrTooManyTags
+	}
+	return tags, nil
+}
+
+// getPostTags возвращает метки одного поста.
+func (u *postUsecase) getPostTags(ctx context.Context, postID int) ([]string, error) {
+	tags, err := u.tagRepo.GetPostTags(ctx, []int{postID})
+	if err != nil {
+		return nil, err
+	}
+	return tagsOrEmpty(tags[postID]), nil
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
 }
 
 func (u *postUsecase) GetPostByID(ctx context.Context, id int) (*entity.Post, error) {
@@ -56,6 +163,9 @@ func (u *postUsecase) GetPostByID(ctx context.Context, id int) (*entity.Post, er
 
 	post, err := u.postRepo.GetPostByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
 		u.logger.Error("Failed to get post by ID", zap.Error(err), zap.Int("postID", id))
 		return nil, err
 	}
@@ -64,6 +174,22 @@ func (u *postUsecase) GetPostByID(ctx context.Context, id int) (*entity.Post, er
 	return post, nil
 }
 
+func (u *postUsecase) GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error) {
+	details, err := u.postRepo.GetPostDetails(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		u.logger.Error("Failed to get post details", zap.Error(err), zap.Int("postID", id))
+		return nil, err
+	}
+	details.Edited = details.EditedBy != nil || details.UpdatedAt.After(details.CreatedAt)
+	if details.Tags, err = u.getPostTags(ctx, id); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
 func (u *postUsecase) UpdatePost(ctx context.Context, post entity.Post) (*entity.Post, error) {
 	u.logger.Info("Updating post",
 		zap.Int("postID", post.ID),
@@ -71,21 +197,46 @@ func (u *postUsecase) UpdatePost(ctx context.Context, post entity.Post) (*entity
 		zap.String("content", post.Content),
 	)
 
+	// Tags == nil оставляет метки поста без изменений
+	var tags []string
+	if post.Tags != nil {
+		var err error
+		if tags, err = u.postTags(ctx, post.Tags); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedPost, err := u.postRepo.UpdatePost(ctx, post)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
 		u.logger.Error("Failed to update post", zap.Error(err), zap.Int("postID", post.ID))
 		return nil, err
 	}
+	if post.Tags != nil {
+		if err := u.tagRepo.SetPostTags(ctx, post.ID, tags); err != nil {
+			return nil, err
+		}
+		updatedPost.Tags = tags
+	} else if updatedPost.Tags, err = u.getPostTags(ctx, post.ID); err != nil {
+		return nil, err
+	}
 
 	u.logger.Info("Post updated successfully", zap.Int("postID", post.ID))
 	return updatedPost, nil
 }
 
-func (u *postUsecase) DeletePost(ctx context.Context, id int) error {
-	u.logger.Info("Deleting post", zap.Int("postID", id))
+// DeletePost переносит пост в корзину от имени deletedBy. Права проверяет
+// вызывающий.
+func (u *postUsecase) DeletePost(ctx context.Context, id, deletedBy int) error {
+	u.logger.Info("Deleting post", zap.Int("postID", id), zap.Int("deletedBy", deletedBy))
 
-	err := u.postRepo.DeletePost(ctx, id)
+	err := u.postRepo.DeletePost(ctx, id, deletedBy)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPostNotFound
+		}
 		u.logger.Error("Failed to delete post", zap.Error(err), zap.Int("postID", id))
 		return err
 	}
@@ -93,3 +244,102 @@ func (u *postUsecase) DeletePost(ctx context.Context, id int) error {
 	u.logger.Info("Post deleted successfully", zap.Int("postID", id))
 	return nil
 }
+
+// GetPostRevisions возвращает все версии поста от первой до текущей.
+// Текущая версия идет последней и помечена Current.
+func (u *postUsecase) GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error) {
+	post, err := u.GetPostByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := u.postRepo.GetPostRevisions(ctx, id)
+	if err != nil {
+		u.logger.Error("Failed to get post revisions", zap.Error(err), zap.Int("postID", id))
+		return nil, err
+	}
+	// Первую версию создал автор вместе с постом
+	if len(revisions) > 0 {
+		revisions[0].EditorID = post.AuthorId
+		revisions[0].CreatedAt = post.CreatedAt
+	}
+
+	current := entity.PostRevision{
+		Version:   len(revisions) + 1,
+		PostID:    post.ID,
+		Title:     post.Title,
+		Content:   post.Content,
+		EditorID:  post.AuthorId,
+		CreatedAt: post.CreatedAt,
+		Current:   true,
+	}
+	if len(revisions) > 0 {
+		current.Reason = post.EditReason
+		current.CreatedAt = post.UpdatedAt
+		if post.EditedBy != nil {
+			current.EditorID = *post.EditedBy
+		}
+	}
+	return append(revisions, current), nil
+}
+
+// GetPostRevision возвращает версию поста с номером version.
+func (u *postUsecase) GetPostRevision(ctx context.Context, id, version int) (entity.PostRevision, error) {
+	revisions, err := u.GetPostRevisions(ctx, id)
+	if err != nil {
+		return entity.PostRevision{}, err
+	}
+	if version < 1 || version > len(revisions) {
+		return entity.PostRevision{}, ErrRevisionNotFound
+	}
+	return revisions[version-1], nil
+}
+
+// DiffPostRevisions сравнивает две версии поста. to, равный 0, означает
+// текущую версию, from, равный 0, — версию перед to.
+func (u *postUsecase) DiffPostRevisions(ctx context.Context, id, from, to int) (entity.PostRevisionDiff, error) {
+	revisions, err := u.GetPostRevisions(ctx, id)
+	if err != nil {
+		return entity.PostRevisionDiff{}, err
+	}
+	if to == 0 {
+		to = len(revisions)
+	}
+	if from == 0 {
+		from = max(to-1, 1)
+	}
+	if from < 1 || from > len(revisions) || to < 1 || to > len(revisions) {
+		return entity.PostRevisionDiff{}, ErrRevisionNotFound
+	}
+
+	a, b := revisions[from-1], revisions[to-1]
+	diff := entity.PostRevisionDiff{
+		From:    from,
+		To:      to,
+		Title:   textdiff.Lines(a.Title, b.Title),
+		Content: textdiff.Lines(a.Content, b.Content),
+	}
+	diff.Changed = textdiff.Changed(diff.Title) || textdiff.Changed(diff.Content)
+	return diff, nil
+}
+
+// RollbackPost возвращает посту заголовок и текст версии version. Откат —
+// обычная правка: он тоже попадает в историю. Права проверяет вызывающий.
+func (u *postUsecase) RollbackPost(ctx context.Context, id, version, editorID int, reason string) (*entity.Post, error) {
+	revision, err := u.GetPostRevision(ctx, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("rollback to version %d", version)
+	}
+
+	u.logger.Info("Rolling back post", zap.Int("postID", id), zap.Int("version", version), zap.Int("editorID", editorID))
+	return u.UpdatePost(ctx, entity.Post{
+		ID:         id,
+		Title:      revision.Title,
+		Content:    revision.Content,
+		EditedBy:   &editorID,
+		EditReason: reason,
+	})
+}
@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/textdiff"
 	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,7 +21,7 @@ func TestPostUsecase_CreatePost_Success(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	post := entity.Post{
 		AuthorId: 1,
@@ -44,7 +47,7 @@ func TestPostUsecase_CreatePost_Failure(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	post := entity.Post{
 		AuthorId: 1,
@@ -68,16 +71,16 @@ func TestPostUsecase_GetPosts_Success(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	posts := []entity.Post{
 		{ID: 1, AuthorId: 1, Title: "Post 1", Content: "Content 1"},
 		{ID: 2, AuthorId: 2, Title: "Post 2", Content: "Content 2"},
 	}
 
-	mockPostRepo.On("GetPosts", mock.Anything).Return(posts, nil)
+	mockPostRepo.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(posts, nil)
 
-	result, err := postUsecase.GetPosts(context.Background(), 10, 0)
+	result, err := postUsecase.GetPosts(context.Background(), entity.PostFilter{Limit: 10})
 
 	assert.NoError(t, err)
 	assert.Equal(t, posts, result)
@@ -91,11 +94,11 @@ func TestPostUsecase_GetPosts_Failure(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
-	mockPostRepo.On("GetPosts", mock.Anything).Return(nil, errors.New("failed to get posts"))
+	mockPostRepo.On("GetPosts", mock.Anything, entity.PostFilter{Limit: 10}).Return(nil, errors.New("failed to get posts"))
 
-	result, err := postUsecase.GetPosts(context.Background(), 10, 0)
+	result, err := postUsecase.GetPosts(context.Background(), entity.PostFilter{Limit: 10})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -109,7 +112,7 @@ func TestPostUsecase_GetPostByID_Success(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	post := entity.Post{
 		ID:       1,
@@ -134,7 +137,7 @@ func TestPostUsecase_GetPostByID_Failure(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(nil, errors.New("failed to get post"))
 
@@ -146,13 +149,85 @@ func TestPostUsecase_GetPostByID_Failure(t *testing.T) {
 	mockPostRepo.AssertExpectations(t)
 }
 
+func TestPostUsecase_GetPostByID_NotFound(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostRepo := new(mocks.PostRepository)
+
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
+
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(nil, sql.ErrNoRows)
+
+	result, err := postUsecase.GetPostByID(context.Background(), 1)
+
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	assert.Nil(t, result)
+}
+
+func TestPostUsecase_GetPostDetails_Edited(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostRepo := new(mocks.PostRepository)
+
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	details := &entity.PostDetails{
+		Post:          entity.Post{ID: 1, AuthorId: 2, CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Minute)},
+		CommentsCount: 3,
+	}
+	mockPostRepo.On("GetPostDetails", mock.Anything, 1).Return(details, nil)
+
+	result, err := postUsecase.GetPostDetails(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Edited)
+	assert.Equal(t, 3, result.CommentsCount)
+}
+
+func TestPostUsecase_GetPostDetails_NotEdited(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostRepo := new(mocks.PostRepository)
+
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	details := &entity.PostDetails{Post: entity.Post{ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt}}
+	mockPostRepo.On("GetPostDetails", mock.Anything, 1).Return(details, nil)
+
+	result, err := postUsecase.GetPostDetails(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.False(t, result.Edited)
+}
+
+func TestPostUsecase_GetPostDetails_NotFound(t *testing.T) {
+
+	logger, _ := zap.NewProduction()
+
+	mockPostRepo := new(mocks.PostRepository)
+
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
+
+	mockPostRepo.On("GetPostDetails", mock.Anything, 7).Return(nil, sql.ErrNoRows)
+
+	result, err := postUsecase.GetPostDetails(context.Background(), 7)
+
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	assert.Nil(t, result)
+}
+
 func TestPostUsecase_UpdatePost_Success(t *testing.T) {
 
 	logger, _ := zap.NewProduction()
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	post := entity.Post{
 		ID:       1,
@@ -177,7 +252,7 @@ func TestPostUsecase_UpdatePost_Failure(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
 	post := entity.Post{
 		ID:       1,
@@ -202,11 +277,11 @@ func TestPostUsecase_DeletePost_Success(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
-	mockPostRepo.On("DeletePost", mock.Anything, 1).Return(nil)
+	mockPostRepo.On("DeletePost", mock.Anything, 1, 2).Return(nil)
 
-	err := postUsecase.DeletePost(context.Background(), 1)
+	err := postUsecase.DeletePost(context.Background(), 1, 2)
 
 	assert.NoError(t, err)
 
@@ -219,13 +294,138 @@ func TestPostUsecase_DeletePost_Failure(t *testing.T) {
 
 	mockPostRepo := new(mocks.PostRepository)
 
-	postUsecase := NewPostUsecase(mockPostRepo, logger)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), logger)
 
-	mockPostRepo.On("DeletePost", mock.Anything, 1).Return(errors.New("failed to delete post"))
+	mockPostRepo.On("DeletePost", mock.Anything, 1, 2).Return(errors.New("failed to delete post"))
 
-	err := postUsecase.DeletePost(context.Background(), 1)
+	err := postUsecase.DeletePost(context.Background(), 1, 2)
 
 	assert.Error(t, err)
 
 	mockPostRepo.AssertExpectations(t)
 }
+
+func TestPostUsecase_UpdatePost_NotFound(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	post := entity.Post{ID: 9, Title: "Updated Post"}
+	mockPostRepo.On("UpdatePost", mock.Anything, post).Return(nil, sql.ErrNoRows)
+
+	result, err := postUsecase.UpdatePost(context.Background(), post)
+
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	assert.Nil(t, result)
+}
+
+func TestPostUsecase_GetPostDetails_EditedWithinSameSecond(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	editorID := 2
+	details := &entity.PostDetails{Post: entity.Post{ID: 1, CreatedAt: createdAt, UpdatedAt: createdAt, EditedBy: &editorID}}
+	mockPostRepo.On("GetPostDetails", mock.Anything, 1).Return(details, nil)
+
+	result, err := postUsecase.GetPostDetails(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Edited)
+}
+
+func TestPostUsecase_GetPostRevisions(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	firstEdit := createdAt.Add(time.Hour)
+	secondEdit := firstEdit.Add(time.Hour)
+	moderatorID := 7
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{
+		ID: 1, AuthorId: 3, Title: "v3", Content: "third", CreatedAt: createdAt, UpdatedAt: secondEdit,
+		EditedBy: &moderatorID, EditReason: "rules",
+	}, nil)
+	mockPostRepo.On("GetPostRevisions", mock.Anything, 1).Return([]entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "v1", Content: "first"},
+		{Version: 2, PostID: 1, Title: "v2", Content: "second", EditorID: 3, Reason: "typo", CreatedAt: firstEdit},
+	}, nil)
+
+	revisions, err := postUsecase.GetPostRevisions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "v1", Content: "first", EditorID: 3, CreatedAt: createdAt},
+		{Version: 2, PostID: 1, Title: "v2", Content: "second", EditorID: 3, Reason: "typo", CreatedAt: firstEdit},
+		{Version: 3, PostID: 1, Title: "v3", Content: "third", EditorID: 7, Reason: "rules", CreatedAt: secondEdit, Current: true},
+	}, revisions)
+}
+
+func TestPostUsecase_GetPostRevisions_NeverEdited(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 3, Title: "t", Content: "c", CreatedAt: createdAt, UpdatedAt: createdAt}, nil)
+	mockPostRepo.On("GetPostRevisions", mock.Anything, 1).Return(nil, nil)
+
+	revisions, err := postUsecase.GetPostRevisions(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "t", Content: "c", EditorID: 3, CreatedAt: createdAt, Current: true},
+	}, revisions)
+}
+
+func TestPostUsecase_GetPostRevision_NotFound(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1}, nil)
+	mockPostRepo.On("GetPostRevisions", mock.Anything, 1).Return(nil, nil)
+
+	_, err := postUsecase.GetPostRevision(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+}
+
+func TestPostUsecase_DiffPostRevisions(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, Title: "same", Content: "a\nc"}, nil)
+	mockPostRepo.On("GetPostRevisions", mock.Anything, 1).Return([]entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "same", Content: "a\nb"},
+	}, nil)
+
+	diff, err := postUsecase.DiffPostRevisions(context.Background(), 1, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 2, diff.To)
+	assert.True(t, diff.Changed)
+	assert.Equal(t, []textdiff.Line{{Op: textdiff.OpEqual, Text: "same"}}, diff.Title)
+	assert.Equal(t, []textdiff.Line{
+		{Op: textdiff.OpEqual, Text: "a"},
+		{Op: textdiff.OpDelete, Text: "b"},
+		{Op: textdiff.OpInsert, Text: "c"},
+	}, diff.Content)
+}
+
+func TestPostUsecase_RollbackPost(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	mockPostRepo.On("GetPostByID", mock.Anything, 1).Return(&entity.Post{ID: 1, AuthorId: 3, Title: "vandalized", Content: "spam"}, nil)
+	mockPostRepo.On("GetPostRevisions", mock.Anything, 1).Return([]entity.PostRevision{
+		{Version: 1, PostID: 1, Title: "original", Content: "text"},
+	}, nil)
+	moderatorID := 7
+	rolledBack := entity.Post{ID: 1, Title: "original", Content: "text", EditedBy: &moderatorID, EditReason: "rollback to version 1"}
+	mockPostRepo.On("UpdatePost", mock.Anything, rolledBack).Return(&rolledBack, nil)
+
+	result, err := postUsecase.RollbackPost(context.Background(), 1, 1, 7, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &rolledBack, result)
+	mockPostRepo.AssertExpectations(t)
+}
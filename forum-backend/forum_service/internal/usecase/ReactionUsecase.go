@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidReaction = errors.New("unknown reaction kind")
+	ErrSelfVote        = errors.New("cannot vote for own post or comment")
+)
+
+type ReactionUsecase interface {
+	// Kinds возвращает виды реакций: голоса up и down, затем эмодзи.
+	Kinds() []string
+	// GetTarget возвращает пост или комментарий, на который ставят реакцию.
+	GetTarget(ctx context.Context, targetType string, id int) (entity.ReactionTarget, error)
+	// React ставит реакцию userID и возвращает новые счетчики цели. Голосовать
+	// за свои посты и комментарии нельзя, ставить эмодзи — можно.
+	React(ctx context.Context, target entity.ReactionTarget, userID int, kind string) (entity.Votes, error)
+	Unreact(ctx context.Context, target entity.ReactionTarget, userID int, kind string) (entity.Votes, error)
+	GetReactions(ctx context.Context, filter entity.ReactionFilter) ([]entity.Reaction, int, error)
+}
+
+type reactionUsecase struct {
+	reactionRepo repository.ReactionRepository
+	postRepo     repository.PostRepository
+	commentRepo  repository.CommentsRepository
+	kinds        []string
+	logger       *zap.Logger
+}
+
+// NewReactionUsecase создает usecase реакций; emojis — разрешенные эмодзи.
+func NewReactionUsecase(
+	reactionRepo repository.ReactionRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentsRepository,
+	emojis []string,
+	logger *zap.Logger,
+) ReactionUsecase {
+	kinds := []string{entity.ReactionUp, entity.ReactionDown}
+	for _, emoji := range emojis {
+		if emoji != entity.ReactionUp && emoji != entity.ReactionDown {
+			kinds = append(kinds, emoji)
+		}
+	}
+	return &reactionUsecase{
+		reactionRepo: reactionRepo,
+		postRepo:     postRepo,
+		commentRepo:  commentRepo,
+		kinds:        kinds,
+		logger:       logger,
+	}
+}
+
+func (u *reactionUsecase) Kinds() []string {
+	return u.kinds
+}
+
+func (u *reactionUsecase) checkKind(kind string) error {
+	for _, k := range u.kinds {
+		if k == kind {
+			return nil
+		}
+	}
+	return ErrInvalidReaction
+}
+
+func (u *reactionUsecase) GetTarget(ctx context.Context, targetType string, id int) (entity.ReactionTarget, error) {
+	switch targetType {
+	case entity.ReactionTargetPost:
+		post, err := u.postRepo.GetPostByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ReactionTarget{}, ErrPostNotFound
+		}
+		if err != nil {
+			return entity.ReactionTarget{}, err
+		}
+		return entity.ReactionTarget{Type: targetType, ID: post.ID, PostID: post.ID, AuthorID: post.AuthorId}, nil
+	case entity.ReactionTargetComment:
+		comment, err := u.commentRepo.GetCommentByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ReactionTarget{}, ErrCommentNotFound
+		}
+		if err != nil {
+			return entity.ReactionTarget{}, err
+		}
+		return entity.ReactionTarget{
+			Type:     targetType,
+			ID:       comment.ID,
+			PostID:   comment.PostId,
+			AuthorID: comment.AuthorId,
+			Deleted:  comment.Deleted,
+		}, nil
+	default:
+		return entity.ReactionTarget{}, ErrInvalidReaction
+	}
+}
+
+func (u *reactionUsecase) React(ctx context.Context, target entity.ReactionTarget, userID int, kind string) (entity.Votes, error) {
+	if err := u.checkKind(kind); err != nil {
+		return entity.Votes{}, err
+	}
+	if target.Deleted {
+		return entity.Votes{}, ErrCommentDeleted
+	}
+	if (kind == entity.ReactionUp || kind == entity.ReactionDown) && target.AuthorID == userID {
+		return entity.Votes{}, ErrSelfVote
+	}
+
+	reaction := entity.Reaction{TargetType: target.Type, TargetID: target.ID, UserID: userID, Kind: kind}
+	if err := u.reactionRepo.AddReaction(ctx, reaction); err != nil {
+		return entity.Votes{}, err
+	}
+	return u.reactionRepo.GetVotes(ctx, target.Type, target.ID)
+}
+
+func (u *reactionUsecase) Unreact(ctx context.Context, target entity.ReactionTarget, userID int, kind string) (entity.Votes, error) {
+	if err := u.checkKind(kind); err != nil {
+		return entity.Votes{}, err
+	}
+	reaction := entity.Reaction{TargetType: target.Type, TargetID: target.ID, UserID: userID, Kind: kind}
+	if err := u.reactionRepo.DeleteReaction(ctx, reaction); err != nil {
+		return entity.Votes{}, err
+	}
+	return u.reactionRepo.GetVotes(ctx, target.Type, target.ID)
+}
+
+func (u *reactionUsecase) GetReactions(ctx context.Context, filter entity.ReactionFilter) ([]entity.Reaction, int, error) {
+	if filter.Kind != "" {
+		if err := u.checkKind(filter.Kind); err != nil {
+			return nil, 0, err
+		}
+	}
+	reactions, err := u.reactionRepo.GetReactions(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.reactionRepo.GetTotalReactionsCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reactions, total, nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestReactionUsecase() (ReactionUsecase, *mocks.ReactionRepository, *mocks.PostRepository, *mocks.CommentsRepository) {
+	reactionRepo := new(mocks.ReactionRepository)
+	postRepo := new(mocks.PostRepository)
+	commentRepo := new(mocks.CommentsRepository)
+	u := NewReactionUsecase(reactionRepo, postRepo, commentRepo, []string{"🎉", "up", "😢"}, zap.NewNop())
+	return u, reactionRepo, postRepo, commentRepo
+}
+
+func TestReactionUsecase_Kinds(t *testing.T) {
+	u, _, _, _ := newTestReactionUsecase()
+
+	assert.Equal(t, []string{"up", "down", "🎉", "😢"}, u.Kinds())
+}
+
+func TestReactionUsecase_GetTarget(t *testing.T) {
+	u, _, postRepo, commentRepo := newTestReactionUsecase()
+
+	commentRepo.On("GetCommentByID", mock.Anything, 5).Return(entity.Comment{ID: 5, PostId: 2, AuthorId: 3, Deleted: true}, nil)
+	commentRepo.On("GetCommentByID", mock.Anything, 6).Return(entity.Comment{}, sql.ErrNoRows)
+	postRepo.On("GetPostByID", mock.Anything, 7).Return(nil, sql.ErrNoRows)
+
+	target, err := u.GetTarget(context.Background(), entity.ReactionTargetComment, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReactionTarget{Type: "comment", ID: 5, PostID: 2, AuthorID: 3, Deleted: true}, target)
+
+	_, err = u.GetTarget(context.Background(), entity.ReactionTargetComment, 6)
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+
+	_, err = u.GetTarget(context.Background(), entity.ReactionTargetPost, 7)
+	assert.ErrorIs(t, err, ErrPostNotFound)
+}
+
+func TestReactionUsecase_React(t *testing.T) {
+	u, reactionRepo, _, _ := newTestReactionUsecase()
+	target := entity.ReactionTarget{Type: entity.ReactionTargetPost, ID: 7, PostID: 7, AuthorID: 1}
+
+	reactionRepo.On("AddReaction", mock.Anything, entity.Reaction{TargetType: "post", TargetID: 7, UserID: 2, Kind: "up"}).Return(nil)
+	reactionRepo.On("GetVotes", mock.Anything, "post", 7).Return(entity.Votes{Upvotes: 1, Score: 1}, nil)
+
+	votes, err := u.React(context.Background(), target, 2, entity.ReactionUp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, votes.Score)
+	reactionRepo.AssertExpectations(t)
+}
+
+func TestReactionUsecase_React_Rejected(t *testing.T) {
+	post := entity.ReactionTarget{Type: entity.ReactionTargetPost, ID: 7, PostID: 7, AuthorID: 1}
+	deleted := entity.ReactionTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7, AuthorID: 3, Deleted: true}
+
+	tests := []struct {
+		name   string
+		target entity.ReactionTarget
+		kind   string
+		err    error
+	}{
+		{"self upvote", post, entity.ReactionUp, ErrSelfVote},
+		{"self downvote", post, entity.ReactionDown, ErrSelfVote},
+		{"unknown emoji", post, "🔥", ErrInvalidReaction},
+		{"deleted comment", deleted, "🎉", ErrCommentDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, reactionRepo, _, _ := newTestReactionUsecase()
+
+			_, err := u.React(context.Background(), tt.target, 1, tt.kind)
+
+			assert.ErrorIs(t, err, tt.err)
+			reactionRepo.AssertNotCalled(t, "AddReaction", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReactionUsecase_React_OwnEmoji(t *testing.T) {
+	u, reactionRepo, _, _ := newTestReactionUsecase()
+	target := entity.ReactionTarget{Type: entity.ReactionTargetComment, ID: 5, PostID: 7, AuthorID: 1}
+
+	reactionRepo.On("AddReaction", mock.Anything, entity.Reaction{TargetType: "comment", TargetID: 5, UserID: 1, Kind: "🎉"}).Return(nil)
+	reactionRepo.On("GetVotes", mock.Anything, "comment", 5).Return(entity.Votes{Reactions: entity.ReactionCounts{"🎉": 1}}, nil)
+
+	votes, err := u.React(context.Background(), target, 1, "🎉")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, votes.Reactions["🎉"])
+}
+
+func TestReactionUsecase_GetReactions_InvalidKind(t *testing.T) {
+	u, reactionRepo, _, _ := newTestReactionUsecase()
+
+	_, _, err := u.GetReactions(context.Background(), entity.ReactionFilter{TargetType: "post", TargetID: 1, Kind: "like"})
+
+	assert.ErrorIs(t, err, ErrInvalidReaction)
+	reactionRepo.AssertNotCalled(t, "GetReactions", mock.Anything, mock.Anything)
+}
+
+func TestPostUsecase_GetPosts_InvalidSort(t *testing.T) {
+	postUsecase := NewPostUsecase(new(mocks.PostRepository), untaggedRepo(), zap.NewNop())
+
+	_, err := postUsecase.GetPosts(context.Background(), entity.PostFilter{Sort: "best"})
+	assert.ErrorIs(t, err, ErrInvalidPostSort)
+
+	_, err = postUsecase.GetPosts(context.Background(), entity.PostFilter{Sort: entity.PostSortTop, Window: "decade"})
+	assert.ErrorIs(t, err, ErrInvalidPostWindow)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/search"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidSearchType = errors.New("search type must be post or comment")
+
+// highlighter экранирует HTML в найденном тексте и заменяет границы
+// совпадений на <mark>.
+var highlighter = strings.NewReplacer(
+	repository.HighlightStart, "<mark>",
+	repository.HighlightEnd, "</mark>",
+)
+
+type SearchUsecase interface {
+	// Search возвращает страницу результатов и общее их число.
+	Search(ctx context.Context, filter entity.SearchFilter) ([]entity.SearchResult, int, error)
+	Reindex(ctx context.Context) error
+}
+
+type searchUsecase struct {
+	searchRepo repository.SearchRepository
+	logger     *zap.Logger
+}
+
+func NewSearchUsecase(searchRepo repository.SearchRepository, logger *zap.Logger) SearchUsecase {
+	return &searchUsecase{searchRepo: searchRepo, logger: logger}
+}
+
+func (u *searchUsecase) Search(ctx context.Context, filter entity.SearchFilter) ([]entity.SearchResult, int, error) {
+	if filter.Type != "" && filter.Type != entity.SearchTypePost && filter.Type != entity.SearchTypeComment {
+		return nil, 0, ErrInvalidSearchType
+	}
+	match, err := search.ParseQuery(filter.Query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results, err := u.searchRepo.Search(ctx, match, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.searchRepo.CountSearchResults(ctx, match, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Title = highlight(results[i].Title)
+		results[i].Snippet = highlight(results[i].Snippet)
+	}
+	return results, total, nil
+}
+
+func (u *searchUsecase) Reindex(ctx context.Context) error {
+	return u.searchRepo.RebuildSearchIndex(ctx)
+}
+
+func highlight(text string) string {
+	return highlighter.Replace(html.EscapeString(text))
+}
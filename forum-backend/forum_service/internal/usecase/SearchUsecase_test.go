@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/search"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestSearchUsecase_Search_Success(t *testing.T) {
+	mockSearchRepo := new(mocks.SearchRepository)
+	searchUsecase := NewSearchUsecase(mockSearchRepo, zap.NewNop())
+
+	filter := entity.SearchFilter{Query: "Постами go*", Limit: 10}
+	found := []entity.SearchResult{{
+		Type:    entity.SearchTypePost,
+		ID:      1,
+		Title:   "\x02Посты\x03 о <script>",
+		Snippet: "…про \x02golang\x03 & C…",
+	}}
+	mockSearchRepo.On("Search", mock.Anything, `"пост"* "go"*`, filter).Return(found, nil)
+	mockSearchRepo.On("CountSearchResults", mock.Anything, `"пост"* "go"*`, filter).Return(1, nil)
+
+	results, total, err := searchUsecase.Search(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, "<mark>Посты</mark> о &lt;script&gt;", results[0].Title)
+	assert.Equal(t, "…про <mark>golang</mark> &amp; C…", results[0].Snippet)
+	mockSearchRepo.AssertExpectations(t)
+}
+
+func TestSearchUsecase_Search_EmptyQuery(t *testing.T) {
+	mockSearchRepo := new(mocks.SearchRepository)
+	searchUsecase := NewSearchUsecase(mockSearchRepo, zap.NewNop())
+
+	_, _, err := searchUsecase.Search(context.Background(), entity.SearchFilter{Query: ` "" * `})
+
+	assert.ErrorIs(t, err, search.ErrEmptyQuery)
+	mockSearchRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSearchUsecase_Search_InvalidType(t *testing.T) {
+	searchUsecase := NewSearchUsecase(new(mocks.SearchRepository), zap.NewNop())
+
+	_, _, err := searchUsecase.Search(context.Background(), entity.SearchFilter{Query: "go", Type: "user"})
+
+	assert.ErrorIs(t, err, ErrInvalidSearchType)
+}
+
+func TestSearchUsecase_Search_RepositoryError(t *testing.T) {
+	mockSearchRepo := new(mocks.SearchRepository)
+	searchUsecase := NewSearchUsecase(mockSearchRepo, zap.NewNop())
+
+	mockSearchRepo.On("Search", mock.Anything, `"go"`, mock.Anything).Return(nil, errors.New("database error"))
+
+	_, _, err := searchUsecase.Search(context.Background(), entity.SearchFilter{Query: "go"})
+
+	assert.EqualError(t, err, "database error")
+	mockSearchRepo.AssertNotCalled(t, "CountSearchResults", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrTagExists      = errors.New("tag or alias with this name already exists")
+	ErrInvalidTag     = errors.New("tag must start with a letter or digit and contain only letters, digits, '+', '#', '.' and '-'")
+	ErrTooManyTags    = fmt.Errorf("post can have at most %d tags", entity.MaxPostTags)
+	ErrInvalidTagMode = errors.New("tag_mode must be all or any")
+	ErrSameTag        = errors.New("tag cannot be merged into itself")
+)
+
+const maxTagLength = 32
+
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}+#.-]*$`)
+
+type TagUsecase interface {
+	GetTags(ctx context.Context, filter entity.TagFilter) ([]entity.Tag, int, error)
+	// GetTag возвращает метку по имени или синониму.
+	GetTag(ctx context.Context, name string, hiddenCategoryIDs []int) (entity.Tag, error)
+	RenameTag(ctx context.Context, name, newName string) (entity.Tag, error)
+	// MergeTags переносит посты source в target; имя source становится
+	// синонимом target.
+	MergeTags(ctx context.Context, source, target string) (entity.Tag, error)
+	DeleteTag(ctx context.Context, name string) error
+	AddAlias(ctx context.Context, name, alias string) (entity.Tag, error)
+	DeleteAlias(ctx context.Context, name, alias string) error
+}
+
+type tagUsecase struct {
+	tagRepo repository.TagRepository
+	logger  *zap.Logger
+}
+
+func NewTagUsecase(tagRepo repository.TagRepository, logger *zap.Logger) TagUsecase {
+	return &tagUsecase{tagRepo: tagRepo, logger: logger}
+}
+
+// normalizeTag приводит метку к виду, в котором она хранится: нижний
+// регистр, пробелы внутри заменены дефисом.
+func normalizeTag(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	if utf8.RuneCountInString(name) > maxTagLength || !tagPattern.MatchString(name) {
+		return "", ErrInvalidTag
+	}
+	return name, nil
+}
+
+// resolveTags нормализует метки, заменяет синонимы основными метками и
+// убирает повторы, сохраняя порядок.
+func resolveTags(ctx context.Context, tagRepo repository.TagRepository, names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := normalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) == 0 {
+		return normalized, nil
+	}
+
+	aliases, err := tagRepo.ResolveAliases(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(normalized))
+	resolved := make([]string, 0, len(normalized))
+	for _, tag := range normalized {
+		if canonical, ok := aliases[tag]; ok {
+			tag = canonical
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			resolved = append(resolved, tag)
+		}
+	}
+	return resolved, nil
+}
+
+func (u *tagUsecase) GetTags(ctx context.Context, filter entity.TagFilter) ([]entity.Tag, int, error) {
+	filter.Prefix = strings.Join(strings.Fields(strings.ToLower(filter.Prefix)), "-")
+	tags, err := u.tagRepo.GetTags(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.tagRepo.GetTotalTagsCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tags, total, nil
+}
+
+func (u *tagUsecase) GetTag(ctx context.Context, name string, hiddenCategoryIDs []int) (entity.Tag, error) {
+	resolved, err := resolveTags(ctx, u.tagRepo, []string{name})
+	if err != nil {
+		if errors.Is(err, ErrInvalidTag) {
+			return entity.Tag{}, ErrTagNotFound
+		}
+		return entity.Tag{}, err
+	}
+	tag, err := u.tagRepo.GetTagByName(ctx, resolved[0], hiddenCategoryIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Tag{}, ErrTagNotFound
+	}
+	return tag, err
+}
+
+// getExactTag ищет метку по основному имени: управление метками по
+// синониму было бы неочевидным.
+func (u *tagUsecase) getExactTag(ctx context.Context, name string) (entity.Tag, error) {
+	name, err := normalizeTag(name)
+	if err != nil {
+		return entity.Tag{}, ErrTagNotFound
+	}
+	tag, err := u.tagRepo.GetTagByName(ctx, name, nil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Tag{}, ErrTagNotFound
+	}
+	return tag, err
+}
+
+// checkNameFree проверяет, что name не занято ни меткой, ни синонимом
+// другой метки, кроме tag.
+func (u *tagUsecase) checkNameFree(ctx context.Context, tag entity.Tag, name string) error {
+	aliases, err := u.tagRepo.ResolveAliases(ctx, []string{name})
+	if err != nil {
+		return err
+	}
+	if canonical, ok := aliases[name]; ok && canonical != tag.Name {
+		return ErrTagExists
+	}
+	_, err = u.tagRepo.GetTagByName(ctx, name, nil)
+	switch {
+	case err == nil:
+		return ErrTagExists
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	default:
+		return err
+	}
+}
+
+func (u *tagUsecase) RenameTag(ctx context.Context, name, newName string) (entity.Tag, error) {
+	tag, err := u.getExactTag(ctx, name)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	newName, err = normalizeTag(newName)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	if newName == tag.Name {
+		return tag, nil
+	}
+	if err := u.checkNameFree(ctx, tag, newName); err != nil {
+		return entity.Tag{}, err
+	}
+
+	// Новое имя могло быть синонимом этой же метки: синоним больше не нужен
+	if err := u.tagRepo.DeleteTagAlias(ctx, tag.ID, newName); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return entity.Tag{}, err
+	}
+	if err := u.tagRepo.RenameTag(ctx, tag.ID, newName); err != nil {
+		return entity.Tag{}, err
+	}
+	u.logger.Info("Tag renamed", zap.String("from", tag.Name), zap.String("to", newName))
+	return u.getExactTag(ctx, newName)
+}
+
+func (u *tagUsecase) MergeTags(ctx context.Context, source, target string) (entity.Tag, error) {
+	sourceTag, err := u.getExactTag(ctx, source)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	targetTag, err := u.GetTag(ctx, target, nil)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	if sourceTag.ID == targetTag.ID {
+		return entity.Tag{}, ErrSameTag
+	}
+
+	if err := u.tagRepo.MergeTags(ctx, sourceTag.ID, targetTag.ID); err != nil {
+		return entity.Tag{}, err
+	}
+	u.logger.Info("Tags merged", zap.String("source", sourceTag.Name), zap.String("target", targetTag.Name))
+	return u.getExactTag(ctx, targetTag.Name)
+}
+
+func (u *tagUsecase) DeleteTag(ctx context.Context, name string) error {
+	tag, err := u.getExactTag(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := u.tagRepo.DeleteTag(ctx, tag.ID); err != nil {
+		return err
+	}
+	u.logger.Info("Tag deleted", zap.String("tag", tag.Name), zap.Int("postsCount", tag.PostsCount))
+	return nil
+}
+
+func (u *tagUsecase) AddAlias(ctx context.Context, name, alias string) (entity.Tag, error) {
+	tag, err := u.getExactTag(ctx, name)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	alias, err = normalizeTag(alias)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+	for _, existing := range tag.Aliases {
+		if existing == alias {
+			return tag, nil
+		}
+	}
+	if alias == tag.Name {
+		return entity.Tag{}, ErrTagExists
+	}
+	if err := u.checkNameFree(ctx, tag, alias); err != nil {
+		return entity.Tag{}, err
+	}
+
+	if err := u.tagRepo.AddTagAlias(ctx, tag.ID, alias); err != nil {
+		return entity.Tag{}, err
+	}
+	return u.getExactTag(ctx, tag.Name)
+}
+
+func (u *tagUsecase) DeleteAlias(ctx context.Context, name, alias string) error {
+	tag, err := u.getExactTag(ctx, name)
+	if err != nil {
+		return err
+	}
+	alias, err = normalizeTag(alias)
+	if err != nil {
+		return ErrTagNotFound
+	}
+	err = u.tagRepo.DeleteTagAlias(ctx, tag.ID, alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTagNotFound
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// untaggedRepo — хранилище меток для тестов постов, где метки не важны.
+func untaggedRepo() *mocks.TagRepository {
+	tagRepo := new(mocks.TagRepository)
+	tagRepo.On("ResolveAliases", mock.Anything, mock.Anything).Return(map[string]string{}, nil).Maybe()
+	tagRepo.On("SetPostTags", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	tagRepo.On("GetPostTags", mock.Anything, mock.Anything).Return(map[int][]string{}, nil).Maybe()
+	return tagRepo
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		{"case folding", "GoLang", "golang", nil},
+		{"inner spaces", "  Machine   Learning ", "machine-learning", nil},
+		{"symbols", "C++", "c++", nil},
+		{"cyrillic", "Новости", "новости", nil},
+		{"empty", "  ", "", ErrInvalidTag},
+		{"leading symbol", "#go", "", ErrInvalidTag},
+		{"comma", "go,grpc", "", ErrInvalidTag},
+		{"too long", "abcdefghijklmnopqrstuvwxyz0123456789", "", ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := normalizeTag(tt.input)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, tag)
+		})
+	}
+}
+
+func TestPostUsecase_CreatePost_Tags(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	mockTagRepo := new(mocks.TagRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, mockTagRepo, zap.NewNop())
+
+	post := entity.Post{AuthorId: 1, Title: "Go", Content: "Текст", Tags: []string{"GoLang", "gRPC", "go"}}
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"golang", "grpc", "go"}).Return(map[string]string{"golang": "go"}, nil)
+	mockPostRepo.On("CreatePost", mock.Anything, post).Return(&entity.Post{ID: 7, AuthorId: 1}, nil)
+	mockTagRepo.On("SetPostTags", mock.Anything, 7, []string{"go", "grpc"}).Return(nil)
+
+	created, err := postUsecase.CreatePost(context.Background(), post)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go", "grpc"}, created.Tags)
+	mockPostRepo.AssertExpectations(t)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestPostUsecase_CreatePost_TooManyTags(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, untaggedRepo(), zap.NewNop())
+
+	_, err := postUsecase.CreatePost(context.Background(), entity.Post{Tags: []string{"a1", "a2", "a3", "a4", "a5", "a6"}})
+
+	assert.ErrorIs(t, err, ErrTooManyTags)
+	mockPostRepo.AssertNotCalled(t, "CreatePost", mock.Anything, mock.Anything)
+}
+
+func TestPostUsecase_UpdatePost_KeepsTags(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	mockTagRepo := new(mocks.TagRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, mockTagRepo, zap.NewNop())
+
+	post := entity.Post{ID: 7, Title: "Go", Content: "Новый текст"}
+	mockPostRepo.On("UpdatePost", mock.Anything, post).Return(&entity.Post{ID: 7}, nil)
+	mockTagRepo.On("GetPostTags", mock.Anything, []int{7}).Return(map[int][]string{7: {"go"}}, nil)
+
+	updated, err := postUsecase.UpdatePost(context.Background(), post)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, updated.Tags)
+	mockTagRepo.AssertNotCalled(t, "SetPostTags", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPostUsecase_GetPosts_TagFilter(t *testing.T) {
+	mockPostRepo := new(mocks.PostRepository)
+	mockTagRepo := new(mocks.TagRepository)
+	postUsecase := NewPostUsecase(mockPostRepo, mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"golang", "grpc"}).Return(map[string]string{"golang": "go"}, nil)
+	resolved := entity.PostFilter{Tags: []string{"go", "grpc"}, TagMode: entity.TagModeAny, Limit: 10}
+	mockPostRepo.On("GetPosts", mock.Anything, resolved).Return([]entity.Post{{ID: 1}, {ID: 2}}, nil)
+	mockTagRepo.On("GetPostTags", mock.Anything, []int{1, 2}).Return(map[int][]string{1: {"go"}}, nil)
+
+	posts, err := postUsecase.GetPosts(context.Background(), entity.PostFilter{Tags: []string{"Golang", "grpc"}, TagMode: entity.TagModeAny, Limit: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go"}, posts[0].Tags)
+	assert.Equal(t, []string{}, posts[1].Tags)
+	mockPostRepo.AssertExpectations(t)
+}
+
+func TestPostUsecase_GetPosts_InvalidTagMode(t *testing.T) {
+	postUsecase := NewPostUsecase(new(mocks.PostRepository), untaggedRepo(), zap.NewNop())
+
+	_, err := postUsecase.GetPosts(context.Background(), entity.PostFilter{Tags: []string{"go"}, TagMode: "xor"})
+
+	assert.ErrorIs(t, err, ErrInvalidTagMode)
+}
+
+func TestTagUsecase_GetTag_ByAlias(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"golang"}).Return(map[string]string{"golang": "go"}, nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int{3}).Return(entity.Tag{ID: 1, Name: "go", Aliases: []string{"golang"}}, nil)
+
+	tag, err := tagUsecase.GetTag(context.Background(), "GoLang", []int{3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "go", tag.Name)
+}
+
+func TestTagUsecase_RenameTag_NameTaken(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "golang", []int(nil)).Return(entity.Tag{ID: 2, Name: "golang"}, nil)
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"go"}).Return(map[string]string{}, nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil)
+
+	_, err := tagUsecase.RenameTag(context.Background(), "golang", "Go")
+
+	assert.ErrorIs(t, err, ErrTagExists)
+	mockTagRepo.AssertNotCalled(t, "RenameTag", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagUsecase_RenameTag_ToOwnAlias(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "golang", []int(nil)).Return(entity.Tag{ID: 1, Name: "golang", Aliases: []string{"go"}}, nil).Once()
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"go"}).Return(map[string]string{"go": "golang"}, nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{}, sql.ErrNoRows).Once()
+	mockTagRepo.On("DeleteTagAlias", mock.Anything, 1, "go").Return(nil)
+	mockTagRepo.On("RenameTag", mock.Anything, 1, "go").Return(nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil).Once()
+
+	tag, err := tagUsecase.RenameTag(context.Background(), "golang", "go")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "go", tag.Name)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagUsecase_MergeTags(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "golang", []int(nil)).Return(entity.Tag{ID: 2, Name: "golang"}, nil)
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"go"}).Return(map[string]string{}, nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil)
+	mockTagRepo.On("MergeTags", mock.Anything, 2, 1).Return(nil)
+
+	tag, err := tagUsecase.MergeTags(context.Background(), "golang", "go")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tag.ID)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestTagUsecase_MergeTags_IntoItsAlias(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil)
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"golang"}).Return(map[string]string{"golang": "go"}, nil)
+
+	_, err := tagUsecase.MergeTags(context.Background(), "go", "golang")
+
+	assert.ErrorIs(t, err, ErrSameTag)
+}
+
+func TestTagUsecase_AddAlias_TagExists(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil)
+	mockTagRepo.On("ResolveAliases", mock.Anything, []string{"grpc"}).Return(map[string]string{}, nil)
+	mockTagRepo.On("GetTagByName", mock.Anything, "grpc", []int(nil)).Return(entity.Tag{ID: 2, Name: "grpc"}, nil)
+
+	_, err := tagUsecase.AddAlias(context.Background(), "go", "grpc")
+
+	assert.ErrorIs(t, err, ErrTagExists)
+	mockTagRepo.AssertNotCalled(t, "AddTagAlias", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagUsecase_DeleteAlias_NotFound(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+	tagUsecase := NewTagUsecase(mockTagRepo, zap.NewNop())
+
+	mockTagRepo.On("GetTagByName", mock.Anything, "go", []int(nil)).Return(entity.Tag{ID: 1, Name: "go"}, nil)
+	mockTagRepo.On("DeleteTagAlias", mock.Anything, 1, "golang").Return(sql.ErrNoRows)
+
+	err := tagUsecase.DeleteAlias(context.Background(), "go", "golang")
+
+	assert.ErrorIs(t, err, ErrTagNotFound)
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+type TrashUsecase interface {
+	GetDeletedPosts(ctx context.Context, limit, offset int) ([]entity.TrashedPost, int, error)
+	GetDeletedComments(ctx context.Context, limit, offset int) ([]entity.TrashedComment, int, error)
+	// RestorePost возвращает пост из корзины вместе с его комментариями и
+	// возвращает пост в том виде, в котором он лежал в корзине.
+	RestorePost(ctx context.Context, id int) (entity.TrashedPost, error)
+	// RestoreComment возвращает комментарий из корзины. Комментарий
+	// удаленного поста отдельно не восстанавливается.
+	RestoreComment(ctx context.Context, id int) (entity.TrashedComment, error)
+	// Purge удаляет насовсем то, что пролежало в корзине дольше срока
+	// хранения.
+	Purge(ctx context.Context) (entity.PurgeResult, error)
+	// RunPurger очищает корзину каждые interval, пока не отменен ctx.
+	RunPurger(ctx context.Context, interval time.Duration)
+}
+
+type trashUsecase struct {
+	trashRepo repository.TrashRepository
+	retention time.Duration
+	logger    *zap.Logger
+}
+
+// NewTrashUsecase создает usecase корзины. Удаленное хранится retention,
+// затем удаляется насовсем; 0 отключает очистку.
+func NewTrashUsecase(trashRepo repository.TrashRepository, retention time.Duration, logger *zap.Logger) TrashUsecase {
+	return &trashUsecase{trashRepo: trashRepo, retention: retention, logger: logger}
+}
+
+func (u *trashUsecase) GetDeletedPosts(ctx context.Context, limit, offset int) ([]entity.TrashedPost, int, error) {
+	posts, err := u.trashRepo.GetDeletedPosts(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.trashRepo.GetTotalDeletedPosts(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+func (u *trashUsecase) GetDeletedComments(ctx context.Context, limit, offset int) ([]entity.TrashedComment, int, error) {
+	comments, err := u.trashRepo.GetDeletedComments(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := u.trashRepo.GetTotalDeletedComments(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+func (u *trashUsecase) RestorePost(ctx context.Context, id int) (entity.TrashedPost, error) {
+	post, err := u.trashRepo.GetDeletedPost(ctx, id)
+	if err == nil {
+		err = u.trashRepo.RestorePost(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.TrashedPost{}, ErrPostNotFound
+		}
+		return entity.TrashedPost{}, err
+	}
+	u.logger.Info("Post restored", zap.Int("postID", id), zap.Int("comments", post.CommentsCount))
+	return post, nil
+}
+
+func (u *trashUsecase) RestoreComment(ctx context.Context, id int) (entity.TrashedComment, error) {
+	comment, err := u.trashRepo.GetDeletedComment(ctx, id)
+	if err == nil {
+		err = u.trashRepo.RestoreComment(ctx, id)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.TrashedComment{}, ErrCommentNotFound
+		}
+		return entity.TrashedComment{}, err
+	}
+	u.logger.Info("Comment restored", zap.Int("commentID", id))
+	return comment, nil
+}
+
+func (u *trashUsecase) Purge(ctx context.Context) (entity.PurgeResult, error) {
+	if u.retention <= 0 {
+		return entity.PurgeResult{}, nil
+	}
+	before := time.Now().Add(-u.retention)
+
+	var result entity.PurgeResult
+	var err error
+	if result.Posts, err = u.trashRepo.PurgePosts(ctx, before); err != nil {
+		return result, err
+	}
+	if result.Comments, err = u.trashRepo.PurgeComments(ctx, before); err != nil {
+		return result, err
+	}
+	if result.Posts > 0 || result.Comments > 0 {
+		u.logger.Info("Trash purged", zap.Int("posts", result.Posts), zap.Int("comments", result.Comments))
+	}
+	return result, nil
+}
+
+func (u *trashUsecase) RunPurger(ctx context.Context, interval time.Duration) {
+	if u.retention <= 0 || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := u.Purge(ctx); err != nil {
+			u.logger.Error("Failed to purge trash", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func newTestTrashUsecase(retention time.Duration) (TrashUsecase, *mocks.TrashRepository) {
+	trashRepo := new(mocks.TrashRepository)
+	return NewTrashUsecase(trashRepo, retention, zap.NewNop()), trashRepo
+}
+
+func TestTrashUsecase_RestorePost(t *testing.T) {
+	u, trashRepo := newTestTrashUsecase(time.Hour)
+	trashed := entity.TrashedPost{ID: 1, CommentsCount: 3}
+
+	trashRepo.On("GetDeletedPost", mock.Anything, 1).Return(trashed, nil)
+	trashRepo.On("RestorePost", mock.Anything, 1).Return(nil)
+
+	post, err := u.RestorePost(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, trashed, post)
+	trashRepo.AssertExpectations(t)
+}
+
+func TestTrashUsecase_Restore_NotInTrash(t *testing.T) {
+	u, trashRepo := newTestTrashUsecase(time.Hour)
+
+	trashRepo.On("GetDeletedPost", mock.Anything, 1).Return(entity.TrashedPost{}, sql.ErrNoRows)
+	trashRepo.On("GetDeletedComment", mock.Anything, 2).Return(entity.TrashedComment{ID: 2}, nil)
+	// Комментарий восстановили между чтением и восстановлением
+	trashRepo.On("RestoreComment", mock.Anything, 2).Return(sql.ErrNoRows)
+
+	_, err := u.RestorePost(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	_, err = u.RestoreComment(context.Background(), 2)
+	assert.ErrorIs(t, err, ErrCommentNotFound)
+	trashRepo.AssertNotCalled(t, "RestorePost", mock.Anything, mock.Anything)
+}
+
+func TestTrashUsecase_Purge(t *testing.T) {
+	u, trashRepo := newTestTrashUsecase(30 * 24 * time.Hour)
+	beforeRetention := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 30*24*time.Hour && time.Since(before) < 30*24*time.Hour+time.Minute
+	})
+
+	trashRepo.On("PurgePosts", mock.Anything, beforeRetention).Return(2, nil)
+	trashRepo.On("PurgeComments", mock.Anything, beforeRetention).Return(5, nil)
+
+	result, err := u.Purge(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PurgeResult{Posts: 2, Comments: 5}, result)
+	trashRepo.AssertExpectations(t)
+}
+
+func TestTrashUsecase_Purge_Disabled(t *testing.T) {
+	u, trashRepo := newTestTrashUsecase(0)
+
+	result, err := u.Purge(context.Background())
+
+	assert.NoError(t, err)
+	assert.Zero(t, result)
+	trashRepo.AssertNotCalled(t, "PurgePosts", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrInvalidTrustLevel = errors.New("unknown trust level")
+	ErrTrustLevelTooLow  = errors.New("trust level too low for this action")
+	ErrPostLimitReached  = errors.New("daily post limit reached")
+)
+
+// Вклад в репутацию: голоса за посты весят больше голосов за комментарии,
+// каждый минус стоит одинаково.
+const (
+	reputationPost            = 2
+	reputationComment         = 1
+	reputationPostUpvote      = 10
+	reputationCommentUpvote   = 5
+	reputationDownvotePenalty = 2
+)
+
+// linkPattern находит в тексте ссылки и изображения: адреса http(s) и www,
+// картинки markdown и теги <img>.
+var linkPattern = regexp.MustCompile(`(?i)https?://|\bwww\.|!\[[^\]]*\]\(|<img\b`)
+
+// TrustPolicy — пороги уровней доверия и ограничения для них.
+type TrustPolicy struct {
+	// Thresholds — репутация, с которой начинается уровень; уровень new
+	// есть у всех.
+	Thresholds map[string]int
+	// LinksLevel — уровень, с которого можно публиковать ссылки и изображения.
+	LinksLevel string
+	// ChatLevel — уровень, с которого можно писать в чат.
+	ChatLevel string
+	// PostsPerDay — сколько постов уровень может создать за сутки; 0 или
+	// отсутствие уровня — без ограничений.
+	PostsPerDay map[string]int
+}
+
+type TrustUsecase interface {
+	// GetTrust пересчитывает репутацию пользователя и возвращает его уровень
+	// доверия.
+	GetTrust(ctx context.Context, userID int) (entity.UserTrust, error)
+	// SetOverride назначает пользователю уровень вместо вычисленного.
+	SetOverride(ctx context.Context, userID int, level string, adminID int) (entity.UserTrust, error)
+	// ClearOverride возвращает пользователю вычисленный уровень.
+	ClearOverride(ctx context.Context, userID int) (entity.UserTrust, error)
+	// CheckPost проверяет, может ли пользователь создать пост с текстом
+	// content: лимит постов в сутки и ссылки в тексте.
+	CheckPost(ctx context.Context, principal entity.Principal, content string) error
+	// CheckContent проверяет, может ли пользователь опубликовать текст
+	// content — комментарий или правку.
+	CheckContent(ctx context.Context, principal entity.Principal, content string) error
+	CheckChat(ctx context.Context, principal entity.Principal) error
+	// Recompute пересчитывает репутацию и уровни всех пользователей и
+	// возвращает их количество.
+	Recompute(ctx context.Context) (int, error)
+}
+
+type trustUsecase struct {
+	trustRepo repository.TrustRepository
+	policy    TrustPolicy
+	logger    *zap.Logger
+}
+
+func NewTrustUsecase(trustRepo repository.TrustRepository, policy TrustPolicy, logger *zap.Logger) TrustUsecase {
+	return &trustUsecase{trustRepo: trustRepo, policy: policy, logger: logger}
+}
+
+// reputation считает репутацию по активности пользователя.
+func reputation(stats entity.ReputationStats) int {
+	return stats.Posts*reputationPost +
+		stats.Comments*reputationComment +
+		stats.PostUpvotes*reputationPostUpvote +
+		stats.CommentUpvotes*reputationCommentUpvote -
+		(stats.PostDownvotes+stats.CommentDownvotes)*reputationDownvotePenalty
+}
+
+// levelFor возвращает высший уровень, порог которого достигает репутация.
+func (u *trustUsecase) levelFor(rep int) string {
+	level := entity.TrustNew
+	for _, l := range entity.TrustLevels[1:] {
+		if threshold, ok := u.policy.Thresholds[l]; ok && rep >= threshold {
+			level = l
+		}
+	}
+	return level
+}
+
+// evaluate пересчитывает репутацию и уровень по stats и сохраняет их, если
+// они изменились или force. stored — сохраненные значения, пустые, если
+// пользователя еще не оценивали.
+func (u *trustUsecase) evaluate(ctx context.Context, stats entity.ReputationStats, stored entity.UserTrust, force bool) (entity.UserTrust, error) {
+	found := stored.UserID != 0
+	trust := stored
+	trust.UserID = stats.UserID
+	trust.Stats = stats
+	trust.Reputation = reputation(stats)
+	trust.Level = u.levelFor(trust.Reputation)
+	if trust.Override != nil {
+		trust.Level = *trust.Override
+	}
+	if !force && found && trust.Reputation == stored.Reputation && trust.Level == stored.Level {
+		return trust, nil
+	}
+
+	if err := u.trustRepo.SaveTrust(ctx, trust); err != nil {
+		return entity.UserTrust{}, err
+	}
+	if found && trust.Level != stored.Level {
+		u.logger.Info("Trust level changed",
+			zap.Int("userID", trust.UserID),
+			zap.String("from", stored.Level),
+			zap.String("to", trust.Level),
+			zap.Int("reputation", trust.Reputation))
+	}
+	trust.UpdatedAt = time.Now()
+	return trust, nil
+}
+
+func (u *trustUsecase) GetTrust(ctx context.Context, userID int) (entity.UserTrust, error) {
+	stats, err := u.trustRepo.GetStats(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.UserTrust{}, ErrUserNotFound
+	}
+	if err != nil {
+		return entity.UserTrust{}, err
+	}
+
+	stored, err := u.trustRepo.GetTrust(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return entity.UserTrust{}, err
+	}
+	return u.evaluate(ctx, stats, stored, false)
+}
+
+func (u *trustUsecase) SetOverride(ctx context.Context, userID int, level string, adminID int) (entity.UserTrust, error) {
+	if entity.TrustRank(level) < 0 {
+		return entity.UserTrust{}, ErrInvalidTrustLevel
+	}
+	if _, err := u.trustRepo.GetStats(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.UserTrust{}, ErrUserNotFound
+		}
+		return entity.UserTrust{}, err
+	}
+	if err := u.trustRepo.SetOverride(ctx, userID, &level, &adminID); err != nil {
+		return entity.UserTrust{}, err
+	}
+	u.logger.Info("Trust level overridden", zap.Int("userID", userID), zap.String("level", level), zap.Int("adminID", adminID))
+	return u.GetTrust(ctx, userID)
+}
+
+func (u *trustUsecase) ClearOverride(ctx context.Context, userID int) (entity.UserTrust, error) {
+	if _, err := u.trustRepo.GetStats(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.UserTrust{}, ErrUserNotFound
+		}
+		return entity.UserTrust{}, err
+	}
+	if err := u.trustRepo.SetOverride(ctx, userID, nil, nil); err != nil {
+		return entity.UserTrust{}, err
+	}
+	u.logger.Info("Trust level override cleared", zap.Int("userID", userID))
+	return u.GetTrust(ctx, userID)
+}
+
+// requireLevel проверяет, что уровень пользователя не ниже level.
+func (u *trustUsecase) requireLevel(ctx context.Context, userID int, level string) error {
+	trust, err := u.GetTrust(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if entity.TrustRank(trust.Level) < entity.TrustRank(level) {
+		return ErrTrustLevelTooLow
+	}
+	return nil
+}
+
+// Права trust.exempt снимают все ограничения уровня доверия.
+
+func (u *trustUsecase) CheckPost(ctx context.Context, principal entity.Principal, content string) error {
+	if principal.Can(entity.PermTrustExempt) {
+		return nil
+	}
+	trust, err := u.GetTrust(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+	if linkPattern.MatchString(content) && entity.TrustRank(trust.Level) < entity.TrustRank(u.policy.LinksLevel) {
+		return ErrTrustLevelTooLow
+	}
+
+	limit := u.policy.PostsPerDay[trust.Level]
+	if limit <= 0 {
+		return nil
+	}
+	count, err := u.trustRepo.CountRecentPosts(ctx, principal.UserID, 24*time.Hour)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return ErrPostLimitReached
+	}
+	return nil
+}
+
+func (u *trustUsecase) CheckContent(ctx context.Context, principal entity.Principal, content string) error {
+	if principal.Can(entity.PermTrustExempt) || !linkPattern.MatchString(content) {
+		return nil
+	}
+	return u.requireLevel(ctx, principal.UserID, u.policy.LinksLevel)
+}
+
+func (u *trustUsecase) CheckChat(ctx context.Context, principal entity.Principal) error {
+	if principal.Can(entity.PermTrustExempt) {
+		return nil
+	}
+	return u.requireLevel(ctx, principal.UserID, u.policy.ChatLevel)
+}
+
+func (u *trustUsecase) Recompute(ctx context.Context) (int, error) {
+	all, err := u.trustRepo.GetAllStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, stats := range all {
+		stored, err := u.trustRepo.GetTrust(ctx, stats.UserID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		// Пересчет с нуля: сохраняем даже неизменившиеся значения.
+		if _, err := u.evaluate(ctx, stats, stored, true); err != nil {
+			return 0, err
+		}
+	}
+	u.logger.Info("Trust levels recomputed", zap.Int("users", len(all)))
+	return len(all), nil
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+	"github.com/miqxzz/miqxzzforum/forum_service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var testTrustPolicy = TrustPolicy{
+	Thresholds: map[string]int{
+		entity.TrustBasic:   20,
+		entity.TrustMember:  100,
+		entity.TrustRegular: 500,
+	},
+	LinksLevel:  entity.TrustBasic,
+	ChatLevel:   entity.TrustBasic,
+	PostsPerDay: map[string]int{entity.TrustNew: 3, entity.TrustBasic: 10},
+}
+
+func newTestTrustUsecase() (TrustUsecase, *mocks.TrustRepository) {
+	trustRepo := new(mocks.TrustRepository)
+	return NewTrustUsecase(trustRepo, testTrustPolicy, zap.NewNop()), trustRepo
+}
+
+func TestReputation(t *testing.T) {
+	stats := entity.ReputationStats{
+		Posts:            2,
+		Comments:         3,
+		PostUpvotes:      4,
+		PostDownvotes:    1,
+		CommentUpvotes:   2,
+		CommentDownvotes: 3,
+	}
+
+	// 2*2 + 3*1 + 4*10 + 2*5 - (1+3)*2
+	assert.Equal(t, 49, reputation(stats))
+}
+
+func TestTrustUsecase_GetTrust_Promotes(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+	stats := entity.ReputationStats{UserID: 3, Posts: 1, PostUpvotes: 10}
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(stats, nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Reputation: 30, Level: entity.TrustBasic}, nil)
+	trustRepo.On("SaveTrust", mock.Anything, mock.MatchedBy(func(trust entity.UserTrust) bool {
+		return trust.UserID == 3 && trust.Reputation == 102 && trust.Level == entity.TrustMember
+	})).Return(nil)
+
+	trust, err := u.GetTrust(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrustMember, trust.Level)
+	assert.Equal(t, stats, trust.Stats)
+	trustRepo.AssertExpectations(t)
+}
+
+func TestTrustUsecase_GetTrust_Unchanged(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3, Comments: 5}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Reputation: 5, Level: entity.TrustNew}, nil)
+
+	trust, err := u.GetTrust(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrustNew, trust.Level)
+	trustRepo.AssertNotCalled(t, "SaveTrust", mock.Anything, mock.Anything)
+}
+
+func TestTrustUsecase_GetTrust_Override(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+	override := entity.TrustNew
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3, PostUpvotes: 60}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Level: entity.TrustNew, Override: &override}, nil)
+	trustRepo.On("SaveTrust", mock.Anything, mock.MatchedBy(func(trust entity.UserTrust) bool {
+		return trust.Reputation == 600 && trust.Level == entity.TrustNew
+	})).Return(nil)
+
+	trust, err := u.GetTrust(context.Background(), 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrustNew, trust.Level)
+}
+
+func TestTrustUsecase_GetTrust_UserNotFound(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+
+	trustRepo.On("GetStats", mock.Anything, 9).Return(entity.ReputationStats{}, sql.ErrNoRows)
+
+	_, err := u.GetTrust(context.Background(), 9)
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestTrustUsecase_SetOverride(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+	level, adminID := entity.TrustRegular, 1
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3}, nil)
+	trustRepo.On("SetOverride", mock.Anything, 3, &level, &adminID).Return(nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Level: entity.TrustNew, Override: &level, OverrideBy: &adminID}, nil)
+	trustRepo.On("SaveTrust", mock.Anything, mock.MatchedBy(func(trust entity.UserTrust) bool {
+		return trust.Level == entity.TrustRegular
+	})).Return(nil)
+
+	trust, err := u.SetOverride(context.Background(), 3, entity.TrustRegular, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.TrustRegular, trust.Level)
+	assert.Equal(t, &adminID, trust.OverrideBy)
+
+	_, err = u.SetOverride(context.Background(), 3, "elder", 1)
+	assert.ErrorIs(t, err, ErrInvalidTrustLevel)
+}
+
+func TestTrustUsecase_CheckPost(t *testing.T) {
+	newUser := entity.Principal{UserID: 3, Role: "user"}
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		recent  int
+		want    error
+	}{
+		{"plain text", "hello", 2, nil},
+		{"link", "see https://example.com", 0, ErrTrustLevelTooLow},
+		{"www link", "see www.example.com", 0, ErrTrustLevelTooLow},
+		{"markdown image", "![cat](cat.png)", 0, ErrTrustLevelTooLow},
+		{"html image", `<IMG src="cat.png">`, 0, ErrTrustLevelTooLow},
+		{"daily limit", "hello", 3, ErrPostLimitReached},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			u, trustRepo := newTestTrustUsecase()
+			trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3}, nil)
+			trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Level: entity.TrustNew}, nil)
+			trustRepo.On("CountRecentPosts", mock.Anything, 3, 24*time.Hour).Return(tc.recent, nil).Maybe()
+
+			assert.Equal(t, tc.want, u.CheckPost(context.Background(), newUser, tc.content))
+		})
+	}
+}
+
+func TestTrustUsecase_CheckPost_RegularUnlimited(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3, PostUpvotes: 50}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Reputation: 500, Level: entity.TrustRegular}, nil)
+
+	err := u.CheckPost(context.Background(), entity.Principal{UserID: 3, Role: "user"}, "https://example.com")
+
+	assert.NoError(t, err)
+	trustRepo.AssertNotCalled(t, "CountRecentPosts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrustUsecase_Exempt(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+	moderator := entity.Principal{UserID: 2, Role: "moderator", Permissions: []string{entity.PermTrustExempt}}
+
+	assert.NoError(t, u.CheckPost(context.Background(), moderator, "https://example.com"))
+	assert.NoError(t, u.CheckContent(context.Background(), moderator, "<img src=x>"))
+	assert.NoError(t, u.CheckChat(context.Background(), moderator))
+	trustRepo.AssertNotCalled(t, "GetStats", mock.Anything, mock.Anything)
+}
+
+func TestTrustUsecase_CheckChat(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+
+	trustRepo.On("GetStats", mock.Anything, 3).Return(entity.ReputationStats{UserID: 3, Comments: 1}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 3).Return(entity.UserTrust{UserID: 3, Reputation: 1, Level: entity.TrustNew}, nil)
+	trustRepo.On("GetStats", mock.Anything, 4).Return(entity.ReputationStats{UserID: 4, CommentUpvotes: 4}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 4).Return(entity.UserTrust{UserID: 4, Reputation: 20, Level: entity.TrustBasic}, nil)
+
+	assert.ErrorIs(t, u.CheckChat(context.Background(), entity.Principal{UserID: 3, Role: "user"}), ErrTrustLevelTooLow)
+	assert.NoError(t, u.CheckChat(context.Background(), entity.Principal{UserID: 4, Role: "user"}))
+}
+
+func TestTrustUsecase_Recompute(t *testing.T) {
+	u, trustRepo := newTestTrustUsecase()
+	override := entity.TrustMember
+
+	trustRepo.On("GetAllStats", mock.Anything).Return([]entity.ReputationStats{
+		{UserID: 1, Posts: 10},
+		{UserID: 2, PostUpvotes: 3},
+	}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 1).Return(entity.UserTrust{UserID: 1, Reputation: 20, Level: entity.TrustBasic}, nil)
+	trustRepo.On("GetTrust", mock.Anything, 2).Return(entity.UserTrust{UserID: 2, Override: &override, Level: entity.TrustMember}, nil)
+	trustRepo.On("SaveTrust", mock.Anything, mock.MatchedBy(func(trust entity.UserTrust) bool {
+		return trust.UserID == 1 && trust.Reputation == 20 && trust.Level == entity.TrustBasic
+	})).Return(nil).Once()
+	trustRepo.On("SaveTrust", mock.Anything, mock.MatchedBy(func(trust entity.UserTrust) bool {
+		return trust.UserID == 2 && trust.Reputation == 30 && trust.Level == entity.TrustMember
+	})).Return(nil).Once()
+
+	count, err := u.Recompute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	trustRepo.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	entity "github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -14,29 +15,163 @@ type ChatRepository struct {
 	mock.Mock
 }
 
-// GetRecentMessages provides a mock function with given fields: ctx, limit
-func (_m *ChatRepository) GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error) {
-	ret := _m.Called(ctx, limit)
+// DeleteMessage provides a mock function with given fields: ctx, id
+func (_m *ChatRepository) DeleteMessage(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRecentMessages")
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMessages provides a mock function with given fields: ctx, ids
+func (_m *ChatRepository) DeleteMessages(ctx context.Context, ids []int) (int, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessages")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (int, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) int); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredMessages provides a mock function with given fields: ctx, now, defaultRetention, limit
+func (_m *ChatRepository) GetExpiredMessages(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]entity.ChatMessage, error) {
+	ret := _m.Called(ctx, now, defaultRetention, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredMessages")
 	}
 
 	var r0 []entity.ChatMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.ChatMessage, error)); ok {
-		return rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]entity.ChatMessage, error)); ok {
+		return rf(ctx, now, defaultRetention, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.ChatMessage); ok {
-		r0 = rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, now, defaultRetention, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ChatMessage)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, defaultRetention, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageByID provides a mock function with given fields: ctx, id
+func (_m *ChatRepository) GetMessageByID(ctx context.Context, id int) (entity.ChatMessage, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageByID")
+	}
+
+	var r0 entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entity.ChatMessage, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entity.ChatMessage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.ChatMessage)
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, limit)
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessages provides a mock function with given fields: ctx, roomID, before, after, limit
+func (_m *ChatRepository) GetMessages(ctx context.Context, roomID int, before int, after int, limit int) ([]entity.ChatMessage, error) {
+	ret := _m.Called(ctx, roomID, before, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessages")
+	}
+
+	var r0 []entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) ([]entity.ChatMessage, error)); ok {
+		return rf(ctx, roomID, before, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, before, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int) error); ok {
+		r1 = rf(ctx, roomID, before, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecentMessages provides a mock function with given fields: ctx, roomID, limit
+func (_m *ChatRepository) GetRecentMessages(ctx context.Context, roomID int, limit int) ([]entity.ChatMessage, error) {
+	ret := _m.Called(ctx, roomID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentMessages")
+	}
+
+	var r0 []entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.ChatMessage, error)); ok {
+		return rf(ctx, roomID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, roomID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -45,16 +180,44 @@ func (_m *ChatRepository) GetRecentMessages(ctx context.Context, limit int) ([]e
 }
 
 // StoreMessage provides a mock function with given fields: ctx, msg
-func (_m *ChatRepository) StoreMessage(ctx context.Context, msg entity.ChatMessage) error {
+func (_m *ChatRepository) StoreMessage(ctx context.Context, msg entity.ChatMessage) (entity.ChatMessage, error) {
 	ret := _m.Called(ctx, msg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for StoreMessage")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.ChatMessage) error); ok {
+	var r0 entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ChatMessage) (entity.ChatMessage, error)); ok {
+		return rf(ctx, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ChatMessage) entity.ChatMessage); ok {
 		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(entity.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ChatMessage) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMessage provides a mock function with given fields: ctx, id, content, editedAt
+func (_m *ChatRepository) UpdateMessage(ctx context.Context, id int, content string, editedAt time.Time) error {
+	ret := _m.Called(ctx, id, content, editedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMessage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, time.Time) error); ok {
+		r0 = rf(ctx, id, content, editedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -14,9 +14,139 @@ type ChatUsecase struct {
 	mock.Mock
 }
 
-// GetRecentMessages provides a mock function with given fields: ctx, limit
-func (_m *ChatUsecase) GetRecentMessages(ctx context.Context, limit int) ([]entity.ChatMessage, error) {
-	ret := _m.Called(ctx, limit)
+// DeleteMessage provides a mock function with given fields: ctx, id, principal
+func (_m *ChatUsecase) DeleteMessage(ctx context.Context, id int, principal entity.Principal) (entity.ChatMessage, error) {
+	ret := _m.Called(ctx, id, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, entity.Principal) (entity.ChatMessage, error)); ok {
+		return rf(ctx, id, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, entity.Principal) entity.ChatMessage); ok {
+		r0 = rf(ctx, id, principal)
+	} else {
+		r0 = ret.Get(0).(entity.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, entity.Principal) error); ok {
+		r1 = rf(ctx, id, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditMessage provides a mock function with given fields: ctx, id, content, principal
+func (_m *ChatUsecase) EditMessage(ctx context.Context, id int, content string, principal entity.Principal) (entity.ChatMessage, error) {
+	ret := _m.Called(ctx, id, content, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessage")
+	}
+
+	var r0 entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, entity.Principal) (entity.ChatMessage, error)); ok {
+		return rf(ctx, id, content, principal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, entity.Principal) entity.ChatMessage); ok {
+		r0 = rf(ctx, id, content, principal)
+	} else {
+		r0 = ret.Get(0).(entity.ChatMessage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, entity.Principal) error); ok {
+		r1 = rf(ctx, id, content, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessages provides a mock function with given fields: ctx, roomID, before, limit
+func (_m *ChatUsecase) GetMessages(ctx context.Context, roomID int, before int, limit int) ([]entity.ChatMessage, int, error) {
+	ret := _m.Called(ctx, roomID, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessages")
+	}
+
+	var r0 []entity.ChatMessage
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]entity.ChatMessage, int, error)); ok {
+		return rf(ctx, roomID, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) int); ok {
+		r1 = rf(ctx, roomID, before, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
+		r2 = rf(ctx, roomID, before, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMissedMessages provides a mock function with given fields: ctx, roomID, since, limit
+func (_m *ChatUsecase) GetMissedMessages(ctx context.Context, roomID int, since int, limit int) ([]entity.ChatMessage, bool, error) {
+	ret := _m.Called(ctx, roomID, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMissedMessages")
+	}
+
+	var r0 []entity.ChatMessage
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]entity.ChatMessage, bool, error)); ok {
+		return rf(ctx, roomID, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ChatMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) bool); ok {
+		r1 = rf(ctx, roomID, since, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, int, int) error); ok {
+		r2 = rf(ctx, roomID, since, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRecentMessages provides a mock function with given fields: ctx, roomID, limit
+func (_m *ChatUsecase) GetRecentMessages(ctx context.Context, roomID int, limit int) ([]entity.ChatMessage, error) {
+	ret := _m.Called(ctx, roomID, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecentMessages")
@@ -24,19 +154,19 @@ func (_m *ChatUsecase) GetRecentMessages(ctx context.Context, limit int) ([]enti
 
 	var r0 []entity.ChatMessage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.ChatMessage, error)); ok {
-		return rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.ChatMessage, error)); ok {
+		return rf(ctx, roomID, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.ChatMessage); ok {
-		r0 = rf(ctx, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.ChatMessage)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, roomID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,22 +174,32 @@ func (_m *ChatUsecase) GetRecentMessages(ctx context.Context, limit int) ([]enti
 	return r0, r1
 }
 
-// HandleMessage provides a mock function with given fields: ctx, userID, username, content
-func (_m *ChatUsecase) HandleMessage(ctx context.Context, userID int, username string, content string) error {
-	ret := _m.Called(ctx, userID, username, content)
+// HandleMessage provides a mock function with given fields: ctx, roomID, userID, username, content
+func (_m *ChatUsecase) HandleMessage(ctx context.Context, roomID int, userID int, username string, content string) (entity.ChatMessage, error) {
+	ret := _m.Called(ctx, roomID, userID, username, content)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HandleMessage")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) error); ok {
-		r0 = rf(ctx, userID, username, content)
+	var r0 entity.ChatMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) (entity.ChatMessage, error)); ok {
+		return rf(ctx, roomID, userID, username, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) entity.ChatMessage); ok {
+		r0 = rf(ctx, roomID, userID, username, content)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(entity.ChatMessage)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string) error); ok {
+		r1 = rf(ctx, roomID, userID, username, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // NewChatUsecase creates a new instance of ChatUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -42,17 +42,17 @@ func (_m *CommentsRepository) CreateComment(ctx context.Context, comment entity.
 	return r0, r1
 }
 
-// DeleteComment provides a mock function with given fields: ctx, id
-func (_m *CommentsRepository) DeleteComment(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteComment provides a mock function with given fields: ctx, id, deletedBy
+func (_m *CommentsRepository) DeleteComment(ctx context.Context, id int, deletedBy int) error {
+	ret := _m.Called(ctx, id, deletedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteComment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, deletedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -88,6 +88,66 @@ func (_m *CommentsRepository) GetCommentByID(ctx context.Context, id int) (entit
 	return r0, r1
 }
 
+// GetCommentRevisions provides a mock function with given fields: ctx, id
+func (_m *CommentsRepository) GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentRevisions")
+	}
+
+	var r0 []entity.CommentRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.CommentRevision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.CommentRevision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CommentRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentThreads provides a mock function with given fields: ctx, postID, limit, offset, maxDepth
+func (_m *CommentsRepository) GetCommentThreads(ctx context.Context, postID int, limit int, offset int, maxDepth int) ([]entity.CommentNode, error) {
+	ret := _m.Called(ctx, postID, limit, offset, maxDepth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentThreads")
+	}
+
+	var r0 []entity.CommentNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) ([]entity.CommentNode, error)); ok {
+		return rf(ctx, postID, limit, offset, maxDepth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) []entity.CommentNode); ok {
+		r0 = rf(ctx, postID, limit, offset, maxDepth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CommentNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int) error); ok {
+		r1 = rf(ctx, postID, limit, offset, maxDepth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetComments provides a mock function with given fields: ctx, postID, limit, offset
 func (_m *CommentsRepository) GetComments(ctx context.Context, postID int, limit int, offset int) ([]entity.Comment, error) {
 	ret := _m.Called(ctx, postID, limit, offset)
@@ -176,6 +236,62 @@ func (_m *CommentsRepository) GetTotalCommentsCount(ctx context.Context, postID
 	return r0, r1
 }
 
+// GetTotalThreadsCount provides a mock function with given fields: ctx, postID
+func (_m *CommentsRepository) GetTotalThreadsCount(ctx context.Context, postID int) (int, error) {
+	ret := _m.Called(ctx, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalThreadsCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateComment provides a mock function with given fields: ctx, id, content, editorID
+func (_m *CommentsRepository) UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error) {
+	ret := _m.Called(ctx, id, content, editorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 entity.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) (entity.Comment, error)); ok {
+		return rf(ctx, id, content, editorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) entity.Comment); ok {
+		r0 = rf(ctx, id, content, editorID)
+	} else {
+		r0 = ret.Get(0).(entity.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, id, content, editorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCommentsRepository creates a new instance of CommentsRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCommentsRepository(t interface {
@@ -42,17 +42,17 @@ func (_m *CommentsUsecases) CreateComment(ctx context.Context, comment entity.Co
 	return r0, r1
 }
 
-// DeleteComment provides a mock function with given fields: ctx, id
-func (_m *CommentsUsecases) DeleteComment(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeleteComment provides a mock function with given fields: ctx, id, deletedBy
+func (_m *CommentsUsecases) DeleteComment(ctx context.Context, id int, deletedBy int) error {
+	ret := _m.Called(ctx, id, deletedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteComment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, deletedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,6 +60,34 @@ func (_m *CommentsUsecases) DeleteComment(ctx context.Context, id int) error {
 	return r0
 }
 
+// DiffCommentRevisions provides a mock function with given fields: ctx, id, from, to
+func (_m *CommentsUsecases) DiffCommentRevisions(ctx context.Context, id int, from int, to int) (entity.RevisionDiff, error) {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffCommentRevisions")
+	}
+
+	var r0 entity.RevisionDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (entity.RevisionDiff, error)); ok {
+		return rf(ctx, id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) entity.RevisionDiff); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(entity.RevisionDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCommentByID provides a mock function with given fields: ctx, id
 func (_m *CommentsUsecases) GetCommentByID(ctx context.Context, id int) (entity.Comment, error) {
 	ret := _m.Called(ctx, id)
@@ -118,6 +146,66 @@ func (_m *CommentsUsecases) GetCommentByPostID(ctx context.Context, postId int)
 	return r0, r1
 }
 
+// GetCommentRevisions provides a mock function with given fields: ctx, id
+func (_m *CommentsUsecases) GetCommentRevisions(ctx context.Context, id int) ([]entity.CommentRevision, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentRevisions")
+	}
+
+	var r0 []entity.CommentRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.CommentRevision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.CommentRevision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.CommentRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCommentThreads provides a mock function with given fields: ctx, postID, limit, offset, maxDepth
+func (_m *CommentsUsecases) GetCommentThreads(ctx context.Context, postID int, limit int, offset int, maxDepth int) ([]*entity.CommentNode, error) {
+	ret := _m.Called(ctx, postID, limit, offset, maxDepth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentThreads")
+	}
+
+	var r0 []*entity.CommentNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) ([]*entity.CommentNode, error)); ok {
+		return rf(ctx, postID, limit, offset, maxDepth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, int) []*entity.CommentNode); ok {
+		r0 = rf(ctx, postID, limit, offset, maxDepth)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.CommentNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, int) error); ok {
+		r1 = rf(ctx, postID, limit, offset, maxDepth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetComments provides a mock function with given fields: ctx, postID, limit, offset
 func (_m *CommentsUsecases) GetComments(ctx context.Context, postID int, limit int, offset int) ([]entity.Comment, error) {
 	ret := _m.Called(ctx, postID, limit, offset)
@@ -176,6 +264,62 @@ func (_m *CommentsUsecases) GetTotalCommentsCount(ctx context.Context, postID in
 	return r0, r1
 }
 
+// GetTotalThreadsCount provides a mock function with given fields: ctx, postID
+func (_m *CommentsUsecases) GetTotalThreadsCount(ctx context.Context, postID int) (int, error) {
+	ret := _m.Called(ctx, postID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalThreadsCount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, postID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, postID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateComment provides a mock function with given fields: ctx, id, content, editorID
+func (_m *CommentsUsecases) UpdateComment(ctx context.Context, id int, content string, editorID int) (entity.Comment, error) {
+	ret := _m.Called(ctx, id, content, editorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 entity.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) (entity.Comment, error)); ok {
+		return rf(ctx, id, content, editorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) entity.Comment); ok {
+		r0 = rf(ctx, id, content, editorID)
+	} else {
+		r0 = ret.Get(0).(entity.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, id, content, editorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCommentsUsecases creates a new instance of CommentsUsecases. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCommentsUsecases(t interface {
@@ -44,17 +44,17 @@ func (_m *PostRepository) CreatePost(ctx context.Context, post entity.Post) (*en
 	return r0, r1
 }
 
-// DeletePost provides a mock function with given fields: ctx, id
-func (_m *PostRepository) DeletePost(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeletePost provides a mock function with given fields: ctx, id, deletedBy
+func (_m *PostRepository) DeletePost(ctx context.Context, id int, deletedBy int) error {
+	ret := _m.Called(ctx, id, deletedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeletePost")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, deletedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,29 +92,29 @@ func (_m *PostRepository) GetPostByID(ctx context.Context, id int) (*entity.Post
 	return r0, r1
 }
 
-// GetPosts provides a mock function with given fields: ctx, limit, offset
-func (_m *PostRepository) GetPosts(ctx context.Context, limit int, offset int) ([]entity.Post, error) {
-	ret := _m.Called(ctx, limit, offset)
+// GetPostDetails provides a mock function with given fields: ctx, id
+func (_m *PostRepository) GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPosts")
+		panic("no return value specified for GetPostDetails")
 	}
 
-	var r0 []entity.Post
+	var r0 *entity.PostDetails
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Post, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entity.PostDetails, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Post); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.PostDetails); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Post)
+			r0 = ret.Get(0).(*entity.PostDetails)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,27 +122,59 @@ func (_m *PostRepository) GetPosts(ctx context.Context, limit int, offset int) (
 	return r0, r1
 }
 
-// GetTotalPostsCount provides a mock function with given fields: ctx
-func (_m *PostRepository) GetTotalPostsCount(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetPostRevisions provides a mock function with given fields: ctx, id
+func (_m *PostRepository) GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTotalPostsCount")
+		panic("no return value specified for GetPostRevisions")
 	}
 
-	var r0 int
+	var r0 []entity.PostRevision
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.PostRevision, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.PostRevision); ok {
+		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPosts provides a mock function with given fields: ctx, filter
+func (_m *PostRepository) GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPosts")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 []entity.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) ([]entity.Post, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) []entity.Post); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PostFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,27 +182,27 @@ func (_m *PostRepository) GetTotalPostsCount(ctx context.Context) (int, error) {
 	return r0, r1
 }
 
-// GetUserIDByToken provides a mock function with given fields: ctx, token
-func (_m *PostRepository) GetUserIDByToken(ctx context.Context, token string) (int, error) {
-	ret := _m.Called(ctx, token)
+// GetTotalPostsCount provides a mock function with given fields: ctx, filter
+func (_m *PostRepository) GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserIDByToken")
+		panic("no return value specified for GetTotalPostsCount")
 	}
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
-		return rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) (int, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
-		r0 = rf(ctx, token)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) int); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, token)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PostFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,17 +44,17 @@ func (_m *PostUsecase) CreatePost(ctx context.Context, post entity.Post) (*entit
 	return r0, r1
 }
 
-// DeletePost provides a mock function with given fields: ctx, id
-func (_m *PostUsecase) DeletePost(ctx context.Context, id int) error {
-	ret := _m.Called(ctx, id)
+// DeletePost provides a mock function with given fields: ctx, id, deletedBy
+func (_m *PostUsecase) DeletePost(ctx context.Context, id int, deletedBy int) error {
+	ret := _m.Called(ctx, id, deletedBy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeletePost")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, deletedBy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,6 +62,34 @@ func (_m *PostUsecase) DeletePost(ctx context.Context, id int) error {
 	return r0
 }
 
+// DiffPostRevisions provides a mock function with given fields: ctx, id, from, to
+func (_m *PostUsecase) DiffPostRevisions(ctx context.Context, id int, from int, to int) (entity.PostRevisionDiff, error) {
+	ret := _m.Called(ctx, id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffPostRevisions")
+	}
+
+	var r0 entity.PostRevisionDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) (entity.PostRevisionDiff, error)); ok {
+		return rf(ctx, id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) entity.PostRevisionDiff); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Get(0).(entity.PostRevisionDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPostByID provides a mock function with given fields: ctx, id
 func (_m *PostUsecase) GetPostByID(ctx context.Context, id int) (*entity.Post, error) {
 	ret := _m.Called(ctx, id)
@@ -92,9 +120,97 @@ func (_m *PostUsecase) GetPostByID(ctx context.Context, id int) (*entity.Post, e
 	return r0, r1
 }
 
-// GetPosts provides a mock function with given fields: ctx, limit, offset
-func (_m *PostUsecase) GetPosts(ctx context.Context, limit int, offset int) ([]entity.Post, error) {
-	ret := _m.Called(ctx, limit, offset)
+// GetPostDetails provides a mock function with given fields: ctx, id
+func (_m *PostUsecase) GetPostDetails(ctx context.Context, id int) (*entity.PostDetails, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostDetails")
+	}
+
+	var r0 *entity.PostDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*entity.PostDetails, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.PostDetails); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PostDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostRevision provides a mock function with given fields: ctx, id, version
+func (_m *PostUsecase) GetPostRevision(ctx context.Context, id int, version int) (entity.PostRevision, error) {
+	ret := _m.Called(ctx, id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostRevision")
+	}
+
+	var r0 entity.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (entity.PostRevision, error)); ok {
+		return rf(ctx, id, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) entity.PostRevision); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Get(0).(entity.PostRevision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPostRevisions provides a mock function with given fields: ctx, id
+func (_m *PostUsecase) GetPostRevisions(ctx context.Context, id int) ([]entity.PostRevision, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPostRevisions")
+	}
+
+	var r0 []entity.PostRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.PostRevision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.PostRevision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PostRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPosts provides a mock function with given fields: ctx, filter
+func (_m *PostUsecase) GetPosts(ctx context.Context, filter entity.PostFilter) ([]entity.Post, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPosts")
@@ -102,19 +218,19 @@ func (_m *PostUsecase) GetPosts(ctx context.Context, limit int, offset int) ([]e
 
 	var r0 []entity.Post
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]entity.Post, error)); ok {
-		return rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) ([]entity.Post, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int) []entity.Post); ok {
-		r0 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) []entity.Post); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Post)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = rf(ctx, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PostFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,9 +238,9 @@ func (_m *PostUsecase) GetPosts(ctx context.Context, limit int, offset int) ([]e
 	return r0, r1
 }
 
-// GetTotalPostsCount provides a mock function with given fields: ctx
-func (_m *PostUsecase) GetTotalPostsCount(ctx context.Context) (int, error) {
-	ret := _m.Called(ctx)
+// GetTotalPostsCount provides a mock function with given fields: ctx, filter
+func (_m *PostUsecase) GetTotalPostsCount(ctx context.Context, filter entity.PostFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTotalPostsCount")
@@ -132,17 +248,47 @@ func (_m *PostUsecase) GetTotalPostsCount(ctx context.Context) (int, error) {
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) (int, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PostFilter) int); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PostFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RollbackPost provides a mock function with given fields: ctx, id, version, editorID, reason
+func (_m *PostUsecase) RollbackPost(ctx context.Context, id int, version int, editorID int, reason string) (*entity.Post, error) {
+	ret := _m.Called(ctx, id, version, editorID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RollbackPost")
+	}
+
+	var r0 *entity.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string) (*entity.Post, error)); ok {
+		return rf(ctx, id, version, editorID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int, string) *entity.Post); ok {
+		r0 = rf(ctx, id, version, editorID, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int, string) error); ok {
+		r1 = rf(ctx, id, version, editorID, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/miqxzz/miqxzzforum/forum_service/internal/controllers/grpc"
+	"github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -49,6 +50,48 @@ func (_m *UserClient) GetUsername(ctx context.Context, userID int) (string, erro
 	return r0, r1
 }
 
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *UserClient) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.UserInfo
+	if rf, ok := ret.Get(0).(func(context.Context, int) entity.UserInfo); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.UserInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx, userIDs
+func (_m *UserClient) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	var r0 map[int]entity.UserInfo
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]entity.UserInfo); ok {
+		r0 = rf(ctx, userIDs)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(map[int]entity.UserInfo)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Close provides a mock function with given fields:
 func (_m *UserClient) Close() error {
 	ret := _m.Called()
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	entity "github.com/miqxzz/miqxzzforum/forum_service/internal/entity"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +33,34 @@ func (_m *UserClientInterface) Close() error {
 	return r0
 }
 
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *UserClientInterface) GetUser(ctx context.Context, userID int) (entity.UserInfo, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 entity.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (entity.UserInfo, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) entity.UserInfo); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.UserInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsername provides a mock function with given fields: ctx, userID
 func (_m *UserClientInterface) GetUsername(ctx context.Context, userID int) (string, error) {
 	ret := _m.Called(ctx, userID)
@@ -59,6 +89,36 @@ func (_m *UserClientInterface) GetUsername(ctx context.Context, userID int) (str
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: ctx, userIDs
+func (_m *UserClientInterface) GetUsers(ctx context.Context, userIDs []int) (map[int]entity.UserInfo, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 map[int]entity.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int) (map[int]entity.UserInfo, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int) map[int]entity.UserInfo); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]entity.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserClientInterface creates a new instance of UserClientInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserClientInterface(t interface {
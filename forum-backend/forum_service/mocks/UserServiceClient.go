@@ -17,6 +17,43 @@ type UserServiceClient struct {
 	mock.Mock
 }
 
+// GetUser provides a mock function with given fields: ctx, in, opts
+func (_m *UserServiceClient) GetUser(ctx context.Context, in *user.UserRequest, opts ...grpc.CallOption) (*user.UserInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *user.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UserRequest, ...grpc.CallOption) (*user.UserInfo, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UserRequest, ...grpc.CallOption) *user.UserInfo); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.UserRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsername provides a mock function with given fields: ctx, in, opts
 func (_m *UserServiceClient) GetUsername(ctx context.Context, in *user.UserRequest, opts ...grpc.CallOption) (*user.UserResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -54,6 +91,80 @@ func (_m *UserServiceClient) GetUsername(ctx context.Context, in *user.UserReque
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: ctx, in, opts
+func (_m *UserServiceClient) GetUsers(ctx context.Context, in *user.UsersRequest, opts ...grpc.CallOption) (*user.UsersResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 *user.UsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UsersRequest, ...grpc.CallOption) (*user.UsersResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UsersRequest, ...grpc.CallOption) *user.UsersResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UsersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.UsersRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchUserChanges provides a mock function with given fields: ctx, in, opts
+func (_m *UserServiceClient) WatchUserChanges(ctx context.Context, in *user.WatchUserChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[user.UserChange], error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserChanges")
+	}
+
+	var r0 grpc.ServerStreamingClient[user.UserChange]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.WatchUserChangesRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[user.UserChange], error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.WatchUserChangesRequest, ...grpc.CallOption) grpc.ServerStreamingClient[user.UserChange]); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(grpc.ServerStreamingClient[user.UserChange])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.WatchUserChangesRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserServiceClient creates a new instance of UserServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserServiceClient(t interface {
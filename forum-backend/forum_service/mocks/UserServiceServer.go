@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
-	user "github.com/miqxzz/miqxzzforum/forum_service/internal/proto"
+	grpc "google.golang.org/grpc"
+
 	mock "github.com/stretchr/testify/mock"
+
+	user "github.com/miqxzz/miqxzzforum/forum_service/internal/proto"
 )
 
 // UserServiceServer is an autogenerated mock type for the UserServiceServer type
@@ -14,6 +17,36 @@ type UserServiceServer struct {
 	mock.Mock
 }
 
+// GetUser provides a mock function with given fields: _a0, _a1
+func (_m *UserServiceServer) GetUser(_a0 context.Context, _a1 *user.UserRequest) (*user.UserInfo, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *user.UserInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UserRequest) (*user.UserInfo, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UserRequest) *user.UserInfo); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UserInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.UserRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsername provides a mock function with given fields: _a0, _a1
 func (_m *UserServiceServer) GetUsername(_a0 context.Context, _a1 *user.UserRequest) (*user.UserResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -44,6 +77,54 @@ func (_m *UserServiceServer) GetUsername(_a0 context.Context, _a1 *user.UserRequ
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: _a0, _a1
+func (_m *UserServiceServer) GetUsers(_a0 context.Context, _a1 *user.UsersRequest) (*user.UsersResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 *user.UsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UsersRequest) (*user.UsersResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *user.UsersRequest) *user.UsersResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*user.UsersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *user.UsersRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WatchUserChanges provides a mock function with given fields: _a0, _a1
+func (_m *UserServiceServer) WatchUserChanges(_a0 *user.WatchUserChangesRequest, _a1 grpc.ServerStreamingServer[user.UserChange]) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchUserChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*user.WatchUserChangesRequest, grpc.ServerStreamingServer[user.UserChange]) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // mustEmbedUnimplementedUserServiceServer provides a mock function with no fields
 func (_m *UserServiceServer) mustEmbedUnimplementedUserServiceServer() {
 	_m.Called()
@@ -90,7 +90,7 @@ const Login = () => {
               username: response.data.username,
               role: response.data.role,
               token: response.data.token
-          }); // Вызываем метод login
+          }, response.data.refresh_token); // Вызываем метод login
             navigate('/posts');
         } catch (error) {
             console.error('Не удалось войти:', error);
@@ -93,6 +93,7 @@ const ChatWrapper = styled.div`
 const Register = () => {
     const [username, setUsername] = useState('');
     const [password, setPassword] = useState('');
+    const [inviteCode, setInviteCode] = useState('');
     const [error, setError] = useState('');
     const navigate = useNavigate();
 
@@ -103,7 +104,7 @@ const Register = () => {
               { 
                 username: username,
                 password: password,
-                role: "user",
+                invite_code: inviteCode || undefined,
               });
             localStorage.setItem('token', response.data.token);
             navigate('/Login');
@@ -129,6 +130,12 @@ const Register = () => {
                     value={password}
                     onChange={(e) => setPassword(e.target.value)}
                 />
+                <Input
+                    type="text"
+                    placeholder="Код приглашения (необязательно)"
+                    value={inviteCode}
+                    onChange={(e) => setInviteCode(e.target.value)}
+                />
                 {error && <ErrorMessage>{error}</ErrorMessage>}
                 <RegisterButton onClick={handleRegister}>Зарегистрироваться</RegisterButton>
             </RegisterContainer>
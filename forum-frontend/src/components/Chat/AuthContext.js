@@ -1,8 +1,32 @@
-import React, { createContext, useState, useContext, useEffect } from 'react';
+import React, { createContext, useState, useContext, useEffect, useCallback } from 'react';
 import PropTypes from 'prop-types';
+import axios from 'axios';
 
 const AuthContext = createContext();
 
+const REFRESH_URL = 'http://localhost:8080/auth/refresh';
+
+// Один запрос обновления на все запросы, получившие 401 одновременно:
+// refresh-токен одноразовый, повторное предъявление отзывает всю сессию.
+let refreshPromise = null;
+
+export const refreshTokens = () => {
+    if (!refreshPromise) {
+        const refreshToken = localStorage.getItem('refreshToken');
+        refreshPromise = (refreshToken
+            ? axios.post(REFRESH_URL, { refresh_token: refreshToken })
+            : Promise.reject(new Error('Нет refresh-токена'))
+        ).then((response) => {
+            localStorage.setItem('token', response.data.token);
+            localStorage.setItem('refreshToken', response.data.refresh_token);
+            return response.data.token;
+        }).finally(() => {
+            refreshPromise = null;
+        });
+    }
+    return refreshPromise;
+};
+
 export const AuthProvider = ({ children }) => {
     const [user, setUser] = useState(null);
     const [isAuthenticated, setIsAuthenticated] = useState(false);
@@ -47,13 +71,44 @@ export const AuthProvider = ({ children }) => {
         loadAuthData();
     }, []);
 
-    const login = (token, userData) => {
+    // Access-токен живет недолго: при 401 обновляем пару токенов и повторяем
+    // запрос, а если обновить не удалось — выходим.
+    useEffect(() => {
+        const interceptor = axios.interceptors.response.use(
+            (response) => response,
+            async (error) => {
+                const config = error.config;
+                if (error.response?.status !== 401 || !config || config._retried ||
+                    config.url === REFRESH_URL || !config.headers?.Authorization) {
+                    return Promise.reject(error);
+                }
+                config._retried = true;
+                try {
+                    const token = await refreshTokens();
+                    setUser((current) => (current ? { ...current, token } : current));
+                    config.headers.Authorization = `Bearer ${token}`;
+                    return axios(config);
+                } catch (refreshError) {
+                    localStorage.clear();
+                    setUser(null);
+                    setIsAuthenticated(false);
+                    return Promise.reject(error);
+                }
+            }
+        );
+        return () => axios.interceptors.response.eject(interceptor);
+    }, []);
+
+    const login = (token, userData, refreshToken) => {
         try {
             if (!token || !userData?.id || !userData.username || !userData.role) {
                 throw new Error('Неверные данные для входа');
             }
 
             localStorage.setItem('token', token);
+            if (refreshToken) {
+                localStorage.setItem('refreshToken', refreshToken);
+            }
             localStorage.setItem('userID', String(userData.id));
             localStorage.setItem('username', userData.username);
             localStorage.setItem('role', userData.role);
@@ -71,7 +126,7 @@ export const AuthProvider = ({ children }) => {
         }   
     };
 
-    const logout = () => {
+    const logout = useCallback(() => {
         try {
             localStorage.clear();
             setUser(null);
@@ -79,7 +134,7 @@ export const AuthProvider = ({ children }) => {
         } catch (error) {
             console.error('Не удалось выйти:', error);
         }
-    };
+    }, []);
 
     return (
         <AuthContext.Provider value={{
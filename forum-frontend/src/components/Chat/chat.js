@@ -1,6 +1,9 @@
 import React, { useState, useEffect, useRef } from 'react';
 import styled from 'styled-components';
-import { useAuth } from './AuthContext';
+import { useAuth, refreshTokens } from './AuthContext';
+
+const WS_URL = 'ws://localhost:8081/ws';
+const GENERAL_ROOM_ID = 1; // общая комната, в которую сервер вводит при подключении
 
 const ChatContainer = styled.div`
   width: 420px;
@@ -135,10 +138,10 @@ const ChatInput = styled.input`
     const [messages, setMessages] = useState([]);
     const [newMessage, setNewMessage] = useState('');
     const [isConnected, setIsConnected] = useState(false);
-    const { user, isAuthenticated } = useAuth();
+    const { user, isAuthenticated, logout } = useAuth();
     const ws = useRef(null);
     const messagesEndRef = useRef(null);
-    const lastMessageRef = useRef(null); // Для отслеживания последнего сообщения
+    const lastSeenRef = useRef(0); // ID последнего полученного сообщения — для since при переподключении
   
     // Форматирование даты
     const formatDate = (timestamp) => {
@@ -151,53 +154,76 @@ const ChatInput = styled.input`
       }
     };
   
-    // Подключение WebSocket
+    // Подключение WebSocket: вошедший пользователь передает токен подпротоколом,
+    // гость подключается только для чтения
     useEffect(() => {
+      let closedByUs = false;
+      let reconnectTimer = null;
+
+      // Обработка событий сервера
+      const handleEvent = (event) => {
+        switch (event.type) {
+          case 'message':
+            if (event.room_id !== GENERAL_ROOM_ID) return;
+            lastSeenRef.current = Math.max(lastSeenRef.current, event.id || 0);
+            setMessages(prev => prev.some(m => m.id === event.id) ? prev : [...prev, {
+              id: event.id,
+              userID: event.userID || 0,
+              username: event.username || 'Unknown',
+              content: event.content || '',
+              timestamp: event.timestamp || new Date().toISOString()
+            }]);
+            break;
+          case 'message.updated':
+            setMessages(prev => prev.map(m => m.id === event.id ? { ...m, content: event.content } : m));
+            break;
+          case 'message.deleted':
+            setMessages(prev => prev.filter(m => m.id !== event.message_id));
+            break;
+          case 'error':
+            console.warn('Ошибка чата:', event.error);
+            break;
+          default:
+            break;
+        }
+      };
+  
       const connectWebSocket = () => {
-        const wsUrl = `ws://localhost:8081/ws?userID=${user?.id || 0}&username=${encodeURIComponent(user?.username || 'Guest')}&auth=${isAuthenticated}`;
-        ws.current = new WebSocket(wsUrl);
+        const since = lastSeenRef.current ? `since=${lastSeenRef.current}` : '';
+        const token = isAuthenticated ? localStorage.getItem('token') : null;
+        let opened = false;
+        if (token) {
+          ws.current = new WebSocket(`${WS_URL}${since ? `?${since}` : ''}`, ['bearer', token]);
+        } else {
+          ws.current = new WebSocket(`${WS_URL}?mode=anonymous${since ? `&${since}` : ''}`);
+        }
   
         ws.current.onopen = () => {
           console.log('WebSocket подключен');
+          opened = true;
           setIsConnected(true);
         };
   
         ws.current.onclose = (e) => {
           console.log('WebSocket отключен', e.code);
           setIsConnected(false);
-          if (e.code !== 1000) {
-            setTimeout(connectWebSocket, 3000);
+          if (closedByUs) return;
+          // Соединение не открылось — возможно, истек access-токен
+          if (token && !opened) {
+            refreshTokens().then(() => {
+              if (!closedByUs) {
+                reconnectTimer = setTimeout(connectWebSocket, 3000);
+              }
+            }).catch(logout); // После выхода чат переподключится гостем
+            return;
           }
+          reconnectTimer = setTimeout(connectWebSocket, 3000);
         };
   
         ws.current.onmessage = (e) => {
           try {
             const data = typeof e.data === 'string' ? e.data : new TextDecoder().decode(e.data);
-            const message = JSON.parse(data);
-            
-            // Проверяем, не является ли это сообщение тем, которое мы только что отправили
-            if (lastMessageRef.current && 
-                lastMessageRef.current.content === message.content && 
-                lastMessageRef.current.userID === message.userID) {
-              lastMessageRef.current = null;
-              return;
-            }
-  
-            setMessages(prev => {
-              // Проверяем дубликаты по содержанию и времени
-              const isDuplicate = prev.some(
-                m => m.content === message.content && 
-                     Math.abs(new Date(m.timestamp) - new Date(message.timestamp)) < 1000
-              );
-              
-              return isDuplicate ? prev : [...prev, {
-                id: message.id || Date.now(),
-                userID: message.userID || 0,
-                username: message.username || 'Unknown',
-                content: message.content || '',
-                timestamp: message.timestamp || new Date().toISOString()
-              }];
-            });
+            handleEvent(JSON.parse(data));
           } catch (err) {
             console.error('Ошибка обработки сообщения:', err);
           }
@@ -211,40 +237,29 @@ const ChatInput = styled.input`
       connectWebSocket();
   
       return () => {
-        if (ws.current?.readyState === WebSocket.OPEN) {
-          ws.current.close(1000, 'Компонент отмонтирован');
-        }
+        closedByUs = true;
+        clearTimeout(reconnectTimer);
+        ws.current?.close(1000, 'Компонент отмонтирован');
       };
-    }, [user, isAuthenticated]);
+    }, [isAuthenticated, logout]);
   
     // Прокрутка к новым сообщениям
     useEffect(() => {
       messagesEndRef.current?.scrollIntoView({ behavior: 'smooth' });
     }, [messages]);
   
-    // Отправка сообщения
+    // Отправка сообщения: автора сервер берет из токена, а само сообщение
+    // приходит обратно событием message
     const sendMessage = (e) => {
       e.preventDefault();
       if (!newMessage.trim() || !isConnected || !isAuthenticated || !ws.current) return;
   
       try {
-        const message = {
-          userID: user.id,
-          username: user.username,
-          content: newMessage.trim(),
-          timestamp: new Date().toISOString()
-        };
-        
-        // Сохраняем ссылку на последнее отправленное сообщение
-        lastMessageRef.current = message;
-        
-        // Добавляем сообщение локально сразу
-        setMessages(prev => [...prev, {
-          ...message,
-          id: Date.now()
-        }]);
-        
-        ws.current.send(JSON.stringify(message));
+        ws.current.send(JSON.stringify({
+          type: 'message',
+          room_id: GENERAL_ROOM_ID,
+          content: newMessage.trim()
+        }));
         setNewMessage('');
       } catch (err) {
         console.error('Ошибка отправки сообщения:', err);